@@ -6,7 +6,7 @@ package agent
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,7 +17,7 @@ import (
 )
 
 func (s *Server) AddReplicationEntries(ctx context.Context, req *idl.AddReplicationEntriesRequest) (*idl.AddReplicationEntriesReply, error) {
-	log.Print("starting add replication entries to pg_hba.conf")
+	slog.InfoContext(ctx, "starting add replication entries to pg_hba.conf")
 
 	err := AddReplicationEntriesToPgHbaConf(req.GetEntries())
 	if err != nil {
@@ -5,7 +5,8 @@ package agent
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
 
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
@@ -13,9 +14,15 @@ import (
 )
 
 func (s *Server) CheckDiskSpace(ctx context.Context, in *idl.CheckSegmentDiskSpaceRequest) (*idl.CheckDiskSpaceReply, error) {
-	log.Printf("starting %s", idl.Substep_check_disk_space)
+	slog.InfoContext(ctx, fmt.Sprintf("starting %s", idl.Substep_check_disk_space))
 
-	usage, err := disk.CheckUsage(step.DevNullStream, disk.Local, in.GetDiskFreeRatio(), in.GetDirs()...)
+	var usage disk.FileSystemDiskUsage
+	var err error
+	if len(in.GetRequired()) > 0 {
+		usage, err = disk.CheckRequired(step.DevNullStream, disk.Local, in.GetRequired())
+	} else {
+		usage, err = disk.CheckUsage(step.DevNullStream, disk.Local, in.GetDiskFreeRatio(), in.GetDirs()...)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -6,7 +6,7 @@ package agent
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 
 	"github.com/greenplum-db/gpupgrade/hub"
@@ -14,7 +14,7 @@ import (
 )
 
 func (s *Server) CreateBackupDirectory(ctx context.Context, req *idl.CreateBackupDirectoryRequest) (*idl.CreateBackupDirectoryReply, error) {
-	log.Printf("starting %s", idl.Substep_create_backupdirs)
+	slog.InfoContext(ctx, fmt.Sprintf("starting %s", idl.Substep_create_backupdirs))
 
 	hostname, err := os.Hostname()
 	if err != nil {
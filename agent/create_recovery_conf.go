@@ -6,7 +6,7 @@ package agent
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sync"
@@ -16,7 +16,7 @@ import (
 )
 
 func (s *Server) CreateRecoveryConf(ctx context.Context, req *idl.CreateRecoveryConfRequest) (*idl.CreateRecoveryConfReply, error) {
-	log.Print("starting create recovery.conf")
+	slog.InfoContext(ctx, "starting create recovery.conf")
 
 	err := createRecoveryConf(req.GetConnections())
 	if err != nil {
@@ -5,7 +5,8 @@ package agent
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
 
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
@@ -16,7 +17,7 @@ import (
 var DeleteDirectoriesFunc = upgrade.DeleteDirectories
 
 func (s *Server) DeleteStateDirectory(ctx context.Context, in *idl.DeleteStateDirectoryRequest) (*idl.DeleteStateDirectoryReply, error) {
-	log.Printf("starting %s", idl.Substep_delete_segment_statedirs)
+	slog.InfoContext(ctx, fmt.Sprintf("starting %s", idl.Substep_delete_segment_statedirs))
 
 	// pass an empty []string to avoid check for any pre-existing files,
 	// this call might come in before any stateDir files are created
@@ -25,7 +26,7 @@ func (s *Server) DeleteStateDirectory(ctx context.Context, in *idl.DeleteStateDi
 }
 
 func (s *Server) DeleteBackupDirectory(ctx context.Context, req *idl.DeleteBackupDirectoryRequest) (*idl.DeleteBackupDirectoryReply, error) {
-	log.Printf("starting %s", idl.Substep_delete_backupdir)
+	slog.InfoContext(ctx, fmt.Sprintf("starting %s", idl.Substep_delete_backupdir))
 
 	// pass an empty []string to avoid check for any pre-existing files,
 	// this call might come in before any backup files are created
@@ -34,14 +35,14 @@ func (s *Server) DeleteBackupDirectory(ctx context.Context, req *idl.DeleteBacku
 }
 
 func (s *Server) DeleteDataDirectories(ctx context.Context, in *idl.DeleteDataDirectoriesRequest) (*idl.DeleteDataDirectoriesReply, error) {
-	log.Printf("starting %s", idl.Substep_delete_target_cluster_datadirs)
+	slog.InfoContext(ctx, fmt.Sprintf("starting %s", idl.Substep_delete_target_cluster_datadirs))
 
 	err := DeleteDirectoriesFunc(in.Datadirs, upgrade.PostgresFiles, step.DevNullStream)
 	return &idl.DeleteDataDirectoriesReply{}, err
 }
 
 func (s *Server) DeleteTablespaceDirectories(ctx context.Context, in *idl.DeleteTablespaceRequest) (*idl.DeleteTablespaceReply, error) {
-	log.Printf("starting %s", idl.Substep_delete_tablespaces)
+	slog.InfoContext(ctx, fmt.Sprintf("starting %s", idl.Substep_delete_tablespaces))
 
 	err := upgrade.DeleteTablespaceDirectories(step.DevNullStream, in.GetDirs())
 	return &idl.DeleteTablespaceReply{}, err
@@ -0,0 +1,64 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// ExecCommand runs an allowlisted command on behalf of the hub for hosts that
+// are not reachable over ssh. Since no shell is involved environment variables
+// in the arguments are expanded by the agent.
+func (s *Server) ExecCommand(ctx context.Context, req *idl.ExecCommandRequest) (*idl.ExecCommandReply, error) {
+	slog.InfoContext(ctx, fmt.Sprintf("starting exec command %q", req.GetName()))
+
+	err := allowed(req.GetName(), req.GetArgs())
+	if err != nil {
+		return &idl.ExecCommandReply{}, err
+	}
+
+	var args []string
+	for _, arg := range req.GetArgs() {
+		args = append(args, os.ExpandEnv(arg))
+	}
+
+	cmd := exec.Command(req.GetName(), args...)
+	slog.InfoContext(ctx, fmt.Sprintf("Executing: %q", cmd.String()))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return &idl.ExecCommandReply{}, status.Errorf(codes.Unknown, "%q failed with %q: %v", cmd.String(), string(output), err)
+	}
+
+	return &idl.ExecCommandReply{Output: output}, nil
+}
+
+// allowed permits echo to read environment variables and the gpupgrade
+// version subcommand.
+func allowed(name string, args []string) error {
+	if name == "echo" {
+		return nil
+	}
+
+	gpupgradePath, err := utils.GetGpupgradePath()
+	if err != nil {
+		return err
+	}
+
+	if name == gpupgradePath && len(args) > 0 && args[0] == "version" {
+		return nil
+	}
+
+	return status.Errorf(codes.PermissionDenied, "command %q is not allowed", strings.Join(append([]string{name}, args...), " "))
+}
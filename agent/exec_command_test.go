@@ -0,0 +1,53 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestExecCommand(t *testing.T) {
+	testlog.SetupTestLogger()
+	server := agent.New()
+
+	t.Run("runs allowlisted commands expanding environment variables", func(t *testing.T) {
+		resetEnv := testutils.SetEnv(t, "LD_LIBRARY_PATH", "/usr/local/lib")
+		defer resetEnv()
+
+		reply, err := server.ExecCommand(context.Background(), &idl.ExecCommandRequest{Name: "echo", Args: []string{"$LD_LIBRARY_PATH"}})
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := "/usr/local/lib\n"
+		if string(reply.GetOutput()) != expected {
+			t.Errorf("got output %q want %q", reply.GetOutput(), expected)
+		}
+	})
+
+	t.Run("denies commands that are not allowlisted", func(t *testing.T) {
+		_, err := server.ExecCommand(context.Background(), &idl.ExecCommandRequest{Name: "rm", Args: []string{"-rf", "/"}})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("got error %#v want code %s", err, codes.PermissionDenied)
+		}
+	})
+
+	t.Run("denies gpupgrade subcommands other than version", func(t *testing.T) {
+		path := testutils.MustGetExecutablePath(t) + "/gpupgrade"
+
+		_, err := server.ExecCommand(context.Background(), &idl.ExecCommandRequest{Name: path, Args: []string{"agent", "--daemonize"}})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("got error %#v want code %s", err, codes.PermissionDenied)
+		}
+	})
+}
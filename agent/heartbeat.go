@@ -0,0 +1,55 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	sigar "github.com/cloudfoundry/gosigar"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+// Version is reported by the heartbeat. It is set to the gpupgrade version
+// when the agent is started.
+var Version string
+
+// Heartbeat reports the health of the agent and its host. Failing to determine
+// the load or disk usage is logged rather than failing the heartbeat since the
+// agent itself is still responsive.
+func (s *Server) Heartbeat(ctx context.Context, in *idl.HeartbeatRequest) (*idl.HeartbeatReply, error) {
+	stateDir := utils.GetStateDir()
+
+	health := &idl.AgentHealth{
+		Version:        Version,
+		Pid:            int32(os.Getpid()),
+		Uptime:         durationpb.New(time.Since(s.startTime)),
+		StateDirectory: stateDir,
+	}
+
+	var load sigar.LoadAverage
+	if err := load.Get(); err != nil {
+		log.Printf("heartbeat: get load average: %v", err)
+	} else {
+		health.LoadAverage1 = load.One
+		health.LoadAverage5 = load.Five
+		health.LoadAverage15 = load.Fifteen
+	}
+
+	usage, err := disk.Local.Usage(stateDir)
+	if err != nil {
+		log.Printf("heartbeat: get disk usage of %q: %v", stateDir, err)
+	} else {
+		health.DiskAvailable = usage.Avail
+		health.DiskTotal = usage.Total
+	}
+
+	return &idl.HeartbeatReply{Health: health}, nil
+}
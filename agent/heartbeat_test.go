@@ -0,0 +1,56 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestHeartbeat(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	agent.Version = "1.2.3"
+	defer func() { agent.Version = "" }()
+
+	server := agent.New()
+
+	reply, err := server.Heartbeat(context.Background(), &idl.HeartbeatRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	health := reply.GetHealth()
+	if health.GetVersion() != "1.2.3" {
+		t.Errorf("got version %q want %q", health.GetVersion(), "1.2.3")
+	}
+
+	if health.GetPid() != int32(os.Getpid()) {
+		t.Errorf("got pid %d want %d", health.GetPid(), os.Getpid())
+	}
+
+	if health.GetStateDirectory() != stateDir {
+		t.Errorf("got state directory %q want %q", health.GetStateDirectory(), stateDir)
+	}
+
+	if health.GetUptime().AsDuration() <= 0 {
+		t.Errorf("got uptime %s want greater than zero", health.GetUptime().AsDuration())
+	}
+
+	if health.GetDiskTotal() == 0 {
+		t.Errorf("expected the disk usage of the state directory to be reported")
+	}
+}
@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"io"
 	"os"
 
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
@@ -24,10 +25,25 @@ func FailedRsync() {
 	os.Exit(2)
 }
 
+func PgUpgradeProgress() {
+	os.Stdout.WriteString("Checking for presence of required libraries                 ok\n")
+	os.Stdout.WriteString("Copying user relation files\n")
+	os.Stdout.WriteString("                                                            ok\n")
+}
+
+// RsyncServer echoes its stdin and exits with a failure once stdin is closed.
+func RsyncServer() {
+	io.Copy(os.Stdout, os.Stdin) //nolint
+	os.Stderr.WriteString("connection closed")
+	os.Exit(3)
+}
+
 func init() {
 	exectest.RegisterMains(
 		Success,
 		FailedMain,
 		FailedRsync,
+		PgUpgradeProgress,
+		RsyncServer,
 	)
 }
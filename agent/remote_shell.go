@@ -0,0 +1,128 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+var ShellCommand = exec.Command
+
+// RemoteShell runs the server side of rsync on behalf of the rsync of another
+// host for hosts that are not reachable over ssh. It is the remote shell given
+// to rsync by "gpupgrade remote-shell" which relays its stdin and stdout.
+func (s *Server) RemoteShell(stream idl.Agent_RemoteShellServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	args := req.GetArgs()
+	if err := allowedShell(args); err != nil {
+		return err
+	}
+
+	slog.InfoContext(stream.Context(), fmt.Sprintf("starting remote shell %q", strings.Join(args, " ")))
+
+	sender := &remoteShellSender{stream: stream}
+	cmd := ShellCommand(args[0], args[1:]...)
+	cmd.Stdout = sender.writer(false)
+	cmd.Stderr = sender.writer(true)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer stdin.Close()
+
+		for {
+			if _, err := stdin.Write(req.GetStdin()); err != nil {
+				return
+			}
+
+			req, err = stream.Recv()
+			if err != nil {
+				if err != io.EOF {
+					log.Printf("remote shell stdin: %v", err)
+				}
+				return
+			}
+		}
+	}()
+
+	err = utils.RunCommand(stream.Context(), cmd)
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return err
+	}
+
+	return sender.send(&idl.RemoteShellReply{Exited: true, ExitCode: int32(cmd.ProcessState.ExitCode())})
+}
+
+// allowedShell only permits the rsync server such that the agent does not run
+// arbitrary commands.
+func allowedShell(args []string) error {
+	if len(args) > 1 && filepath.Base(args[0]) == "rsync" && args[1] == "--server" {
+		return nil
+	}
+
+	return status.Errorf(codes.PermissionDenied, "remote shell command %q is not allowed", strings.Join(args, " "))
+}
+
+// remoteShellSender sends the output of the command. Unlike the output of
+// pg_upgrade, the output is the rsync protocol so send errors are returned
+// failing the command.
+type remoteShellSender struct {
+	stream idl.Agent_RemoteShellServer
+	mutex  sync.Mutex
+}
+
+func (r *remoteShellSender) send(reply *idl.RemoteShellReply) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.stream.Send(reply)
+}
+
+func (r *remoteShellSender) writer(stderr bool) io.Writer {
+	return &remoteShellWriter{sender: r, stderr: stderr}
+}
+
+type remoteShellWriter struct {
+	sender *remoteShellSender
+	stderr bool
+}
+
+func (w *remoteShellWriter) Write(p []byte) (int, error) {
+	// Copy the buffer since the caller may reuse it after Write returns.
+	buffer := make([]byte, len(p))
+	copy(buffer, p)
+
+	reply := &idl.RemoteShellReply{Stdout: buffer}
+	if w.stderr {
+		reply = &idl.RemoteShellReply{Stderr: buffer}
+	}
+
+	if err := w.sender.send(reply); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
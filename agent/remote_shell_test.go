@@ -0,0 +1,95 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestRemoteShell(t *testing.T) {
+	testlog.SetupTestLogger()
+	agentServer := agent.New()
+
+	t.Run("relays stdin to the rsync server and its output and exit code back", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		args := []string{"rsync", "--server", "-logDtprze.iLsfxC", ".", "/data/backup"}
+		agent.ShellCommand = exectest.NewCommandWithVerifier(agent.RsyncServer, func(name string, arg ...string) {
+			if !reflect.DeepEqual(append([]string{name}, arg...), args) {
+				t.Errorf("got command %q want %q", append([]string{name}, arg...), args)
+			}
+		})
+		defer func() { agent.ShellCommand = exec.Command }()
+
+		stream := mock_idl.NewMockAgent_RemoteShellServer(ctrl)
+		stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+		gomock.InOrder(
+			stream.EXPECT().Recv().Return(&idl.RemoteShellRequest{Args: args, Stdin: []byte("hello ")}, nil),
+			stream.EXPECT().Recv().Return(&idl.RemoteShellRequest{Stdin: []byte("world")}, nil),
+			stream.EXPECT().Recv().Return(nil, io.EOF),
+		)
+
+		var mutex sync.Mutex
+		var stdout, stderr string
+		var exit *idl.RemoteShellReply
+		stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(reply *idl.RemoteShellReply) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			stdout += string(reply.GetStdout())
+			stderr += string(reply.GetStderr())
+			if reply.GetExited() {
+				exit = reply
+			}
+			return nil
+		}).AnyTimes()
+
+		err := agentServer.RemoteShell(stream)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if stdout != "hello world" || stderr != "connection closed" {
+			t.Errorf("got stdout %q stderr %q", stdout, stderr)
+		}
+
+		if exit == nil || exit.GetExitCode() != 3 {
+			t.Errorf("got exit %v want exit code 3", exit)
+		}
+	})
+
+	t.Run("only runs the rsync server", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		agent.ShellCommand = exectest.NewCommandWithVerifier(agent.Success, func(name string, arg ...string) {
+			t.Errorf("expected %q to not be run", name)
+		})
+		defer func() { agent.ShellCommand = exec.Command }()
+
+		stream := mock_idl.NewMockAgent_RemoteShellServer(ctrl)
+		stream.EXPECT().Recv().Return(&idl.RemoteShellRequest{Args: []string{"bash", "-c", "rm -rf /"}}, nil)
+
+		err := agentServer.RemoteShell(stream)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("got error %#v want code %s", err, codes.PermissionDenied)
+		}
+	})
+}
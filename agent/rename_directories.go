@@ -5,7 +5,8 @@ package agent
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
 
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/upgrade"
@@ -15,10 +16,21 @@ import (
 var RenameDirectories = upgrade.RenameDirectories
 
 func (s *Server) RenameDirectories(ctx context.Context, in *idl.RenameDirectoriesRequest) (*idl.RenameDirectoriesReply, error) {
-	log.Printf("starting %s", idl.Substep_update_data_directories)
+	slog.InfoContext(ctx, fmt.Sprintf("starting %s", idl.Substep_update_data_directories))
 
 	var mErr error
 	for _, dir := range in.GetDirs() {
+		if in.GetVerifyOnly() {
+			renamed, err := upgrade.VerifyRenameDirectories(dir.GetTarget())
+			if err != nil {
+				mErr = errorlist.Append(mErr, err)
+			} else if renamed {
+				slog.InfoContext(ctx, fmt.Sprintf("Data directory %q already renamed.", dir.GetTarget()))
+			}
+
+			continue
+		}
+
 		err := RenameDirectories(dir.GetSource(), dir.GetTarget())
 		if err != nil {
 			mErr = errorlist.Append(mErr, err)
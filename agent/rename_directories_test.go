@@ -6,11 +6,15 @@ package agent_test
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/greenplum-db/gpupgrade/agent"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
 func TestRenameDirectories(t *testing.T) {
@@ -29,4 +33,39 @@ func TestRenameDirectories(t *testing.T) {
 			t.Errorf("returned error %#v, want %#v", err, expected)
 		}
 	})
+	t.Run("only verifies the directories when requested", func(t *testing.T) {
+		agent.RenameDirectories = func(source, target string) error {
+			t.Errorf("expected directories to not be renamed")
+			return nil
+		}
+		defer func() { agent.RenameDirectories = upgrade.RenameDirectories }()
+
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		// seg1 is not yet renamed, seg2 was already renamed, and seg3 no
+		// longer exists such that it cannot be renamed.
+		for _, path := range []string{"seg1_123ABC", "seg2" + upgrade.OldSuffix} {
+			testutils.MustCreateDir(t, filepath.Join(dir, path))
+			for _, file := range upgrade.PostgresFiles {
+				testutils.MustWriteToFile(t, filepath.Join(dir, path, file), "")
+			}
+		}
+
+		req := &idl.RenameDirectoriesRequest{VerifyOnly: true, Dirs: []*idl.RenameDirectories{
+			{Source: filepath.Join(dir, "seg1"), Target: filepath.Join(dir, "seg1_123ABC")},
+			{Source: filepath.Join(dir, "seg2"), Target: filepath.Join(dir, "seg2")},
+		}}
+		_, err := agentServer.RenameDirectories(context.Background(), req)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		req.Dirs = append(req.Dirs, &idl.RenameDirectories{Source: filepath.Join(dir, "seg3"), Target: filepath.Join(dir, "seg3_123ABC")})
+		_, err = agentServer.RenameDirectories(context.Background(), req)
+		var errs errorlist.Errors
+		if !errors.As(err, &errs) || !errors.Is(errs[0], upgrade.ErrInvalidDataDirectory) {
+			t.Errorf("got error %#v want %#v", err, upgrade.ErrInvalidDataDirectory)
+		}
+	})
 }
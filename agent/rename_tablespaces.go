@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sync"
@@ -16,7 +17,7 @@ import (
 )
 
 func (s *Server) RenameTablespaces(ctx context.Context, req *idl.RenameTablespacesRequest) (*idl.RenameTablespacesReply, error) {
-	log.Print("starting rename tablespaces")
+	slog.InfoContext(ctx, "starting rename tablespaces")
 
 	err := renameTablespaces(req.GetRenamePairs())
 	if err != nil {
@@ -5,7 +5,8 @@ package agent
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
 
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
@@ -14,7 +15,7 @@ import (
 )
 
 func (s *Server) RestorePrimariesPgControl(ctx context.Context, in *idl.RestorePgControlRequest) (*idl.RestorePgControlReply, error) {
-	log.Printf("starting %s", idl.Substep_restore_pgcontrol)
+	slog.InfoContext(ctx, fmt.Sprintf("starting %s", idl.Substep_restore_pgcontrol))
 
 	var mErr error
 
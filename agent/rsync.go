@@ -6,19 +6,19 @@ package agent
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
-	"sync"
 
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 	"github.com/greenplum-db/gpupgrade/utils/rsync"
+	"github.com/greenplum-db/gpupgrade/utils/workerpool"
 )
 
 func (s *Server) RsyncDataDirectories(ctx context.Context, in *idl.RsyncRequest) (*idl.RsyncReply, error) {
-	log.Print("starting rsync data directories")
+	slog.InfoContext(ctx, "starting rsync data directories")
 
 	// verify source data directories
 	var mErr error
@@ -32,11 +32,12 @@ func (s *Server) RsyncDataDirectories(ctx context.Context, in *idl.RsyncRequest)
 		return &idl.RsyncReply{}, mErr
 	}
 
-	return &idl.RsyncReply{}, rsyncRequestDirs(in)
+	stats, err := rsyncRequestDirs(ctx, in)
+	return &idl.RsyncReply{Stats: stats.Proto()}, err
 }
 
 func (s *Server) RsyncTablespaceDirectories(ctx context.Context, in *idl.RsyncRequest) (*idl.RsyncReply, error) {
-	log.Print("starting rsync tablespace directories")
+	slog.InfoContext(ctx, "starting rsync tablespace directories")
 
 	// We can only verify the source directories since the destination
 	// directories are on another host.
@@ -48,45 +49,48 @@ func (s *Server) RsyncTablespaceDirectories(ctx context.Context, in *idl.RsyncRe
 		}
 	}
 
-	return &idl.RsyncReply{}, rsyncRequestDirs(in)
+	stats, err := rsyncRequestDirs(ctx, in)
+	return &idl.RsyncReply{Stats: stats.Proto()}, err
 }
 
-func rsyncRequestDirs(in *idl.RsyncRequest) error {
+// rsyncRequestDirs runs the requested rsyncs returning their aggregated stats.
+// The rsyncs are terminated when the context is cancelled.
+func rsyncRequestDirs(ctx context.Context, in *idl.RsyncRequest) (rsync.Stats, error) {
 	hostname, err := os.Hostname()
 	if err != nil {
-		return err
+		return rsync.Stats{}, err
 	}
 
-	var wg sync.WaitGroup
+	stats := &rsync.Collector{}
+	pool := workerpool.New(int(in.GetConcurrency()))
 	errs := make(chan error, len(in.GetOptions()))
 
 	for _, opts := range in.GetOptions() {
 		opts := opts
 
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-
-			opts := []rsync.Option{
+		pool.Go(func() {
+			options := []rsync.Option{
 				rsync.WithSources(opts.GetSources()...),
 				rsync.WithDestinationHost(opts.GetDestinationHost()),
 				rsync.WithDestination(opts.GetDestination()),
 				rsync.WithOptions(opts.GetOptions()...),
 				rsync.WithExcludedFiles(opts.GetExcludedFiles()...),
+				rsync.WithStats(stats),
+				rsync.WithContext(ctx),
 			}
-			err := rsync.Rsync(opts...)
+			err := rsync.RequestSettings(opts).Rsync(options...)
 			if err != nil {
 				errs <- fmt.Errorf("on host %q: %w", hostname, err)
 			}
-		}()
+		})
 	}
 
-	wg.Wait()
+	pool.Wait()
 	close(errs)
 
 	for e := range errs {
 		err = errorlist.Append(err, e)
 	}
 
-	return err
+	return stats.Stats(), err
 }
@@ -11,6 +11,7 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"time"
 
 	"golang.org/x/xerrors"
 	"google.golang.org/grpc"
@@ -19,6 +20,8 @@ import (
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/utils/daemon"
 	"github.com/greenplum-db/gpupgrade/utils/logger"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+	"github.com/greenplum-db/gpupgrade/utils/trace"
 )
 
 type Server struct {
@@ -26,21 +29,30 @@ type Server struct {
 	gRPCserver  *grpc.Server
 	listener    net.Listener
 	stoppedChan chan struct{}
+	startTime   time.Time
 }
 
 func New() *Server {
 	return &Server{
 		stoppedChan: make(chan struct{}, 1),
+		startTime:   time.Now(),
 	}
 }
 
-func (s *Server) Start(port int, stateDir string, daemonize bool) error {
+// Start serves on the listen address and port. When listenAddress is empty
+// the agent listens on all interfaces.
+func (s *Server) Start(port int, listenAddress string, tlsConfig mtls.Config, stateDir string, daemonize bool) error {
 	err := createStateDirectory(stateDir)
 	if err != nil {
 		return err
 	}
 
-	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	creds, err := tlsConfig.ServerCredentials()
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(listenAddress, strconv.Itoa(port)))
 	if err != nil {
 		return fmt.Errorf("listen on port %d: %w", port, err)
 	}
@@ -49,7 +61,19 @@ func (s *Server) Start(port int, stateDir string, daemonize bool) error {
 		defer logger.WritePanics()
 		return handler(ctx, req)
 	}
-	gRPCserver := grpc.NewServer(grpc.UnaryInterceptor(interceptor))
+
+	// Heartbeats poll the agent while steps run so are not traced as part of
+	// them.
+	traceInterceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod == idl.Agent_Heartbeat_FullMethodName {
+			return handler(ctx, req)
+		}
+
+		return trace.UnaryServerInterceptor(ctx, req, info, handler)
+	}
+	gRPCserver := grpc.NewServer(grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(interceptor, logger.UnaryServerInterceptor, traceInterceptor),
+		grpc.ChainStreamInterceptor(logger.StreamServerInterceptor, trace.StreamServerInterceptor))
 
 	s.mutex.Lock()
 	s.gRPCserver = gRPCserver
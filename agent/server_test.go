@@ -17,6 +17,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
 	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
 )
 
 const timeout = 1 * time.Second
@@ -35,7 +36,7 @@ func TestServerStart(t *testing.T) {
 
 		errChan := make(chan error, 1)
 		go func() {
-			errChan <- agentServer.Start(testutils.MustGetPort(t), stateDir, false)
+			errChan <- agentServer.Start(testutils.MustGetPort(t), "", mtls.Config{}, stateDir, false)
 		}()
 
 		exists, err := doesPathEventuallyExist(t, stateDir)
@@ -69,7 +70,7 @@ func TestServerStart(t *testing.T) {
 
 		errChan := make(chan error, 1)
 		go func() {
-			errChan <- agentServer.Start(testutils.MustGetPort(t), stateDir, false)
+			errChan <- agentServer.Start(testutils.MustGetPort(t), "", mtls.Config{}, stateDir, false)
 		}()
 
 		testutils.PathMustExist(t, stateDir)
@@ -97,7 +98,7 @@ func TestServerStart(t *testing.T) {
 
 		errChan := make(chan error, 1)
 		go func() {
-			errChan <- agentServer.Start(portInUse, stateDir, false)
+			errChan <- agentServer.Start(portInUse, "", mtls.Config{}, stateDir, false)
 		}()
 
 		select {
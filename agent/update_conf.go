@@ -6,7 +6,7 @@ package agent
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 
 	"github.com/greenplum-db/gpupgrade/hub"
@@ -14,7 +14,7 @@ import (
 )
 
 func (s *Server) UpdateConfiguration(ctx context.Context, req *idl.UpdateConfigurationRequest) (*idl.UpdateConfigurationReply, error) {
-	log.Printf("starting %s", idl.Substep_update_target_conf_files)
+	slog.InfoContext(ctx, fmt.Sprintf("starting %s", idl.Substep_update_target_conf_files))
 
 	hostname, err := os.Hostname()
 	if err != nil {
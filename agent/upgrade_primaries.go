@@ -8,50 +8,52 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"os"
 	"strconv"
 	"sync"
 
 	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/logger"
 	"github.com/greenplum-db/gpupgrade/utils/rsync"
+	"github.com/greenplum-db/gpupgrade/utils/workerpool"
 )
 
-func (s *Server) UpgradePrimaries(ctx context.Context, req *idl.UpgradePrimariesRequest) (*idl.UpgradePrimariesReply, error) {
-	log.Printf("starting %s", req.GetAction())
+func (s *Server) UpgradePrimaries(req *idl.UpgradePrimariesRequest, stream idl.Agent_UpgradePrimariesServer) error {
+	slog.InfoContext(stream.Context(), fmt.Sprintf("starting %s", req.GetAction()))
 
-	err := upgradePrimariesInParallel(req.GetOpts())
-	if err != nil {
-		return &idl.UpgradePrimariesReply{}, err
-	}
-
-	return &idl.UpgradePrimariesReply{}, nil
+	return upgradePrimariesInParallel(stream.Context(), &upgradePrimariesSender{stream: stream}, req.GetOpts(), int(req.GetConcurrency()))
 }
 
-func upgradePrimariesInParallel(opts []*idl.PgOptions) error {
+// upgradePrimariesInParallel runs pg_upgrade for each primary on the host with
+// at most concurrency running at once. Zero is unlimited. When the context is
+// cancelled, such as when the hub cancels the step, the running pg_upgrade
+// processes are terminated and the remaining primaries are not upgraded.
+func upgradePrimariesInParallel(ctx context.Context, sender *upgradePrimariesSender, opts []*idl.PgOptions, concurrency int) error {
 	host, err := utils.System.Hostname()
 	if err != nil {
 		return err
 	}
 
-	var wg sync.WaitGroup
+	pool := workerpool.New(concurrency)
 	errs := make(chan error, len(opts))
 
 	for _, opt := range opts {
-		wg.Add(1)
-		go func(host string, opt *idl.PgOptions) {
-			defer wg.Done()
+		opt := opt
 
-			errs <- upgradePrimarySegment(host, opt)
-		}(host, opt)
+		pool.Go(func() {
+			errs <- upgradePrimarySegment(ctx, sender, host, opt)
+		})
 	}
 
-	wg.Wait()
+	pool.Wait()
 	close(errs)
 
 	for e := range errs {
@@ -61,8 +63,31 @@ func upgradePrimariesInParallel(opts []*idl.PgOptions) error {
 	return err
 }
 
-func upgradePrimarySegment(host string, opt *idl.PgOptions) error {
+func upgradePrimarySegment(ctx context.Context, sender *upgradePrimariesSender, host string, opt *idl.PgOptions) (err error) {
+	segmentLog := slog.With(logger.ContentIDKey, opt.GetContentID())
+	segmentLog.InfoContext(ctx, fmt.Sprintf("starting %s of primary %s", opt.GetAction(), opt.GetNewDataDir()))
+
+	progress := &idl.SegmentProgress{Host: host, ContentID: opt.GetContentID()}
+	defer func() {
+		if err != nil {
+			segmentLog.ErrorContext(ctx, fmt.Sprintf("%s of primary failed", opt.GetAction()), "error", err.Error())
+		} else {
+			segmentLog.InfoContext(ctx, fmt.Sprintf("finished %s of primary", opt.GetAction()))
+		}
+
+		progress.Done = true
+		progress.Failed = err != nil
+		sender.sendProgress(progress)
+	}()
+
+	if err := ctx.Err(); err != nil {
+		return xerrors.Errorf("%s primary on host %s with content %d: %w", opt.GetAction(), host, opt.GetContentID(), err)
+	}
+
 	if opt.GetAction() != idl.PgOptions_check {
+		progress.Stage = "Restoring backup of upgraded master data directory"
+		sender.sendProgress(progress)
+
 		err := restoreBackup(opt.GetBackupDir(), opt.GetNewDataDir())
 		if err != nil {
 			return xerrors.Errorf("restore backup of upgraded master data directory on host %s for content id %d: %w", host, opt.GetContentID(), err)
@@ -74,7 +99,15 @@ func upgradePrimarySegment(host string, opt *idl.PgOptions) error {
 		}
 	}
 
-	err := upgrade.Run(io.Discard, io.Discard, opt)
+	progressWriter := upgrade.NewProgressWriter(func(p upgrade.Progress) {
+		progress.Stage = p.Stage
+		progress.CompletedStages = int32(p.CompletedStages)
+		sender.sendProgress(progress)
+	})
+	defer progressWriter.Flush()
+
+	stdout := io.MultiWriter(sender.writer(idl.Chunk_stdout), progressWriter)
+	err = upgrade.Run(ctx, stdout, sender.writer(idl.Chunk_stderr), opt)
 	if err != nil {
 		return xerrors.Errorf("%s primary on host %s with content %d: %w", opt.GetAction(), host, opt.GetContentID(), err)
 	}
@@ -82,6 +115,57 @@ func upgradePrimarySegment(host string, opt *idl.PgOptions) error {
 	return nil
 }
 
+// upgradePrimariesSender sends the output and progress of the primaries
+// being upgraded in parallel to the hub. Since the hub may close the
+// connection at any point, send errors are logged and otherwise ignored.
+// After the first send error, no more attempts are made.
+type upgradePrimariesSender struct {
+	stream idl.Agent_UpgradePrimariesServer
+	mutex  sync.Mutex
+}
+
+func (u *upgradePrimariesSender) send(reply *idl.UpgradePrimariesReply) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if u.stream == nil {
+		return
+	}
+
+	if err := u.stream.Send(reply); err != nil {
+		log.Printf("halting hub sender: %v", err)
+		u.stream = nil
+	}
+}
+
+func (u *upgradePrimariesSender) sendProgress(progress *idl.SegmentProgress) {
+	// Copy the progress since it continues to be updated after being sent.
+	p := proto.Clone(progress).(*idl.SegmentProgress)
+	u.send(&idl.UpgradePrimariesReply{Contents: &idl.UpgradePrimariesReply_Progress{Progress: p}})
+}
+
+func (u *upgradePrimariesSender) writer(cType idl.Chunk_Type) io.Writer {
+	return &upgradePrimariesWriter{sender: u, cType: cType}
+}
+
+type upgradePrimariesWriter struct {
+	sender *upgradePrimariesSender
+	cType  idl.Chunk_Type
+}
+
+func (w *upgradePrimariesWriter) Write(p []byte) (int, error) {
+	// Copy the buffer since the caller may reuse it after Write returns.
+	buffer := make([]byte, len(p))
+	copy(buffer, p)
+
+	w.sender.send(&idl.UpgradePrimariesReply{Contents: &idl.UpgradePrimariesReply_Chunk{Chunk: &idl.Chunk{
+		Buffer: buffer,
+		Type:   w.cType,
+	}}})
+
+	return len(p), nil
+}
+
 func restoreBackup(backupDir string, newDataDir string) error {
 	options := []rsync.Option{
 		rsync.WithSources(utils.GetCoordinatorPostUpgradeBackupDir(backupDir) + string(os.PathSeparator)),
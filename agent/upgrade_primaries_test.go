@@ -15,9 +15,13 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/proto"
+
 	"github.com/greenplum-db/gpupgrade/agent"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
 	"github.com/greenplum-db/gpupgrade/upgrade"
@@ -30,6 +34,13 @@ func TestUpgradePrimaries(t *testing.T) {
 	testlog.SetupTestLogger()
 	agentServer := agent.New()
 
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stream := mock_idl.NewMockAgent_UpgradePrimariesServer(ctrl)
+	stream.EXPECT().Send(gomock.Any()).AnyTimes()
+	stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+
 	t.Run("succeeds", func(t *testing.T) {
 		rsync.SetRsyncCommand(exectest.NewCommand(agent.Success))
 		defer rsync.ResetRsyncCommand()
@@ -43,10 +54,94 @@ func TestUpgradePrimaries(t *testing.T) {
 			TargetVersion: "6.0.0",
 		}}
 
-		_, err := agentServer.UpgradePrimaries(context.Background(), &idl.UpgradePrimariesRequest{Opts: opts})
+		err := agentServer.UpgradePrimaries(&idl.UpgradePrimariesRequest{Opts: opts}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("streams pg_upgrade output and progress", func(t *testing.T) {
+		utils.System.Hostname = func() (string, error) {
+			return "sdw1", nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		upgrade.SetPgUpgradeCommand(exectest.NewCommand(agent.PgUpgradeProgress))
+		defer upgrade.ResetPgUpgradeCommand()
+
+		var stdout []byte
+		var progress []*idl.SegmentProgress
+		stream := mock_idl.NewMockAgent_UpgradePrimariesServer(ctrl)
+		stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(reply *idl.UpgradePrimariesReply) error {
+			switch x := reply.GetContents().(type) {
+			case *idl.UpgradePrimariesReply_Chunk:
+				stdout = append(stdout, x.Chunk.GetBuffer()...)
+			case *idl.UpgradePrimariesReply_Progress:
+				progress = append(progress, x.Progress)
+			}
+			return nil
+		}).AnyTimes()
+		stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+
+		opts := []*idl.PgOptions{{
+			Role:          greenplum.PrimaryRole,
+			Action:        idl.PgOptions_check,
+			TargetVersion: "6.0.0",
+			ContentID:     1,
+		}}
+
+		err := agentServer.UpgradePrimaries(&idl.UpgradePrimariesRequest{Opts: opts}, stream)
 		if err != nil {
 			t.Fatalf("unexpected error %+v", err)
 		}
+
+		expectedStdout := "Checking for presence of required libraries                 ok\n" +
+			"Copying user relation files\n" +
+			"                                                            ok\n"
+		if string(stdout) != expectedStdout {
+			t.Errorf("got stdout %q want %q", stdout, expectedStdout)
+		}
+
+		expected := []*idl.SegmentProgress{
+			{Host: "sdw1", ContentID: 1, Stage: "Checking for presence of required libraries", CompletedStages: 1},
+			{Host: "sdw1", ContentID: 1, Stage: "Copying user relation files", CompletedStages: 1},
+			{Host: "sdw1", ContentID: 1, Stage: "Copying user relation files", CompletedStages: 2},
+			{Host: "sdw1", ContentID: 1, Stage: "Copying user relation files", CompletedStages: 2, Done: true},
+		}
+		if len(progress) != len(expected) {
+			t.Fatalf("got %d progress messages %v want %d", len(progress), progress, len(expected))
+		}
+
+		for i := range expected {
+			if !proto.Equal(progress[i], expected[i]) {
+				t.Errorf("got progress %v want %v", progress[i], expected[i])
+			}
+		}
+	})
+
+	t.Run("does not run pg_upgrade once the hub cancels the request", func(t *testing.T) {
+		upgrade.SetPgUpgradeCommand(exectest.NewCommandWithVerifier(agent.Success, func(utility string, args ...string) {
+			t.Errorf("expected pg_upgrade to not be run")
+		}))
+		defer upgrade.ResetPgUpgradeCommand()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		stream := mock_idl.NewMockAgent_UpgradePrimariesServer(ctrl)
+		stream.EXPECT().Send(gomock.Any()).AnyTimes()
+		stream.EXPECT().Context().Return(ctx).AnyTimes()
+
+		opts := []*idl.PgOptions{{
+			Role:          greenplum.PrimaryRole,
+			Action:        idl.PgOptions_check,
+			TargetVersion: "6.0.0",
+		}}
+
+		err := agentServer.UpgradePrimaries(&idl.UpgradePrimariesRequest{Opts: opts}, stream)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got error %#v want %#v", err, context.Canceled)
+		}
 	})
 
 	t.Run("restores backup and tablespaces when not calling --check", func(t *testing.T) {
@@ -83,7 +178,7 @@ func TestUpgradePrimaries(t *testing.T) {
 			},
 		}
 
-		_, err := agentServer.UpgradePrimaries(context.Background(), &idl.UpgradePrimariesRequest{Opts: opts})
+		err := agentServer.UpgradePrimaries(&idl.UpgradePrimariesRequest{Opts: opts}, stream)
 		if err != nil {
 			t.Fatalf("unexpected error %+v", err)
 		}
@@ -125,7 +220,7 @@ func TestUpgradePrimaries(t *testing.T) {
 			TargetVersion: "6.0.0",
 		}}
 
-		_, err := agentServer.UpgradePrimaries(context.Background(), &idl.UpgradePrimariesRequest{Opts: opts})
+		err := agentServer.UpgradePrimaries(&idl.UpgradePrimariesRequest{Opts: opts}, stream)
 		if err != nil {
 			t.Fatalf("unexpected error %+v", err)
 		}
@@ -156,7 +251,7 @@ func TestUpgradePrimaries(t *testing.T) {
 			},
 		}
 
-		_, err := agentServer.UpgradePrimaries(context.Background(), &idl.UpgradePrimariesRequest{Opts: opts})
+		err := agentServer.UpgradePrimaries(&idl.UpgradePrimariesRequest{Opts: opts}, stream)
 		var exitErr *exec.ExitError
 		if !errors.As(err, &exitErr) {
 			t.Fatalf("got error %T, want type %T", err, exitErr)
@@ -188,7 +283,7 @@ func TestUpgradePrimaries(t *testing.T) {
 			},
 		}
 
-		_, err := agentServer.UpgradePrimaries(context.Background(), &idl.UpgradePrimariesRequest{Opts: opts})
+		err := agentServer.UpgradePrimaries(&idl.UpgradePrimariesRequest{Opts: opts}, stream)
 		var linkErr *os.LinkError
 		if !errors.As(err, &linkErr) {
 			t.Errorf("got error %T, want type %T", err, linkErr)
@@ -211,7 +306,7 @@ func TestUpgradePrimaries(t *testing.T) {
 			{Role: greenplum.PrimaryRole, Action: idl.PgOptions_upgrade, TargetVersion: "6.0.0", ContentID: 2, OldDBID: "2"},
 		}
 
-		_, err := agentServer.UpgradePrimaries(context.Background(), &idl.UpgradePrimariesRequest{Opts: opts})
+		err := agentServer.UpgradePrimaries(&idl.UpgradePrimariesRequest{Opts: opts}, stream)
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Errorf("error %T does not contain type %T", err, errs)
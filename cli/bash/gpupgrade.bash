@@ -421,6 +421,153 @@ _gpupgrade_apply()
     noun_aliases=()
 }
 
+_gpupgrade_attach_help()
+{
+    last_command="gpupgrade_attach_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_gpupgrade_attach()
+{
+    last_command="gpupgrade_attach"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--?")
+    flags+=("-?")
+    local_nonpersistent_flags+=("--?")
+    local_nonpersistent_flags+=("-?")
+    flags+=("--verbose")
+    flags+=("-v")
+    local_nonpersistent_flags+=("--verbose")
+    local_nonpersistent_flags+=("-v")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_gpupgrade_cancel_help()
+{
+    last_command="gpupgrade_cancel_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_gpupgrade_cancel()
+{
+    last_command="gpupgrade_cancel"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--?")
+    flags+=("-?")
+    local_nonpersistent_flags+=("--?")
+    local_nonpersistent_flags+=("-?")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_gpupgrade_check_help()
+{
+    last_command="gpupgrade_check_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_gpupgrade_check()
+{
+    last_command="gpupgrade_check"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--?")
+    flags+=("-?")
+    local_nonpersistent_flags+=("--?")
+    local_nonpersistent_flags+=("-?")
+    flags+=("--file=")
+    two_word_flags+=("--file")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file")
+    local_nonpersistent_flags+=("--file=")
+    local_nonpersistent_flags+=("-f")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _gpupgrade_config_show_help()
 {
     last_command="gpupgrade_config_show_help"
@@ -536,12 +683,20 @@ _gpupgrade_execute()
     flags+=("-?")
     local_nonpersistent_flags+=("--?")
     local_nonpersistent_flags+=("-?")
+    flags+=("--dry-run")
+    local_nonpersistent_flags+=("--dry-run")
     flags+=("--parent-backup-dirs=")
     two_word_flags+=("--parent-backup-dirs")
     local_nonpersistent_flags+=("--parent-backup-dirs")
     local_nonpersistent_flags+=("--parent-backup-dirs=")
+    flags+=("--pause-before=")
+    two_word_flags+=("--pause-before")
+    local_nonpersistent_flags+=("--pause-before")
+    local_nonpersistent_flags+=("--pause-before=")
     flags+=("--pg-upgrade-verbose")
     local_nonpersistent_flags+=("--pg-upgrade-verbose")
+    flags+=("--recover")
+    local_nonpersistent_flags+=("--recover")
     flags+=("--verbose")
     flags+=("-v")
     local_nonpersistent_flags+=("--verbose")
@@ -591,6 +746,18 @@ _gpupgrade_finalize()
     flags+=("-?")
     local_nonpersistent_flags+=("--?")
     local_nonpersistent_flags+=("-?")
+    flags+=("--dry-run")
+    local_nonpersistent_flags+=("--dry-run")
+    flags+=("--html-report")
+    local_nonpersistent_flags+=("--html-report")
+    flags+=("--pause-before=")
+    two_word_flags+=("--pause-before")
+    local_nonpersistent_flags+=("--pause-before")
+    local_nonpersistent_flags+=("--pause-before=")
+    flags+=("--recover")
+    local_nonpersistent_flags+=("--recover")
+    flags+=("--validate")
+    local_nonpersistent_flags+=("--validate")
     flags+=("--verbose")
     flags+=("-v")
     local_nonpersistent_flags+=("--verbose")
@@ -717,6 +884,12 @@ _gpupgrade_initialize()
     flags+=("-?")
     local_nonpersistent_flags+=("--?")
     local_nonpersistent_flags+=("-?")
+    flags+=("--agent-heartbeat-interval=")
+    two_word_flags+=("--agent-heartbeat-interval")
+    local_nonpersistent_flags+=("--agent-heartbeat-interval")
+    local_nonpersistent_flags+=("--agent-heartbeat-interval=")
+    flags+=("--agent-listen-on-hostname")
+    local_nonpersistent_flags+=("--agent-listen-on-hostname")
     flags+=("--agent-port=")
     two_word_flags+=("--agent-port")
     local_nonpersistent_flags+=("--agent-port")
@@ -725,20 +898,52 @@ _gpupgrade_initialize()
     two_word_flags+=("--disk-free-ratio")
     local_nonpersistent_flags+=("--disk-free-ratio")
     local_nonpersistent_flags+=("--disk-free-ratio=")
+    flags+=("--dry-run")
+    local_nonpersistent_flags+=("--dry-run")
     flags+=("--dynamic-library-path=")
     two_word_flags+=("--dynamic-library-path")
     local_nonpersistent_flags+=("--dynamic-library-path")
     local_nonpersistent_flags+=("--dynamic-library-path=")
+    flags+=("--estimate-disk-space")
+    local_nonpersistent_flags+=("--estimate-disk-space")
+    flags+=("--execute-rsync-options=")
+    two_word_flags+=("--execute-rsync-options")
+    local_nonpersistent_flags+=("--execute-rsync-options")
+    local_nonpersistent_flags+=("--execute-rsync-options=")
     flags+=("--file=")
     two_word_flags+=("--file")
     two_word_flags+=("-f")
     local_nonpersistent_flags+=("--file")
     local_nonpersistent_flags+=("--file=")
     local_nonpersistent_flags+=("-f")
+    flags+=("--finalize-rsync-options=")
+    two_word_flags+=("--finalize-rsync-options")
+    local_nonpersistent_flags+=("--finalize-rsync-options")
+    local_nonpersistent_flags+=("--finalize-rsync-options=")
+    flags+=("--hooks-dir=")
+    two_word_flags+=("--hooks-dir")
+    local_nonpersistent_flags+=("--hooks-dir")
+    local_nonpersistent_flags+=("--hooks-dir=")
+    flags+=("--host-concurrency=")
+    two_word_flags+=("--host-concurrency")
+    local_nonpersistent_flags+=("--host-concurrency")
+    local_nonpersistent_flags+=("--host-concurrency=")
+    flags+=("--hub-listen-address=")
+    two_word_flags+=("--hub-listen-address")
+    local_nonpersistent_flags+=("--hub-listen-address")
+    local_nonpersistent_flags+=("--hub-listen-address=")
     flags+=("--hub-port=")
     two_word_flags+=("--hub-port")
     local_nonpersistent_flags+=("--hub-port")
     local_nonpersistent_flags+=("--hub-port=")
+    flags+=("--log-format=")
+    two_word_flags+=("--log-format")
+    local_nonpersistent_flags+=("--log-format")
+    local_nonpersistent_flags+=("--log-format=")
+    flags+=("--metrics-port=")
+    two_word_flags+=("--metrics-port")
+    local_nonpersistent_flags+=("--metrics-port")
+    local_nonpersistent_flags+=("--metrics-port=")
     flags+=("--mode=")
     two_word_flags+=("--mode")
     local_nonpersistent_flags+=("--mode")
@@ -747,12 +952,34 @@ _gpupgrade_initialize()
     two_word_flags+=("--parent-backup-dirs")
     local_nonpersistent_flags+=("--parent-backup-dirs")
     local_nonpersistent_flags+=("--parent-backup-dirs=")
+    flags+=("--pause-before=")
+    two_word_flags+=("--pause-before")
+    local_nonpersistent_flags+=("--pause-before")
+    local_nonpersistent_flags+=("--pause-before=")
+    flags+=("--pg-upgrade-concurrency=")
+    two_word_flags+=("--pg-upgrade-concurrency")
+    local_nonpersistent_flags+=("--pg-upgrade-concurrency")
+    local_nonpersistent_flags+=("--pg-upgrade-concurrency=")
     flags+=("--pg-upgrade-jobs=")
     two_word_flags+=("--pg-upgrade-jobs")
     local_nonpersistent_flags+=("--pg-upgrade-jobs")
     local_nonpersistent_flags+=("--pg-upgrade-jobs=")
     flags+=("--pg-upgrade-verbose")
     local_nonpersistent_flags+=("--pg-upgrade-verbose")
+    flags+=("--recover")
+    local_nonpersistent_flags+=("--recover")
+    flags+=("--remote-executor=")
+    two_word_flags+=("--remote-executor")
+    local_nonpersistent_flags+=("--remote-executor")
+    local_nonpersistent_flags+=("--remote-executor=")
+    flags+=("--revert-rsync-options=")
+    two_word_flags+=("--revert-rsync-options")
+    local_nonpersistent_flags+=("--revert-rsync-options")
+    local_nonpersistent_flags+=("--revert-rsync-options=")
+    flags+=("--rsync-concurrency=")
+    two_word_flags+=("--rsync-concurrency")
+    local_nonpersistent_flags+=("--rsync-concurrency")
+    local_nonpersistent_flags+=("--rsync-concurrency=")
     flags+=("--source-gphome=")
     two_word_flags+=("--source-gphome")
     local_nonpersistent_flags+=("--source-gphome")
@@ -769,8 +996,24 @@ _gpupgrade_initialize()
     two_word_flags+=("--temp-port-range")
     local_nonpersistent_flags+=("--temp-port-range")
     local_nonpersistent_flags+=("--temp-port-range=")
+    flags+=("--tls-ca-file=")
+    two_word_flags+=("--tls-ca-file")
+    local_nonpersistent_flags+=("--tls-ca-file")
+    local_nonpersistent_flags+=("--tls-ca-file=")
+    flags+=("--tls-cert-file=")
+    two_word_flags+=("--tls-cert-file")
+    local_nonpersistent_flags+=("--tls-cert-file")
+    local_nonpersistent_flags+=("--tls-cert-file=")
+    flags+=("--tls-key-file=")
+    two_word_flags+=("--tls-key-file")
+    local_nonpersistent_flags+=("--tls-key-file")
+    local_nonpersistent_flags+=("--tls-key-file=")
     flags+=("--use-hba-hostnames")
     local_nonpersistent_flags+=("--use-hba-hostnames")
+    flags+=("--validation-row-count-sample=")
+    two_word_flags+=("--validation-row-count-sample")
+    local_nonpersistent_flags+=("--validation-row-count-sample")
+    local_nonpersistent_flags+=("--validation-row-count-sample=")
     flags+=("--verbose")
     flags+=("-v")
     local_nonpersistent_flags+=("--verbose")
@@ -860,6 +1103,12 @@ _gpupgrade_revert()
     flags+=("-?")
     local_nonpersistent_flags+=("--?")
     local_nonpersistent_flags+=("-?")
+    flags+=("--dry-run")
+    local_nonpersistent_flags+=("--dry-run")
+    flags+=("--html-report")
+    local_nonpersistent_flags+=("--html-report")
+    flags+=("--recover")
+    local_nonpersistent_flags+=("--recover")
     flags+=("--verbose")
     flags+=("-v")
     local_nonpersistent_flags+=("--verbose")
@@ -870,6 +1119,114 @@ _gpupgrade_revert()
     noun_aliases=()
 }
 
+_gpupgrade_status_help()
+{
+    last_command="gpupgrade_status_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_gpupgrade_status()
+{
+    last_command="gpupgrade_status"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--?")
+    flags+=("-?")
+    local_nonpersistent_flags+=("--?")
+    local_nonpersistent_flags+=("-?")
+    flags+=("--format=")
+    two_word_flags+=("--format")
+    local_nonpersistent_flags+=("--format")
+    local_nonpersistent_flags+=("--format=")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_gpupgrade_validate_help()
+{
+    last_command="gpupgrade_validate_help"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_gpupgrade_validate()
+{
+    last_command="gpupgrade_validate"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("help")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--?")
+    flags+=("-?")
+    local_nonpersistent_flags+=("--?")
+    local_nonpersistent_flags+=("-?")
+    flags+=("--json")
+    local_nonpersistent_flags+=("--json")
+    flags+=("--snapshot=")
+    two_word_flags+=("--snapshot")
+    local_nonpersistent_flags+=("--snapshot")
+    local_nonpersistent_flags+=("--snapshot=")
+    flags+=("--target-gphome=")
+    two_word_flags+=("--target-gphome")
+    local_nonpersistent_flags+=("--target-gphome")
+    local_nonpersistent_flags+=("--target-gphome=")
+    flags+=("--target-port=")
+    two_word_flags+=("--target-port")
+    local_nonpersistent_flags+=("--target-port")
+    local_nonpersistent_flags+=("--target-port=")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _gpupgrade_version()
 {
     last_command="gpupgrade_version"
@@ -902,6 +1259,9 @@ _gpupgrade_root_command()
 
     commands=()
     commands+=("apply")
+    commands+=("attach")
+    commands+=("cancel")
+    commands+=("check")
     commands+=("config")
     commands+=("execute")
     commands+=("finalize")
@@ -911,6 +1271,8 @@ _gpupgrade_root_command()
     commands+=("kill-services")
     commands+=("restart-services")
     commands+=("revert")
+    commands+=("status")
+    commands+=("validate")
     commands+=("version")
 
     flags=()
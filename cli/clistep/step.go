@@ -5,9 +5,12 @@ package clistep
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log"
+	"path/filepath"
 	"strings"
 
 	"golang.org/x/text/cases"
@@ -19,13 +22,13 @@ import (
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/substeps"
+	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/logger"
 	"github.com/greenplum-db/gpupgrade/utils/stopwatch"
 )
 
-const StepsFileName = "steps.json"
-
 const nextActionRunRevertText = "If you would like to return the cluster to its original state, please run \"gpupgrade revert\".\n"
 
 var additionalNextActions = map[idl.Step]string{
@@ -38,16 +41,21 @@ var additionalNextActions = map[idl.Step]string{
 type Step struct {
 	stepName     string
 	step         idl.Step
-	stepStore    StepStore
+	stepStore    step.StepStore
 	substepStore step.SubstepStore
 	streams      step.OutStreams
 	verbose      bool
 	stepTimer    *stopwatch.Stopwatch
 	lastSubstep  idl.Substep
+	dryRun       bool
+	recover      bool
+	hooks        step.Hooks
 	err          error
 }
 
-func NewStep(currentStep idl.Step, stepName string, stepStore StepStore, substepStore step.SubstepStore, streams step.OutStreams, verbose bool) (*Step, error) {
+func NewStep(currentStep idl.Step, stepName string, stepStore step.StepStore, substepStore step.SubstepStore, streams step.OutStreams, verbose bool) (*Step, error) {
+	logger.SetStep(currentStep.String())
+
 	return &Step{
 		stepName:     stepName,
 		step:         currentStep,
@@ -65,11 +73,11 @@ func Begin(currentStep idl.Step, verbose bool, nonInteractive bool, confirmation
 	// stdout as usual such that it appears when verbose is not set.
 	streams := step.NewLogStdStreams(verbose)
 
-	stepStore, err := NewStepFileStore()
+	stepStore, err := step.NewStepFileStore()
 	if err != nil {
 		context := fmt.Sprintf("Note: If commands were issued in order, ensure gpupgrade can write to %s", utils.GetStateDir())
-		wrappedErr := xerrors.Errorf("%v\n\n%v", StepErr, context)
-		return &Step{}, utils.NewNextActionErr(wrappedErr, RunInitialize)
+		wrappedErr := xerrors.Errorf("%v\n\n%v", step.StepErr, context)
+		return &Step{}, utils.NewNextActionErr(wrappedErr, step.RunInitialize)
 	}
 
 	err = stepStore.ValidateStep(currentStep)
@@ -108,6 +116,83 @@ func Begin(currentStep idl.Step, verbose bool, nonInteractive bool, confirmation
 	return NewStep(currentStep, stepName, stepStore, substepStore, streams, verbose)
 }
 
+// BeginDryRun begins a step where rather than running each substep its plan is
+// printed. That is whether it would run, be skipped since it already completed,
+// or be bypassed since its run condition is not met. Hub substeps are expected
+// to request a dry run from the hub. Nothing is prompted or written.
+func BeginDryRun(currentStep idl.Step, verbose bool) (*Step, error) {
+	streams := step.NewLogStdStreams(verbose)
+
+	exist, err := upgrade.PathExist(utils.GetStateDir())
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a state directory no steps have run, and the stores are left
+	// unset such that every substep is reported as would run.
+	var stepStore step.StepStore
+	var substepStore step.SubstepStore
+	if exist {
+		stepFileStore, err := step.NewStepFileStore()
+		if err != nil {
+			return nil, err
+		}
+
+		err = stepFileStore.ValidateStep(currentStep)
+		if err != nil {
+			return nil, err
+		}
+
+		stepStore = stepFileStore
+		substepStore = step.NewSubstepStoreUsingFile(filepath.Join(utils.GetStateDir(), step.SubstepsFileName))
+	}
+
+	stepName := cases.Title(language.English).String(currentStep.String())
+
+	text := fmt.Sprintf("\n%s dry run. No changes will be made.\n\n", stepName)
+	fmt.Print(text)
+	log.Print(text)
+
+	st, err := NewStep(currentStep, stepName, stepStore, substepStore, streams, verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	st.dryRun = true
+	return st, nil
+}
+
+// Recover enables re-running substeps that were left running by a previous
+// attempt rather than failing. The CLI substeps are safe to run again.
+func (s *Step) Recover() {
+	s.recover = true
+}
+
+// RunHooks runs the pre hook of the step now, the hooks before and after each
+// substep that runs, and the post hook of the step on Complete. A failing pre
+// hook fails the step or substep, while a failing post hook is only reported.
+func (s *Step) RunHooks(hooks step.Hooks) {
+	if s.dryRun {
+		return
+	}
+
+	s.hooks = hooks
+
+	if s.err != nil {
+		return
+	}
+
+	err := hooks.Run(context.Background(), s.streams, step.PreHook, s.step, idl.Substep_unknown_substep, idl.Status_running)
+	if err != nil {
+		s.err = err
+	}
+}
+
+// DryRun returns whether the substeps are being reported rather than run.
+func (s *Step) DryRun() bool {
+	return s.dryRun
+}
+
 func (s *Step) Err() error {
 	return s.err
 }
@@ -134,6 +219,10 @@ func (s *Step) AlwaysRun(substep idl.Substep, f func(streams step.OutStreams) er
 func (s *Step) RunConditionally(substep idl.Substep, shouldRun bool, f func(streams step.OutStreams) error) {
 	if !shouldRun {
 		log.Printf("%s skipped. Run condition not met.", substeps.SubstepDescriptions[substep].HelpText)
+		if s.dryRun && s.err == nil {
+			commanders.PrintPlan(&idl.SubstepPlan{Substep: substep, Action: idl.SubstepPlan_bypass})
+		}
+
 		return
 	}
 
@@ -145,6 +234,11 @@ func (s *Step) Run(substep idl.Substep, f func(streams step.OutStreams) error) {
 }
 
 func (s *Step) run(substep idl.Substep, f func(streams step.OutStreams) error, alwaysRun bool) {
+	if s.dryRun {
+		s.planSubstep(substep, alwaysRun)
+		return
+	}
+
 	var err error
 	defer func() {
 		if s.err == nil {
@@ -168,8 +262,8 @@ func (s *Step) run(substep idl.Substep, f func(streams step.OutStreams) error, a
 		return
 	}
 
-	if status == idl.Status_running {
-		err = fmt.Errorf("Found previous substep %s was running. Manual intervention needed to cleanup. Please contact support.", substep)
+	if status == idl.Status_running && !(s.recover && step.Recoverable(substep)) {
+		err = step.RunningErr(s.step, substep)
 		if pErr := s.printStatus(substep, idl.Status_failed); pErr != nil {
 			err = errorlist.Append(err, pErr)
 			return
@@ -178,6 +272,10 @@ func (s *Step) run(substep idl.Substep, f func(streams step.OutStreams) error, a
 		return
 	}
 
+	if status == idl.Status_running {
+		log.Printf("Found previous substep %s was running. Recovering: %s.", substep, substeps.RecoveryDescriptions[substep])
+	}
+
 	// Only re-run substeps that are failed or pending. Do not skip substeps that must always be run.
 	if status == idl.Status_complete && !alwaysRun {
 		if pErr := s.printStatus(substep, idl.Status_skipped); pErr != nil {
@@ -202,7 +300,19 @@ func (s *Step) run(substep idl.Substep, f func(streams step.OutStreams) error, a
 		return
 	}
 
-	err = f(s.streams)
+	logger.SetSubstep(substep.String())
+	defer logger.SetSubstep("")
+
+	result := idl.Status_failed
+	defer func() {
+		s.runPostHook(substep, result)
+	}()
+
+	err = s.hooks.Run(context.Background(), s.streams, step.PreHook, s.step, substep, idl.Status_running)
+	if err == nil {
+		err = f(s.streams)
+	}
+
 	if err != nil {
 		status := idl.Status_failed
 
@@ -215,32 +325,91 @@ func (s *Step) run(substep idl.Substep, f func(streams step.OutStreams) error, a
 			status = idl.Status_quit
 		}
 
+		result = status
+
 		if pErr := s.printStatus(substep, status); pErr != nil {
 			err = errorlist.Append(err, pErr)
 			return
 		}
 
+		if err != nil && s.substepStore != nil {
+			if wErr := s.substepStore.WriteError(s.step, substep, err); wErr != nil {
+				err = errorlist.Append(err, wErr)
+			}
+		}
+
 		return
 	}
 
+	result = idl.Status_complete
 	if pErr := s.printStatus(substep, idl.Status_complete); pErr != nil {
 		err = errorlist.Append(err, pErr)
 		return
 	}
 }
 
+// runPostHook reports rather than returns the error of the post hook of the
+// step or substep since it has already run.
+func (s *Step) runPostHook(substep idl.Substep, status idl.Status) {
+	err := s.hooks.Run(context.Background(), s.streams, step.PostHook, s.step, substep, status)
+	if err != nil {
+		log.Printf("post hook failed: %v", err)
+		_, _ = fmt.Fprintf(s.streams.Stderr(), "%v\n", err)
+	}
+}
+
+// planSubstep prints whether the substep would run or be skipped without
+// running it.
+func (s *Step) planSubstep(substep idl.Substep, alwaysRun bool) {
+	if s.err != nil {
+		return
+	}
+
+	status := idl.Status_unknown_status
+	if s.substepStore != nil {
+		var err error
+		status, err = s.substepStore.Read(s.step, substep)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			s.err = xerrors.Errorf("substep %q: %w", substep, err)
+			return
+		}
+	}
+
+	if status == idl.Status_running && !(s.recover && step.Recoverable(substep)) {
+		s.err = xerrors.Errorf("substep %q: %w", substep, step.RunningErr(s.step, substep))
+		return
+	}
+
+	action := idl.SubstepPlan_run
+	if status == idl.Status_complete && !alwaysRun {
+		action = idl.SubstepPlan_skip
+	}
+
+	commanders.PrintPlan(&idl.SubstepPlan{Substep: substep, Action: action})
+}
+
 func (s *Step) DisableStore() {
 	s.stepStore = nil
 	s.substepStore = nil
 }
 
 func (s *Step) Complete(completedText string) error {
+	if s.dryRun {
+		return s.completeDryRun()
+	}
+
 	if pErr := s.printDuration(s.stepName, s.stepTimer.Stop().String()); pErr != nil {
 		s.err = errorlist.Append(s.err, pErr)
 	}
 
+	var pausedErr step.PausedErr
+	paused := errors.As(s.Err(), &pausedErr)
+
 	status := idl.Status_complete
-	if s.Err() != nil {
+	switch {
+	case paused:
+		status = idl.Status_paused
+	case s.Err() != nil:
 		status = idl.Status_failed
 	}
 
@@ -250,6 +419,15 @@ func (s *Step) Complete(completedText string) error {
 		}
 	}
 
+	s.runPostHook(idl.Substep_unknown_substep, status)
+
+	if paused {
+		text := fmt.Sprintf("\n%s paused before substep %s.\nTo continue run \"gpupgrade %s\".\n", s.stepName, pausedErr.Substep, s.step)
+		fmt.Print(text)
+		log.Print(text)
+		return nil
+	}
+
 	if s.Err() != nil {
 		fmt.Println() // Separate the step status from the error text
 		if s.verbose {
@@ -282,6 +460,18 @@ func (s *Step) Complete(completedText string) error {
 	return nil
 }
 
+func (s *Step) completeDryRun() error {
+	if s.Err() != nil {
+		fmt.Println()
+		return s.Err()
+	}
+
+	text := fmt.Sprintf("\n%s dry run completed. No changes were made.\n", s.stepName)
+	fmt.Print(text)
+	log.Print(text)
+	return nil
+}
+
 func (s *Step) printStatus(substep idl.Substep, status idl.Status) error {
 	if substep == s.lastSubstep {
 		// For the same substep reset the cursor to overwrite the current status.
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/substeps"
 	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
 )
 
@@ -142,6 +144,67 @@ func TestSubstep(t *testing.T) {
 		}
 	})
 
+	t.Run("a dry run prints the plan of each substep without running them", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", filepath.Join(stateDir, "does-not-exist"))
+		defer resetEnv()
+
+		d := BufferStandardDescriptors(t)
+
+		st, err := clistep.BeginDryRun(idl.Step_initialize, false)
+		if err != nil {
+			d.Close()
+			t.Fatalf("unexpected err %#v", err)
+		}
+
+		var called bool
+		st.RunConditionally(idl.Substep_verify_gpdb_versions, false, func(streams step.OutStreams) error {
+			called = true
+			return nil
+		})
+
+		st.Run(idl.Substep_saving_source_cluster_config, func(streams step.OutStreams) error {
+			called = true
+			return nil
+		})
+
+		err = st.Complete("")
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		stdout, stderr := d.Collect()
+		d.Close()
+		if len(stderr) != 0 {
+			t.Errorf("unexpected stderr %#v", string(stderr))
+		}
+
+		if called {
+			t.Error("expected substeps to not be called")
+		}
+
+		expected := "\nInitialize dry run. No changes will be made.\n\n"
+		expected += commanders.FormatPlan(&idl.SubstepPlan{Substep: idl.Substep_verify_gpdb_versions, Action: idl.SubstepPlan_bypass}) + "\n"
+		expected += commanders.FormatPlan(&idl.SubstepPlan{Substep: idl.Substep_saving_source_cluster_config, Action: idl.SubstepPlan_run}) + "\n"
+		expected += "\nInitialize dry run completed. No changes were made.\n"
+
+		actual := string(stdout)
+		if actual != expected {
+			t.Errorf("output %#v want %#v", actual, expected)
+		}
+
+		exist, err := upgrade.PathExist(filepath.Join(stateDir, "does-not-exist"))
+		if err != nil {
+			t.Fatalf("unexpected err %#v", err)
+		}
+
+		if exist {
+			t.Error("expected the state directory to not be created")
+		}
+	})
+
 	t.Run("skips completed substeps", func(t *testing.T) {
 		substepStore := &MockSubstepStore{Status: idl.Status_complete}
 		st, err := clistep.NewStep(idl.Step_initialize, "initialize", &MockStepStore{}, substepStore, step.NewLogStdStreams(false), false)
@@ -185,17 +248,70 @@ func TestSubstep(t *testing.T) {
 			t.Errorf("unexpected err %#v", err)
 		}
 
-		st.Run(idl.Substep_check_disk_space, func(streams step.OutStreams) error {
+		st.Run(idl.Substep_generate_data_migration_scripts, func(streams step.OutStreams) error {
 			return nil
 		})
 
 		err = st.Complete("")
-		expected := fmt.Sprintf("Found previous substep %s was running. Manual intervention needed to cleanup.", idl.Substep_check_disk_space)
+		expected := fmt.Sprintf("Found previous substep %s was running. Manual intervention needed to cleanup.", idl.Substep_generate_data_migration_scripts)
 		if !strings.Contains(err.Error(), expected) {
 			t.Errorf("expected err %#v to contain %q", err, expected)
 		}
 	})
 
+	t.Run("errors with a next action to recover when a recoverable substep was previously running", func(t *testing.T) {
+		substepStore := &MockSubstepStore{Status: idl.Status_running}
+		st, err := clistep.NewStep(idl.Step_initialize, "initialize", &MockStepStore{}, substepStore, step.NewLogStdStreams(false), false)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		var called bool
+		st.Run(idl.Substep_start_hub, func(streams step.OutStreams) error {
+			called = true
+			return nil
+		})
+
+		if called {
+			t.Error("expected substep to not be called")
+		}
+
+		err = st.Complete("")
+		var nextActionsErr utils.NextActionErr
+		if !errors.As(err, &nextActionsErr) {
+			t.Fatalf("got type %T want %T", err, nextActionsErr)
+		}
+
+		expected := `To recover the substep run "gpupgrade initialize --recover"`
+		if !strings.Contains(nextActionsErr.NextAction, expected) {
+			t.Errorf("expected next action %q to contain %q", nextActionsErr.NextAction, expected)
+		}
+	})
+
+	t.Run("re-runs a recoverable substep that was previously running when recovering", func(t *testing.T) {
+		substepStore := &MockSubstepStore{Status: idl.Status_running}
+		st, err := clistep.NewStep(idl.Step_initialize, "initialize", &MockStepStore{}, substepStore, step.NewLogStdStreams(false), false)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		st.Recover()
+
+		var called bool
+		st.Run(idl.Substep_start_hub, func(streams step.OutStreams) error {
+			called = true
+			return nil
+		})
+
+		if !called {
+			t.Error("expected substep to be called")
+		}
+
+		if st.Err() != nil {
+			t.Errorf("unexpected err %#v", st.Err())
+		}
+	})
+
 	t.Run("when a CLI substep is quit by the user its status is printed without the generic next action error", func(t *testing.T) {
 		d := BufferStandardDescriptors(t)
 
@@ -443,8 +559,8 @@ func TestSubstep(t *testing.T) {
 			t.Errorf("got %T, want %T", err, nextActionsErr)
 		}
 
-		if nextActionsErr.NextAction != clistep.RunInitialize {
-			t.Errorf("got %q want %q", nextActionsErr.NextAction, clistep.RunInitialize)
+		if nextActionsErr.NextAction != step.RunInitialize {
+			t.Errorf("got %q want %q", nextActionsErr.NextAction, step.RunInitialize)
 		}
 	})
 
@@ -548,7 +664,7 @@ func TestStepStatus(t *testing.T) {
 	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
 	defer resetEnv()
 
-	stepStore, err := clistep.NewStepFileStore()
+	stepStore, err := step.NewStepFileStore()
 	if err != nil {
 		t.Fatalf("NewStepStore failed: %v", err)
 	}
@@ -648,6 +764,49 @@ func TestStepStatus(t *testing.T) {
 		}
 	})
 
+	t.Run("when a hub substep pauses it sets the step status to paused without error", func(t *testing.T) {
+		st, err := clistep.Begin(idl.Step_initialize, false, true, "")
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		st.RunHubSubstep(func(streams step.OutStreams) error {
+			return step.PausedErr{Substep: idl.Substep_init_target_cluster}
+		})
+
+		ran := false
+		st.Run(idl.Substep_check_disk_space, func(streams step.OutStreams) error {
+			ran = true
+			return nil
+		})
+
+		if ran {
+			t.Error("expected substep to not be run")
+		}
+
+		d := BufferStandardDescriptors(t)
+		err = st.Complete("")
+		stdout, _ := d.Collect()
+		d.Close()
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		expected := "Initialize paused before substep init_target_cluster.\nTo continue run \"gpupgrade initialize\".\n"
+		if !strings.HasSuffix(string(stdout), expected) {
+			t.Errorf("got stdout %q want suffix %q", stdout, expected)
+		}
+
+		status, err := stepStore.Read(idl.Step_initialize)
+		if err != nil {
+			t.Errorf("Read failed %#v", err)
+		}
+
+		if status != idl.Status_paused {
+			t.Errorf("got status %q want %q", status, idl.Status_paused)
+		}
+	})
+
 	t.Run("confirmation text is not printed when a step is invalid", func(t *testing.T) {
 		d := BufferStandardDescriptors(t)
 
@@ -725,6 +884,92 @@ Initialize in progress.
 	})
 }
 
+func TestStepHooks(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	writeHook := func(name string, script string) {
+		path := filepath.Join(dir, name)
+		testutils.MustWriteToFile(t, path, "#!/bin/sh\n"+script)
+		if err := os.Chmod(path, 0700); err != nil {
+			t.Fatalf("chmod %q: %v", path, err)
+		}
+	}
+
+	writeHook("post-initialize", `echo "$GPUPGRADE_STATUS" > post-initialize.out`)
+
+	t.Run("runs the hooks of the step and its substeps", func(t *testing.T) {
+		writeHook("pre-check_disk_space", `echo "before $GPUPGRADE_SUBSTEP"`)
+		defer testutils.MustRemoveAll(t, filepath.Join(dir, "pre-check_disk_space"))
+
+		streams := &step.BufferedStreams{}
+		st, err := clistep.NewStep(idl.Step_initialize, "Initialize", &MockStepStore{}, &MockSubstepStore{}, streams, false)
+		if err != nil {
+			t.Fatalf("unexpected err %#v", err)
+		}
+
+		st.RunHooks(step.Hooks{Dir: dir})
+		st.Run(idl.Substep_check_disk_space, func(streams step.OutStreams) error {
+			return nil
+		})
+
+		d := BufferStandardDescriptors(t)
+		err = st.Complete("")
+		d.Close()
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		if !strings.HasPrefix(streams.StdoutBuf.String(), "before check_disk_space\n") {
+			t.Errorf("got stdout %q", streams.StdoutBuf.String())
+		}
+
+		status := testutils.MustReadFile(t, filepath.Join(dir, "post-initialize.out"))
+		if status != "complete\n" {
+			t.Errorf("got status %q want %q", status, "complete\n")
+		}
+	})
+
+	t.Run("a failing pre hook of the step fails the step without running its substeps", func(t *testing.T) {
+		writeHook("pre-initialize", "exit 1")
+		defer testutils.MustRemoveAll(t, filepath.Join(dir, "pre-initialize"))
+
+		stepStore := &MockStepStore{}
+		st, err := clistep.NewStep(idl.Step_initialize, "Initialize", stepStore, &MockSubstepStore{}, step.DevNullStream, false)
+		if err != nil {
+			t.Fatalf("unexpected err %#v", err)
+		}
+
+		st.RunHooks(step.Hooks{Dir: dir})
+
+		ran := false
+		st.Run(idl.Substep_check_disk_space, func(streams step.OutStreams) error {
+			ran = true
+			return nil
+		})
+
+		if ran {
+			t.Error("expected substep to not be run")
+		}
+
+		d := BufferStandardDescriptors(t)
+		err = st.Complete("")
+		d.Close()
+		if err == nil || !strings.Contains(err.Error(), "pre-initialize") {
+			t.Errorf("got error %#v want the pre hook to fail the step", err)
+		}
+
+		if stepStore.Status != idl.Status_failed {
+			t.Errorf("got status %q want %q", stepStore.Status, idl.Status_failed)
+		}
+
+		status := testutils.MustReadFile(t, filepath.Join(dir, "post-initialize.out"))
+		if status != "failed\n" {
+			t.Errorf("got status %q want %q", status, "failed\n")
+		}
+	})
+}
+
 func TestPrompt(t *testing.T) {
 	t.Run("returns error when failing to read input", func(t *testing.T) {
 		input := ""
@@ -801,8 +1046,9 @@ func (s *MockStepStore) HasStatus(step idl.Step, check func(status idl.Status) b
 }
 
 type MockSubstepStore struct {
-	Status   idl.Status
-	WriteErr error
+	Status    idl.Status
+	LastError error
+	WriteErr  error
 }
 
 func (t *MockSubstepStore) Read(_ idl.Step, substep idl.Substep) (idl.Status, error) {
@@ -813,3 +1059,8 @@ func (t *MockSubstepStore) Write(_ idl.Step, substep idl.Substep, status idl.Sta
 	t.Status = status
 	return t.WriteErr
 }
+
+func (t *MockSubstepStore) WriteError(_ idl.Step, substep idl.Substep, err error) error {
+	t.LastError = err
+	return t.WriteErr
+}
@@ -21,6 +21,7 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/report"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
@@ -128,6 +129,16 @@ func ApplyDataMigrationScripts(streams step.OutStreams, nonInteractive bool, gph
 		}
 	}
 
+	var scripts []string
+	for _, scriptDir := range scriptDirsToRun {
+		scripts = append(scripts, filepath.Base(scriptDir))
+	}
+
+	err = report.RecordDataMigrationScripts(logDir, phase, scripts)
+	if err != nil {
+		return err
+	}
+
 	if phase == idl.Step_stats {
 		fmt.Print(color.YellowString("\nTo receive an upgrade time estimate send the stats output:\n%s\n", utils.Bold.Sprint(filepath.Join(logDir, "apply_"+phase.String()+".log"))))
 	}
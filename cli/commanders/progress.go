@@ -0,0 +1,107 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/vbauerster/mpb/v8"
+	"github.com/vbauerster/mpb/v8/decor"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+// segmentProgress displays a line per segment showing the pg_upgrade stage
+// it is on and how many stages have completed.
+type segmentProgress struct {
+	progress *mpb.Progress
+	bars     map[string]*segmentBar
+	order    []string
+}
+
+type segmentBar struct {
+	bar   *mpb.Bar
+	mutex sync.Mutex
+	stage string
+	done  bool
+}
+
+func newSegmentProgress() *segmentProgress {
+	return &segmentProgress{
+		progress: mpb.New(),
+		bars:     make(map[string]*segmentBar),
+	}
+}
+
+func (s *segmentProgress) update(progress *idl.SegmentProgress) {
+	key := fmt.Sprintf("%s content %d", progress.GetHost(), progress.GetContentID())
+
+	segment, ok := s.bars[key]
+	if !ok {
+		segment = &segmentBar{}
+		segment.bar = s.progress.New(0,
+			mpb.NopStyle(),
+			mpb.PrependDecorators(decor.Name("  "+key, decor.WCSyncSpaceR)),
+			mpb.AppendDecorators(decor.Any(segment.status)))
+
+		s.bars[key] = segment
+		s.order = append(s.order, key)
+	}
+
+	// Update the status before the bar since the bar renders the status
+	// using the same lock.
+	segment.mutex.Lock()
+	if segment.done {
+		segment.mutex.Unlock()
+		return
+	}
+
+	segment.stage = progress.GetStage()
+	switch {
+	case progress.GetFailed():
+		segment.stage = "failed"
+		segment.done = true
+	case progress.GetDone():
+		segment.stage = "done"
+		segment.done = true
+	}
+	segment.mutex.Unlock()
+
+	segment.bar.SetCurrent(int64(progress.GetCompletedStages()))
+
+	switch {
+	case progress.GetFailed():
+		segment.bar.Abort(false)
+	case progress.GetDone():
+		segment.bar.SetTotal(-1, true)
+	}
+}
+
+// finish stops the display once the substep completes. Segments that did not
+// report being done, such as when the connection to an agent is lost, are
+// aborted.
+func (s *segmentProgress) finish() {
+	for _, key := range s.order {
+		segment := s.bars[key]
+
+		segment.mutex.Lock()
+		done := segment.done
+		segment.done = true
+		segment.mutex.Unlock()
+
+		if !done {
+			segment.bar.Abort(false)
+		}
+	}
+
+	s.progress.Wait()
+}
+
+func (s *segmentBar) status(stats decor.Statistics) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return fmt.Sprintf("%s (%d stages completed)", s.stage, stats.Current)
+}
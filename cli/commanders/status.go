@@ -0,0 +1,327 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/substeps"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+const NoUpgradeInProgressText = `No upgrade is in progress.
+
+NEXT ACTIONS
+------------
+To begin the upgrade, run "gpupgrade initialize".`
+
+const statusTimeFormat = "2006-01-02 15:04:05"
+
+// StatusText returns a human readable report of each step and substep
+// including their status, start time and duration followed by the next
+// actions.
+func StatusText(reply *idl.GetStatusReply) string {
+	var b strings.Builder
+
+	for _, stepStatus := range reply.GetSteps() {
+		title := cases.Title(language.English).String(stepStatus.GetStep().String())
+		fmt.Fprintf(&b, "%-67s%s\n", title, statusIndicator(stepStatus.GetStatus()))
+
+		for _, substep := range stepStatus.GetSubsteps() {
+			startTime, duration := "-", "-"
+			if substep.GetStartTime() != nil {
+				startTime = substep.GetStartTime().AsTime().Local().Format(statusTimeFormat)
+			}
+
+			if substep.GetDuration() != nil {
+				duration = substep.GetDuration().AsDuration().Round(time.Second).String()
+			}
+
+			fmt.Fprintf(&b, "  %-65s%-13s%-21s%s\n",
+				substeps.SubstepDescriptions[substep.GetSubstep()].OutputText,
+				statusIndicator(substep.GetStatus()), startTime, duration)
+
+			writeSegmentStatuses(&b, substep.GetSegments())
+			writeRsyncStats(&b, substep.GetRsyncStats())
+		}
+
+		b.WriteString("\n")
+	}
+
+	writeAgentStatuses(&b, reply.GetAgents())
+
+	b.WriteString("NEXT ACTIONS\n------------\n")
+	if reply.GetNextStep() == idl.Step_unknown_step {
+		b.WriteString("There are no gpupgrade commands left to run.\n")
+	} else {
+		fmt.Fprintf(&b, "Run \"gpupgrade %s\".\n", reply.GetNextStep())
+	}
+
+	var valid []string
+	for _, s := range reply.GetValidSteps() {
+		valid = append(valid, s.String())
+	}
+
+	if len(valid) > 0 {
+		fmt.Fprintf(&b, "Valid commands: %s\n", strings.Join(valid, ", "))
+	}
+
+	return b.String()
+}
+
+// writeSegmentStatuses summarizes the segments of substeps that track them
+// individually. Completed contents are listed as ranges to stay readable for
+// large clusters while any others are listed along with their host.
+func writeSegmentStatuses(b *strings.Builder, segments []*idl.SegmentStatus) {
+	if len(segments) == 0 {
+		return
+	}
+
+	var complete []int32
+	others := make(map[idl.Status][]string)
+	for _, segment := range segments {
+		if segment.GetStatus() == idl.Status_complete {
+			complete = append(complete, segment.GetContentID())
+			continue
+		}
+
+		others[segment.GetStatus()] = append(others[segment.GetStatus()], fmt.Sprintf("%d on %s", segment.GetContentID(), segment.GetHost()))
+	}
+
+	fmt.Fprintf(b, "    %d of %d segments complete\n", len(complete), len(segments))
+	if len(complete) > 0 {
+		fmt.Fprintf(b, "    complete contents: %s\n", contentRanges(complete))
+	}
+
+	for _, status := range []idl.Status{idl.Status_running, idl.Status_failed} {
+		if len(others[status]) > 0 {
+			fmt.Fprintf(b, "    %s contents: %s\n", status, strings.Join(others[status], ", "))
+		}
+	}
+}
+
+// writeRsyncStats summarizes the data transferred by substeps that use rsync.
+func writeRsyncStats(b *strings.Builder, stats *idl.RsyncStats) {
+	if stats == nil {
+		return
+	}
+
+	fmt.Fprintf(b, "    rsync: %d files transferred, %s sent, %s received, speedup %.2f, %s elapsed\n",
+		stats.GetFilesTransferred(),
+		disk.FormatBytes(uint64(stats.GetBytesSent())/1000),
+		disk.FormatBytes(uint64(stats.GetBytesReceived())/1000),
+		stats.GetSpeedup(),
+		stats.GetElapsed().AsDuration().Round(time.Second))
+}
+
+// writeAgentStatuses shows the result of the latest heartbeat sent to each
+// agent. The health is from the last successful heartbeat.
+func writeAgentStatuses(b *strings.Builder, agents []*idl.AgentStatus) {
+	if len(agents) == 0 {
+		return
+	}
+
+	b.WriteString("Agents\n")
+	for _, agent := range agents {
+		indicator := "[RESPONDING]"
+		if agent.GetError() != "" {
+			indicator = "[NOT RESPONDING]"
+		}
+
+		lastHeartbeat := "-"
+		if agent.GetLastHeartbeat() != nil {
+			lastHeartbeat = agent.GetLastHeartbeat().AsTime().Local().Format(statusTimeFormat)
+		}
+
+		fmt.Fprintf(b, "  %-65s%-18s%s\n", agent.GetHost(), indicator, lastHeartbeat)
+
+		if agent.GetLastHeartbeat() != nil {
+			health := agent.GetHealth()
+			fmt.Fprintf(b, "    version %s, pid %d, uptime %s, load average %.2f %.2f %.2f, %s of %s available in %s\n",
+				health.GetVersion(), health.GetPid(), health.GetUptime().AsDuration().Round(time.Second),
+				health.GetLoadAverage1(), health.GetLoadAverage5(), health.GetLoadAverage15(),
+				disk.FormatBytes(health.GetDiskAvailable()), disk.FormatBytes(health.GetDiskTotal()), health.GetStateDirectory())
+		}
+
+		if agent.GetError() != "" {
+			fmt.Fprintf(b, "    error: %s\n", agent.GetError())
+		}
+	}
+
+	b.WriteString("\n")
+}
+
+// contentRanges collapses the sorted content IDs into ranges such as "0-3, 5".
+func contentRanges(contentIDs []int32) string {
+	var ranges []string
+	for i := 0; i < len(contentIDs); {
+		j := i
+		for j+1 < len(contentIDs) && contentIDs[j+1] == contentIDs[j]+1 {
+			j++
+		}
+
+		if i == j {
+			ranges = append(ranges, fmt.Sprintf("%d", contentIDs[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", contentIDs[i], contentIDs[j]))
+		}
+
+		i = j + 1
+	}
+
+	return strings.Join(ranges, ", ")
+}
+
+func statusIndicator(status idl.Status) string {
+	indicator, ok := indicators[status]
+	if !ok {
+		return "[PENDING]"
+	}
+
+	return indicator
+}
+
+type statusJSON struct {
+	Steps      []stepStatusJSON
+	ValidSteps []string
+	NextStep   string
+	Agents     []agentStatusJSON `json:",omitempty"`
+}
+
+type stepStatusJSON struct {
+	Step     string
+	Status   string
+	Substeps []substepStatusJSON
+}
+
+type substepStatusJSON struct {
+	Substep    string
+	Status     string
+	StartTime  *time.Time          `json:",omitempty"`
+	Duration   string              `json:",omitempty"`
+	Segments   []segmentStatusJSON `json:",omitempty"`
+	RsyncStats *rsyncStatsJSON     `json:",omitempty"`
+}
+
+type segmentStatusJSON struct {
+	Host      string
+	ContentID int32
+	Status    string
+}
+
+type agentStatusJSON struct {
+	Host           string
+	LastHeartbeat  *time.Time `json:",omitempty"`
+	Version        string
+	Pid            int32
+	Uptime         string
+	StateDirectory string
+	LoadAverage    [3]float64
+	DiskAvailable  uint64 // KB
+	DiskTotal      uint64 // KB
+	Error          string `json:",omitempty"`
+}
+
+type rsyncStatsJSON struct {
+	FilesTransferred int64
+	TotalFileSize    int64
+	BytesSent        int64
+	BytesReceived    int64
+	Speedup          float64
+	Elapsed          string
+}
+
+// StatusJSON returns the status report as JSON suitable for scripting.
+func StatusJSON(reply *idl.GetStatusReply) ([]byte, error) {
+	output := statusJSON{
+		Steps:      []stepStatusJSON{},
+		ValidSteps: []string{},
+	}
+
+	for _, stepStatus := range reply.GetSteps() {
+		s := stepStatusJSON{
+			Step:     stepStatus.GetStep().String(),
+			Status:   stepStatus.GetStatus().String(),
+			Substeps: []substepStatusJSON{},
+		}
+
+		for _, substep := range stepStatus.GetSubsteps() {
+			ss := substepStatusJSON{
+				Substep: substep.GetSubstep().String(),
+				Status:  substep.GetStatus().String(),
+			}
+
+			if substep.GetStartTime() != nil {
+				startTime := substep.GetStartTime().AsTime()
+				ss.StartTime = &startTime
+			}
+
+			if substep.GetDuration() != nil {
+				ss.Duration = substep.GetDuration().AsDuration().String()
+			}
+
+			for _, segment := range substep.GetSegments() {
+				ss.Segments = append(ss.Segments, segmentStatusJSON{
+					Host:      segment.GetHost(),
+					ContentID: segment.GetContentID(),
+					Status:    segment.GetStatus().String(),
+				})
+			}
+
+			if stats := substep.GetRsyncStats(); stats != nil {
+				ss.RsyncStats = &rsyncStatsJSON{
+					FilesTransferred: stats.GetFilesTransferred(),
+					TotalFileSize:    stats.GetTotalFileSize(),
+					BytesSent:        stats.GetBytesSent(),
+					BytesReceived:    stats.GetBytesReceived(),
+					Speedup:          stats.GetSpeedup(),
+					Elapsed:          stats.GetElapsed().AsDuration().String(),
+				}
+			}
+
+			s.Substeps = append(s.Substeps, ss)
+		}
+
+		output.Steps = append(output.Steps, s)
+	}
+
+	for _, s := range reply.GetValidSteps() {
+		output.ValidSteps = append(output.ValidSteps, s.String())
+	}
+
+	if reply.GetNextStep() != idl.Step_unknown_step {
+		output.NextStep = reply.GetNextStep().String()
+	}
+
+	for _, agent := range reply.GetAgents() {
+		health := agent.GetHealth()
+		a := agentStatusJSON{
+			Host:           agent.GetHost(),
+			Version:        health.GetVersion(),
+			Pid:            health.GetPid(),
+			Uptime:         health.GetUptime().AsDuration().String(),
+			StateDirectory: health.GetStateDirectory(),
+			LoadAverage:    [3]float64{health.GetLoadAverage1(), health.GetLoadAverage5(), health.GetLoadAverage15()},
+			DiskAvailable:  health.GetDiskAvailable(),
+			DiskTotal:      health.GetDiskTotal(),
+			Error:          agent.GetError(),
+		}
+
+		if agent.GetLastHeartbeat() != nil {
+			lastHeartbeat := agent.GetLastHeartbeat().AsTime()
+			a.LastHeartbeat = &lastHeartbeat
+		}
+
+		output.Agents = append(output.Agents, a)
+	}
+
+	return json.MarshalIndent(output, "", "  ")
+}
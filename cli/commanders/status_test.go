@@ -0,0 +1,194 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func TestStatus(t *testing.T) {
+	startTime := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+
+	reply := &idl.GetStatusReply{
+		Steps: []*idl.StepStatus{{
+			Step:   idl.Step_initialize,
+			Status: idl.Status_complete,
+			Substeps: []*idl.SubstepDetails{
+				{Substep: idl.Substep_saving_source_cluster_config, Status: idl.Status_complete, StartTime: timestamppb.New(startTime), Duration: durationpb.New(90 * time.Second)},
+				{Substep: idl.Substep_start_hub, Status: idl.Status_failed},
+			},
+		}, {
+			Step:   idl.Step_execute,
+			Status: idl.Status_failed,
+			Substeps: []*idl.SubstepDetails{
+				{Substep: idl.Substep_copy_master, Status: idl.Status_complete, RsyncStats: &idl.RsyncStats{
+					FilesTransferred: 1100,
+					TotalFileSize:    52428800,
+					BytesSent:        10485760,
+					BytesReceived:    20972,
+					Speedup:          4.99,
+					Elapsed:          durationpb.New(2 * time.Minute),
+				}},
+				{Substep: idl.Substep_upgrade_primaries, Status: idl.Status_failed, Segments: []*idl.SegmentStatus{
+					{Host: "sdw1", ContentID: 0, Status: idl.Status_complete},
+					{Host: "sdw1", ContentID: 1, Status: idl.Status_complete},
+					{Host: "sdw1", ContentID: 2, Status: idl.Status_complete},
+					{Host: "sdw2", ContentID: 3, Status: idl.Status_failed},
+					{Host: "sdw2", ContentID: 4, Status: idl.Status_complete},
+				}},
+			},
+		}},
+		ValidSteps: []idl.Step{idl.Step_initialize, idl.Step_revert},
+		NextStep:   idl.Step_initialize,
+		Agents: []*idl.AgentStatus{
+			{Host: "sdw1", LastHeartbeat: timestamppb.New(startTime), Health: &idl.AgentHealth{
+				Version:        "1.2.3",
+				Pid:            42,
+				Uptime:         durationpb.New(time.Hour),
+				StateDirectory: "/home/gpadmin/.gpupgrade",
+				LoadAverage1:   0.5,
+				LoadAverage5:   0.25,
+				LoadAverage15:  0.125,
+				DiskAvailable:  2000,
+				DiskTotal:      8000,
+			}},
+			{Host: "sdw2", Health: &idl.AgentHealth{}, Error: "connection refused"},
+		},
+	}
+
+	t.Run("formats the status as text", func(t *testing.T) {
+		text := commanders.StatusText(reply)
+
+		expected := []string{
+			"  Saving source cluster configuration...                           [COMPLETE]   ",
+			startTime.Local().Format("2006-01-02 15:04:05") + "  1m30s",
+			"Starting gpupgrade hub process...",
+			"    4 of 5 segments complete\n",
+			"    complete contents: 0-2, 4\n",
+			"    failed contents: 3 on sdw2\n",
+			"    rsync: 1100 files transferred, 10.48 MB sent, 20 KB received, speedup 4.99, 2m0s elapsed\n",
+			"  sdw1                                                             [RESPONDING]      " + startTime.Local().Format("2006-01-02 15:04:05") + "\n",
+			"    version 1.2.3, pid 42, uptime 1h0m0s, load average 0.50 0.25 0.12, 2 MB of 8 MB available in /home/gpadmin/.gpupgrade\n",
+			"  sdw2                                                             [NOT RESPONDING]  -\n",
+			"    error: connection refused\n",
+			`Run "gpupgrade initialize".`,
+			"Valid commands: initialize, revert",
+		}
+		for _, e := range expected {
+			if !strings.Contains(text, e) {
+				t.Errorf("expected %q in %q", e, text)
+			}
+		}
+	})
+
+	t.Run("formats the status as json", func(t *testing.T) {
+		output, err := commanders.StatusJSON(reply)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		var actual map[string]interface{}
+		if err := json.Unmarshal(output, &actual); err != nil {
+			t.Fatalf("unmarshal %q: %v", output, err)
+		}
+
+		expected := map[string]interface{}{
+			"Steps": []interface{}{
+				map[string]interface{}{
+					"Step":   "initialize",
+					"Status": "complete",
+					"Substeps": []interface{}{
+						map[string]interface{}{
+							"Substep":   "saving_source_cluster_config",
+							"Status":    "complete",
+							"StartTime": "2023-04-05T06:07:08Z",
+							"Duration":  "1m30s",
+						},
+						map[string]interface{}{
+							"Substep": "start_hub",
+							"Status":  "failed",
+						},
+					},
+				},
+				map[string]interface{}{
+					"Step":   "execute",
+					"Status": "failed",
+					"Substeps": []interface{}{
+						map[string]interface{}{
+							"Substep": "copy_master",
+							"Status":  "complete",
+							"RsyncStats": map[string]interface{}{
+								"FilesTransferred": float64(1100),
+								"TotalFileSize":    float64(52428800),
+								"BytesSent":        float64(10485760),
+								"BytesReceived":    float64(20972),
+								"Speedup":          4.99,
+								"Elapsed":          "2m0s",
+							},
+						},
+						map[string]interface{}{
+							"Substep": "upgrade_primaries",
+							"Status":  "failed",
+							"Segments": []interface{}{
+								map[string]interface{}{"Host": "sdw1", "ContentID": float64(0), "Status": "complete"},
+								map[string]interface{}{"Host": "sdw1", "ContentID": float64(1), "Status": "complete"},
+								map[string]interface{}{"Host": "sdw1", "ContentID": float64(2), "Status": "complete"},
+								map[string]interface{}{"Host": "sdw2", "ContentID": float64(3), "Status": "failed"},
+								map[string]interface{}{"Host": "sdw2", "ContentID": float64(4), "Status": "complete"},
+							},
+						},
+					},
+				},
+			},
+			"ValidSteps": []interface{}{"initialize", "revert"},
+			"NextStep":   "initialize",
+			"Agents": []interface{}{
+				map[string]interface{}{
+					"Host":           "sdw1",
+					"LastHeartbeat":  "2023-04-05T06:07:08Z",
+					"Version":        "1.2.3",
+					"Pid":            float64(42),
+					"Uptime":         "1h0m0s",
+					"StateDirectory": "/home/gpadmin/.gpupgrade",
+					"LoadAverage":    []interface{}{0.5, 0.25, 0.125},
+					"DiskAvailable":  float64(2000),
+					"DiskTotal":      float64(8000),
+				},
+				map[string]interface{}{
+					"Host":           "sdw2",
+					"Version":        "",
+					"Pid":            float64(0),
+					"Uptime":         "0s",
+					"StateDirectory": "",
+					"LoadAverage":    []interface{}{0.0, 0.0, 0.0},
+					"DiskAvailable":  float64(0),
+					"DiskTotal":      float64(0),
+					"Error":          "connection refused",
+				},
+			},
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("got %v want %v", actual, expected)
+		}
+	})
+
+	t.Run("reports when no commands are left to run", func(t *testing.T) {
+		text := commanders.StatusText(&idl.GetStatusReply{})
+
+		expected := "There are no gpupgrade commands left to run."
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected %q in %q", expected, text)
+		}
+	})
+}
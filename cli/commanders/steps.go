@@ -15,6 +15,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/substeps"
 	"github.com/greenplum-db/gpupgrade/utils"
 )
@@ -29,6 +30,13 @@ var indicators = map[idl.Status]string{
 	idl.Status_failed:   "[FAILED]",
 	idl.Status_skipped:  "[SKIPPED]",
 	idl.Status_quit:     "[QUIT]",
+	idl.Status_paused:   "[PAUSED]",
+}
+
+var planIndicators = map[idl.SubstepPlan_Action]string{
+	idl.SubstepPlan_run:    "[WOULD RUN]",
+	idl.SubstepPlan_skip:   "[WOULD SKIP]",
+	idl.SubstepPlan_bypass: "[BYPASSED]",
 }
 
 func Initialize(client idl.CliToHubClient, request *idl.InitializeRequest, verbose bool) (err error) {
@@ -83,8 +91,8 @@ func Execute(client idl.CliToHubClient, request *idl.ExecuteRequest, verbose boo
 	return executeResponse, nil
 }
 
-func Finalize(client idl.CliToHubClient, verbose bool) (*idl.FinalizeResponse, error) {
-	stream, err := client.Finalize(context.Background(), &idl.FinalizeRequest{})
+func Finalize(client idl.CliToHubClient, request *idl.FinalizeRequest, verbose bool) (*idl.FinalizeResponse, error) {
+	stream, err := client.Finalize(context.Background(), request)
 	if err != nil {
 		return &idl.FinalizeResponse{}, err
 	}
@@ -102,8 +110,20 @@ func Finalize(client idl.CliToHubClient, verbose bool) (*idl.FinalizeResponse, e
 	return finalizeResponse, nil
 }
 
-func Revert(client idl.CliToHubClient, verbose bool) (*idl.RevertResponse, error) {
-	stream, err := client.Revert(context.Background(), &idl.RevertRequest{})
+// Attach replays the output of the running step and then follows it until it
+// ends. The step is unaffected when the viewer detaches.
+func Attach(client idl.CliToHubClient, verbose bool) error {
+	stream, err := client.Attach(context.Background(), &idl.AttachRequest{})
+	if err != nil {
+		return err
+	}
+
+	_, err = UILoop(stream, verbose)
+	return err
+}
+
+func Revert(client idl.CliToHubClient, request *idl.RevertRequest, verbose bool) (*idl.RevertResponse, error) {
+	stream, err := client.Revert(context.Background(), request)
 	if err != nil {
 		return &idl.RevertResponse{}, err
 	}
@@ -124,6 +144,9 @@ func Revert(client idl.CliToHubClient, verbose bool) (*idl.RevertResponse, error
 func UILoop(stream receiver, verbose bool) (*idl.Response, error) {
 	var response *idl.Response
 	var lastStep idl.Substep
+	var bypassed *idl.SubstepPlan
+	var progress *segmentProgress
+	paused := idl.Substep_unknown_substep
 	var err error
 
 	for {
@@ -146,6 +169,14 @@ func UILoop(stream receiver, verbose bool) (*idl.Response, error) {
 			}
 
 		case *idl.Message_Status:
+			// The segment progress is displayed below the status of the
+			// running substep, so print the next status on a new line.
+			if progress != nil {
+				progress.finish()
+				progress = nil
+				lastStep = idl.Substep_unknown_substep
+			}
+
 			// Rewrite the current line whenever we get an update for the
 			// current step. (This behavior is switched off in verbose mode,
 			// because it interferes with the output stream.)
@@ -160,6 +191,9 @@ func UILoop(stream receiver, verbose bool) (*idl.Response, error) {
 				}
 			}
 			lastStep = x.Status.Step
+			if x.Status.Status == idl.Status_paused {
+				paused = x.Status.Step
+			}
 
 			fmt.Print(FormatStatus(x.Status))
 			log.Print(FormatStatus(x.Status))
@@ -167,6 +201,35 @@ func UILoop(stream receiver, verbose bool) (*idl.Response, error) {
 				fmt.Println()
 			}
 
+		case *idl.Message_Progress:
+			// In verbose mode the pg_upgrade output is already printed.
+			if verbose {
+				continue
+			}
+
+			if progress == nil {
+				fmt.Println()
+				progress = newSegmentProgress()
+			}
+
+			progress.update(x.Progress)
+
+		case *idl.Message_Plan:
+			// A substep can be bypassed and then run such as when its run
+			// condition selects between alternatives. Hold onto bypassed
+			// substeps to only print them when not followed by a run.
+			if bypassed != nil && bypassed.GetSubstep() != x.Plan.GetSubstep() {
+				PrintPlan(bypassed)
+			}
+			bypassed = nil
+
+			if x.Plan.GetAction() == idl.SubstepPlan_bypass {
+				bypassed = x.Plan
+				continue
+			}
+
+			PrintPlan(x.Plan)
+
 		case *idl.Message_Response:
 			response = x.Response
 
@@ -175,10 +238,23 @@ func UILoop(stream receiver, verbose bool) (*idl.Response, error) {
 		}
 	}
 
+	if bypassed != nil {
+		PrintPlan(bypassed)
+	}
+
+	if progress != nil {
+		progress.finish()
+	}
+
 	if !verbose {
 		fmt.Println()
 	}
 
+	// The hub stops the step once it pauses before a substep.
+	if err != io.EOF && paused != idl.Substep_unknown_substep {
+		return response, step.PausedErr{Substep: paused}
+	}
+
 	if err != io.EOF {
 		statusErr, ok := status.FromError(err)
 		if !ok || len(statusErr.Details()) == 0 {
@@ -222,3 +298,33 @@ func Format(description string, status idl.Status) string {
 
 	return fmt.Sprintf("%-67s%-13s", description, indicator)
 }
+
+// PrintPlan prints and logs the plan of a substep.
+func PrintPlan(plan *idl.SubstepPlan) {
+	fmt.Println(FormatPlan(plan))
+	log.Print(FormatPlan(plan))
+}
+
+// FormatPlan returns what the substep would do during a dry run followed by
+// the commands it would issue.
+//
+// FormatPlan panics if it doesn't have a string representation for a given
+// substep or action.
+func FormatPlan(plan *idl.SubstepPlan) string {
+	line, ok := substeps.SubstepDescriptions[plan.GetSubstep()]
+	if !ok {
+		panic(fmt.Sprintf("unexpected step %#v", plan.GetSubstep()))
+	}
+
+	indicator, ok := planIndicators[plan.GetAction()]
+	if !ok {
+		panic(fmt.Sprintf("unexpected action %#v", plan.GetAction()))
+	}
+
+	text := fmt.Sprintf("%-67s%s", line.OutputText, indicator)
+	for _, cmd := range plan.GetCommands() {
+		text += fmt.Sprintf("\n    %s: %s", cmd.GetHost(), cmd.GetCommand())
+	}
+
+	return text
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/substeps"
 	"github.com/greenplum-db/gpupgrade/utils"
 )
@@ -43,6 +44,20 @@ func (m *errStream) Recv() (*idl.Message, error) {
 	return nil, m.err
 }
 
+// msgsThenErrStream returns the messages and then the error.
+type msgsThenErrStream struct {
+	msgs msgStream
+	err  error
+}
+
+func (m *msgsThenErrStream) Recv() (*idl.Message, error) {
+	if len(m.msgs) == 0 {
+		return nil, m.err
+	}
+
+	return m.msgs.Recv()
+}
+
 func TestUILoop(t *testing.T) {
 	t.Run("writes STDOUT and STDERR chunks in the order they are received", func(t *testing.T) {
 		msgs := msgStream{
@@ -128,6 +143,33 @@ func TestUILoop(t *testing.T) {
 		}
 	})
 
+	t.Run("returns a paused error when the step pauses before a substep", func(t *testing.T) {
+		stream := &msgsThenErrStream{
+			msgs: msgStream{
+				{Contents: &idl.Message_Status{Status: &idl.SubstepStatus{
+					Step:   idl.Substep_upgrade_primaries,
+					Status: idl.Status_paused,
+				}}},
+			},
+			err: status.New(codes.Internal, "paused before substep upgrade_primaries").Err(),
+		}
+
+		d := BufferStandardDescriptors(t)
+		defer d.Close()
+
+		_, err := commanders.UILoop(stream, false)
+		d.Collect()
+
+		var pausedErr step.PausedErr
+		if !errors.As(err, &pausedErr) {
+			t.Fatalf("got type %T want %T", err, pausedErr)
+		}
+
+		if pausedErr.Substep != idl.Substep_upgrade_primaries {
+			t.Errorf("got substep %s want %s", pausedErr.Substep, idl.Substep_upgrade_primaries)
+		}
+	})
+
 	t.Run("writes status and stdout chunks serially in verbose mode", func(t *testing.T) {
 		msgs := msgStream{
 			{Contents: &idl.Message_Status{Status: &idl.SubstepStatus{
@@ -227,6 +269,118 @@ func TestUILoop(t *testing.T) {
 		}
 	})
 
+	t.Run("finishes displaying segment progress before the next status in non-verbose mode", func(t *testing.T) {
+		msgs := msgStream{
+			{Contents: &idl.Message_Status{Status: &idl.SubstepStatus{
+				Step:   idl.Substep_upgrade_primaries,
+				Status: idl.Status_running,
+			}}},
+			{Contents: &idl.Message_Progress{Progress: &idl.SegmentProgress{
+				Host: "sdw1", ContentID: 0, Stage: "Copying user relation files", CompletedStages: 1,
+			}}},
+			{Contents: &idl.Message_Progress{Progress: &idl.SegmentProgress{
+				Host: "sdw2", ContentID: 1, Stage: "Setting next OID for new cluster", CompletedStages: 2, Failed: true,
+			}}},
+			{Contents: &idl.Message_Progress{Progress: &idl.SegmentProgress{
+				Host: "sdw1", ContentID: 0, Stage: "Copying user relation files", CompletedStages: 3, Done: true,
+			}}},
+			{Contents: &idl.Message_Status{Status: &idl.SubstepStatus{
+				Step:   idl.Substep_upgrade_primaries,
+				Status: idl.Status_failed,
+			}}},
+		}
+
+		running := commanders.FormatStatus(msgs[0].GetStatus()) + "\n"
+		failed := commanders.FormatStatus(msgs[4].GetStatus()) + "\n"
+
+		d := BufferStandardDescriptors(t)
+		defer d.Close()
+
+		_, err := commanders.UILoop(&msgs, false)
+		if err != nil {
+			t.Errorf("UILoop() returned %#v", err)
+		}
+
+		actualOut, actualErr := d.Collect()
+
+		if len(actualErr) != 0 {
+			t.Errorf("unexpected stderr %#v", string(actualErr))
+		}
+
+		// The progress bars are only rendered when stdout is a terminal.
+		actual, expected := string(actualOut), running+failed
+		if actual != expected {
+			t.Errorf("output %#v want %#v", actual, expected)
+		}
+	})
+
+	t.Run("ignores segment progress in verbose mode", func(t *testing.T) {
+		msgs := msgStream{
+			{Contents: &idl.Message_Progress{Progress: &idl.SegmentProgress{
+				Host: "sdw1", ContentID: 0, Stage: "Copying user relation files", CompletedStages: 1,
+			}}},
+		}
+
+		d := BufferStandardDescriptors(t)
+		defer d.Close()
+
+		_, err := commanders.UILoop(&msgs, true)
+		if err != nil {
+			t.Errorf("UILoop() returned %#v", err)
+		}
+
+		actualOut, actualErr := d.Collect()
+		if len(actualOut) != 0 || len(actualErr) != 0 {
+			t.Errorf("unexpected output %#v and stderr %#v", string(actualOut), string(actualErr))
+		}
+	})
+
+	t.Run("prints plans and only prints a bypassed substep when it is not then run", func(t *testing.T) {
+		msgs := msgStream{
+			{Contents: &idl.Message_Plan{Plan: &idl.SubstepPlan{
+				Substep: idl.Substep_check_active_connections_on_target_cluster,
+				Action:  idl.SubstepPlan_skip,
+			}}},
+			{Contents: &idl.Message_Plan{Plan: &idl.SubstepPlan{
+				Substep: idl.Substep_upgrade_mirrors,
+				Action:  idl.SubstepPlan_bypass,
+			}}},
+			{Contents: &idl.Message_Plan{Plan: &idl.SubstepPlan{
+				Substep:  idl.Substep_upgrade_mirrors,
+				Action:   idl.SubstepPlan_run,
+				Commands: []*idl.PlannedCommand{{Host: "cdw", Command: "gpaddmirrors -a"}},
+			}}},
+			{Contents: &idl.Message_Plan{Plan: &idl.SubstepPlan{
+				Substep: idl.Substep_upgrade_standby,
+				Action:  idl.SubstepPlan_bypass,
+			}}},
+		}
+
+		expected := commanders.FormatPlan(msgs[0].GetPlan()) + "\n"
+		expected += commanders.FormatPlan(msgs[2].GetPlan()) + "\n"
+		expected += commanders.FormatPlan(msgs[3].GetPlan()) + "\n"
+		expected += "\n"
+
+		d := BufferStandardDescriptors(t)
+		defer d.Close()
+
+		_, err := commanders.UILoop(&msgs, false)
+		if err != nil {
+			t.Errorf("UILoop() returned %#v", err)
+		}
+
+		actualOut, actualErr := d.Collect()
+
+		if len(actualErr) != 0 {
+			t.Errorf("unexpected stderr %#v", string(actualErr))
+		}
+
+		actual := string(actualOut)
+		if actual != expected {
+			t.Errorf("output %#v want %#v", actual, expected)
+		}
+	})
+
 	t.Run("processes responses successfully", func(t *testing.T) {
 		source := MustCreateCluster(t, greenplum.SegConfigs{
 			{ContentID: -1, DbID: 1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole, Port: 15432},
@@ -395,3 +549,41 @@ func TestFormatStatus(t *testing.T) {
 		}
 	})
 }
+
+func TestFormatPlan(t *testing.T) {
+	cases := []struct {
+		name     string
+		plan     *idl.SubstepPlan
+		expected string
+	}{
+		{
+			name:     "skip",
+			plan:     &idl.SubstepPlan{Substep: idl.Substep_upgrade_master, Action: idl.SubstepPlan_skip},
+			expected: "Upgrading master...                                                [WOULD SKIP]",
+		},
+		{
+			name:     "bypass",
+			plan:     &idl.SubstepPlan{Substep: idl.Substep_upgrade_master, Action: idl.SubstepPlan_bypass},
+			expected: "Upgrading master...                                                [BYPASSED]",
+		},
+		{
+			name: "run with commands",
+			plan: &idl.SubstepPlan{Substep: idl.Substep_upgrade_master, Action: idl.SubstepPlan_run, Commands: []*idl.PlannedCommand{
+				{Host: "cdw", Command: "rsync --archive /data/backup/ /data/qddir/demoDataDir-1"},
+				{Host: "cdw", Command: "/usr/local/target/bin/pg_upgrade --retain"},
+			}},
+			expected: "Upgrading master...                                                [WOULD RUN]" +
+				"\n    cdw: rsync --archive /data/backup/ /data/qddir/demoDataDir-1" +
+				"\n    cdw: /usr/local/target/bin/pg_upgrade --retain",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := commanders.FormatPlan(c.plan)
+			if actual != c.expected {
+				t.Errorf("got %q want %q", actual, c.expected)
+			}
+		})
+	}
+}
@@ -11,12 +11,16 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/daemon"
 	"github.com/greenplum-db/gpupgrade/utils/logger"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
 )
 
 func Agent() *cobra.Command {
 	var agentPort int
+	var listenAddress string
+	var tlsConfig mtls.Config
 	var stateDir string
 	var shouldDaemonize bool
+	var logFormat string
 
 	var cmd = &cobra.Command{
 		Use:    "agent",
@@ -25,17 +29,24 @@ func Agent() *cobra.Command {
 		Hidden: true,
 		Args:   cobra.MaximumNArgs(0), // no positional args allowed
 		RunE: func(cmd *cobra.Command, args []string) error {
-			logger.Initialize("agent")
+			logger.Initialize("agent", logFormat)
+			logger.InitializeTracing("agent")
 			defer logger.WritePanics()
 
+			agent.Version = Version
 			agentServer := agent.New()
 
 			// blocking call
-			return agentServer.Start(agentPort, stateDir, shouldDaemonize)
+			return agentServer.Start(agentPort, listenAddress, tlsConfig, stateDir, shouldDaemonize)
 		},
 	}
 
 	cmd.Flags().IntVar(&agentPort, "port", upgrade.DefaultAgentPort, "the port to listen for commands on")
+	cmd.Flags().StringVar(&listenAddress, "listen-address", "", "the address to listen for commands on. Defaults to all interfaces.")
+	cmd.Flags().StringVar(&tlsConfig.CertFile, "tls-cert-file", "", "the certificate used for mutual TLS")
+	cmd.Flags().StringVar(&tlsConfig.KeyFile, "tls-key-file", "", "the private key of the certificate used for mutual TLS")
+	cmd.Flags().StringVar(&tlsConfig.CAFile, "tls-ca-file", "", "the certificate authority used to verify peers for mutual TLS")
+	cmd.Flags().StringVar(&logFormat, "log-format", logger.TextFormat, "the format of the log file. Either text or json.")
 	cmd.Flags().StringVar(&stateDir, "state-directory", utils.GetStateDir(), "Agent state directory")
 
 	daemon.MakeDaemonizable(cmd, &shouldDaemonize)
@@ -0,0 +1,52 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/step"
+)
+
+func attach() *cobra.Command {
+	var verbose bool
+
+	cmd := &cobra.Command{
+		Use:   "attach",
+		Short: "follows the running step from a new session",
+		Long:  AttachHelp,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			client, err := connectToHub()
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("Attached to the running step. Press Ctrl-C to detach without affecting it.")
+
+			err = commanders.Attach(client, verbose)
+			var pausedErr step.PausedErr
+			if errors.As(err, &pausedErr) {
+				fmt.Printf("\nThe step paused before substep %s.\n", pausedErr.Substep)
+				return nil
+			}
+
+			if err != nil {
+				return xerrors.Errorf("attaching to step: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "print the output stream from all substeps")
+
+	return addHelpToCommand(cmd, AttachHelp)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func cancel() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "cancels the running step",
+		Long:  CancelHelp,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			client, err := connectToHub()
+			if err != nil {
+				return err
+			}
+
+			reply, err := client.Cancel(context.Background(), &idl.CancelRequest{})
+			if err != nil {
+				return xerrors.Errorf("cancelling step: %w", err)
+			}
+
+			fmt.Printf("Cancelling %[1]s. To resume run \"gpupgrade %[1]s\" which re-runs the interrupted substep.\n", reply.GetStep())
+			return nil
+		},
+	}
+
+	return addHelpToCommand(cmd, CancelHelp)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/preflight"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
+)
+
+func check() *cobra.Command {
+	var file string
+	var sourceGPHome, targetGPHome string
+	var sourcePort int
+	var agentPort int
+	var tlsConfig mtls.Config
+	var remoteExecutor string
+	var parentBackupDirs string
+	var diskFreeRatio float64
+	var ports string
+	var mode string
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "runs the pre-upgrade checks without making any changes",
+		Long:  CheckHelp,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			configFile, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if cErr := configFile.Close(); cErr != nil {
+					err = errorlist.Append(err, cErr)
+				}
+			}()
+
+			flags, err := ParseConfig(configFile)
+			if err != nil {
+				return xerrors.Errorf("in file %q: %w", file, err)
+			}
+
+			err = addFlags(cmd, checkParameters(cmd, flags))
+			if err != nil {
+				return err
+			}
+
+			parsedMode, err := parseMode(mode)
+			if err != nil {
+				return err
+			}
+
+			// if diskFreeRatio is not explicitly set, use defaults
+			if !cmd.Flag("disk-free-ratio").Changed {
+				diskFreeRatio = 0.2
+				if parsedMode == idl.Mode_copy {
+					diskFreeRatio = 0.6
+				}
+			}
+
+			parsedPorts, err := ParsePorts(ports)
+			if err != nil {
+				return err
+			}
+
+			err = tlsConfig.Validate()
+			if err != nil {
+				return err
+			}
+
+			err = remote.ValidateExecutor(remoteExecutor)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			report := preflight.Run(step.DevNullStream, preflight.Options{
+				SourceGPHome:     filepath.Clean(sourceGPHome),
+				TargetGPHome:     filepath.Clean(targetGPHome),
+				SourcePort:       sourcePort,
+				Ports:            parsedPorts,
+				Mode:             parsedMode,
+				DiskFreeRatio:    diskFreeRatio,
+				ParentBackupDirs: parentBackupDirs,
+				AgentPort:        agentPort,
+				RemoteExecutor:   remoteExecutor,
+				TLS:              tlsConfig,
+			})
+
+			fmt.Print(report.String())
+
+			if report.Failed() {
+				cmd.SilenceErrors = true
+				return errors.New("pre-upgrade checks failed")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "the configuration file to use")
+	cmd.MarkFlagRequired("file") //nolint
+	cmd.Flags().IntVar(&sourcePort, "source-master-port", 0, "master port for source gpdb cluster")
+	cmd.Flags().StringVar(&sourceGPHome, "source-gphome", "", "path for the source Greenplum installation")
+	cmd.Flags().StringVar(&targetGPHome, "target-gphome", "", "path for the target Greenplum installation")
+	cmd.Flags().StringVar(&mode, "mode", "copy", "performs upgrade in either copy or link mode. Default is copy.")
+	cmd.Flags().StringVar(&parentBackupDirs, "parent-backup-dirs", "", "parent directories on each host to internally store the backup of the coordinator data directory and user defined coordinator tablespaces.")
+	cmd.Flags().Float64Var(&diskFreeRatio, "disk-free-ratio", 0.60, "percentage of disk space that must be available (from 0.0 - 1.0)")
+	cmd.Flags().StringVar(&ports, "temp-port-range", "50432-65535", "set of ports to use when initializing the target cluster")
+	cmd.Flags().IntVar(&agentPort, "agent-port", upgrade.DefaultAgentPort, "the port gpupgrade agent uses to listen for commands on")
+	cmd.Flags().StringVar(&tlsConfig.CertFile, "tls-cert-file", "", "the certificate on all hosts used by gpupgrade hub and agents for mutual TLS")
+	cmd.Flags().StringVar(&tlsConfig.KeyFile, "tls-key-file", "", "the private key of the certificate on all hosts used for mutual TLS")
+	cmd.Flags().StringVar(&tlsConfig.CAFile, "tls-ca-file", "", "the certificate authority on all hosts used to verify peers for mutual TLS")
+	cmd.Flags().StringVar(&remoteExecutor, "remote-executor", remote.SSHExecutor, "how gpupgrade runs commands on the hosts. Either ssh, agent to use already running agents, or local to run all hosts on this machine. Defaults to ssh.")
+
+	// Only the file is expected on the command line. The remaining flags are
+	// set from the configuration file.
+	for _, name := range []string{"source-master-port", "source-gphome", "target-gphome", "mode", "parent-backup-dirs", "disk-free-ratio", "temp-port-range", "agent-port", "tls-cert-file", "tls-key-file", "tls-ca-file", "remote-executor"} {
+		cmd.Flags().MarkHidden(name) //nolint
+	}
+
+	return addHelpToCommand(cmd, CheckHelp)
+}
+
+// checkParameters removes the configuration parameters that are valid for
+// initialize but not used by any of the checks, such as the hub port.
+func checkParameters(cmd *cobra.Command, flags map[string]string) map[string]string {
+	initializeFlags := initialize().Flags()
+
+	params := make(map[string]string)
+	for name, value := range flags {
+		if cmd.Flag(name) == nil && initializeFlags.Lookup(name) != nil {
+			continue
+		}
+
+		params[name] = value
+	}
+
+	return params
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckParameters(t *testing.T) {
+	t.Run("drops initialize parameters not used by check", func(t *testing.T) {
+		flags := map[string]string{
+			"source-gphome":      "/usr/local/gpdb5",
+			"source-master-port": "5432",
+			"hub-port":           "7527",
+			"use-hba-hostnames":  "true",
+		}
+
+		params := checkParameters(check(), flags)
+
+		expected := map[string]string{
+			"source-gphome":      "/usr/local/gpdb5",
+			"source-master-port": "5432",
+		}
+		if !reflect.DeepEqual(params, expected) {
+			t.Errorf("got %v want %v", params, expected)
+		}
+	})
+
+	t.Run("keeps unknown parameters such that they are reported", func(t *testing.T) {
+		cmd := check()
+		params := checkParameters(cmd, map[string]string{"unknown": "value"})
+
+		err := addFlags(cmd, params)
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
@@ -35,6 +35,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"strconv"
 	"strings"
@@ -44,14 +45,15 @@ import (
 	"github.com/spf13/pflag"
 	"golang.org/x/xerrors"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/greenplum-db/gpupgrade/cli/clistep"
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
 	"github.com/greenplum-db/gpupgrade/config"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
 )
 
 func BuildRootCommand() *cobra.Command {
@@ -82,9 +84,15 @@ func BuildRootCommand() *cobra.Command {
 	root.AddCommand(execute())
 	root.AddCommand(finalize())
 	root.AddCommand(revert())
+	root.AddCommand(status())
+	root.AddCommand(check())
+	root.AddCommand(cancel())
+	root.AddCommand(attach())
+	root.AddCommand(validate())
 	root.AddCommand(restartServices)
 	root.AddCommand(killServices)
 	root.AddCommand(Agent())
+	root.AddCommand(RemoteShell())
 	root.AddCommand(Hub())
 
 	subConfigShow := createConfigShowSubcommand()
@@ -230,12 +238,7 @@ var killServices = &cobra.Command{
 }
 
 func stopHubAndAgents() error {
-	port, err := hubPort()
-	if err != nil {
-		return xerrors.Errorf("hub port: %w", err)
-	}
-
-	client, err := connectToHubOnPort(port)
+	client, err := connectToHub()
 	if err != nil {
 		return err
 	}
@@ -254,27 +257,49 @@ func stopHubAndAgents() error {
 
 //////////////////////////// Helpers ///////////////////////////////////////////
 
-// calls connectToHubOnPort() using the port defined in the configuration file
+// beginStep begins the step or when dryRun is set begins a dry run which
+// neither prompts for confirmation nor makes any changes.
+func beginStep(currentStep idl.Step, verbose bool, nonInteractive bool, dryRun bool, confirmationText string) (*clistep.Step, error) {
+	if dryRun {
+		return clistep.BeginDryRun(currentStep, verbose)
+	}
+
+	return clistep.Begin(currentStep, verbose, nonInteractive, confirmationText)
+}
+
+// calls connectToHubOnPort() using the port, listen address, and TLS
+// configuration defined in the configuration file
 func connectToHub() (idl.CliToHubClient, error) {
-	port, err := hubPort()
+	conf, err := hubConfig()
 	if err != nil {
 		return nil, xerrors.Errorf("hub port: %w", err)
 	}
 
-	return connectToHubOnPort(port)
+	return connectToHubOnPort(conf.HubPort, conf.HubListenAddress, conf.TLS)
 }
 
 // connectToHubOnPort() performs a blocking connection to the hub based on the
 // passed in port, and returns a CliToHubClient which wraps the resulting gRPC channel.
+// When the hub listens on a specific address it is used rather than localhost.
 // Any errors result in a call to os.Exit(1).
-func connectToHubOnPort(port int) (idl.CliToHubClient, error) {
+func connectToHubOnPort(port int, listenAddress string, tlsConfig mtls.Config) (idl.CliToHubClient, error) {
+	creds, err := tlsConfig.ClientCredentials()
+	if err != nil {
+		return nil, err
+	}
+
 	// Set up our timeout.
 	ctx, cancel := context.WithTimeout(context.Background(), connTimeout())
 	defer cancel()
 
+	host := "localhost"
+	if listenAddress != "" {
+		host = listenAddress
+	}
+
 	// Attempt a connection.
-	address := "localhost:" + strconv.Itoa(port)
-	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(creds), grpc.WithBlock())
 	if err != nil {
 		err = xerrors.Errorf("connecting to hub on port %d: %w", port, err)
 		if ctx.Err() == context.DeadlineExceeded {
@@ -316,15 +341,26 @@ func connTimeout() time.Duration {
 // NOTE: This overloads the hub's persisted configuration with that of the
 // CLI when ideally these would be separate.
 func hubPort() (int, error) {
+	conf, err := hubConfig()
+	if err != nil {
+		return -1, err
+	}
+
+	return conf.HubPort, nil
+}
+
+// hubConfig reads the gpupgrade persisted configuration. If the configuration
+// does not exist a configuration with the default hub port is returned.
+func hubConfig() (*config.Config, error) {
 	conf, err := config.Read()
 	var pathError *os.PathError
 	if errors.As(err, &pathError) {
-		return upgrade.DefaultHubPort, nil
+		return &config.Config{HubPort: upgrade.DefaultHubPort}, nil
 	}
 
 	if err != nil {
-		return -1, xerrors.Errorf("read config: %w", err)
+		return nil, xerrors.Errorf("read config: %w", err)
 	}
 
-	return conf.HubPort, nil
+	return conf, nil
 }
@@ -45,6 +45,9 @@ MASTER_DATA_DIRECTORY=%s
 The gpupgrade logs can be found on the master and segment hosts in
 %s
 
+The upgrade report can be found on the master host in
+%s
+
 NEXT ACTIONS
 ------------
 To use the upgraded cluster:
@@ -73,6 +76,9 @@ export PGPORT=%d
 The gpupgrade logs can be found on the master and segment hosts in
 %s
 
+The upgrade report can be found on the master host in
+%s
+
 NEXT ACTIONS
 ------------
 If you have not already, execute the “%s” data migration scripts with
@@ -13,17 +13,36 @@ You are about to initialize a major-version upgrade of Greenplum.
 gpupgrade log files can be found on all hosts in %s
 
 gpupgrade initialize will use these values from %s
-source_master_port:   %d
-source_gphome:        %s
-target_gphome:        %s
-mode:                 %s
-disk_free_ratio:      %.1f
-pg_upgrade_jobs:      %d
-use_hba_hostnames:    %t
-dynamic_library_path: %s
-temp_port_range:      %s
-hub_port:             %d
-agent_port:           %d
+source_master_port:          %d
+source_gphome:               %s
+target_gphome:               %s
+mode:                        %s
+disk_free_ratio:             %.1f
+estimate_disk_space:         %t
+pg_upgrade_jobs:             %d
+pg_upgrade_concurrency:      %d
+rsync_concurrency:           %d
+host_concurrency:            %d
+execute_rsync_options:       %s
+finalize_rsync_options:      %s
+revert_rsync_options:        %s
+agent_heartbeat_interval:    %s
+pause_before:                %s
+validation_row_count_sample: %d
+hooks_dir:                   %s
+metrics_port:                %d
+use_hba_hostnames:           %t
+dynamic_library_path:        %s
+temp_port_range:             %s
+hub_port:                    %d
+agent_port:                  %d
+hub_listen_address:          %s
+agent_listen_on_hostname:    %t
+tls_cert_file:               %s
+tls_key_file:                %s
+tls_ca_file:                 %s
+remote_executor:             %s
+log_format:                  %s
 
 You will still have the opportunity to revert the cluster to its original state 
 after this step.
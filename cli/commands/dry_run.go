@@ -0,0 +1,120 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"io"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/greenplum/connection"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+)
+
+// dryRunConfig returns the saved configuration if initialize already saved
+// it. Otherwise, the configuration is created in memory without being written.
+func dryRunConfig(sourceGPHome string, targetGPHome string, sourcePort int, hubPort int, agentPort int, mode idl.Mode, useHbaHostnames bool, ports string, pgUpgradeJobs uint, parentBackupDirs string, hubListenAddress string, agentListenOnHostname bool, tlsConfig mtls.Config, remoteExecutor string, logFormat string, concurrency config.Concurrency, rsyncPhases config.RsyncPhases, heartbeatInterval time.Duration, pauseBefore idl.Substep, validationRowCountSample uint, hooksDir string, metricsPort int) (_ *config.Config, err error) {
+	exist, err := upgrade.PathExist(config.GetConfigFile())
+	if err != nil {
+		return nil, err
+	}
+
+	if exist {
+		return config.Read()
+	}
+
+	parsedPorts, err := ParsePorts(ports)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := connection.Bootstrap(idl.ClusterDestination_source, sourceGPHome, sourcePort)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	conf, err := config.Create(
+		db, hubPort, agentPort,
+		filepath.Clean(sourceGPHome),
+		filepath.Clean(targetGPHome),
+		mode, useHbaHostnames, parsedPorts, pgUpgradeJobs,
+		parentBackupDirs, hubListenAddress, agentListenOnHostname, tlsConfig, remoteExecutor, logFormat, concurrency, rsyncPhases, heartbeatInterval, pauseBefore, validationRowCountSample, hooksDir, metricsPort,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conf, nil
+}
+
+// planInitializeHubSubsteps prints the plan of the initialize hub substeps.
+// Since the hub is not yet running during a dry run of initialize its
+// handlers are called in process.
+func planInitializeHubSubsteps(conf *config.Config, initializeRequest *idl.InitializeRequest, createClusterRequest *idl.InitializeCreateClusterRequest, verbose bool) error {
+	server := hub.New(conf)
+
+	err := receivePlan(func(stream *dryRunStream) error {
+		return server.Initialize(initializeRequest, stream)
+	}, verbose)
+	if err != nil {
+		return err
+	}
+
+	if createClusterRequest == nil {
+		return nil
+	}
+
+	return receivePlan(func(stream *dryRunStream) error {
+		return server.InitializeCreateCluster(createClusterRequest, stream)
+	}, verbose)
+}
+
+func receivePlan(handler func(stream *dryRunStream) error, verbose bool) error {
+	stream := &dryRunStream{messages: make(chan *idl.Message)}
+	go func() {
+		stream.err = handler(stream)
+		close(stream.messages)
+	}()
+
+	_, err := commanders.UILoop(stream, verbose)
+	return err
+}
+
+// dryRunStream connects a hub handler called in process to the UI loop.
+type dryRunStream struct {
+	grpc.ServerStream
+	messages chan *idl.Message
+	err      error
+}
+
+func (d *dryRunStream) Send(msg *idl.Message) error {
+	d.messages <- msg
+	return nil
+}
+
+func (d *dryRunStream) Recv() (*idl.Message, error) {
+	msg, ok := <-d.messages
+	if !ok {
+		if d.err != nil {
+			return nil, d.err
+		}
+
+		return nil, io.EOF
+	}
+
+	return msg, nil
+}
@@ -12,10 +12,10 @@ import (
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
-	"github.com/greenplum-db/gpupgrade/cli/clistep"
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
 	"github.com/greenplum-db/gpupgrade/config"
 	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/utils"
@@ -26,7 +26,10 @@ func execute() *cobra.Command {
 	var pgUpgradeVerbose bool
 	var skipPgUpgradeChecks bool
 	var nonInteractive bool
+	var dryRun bool
+	var recoverSubsteps bool
 	var parentBackupDirs string
+	var pauseBefore string
 
 	cmd := &cobra.Command{
 		Use:   "execute",
@@ -40,6 +43,11 @@ func execute() *cobra.Command {
 				return fmt.Errorf("expected --verbose when using --pg-upgrade-verbose")
 			}
 
+			pauseBeforeSubstep, err := step.ParsePauseBefore(pauseBefore, idl.Step_execute)
+			if err != nil {
+				return err
+			}
+
 			conf, err := config.Read()
 			if err != nil {
 				return err
@@ -59,7 +67,7 @@ func execute() *cobra.Command {
 				cases.Title(language.English).String(idl.Step_execute.String()),
 				executeSubsteps, logdir)
 
-			st, err := clistep.Begin(idl.Step_execute, verbose, nonInteractive, confirmationText)
+			st, err := beginStep(idl.Step_execute, verbose, nonInteractive, dryRun, confirmationText)
 			if err != nil {
 				if errors.Is(err, step.Quit) {
 					// If user cancels don't return an error to main to avoid
@@ -69,6 +77,12 @@ func execute() *cobra.Command {
 				return err
 			}
 
+			if recoverSubsteps {
+				st.Recover()
+			}
+
+			st.RunHooks(hub.Hooks(conf, idl.Step_execute))
+
 			intermediate := &greenplum.Cluster{}
 			st.RunHubSubstep(func(streams step.OutStreams) error {
 				client, err := connectToHub()
@@ -80,6 +94,9 @@ func execute() *cobra.Command {
 					PgUpgradeVerbose:    pgUpgradeVerbose,
 					SkipPgUpgradeChecks: skipPgUpgradeChecks,
 					ParentBackupDirs:    parentBackupDirs,
+					DryRun:              dryRun,
+					Recover:             recoverSubsteps,
+					PauseBefore:         pauseBeforeSubstep,
 				}
 				response, err = commanders.Execute(client, request, verbose)
 				if err != nil {
@@ -107,6 +124,9 @@ func execute() *cobra.Command {
 	cmd.Flags().MarkHidden("skip-pg-upgrade-checks") //nolint
 	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt for confirmation to proceed")
 	cmd.Flags().MarkHidden("non-interactive") //nolint
+	cmd.Flags().BoolVar(&recoverSubsteps, "recover", false, "recover a substep left running by a previous attempt, such as when the hub crashed, and continue")
+	cmd.Flags().StringVar(&pauseBefore, "pause-before", "", "the substep to pause before such that its state can be inspected. Re-run execute to continue. Overrides pause_before from the configuration file.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the substeps that would run, be skipped, or be bypassed along with the commands they would issue without making any changes")
 	cmd.Flags().StringVar(&parentBackupDirs, "parent-backup-dirs", "", "parent directories on each host to internally store the backup of the coordinator data directory and user defined coordinator tablespaces."+
 		"Defaults to the parent directory of each primary data directory on each primary host."+
 		"To specify a single directory across all hosts set a single directory such as /dir."+
@@ -15,7 +15,9 @@ import (
 	"github.com/greenplum-db/gpupgrade/cli/clistep"
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
 	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/report"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
@@ -24,6 +26,11 @@ import (
 func finalize() *cobra.Command {
 	var verbose bool
 	var nonInteractive bool
+	var dryRun bool
+	var recoverSubsteps bool
+	var htmlReport bool
+	var pauseBefore string
+	var validate bool
 
 	cmd := &cobra.Command{
 		Use:   "finalize",
@@ -32,6 +39,16 @@ func finalize() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			var response *idl.FinalizeResponse
 
+			pauseBeforeSubstep, err := step.ParsePauseBefore(pauseBefore, idl.Step_finalize)
+			if err != nil {
+				return err
+			}
+
+			conf, err := hubConfig()
+			if err != nil {
+				return err
+			}
+
 			logdir, err := utils.GetLogDir()
 			if err != nil {
 				return err
@@ -41,7 +58,7 @@ func finalize() *cobra.Command {
 				cases.Title(language.English).String(idl.Step_finalize.String()),
 				finalizeSubsteps, logdir)
 
-			st, err := clistep.Begin(idl.Step_finalize, verbose, nonInteractive, confirmationText)
+			st, err := beginStep(idl.Step_finalize, verbose, nonInteractive, dryRun, confirmationText)
 			if err != nil {
 				if errors.Is(err, step.Quit) {
 					// If user cancels don't return an error to main to avoid
@@ -51,6 +68,12 @@ func finalize() *cobra.Command {
 				return err
 			}
 
+			if recoverSubsteps {
+				st.Recover()
+			}
+
+			st.RunHooks(hub.Hooks(conf, idl.Step_finalize))
+
 			target := &greenplum.Cluster{}
 			st.RunHubSubstep(func(streams step.OutStreams) error {
 				client, err := connectToHub()
@@ -58,7 +81,7 @@ func finalize() *cobra.Command {
 					return err
 				}
 
-				response, err = commanders.Finalize(client, verbose)
+				response, err = commanders.Finalize(client, &idl.FinalizeRequest{DryRun: dryRun, Recover: recoverSubsteps, HtmlReport: htmlReport, PauseBefore: pauseBeforeSubstep, Validate: validate}, verbose)
 				if err != nil {
 					return err
 				}
@@ -81,8 +104,13 @@ func finalize() *cobra.Command {
 				}
 
 				currentDir := filepath.Join(response.GetLogArchiveDirectory(), "data-migration-scripts", "current")
-				return commanders.ApplyDataMigrationScripts(streams, nonInteractive, target.GPHome, target.CoordinatorPort(),
+				err := commanders.ApplyDataMigrationScripts(streams, nonInteractive, target.GPHome, target.CoordinatorPort(),
 					response.GetLogArchiveDirectory(), utils.System.DirFS(currentDir), currentDir, idl.Step_finalize)
+				if err != nil {
+					return err
+				}
+
+				return report.UpdateDataMigrationScripts(response.GetLogArchiveDirectory())
 			})
 
 			st.Run(idl.Substep_analyze_target_cluster, func(streams step.OutStreams) error {
@@ -120,6 +148,7 @@ If you postpone creating statistics then after the upgrade run "vacuumdb --all -
 				fmt.Sprintf("%s.<contentID>%s", response.GetUpgradeID(), upgrade.OldSuffix),
 				response.GetArchivedSourceCoordinatorDataDirectory(),
 				response.GetLogArchiveDirectory(),
+				filepath.Join(response.GetLogArchiveDirectory(), report.JSONFileName),
 				filepath.Join(target.GPHome, "greenplum_path.sh"),
 				filepath.Join(filepath.Dir(target.GPHome), "greenplum-db"), target.GPHome,
 				filepath.Join(target.GPHome, "greenplum_path.sh"),
@@ -134,5 +163,10 @@ If you postpone creating statistics then after the upgrade run "vacuumdb --all -
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "print the output stream from all substeps")
 	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt for confirmation to proceed")
 	cmd.Flags().MarkHidden("non-interactive") //nolint
+	cmd.Flags().BoolVar(&recoverSubsteps, "recover", false, "recover a substep left running by a previous attempt, such as when the hub crashed, and continue")
+	cmd.Flags().BoolVar(&htmlReport, "html-report", false, "also write the upgrade report as HTML alongside the JSON report in the log archive directory")
+	cmd.Flags().StringVar(&pauseBefore, "pause-before", "", "the substep to pause before such that its state can be inspected. Re-run finalize to continue. Overrides pause_before from the configuration file.")
+	cmd.Flags().BoolVar(&validate, "validate", false, "compare the target cluster against the snapshot of the source cluster taken during initialize, stopping before the backups are removed when there are differences")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the substeps that would run, be skipped, or be bypassed along with the commands they would issue without making any changes")
 	return addHelpToCommand(cmd, FinalizeHelp)
 }
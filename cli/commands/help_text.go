@@ -33,76 +33,10 @@ func init() {
 		panic(fmt.Sprintf("failed to get log directory: %v", err))
 	}
 
-	initializeSubsteps = substeps.Substeps{
-		idl.Substep_verify_gpdb_versions,
-		idl.Substep_saving_source_cluster_config,
-		idl.Substep_start_hub,
-		idl.Substep_generate_data_migration_scripts,
-		idl.Substep_execute_stats_data_migration_scripts,
-		idl.Substep_execute_initialize_data_migration_scripts,
-		idl.Substep_verify_gpupgrade_is_installed_across_all_hosts,
-		idl.Substep_start_agents,
-		idl.Substep_check_environment,
-		idl.Substep_create_backupdirs,
-		idl.Substep_check_disk_space,
-		idl.Substep_generate_target_config,
-		idl.Substep_init_target_cluster,
-		idl.Substep_setting_dynamic_library_path_on_target_cluster,
-		idl.Substep_shutdown_target_cluster,
-		idl.Substep_backup_target_master,
-		idl.Substep_initialize_wait_for_cluster_to_be_ready,
-		idl.Substep_check_upgrade,
-	}
-
-	executeSubsteps = substeps.Substeps{
-		idl.Substep_ensure_gpupgrade_agents_are_running,
-		idl.Substep_check_active_connections_on_source_cluster,
-		idl.Substep_wait_for_cluster_to_be_ready_before_upgrade_master,
-		idl.Substep_shutdown_source_cluster,
-		idl.Substep_upgrade_master,
-		idl.Substep_copy_master,
-		idl.Substep_upgrade_primaries,
-		idl.Substep_start_target_cluster,
-	}
-
-	finalizeSubsteps = substeps.Substeps{
-		idl.Substep_ensure_gpupgrade_agents_are_running,
-		idl.Substep_check_active_connections_on_target_cluster,
-		idl.Substep_upgrade_mirrors,
-		idl.Substep_upgrade_standby,
-		idl.Substep_wait_for_cluster_to_be_ready_after_adding_mirrors_and_standby,
-		idl.Substep_shutdown_target_cluster,
-		idl.Substep_update_target_catalog,
-		idl.Substep_update_data_directories,
-		idl.Substep_update_target_conf_files,
-		idl.Substep_start_target_cluster,
-		idl.Substep_wait_for_cluster_to_be_ready_after_updating_catalog,
-		idl.Substep_archive_log_directories,
-		idl.Substep_delete_backupdir,
-		idl.Substep_delete_segment_statedirs,
-		idl.Substep_stop_hub_and_agents,
-		idl.Substep_execute_finalize_data_migration_scripts,
-		idl.Substep_analyze_target_cluster,
-		idl.Substep_delete_master_statedir,
-	}
-
-	revertSubsteps = substeps.Substeps{
-		idl.Substep_ensure_gpupgrade_agents_are_running,
-		idl.Substep_check_active_connections_on_target_cluster,
-		idl.Substep_shutdown_target_cluster,
-		idl.Substep_delete_target_cluster_datadirs,
-		idl.Substep_delete_tablespaces,
-		idl.Substep_restore_pgcontrol,
-		idl.Substep_restore_source_cluster,
-		idl.Substep_start_source_cluster,
-		idl.Substep_recoverseg_source_cluster,
-		idl.Substep_archive_log_directories,
-		idl.Substep_delete_backupdir,
-		idl.Substep_delete_segment_statedirs,
-		idl.Substep_stop_hub_and_agents,
-		idl.Substep_execute_revert_data_migration_scripts,
-		idl.Substep_delete_master_statedir,
-	}
+	initializeSubsteps = substeps.StepSubsteps[idl.Step_initialize]
+	executeSubsteps = substeps.StepSubsteps[idl.Step_execute]
+	finalizeSubsteps = substeps.StepSubsteps[idl.Step_finalize]
+	revertSubsteps = substeps.StepSubsteps[idl.Step_revert]
 
 	InitializeHelp = fmt.Sprintf(initializeHelpText, cases.Title(language.English).String(idl.Step_initialize.String()), initializeSubsteps, logDir)
 	ExecuteHelp = fmt.Sprintf(executeHelpText, cases.Title(language.English).String(idl.Step_execute.String()), executeSubsteps, logDir)
@@ -138,6 +72,10 @@ Optional Flags:
   -h, --help                 displays help output for initialize
   -v, --verbose              outputs detailed logs for initialize
       --pg-upgrade-verbose   execute pg_upgrade with verbose internal logging. Requires the verbose flag.
+      --dry-run              prints which substeps would run, be skipped, or be bypassed and the 
+                             commands they would issue without making any changes
+      --recover              recovers a substep left running by a previous attempt, such as when 
+                             the hub crashed, and continues
 
 gpupgrade log files can be found on all hosts in %s
 `
@@ -161,6 +99,13 @@ Optional Flags:
                              master data directory and user defined master tablespaces. Defaults to the 
                              parent directory of the master data directory such as /data given 
                              /data/master/gpseg-1.
+      --dry-run              prints which substeps would run, be skipped, or be bypassed and the 
+                             commands they would issue without making any changes
+      --recover              recovers a substep left running by a previous attempt, such as when 
+                             the hub crashed, and continues
+      --pause-before         pauses before the named substep such as upgrade_primaries so the 
+                             cluster can be inspected. Re-run execute to continue. Overrides 
+                             pause_before from the config file.
 
 gpupgrade log files can be found on all hosts in %s
 `
@@ -177,8 +122,20 @@ Usage: gpupgrade finalize
 
 Optional Flags:
 
-  -h, --help      displays help output for finalize
-  -v, --verbose   outputs detailed logs for finalize
+  -h, --help          displays help output for finalize
+  -v, --verbose       outputs detailed logs for finalize
+      --dry-run       prints which substeps would run, be skipped, or be bypassed and the 
+                      commands they would issue without making any changes
+      --recover       recovers a substep left running by a previous attempt, such as when 
+                      the hub crashed, and continues
+      --html-report   also writes the upgrade report as HTML. The JSON upgrade report is 
+                      always written to the log archive directory.
+      --pause-before  pauses before the named substep such as upgrade_mirrors so the 
+                      cluster can be inspected. Re-run finalize to continue. Overrides 
+                      pause_before from the config file.
+      --validate      compares the target cluster against the snapshot of the source 
+                      cluster taken during initialize and stops before removing the 
+                      backups when there are differences
 
 NOTE: After running finalize, you must execute data migration scripts. 
 Refer to documentation for instructions.
@@ -196,8 +153,14 @@ Usage: gpupgrade revert
 
 Optional Flags:
 
-  -h, --help      displays help output for revert
-  -v, --verbose   outputs detailed logs for revert
+  -h, --help          displays help output for revert
+  -v, --verbose       outputs detailed logs for revert
+      --dry-run       prints which substeps would run, be skipped, or be bypassed and the 
+                      commands they would issue without making any changes
+      --recover       recovers a substep left running by a previous attempt, such as when 
+                      the hub crashed, and continues
+      --html-report   also writes the upgrade report as HTML. The JSON upgrade report is 
+                      always written to the log archive directory.
 
 NOTE: After running revert, you must execute data migration scripts. 
 Refer to documentation for instructions.
@@ -246,6 +209,90 @@ Optional Flags:
   --input-dir    path to the generated data migration SQL files. 
                  Defaults to $HOME/gpAdminLogs/gpupgrade/data-migration-scripts
 `
+const StatusHelp = `
+Shows the status, start time and duration of each step and substep, and the
+next gpupgrade command to run. If the hub is not running the status is read
+directly from the gpupgrade state directory.
+
+Usage: gpupgrade status
+
+Optional Flags:
+
+  -h, --help      displays help output for status
+      --format    the output format as either "text" or "json". Default is text.
+`
+const CancelHelp = `
+Cancels the running step such as a long running execute or finalize. The
+running substep is interrupted terminating any pg_upgrade and rsync processes
+on all hosts, and is marked failed. Re-run the step to resume from the
+interrupted substep.
+
+Usage: gpupgrade cancel
+
+Optional Flags:
+
+  -h, --help      displays help output for cancel
+`
+const AttachHelp = `
+Attaches to the running step such as execute from a new session after the
+session which ran it was lost. The output of the step so far is replayed and
+then followed until the step ends. Several sessions can attach at once.
+Detaching with Ctrl-C does not affect the running step.
+
+Usage: gpupgrade attach
+
+Optional Flags:
+
+  -h, --help      displays help output for attach
+  -v, --verbose   print the output stream from all substeps
+`
+const CheckHelp = `
+Runs the pre-upgrade checks performed by initialize and reports whether each
+passed, warned, failed, or was unchecked. Nothing is changed on the cluster, and
+no state directory, backup directories, or intermediate cluster are created.
+Checks against the segment hosts use agents that are already running when
+available. Without them the disk space of only the coordinator is checked and
+the remaining hosts are reported as unchecked.
+
+pg_upgrade --check is not performed since it requires the intermediate cluster.
+It is reported as unchecked and is run by "gpupgrade initialize".
+
+Usage: gpupgrade check --file <path/to/config_file>
+
+Required Flags:
+
+  -f, --file      config file containing upgrade parameters
+                  (e.g. gpupgrade_config)
+
+Optional Flags:
+
+  -h, --help      displays help output for check
+`
+const ValidateHelp = `
+Compares the target cluster against the snapshot of the source cluster taken
+during initialize. The databases, relations by kind, row counts of the sampled
+tables, roles, tablespaces, extensions, and segment topology are compared and
+any differences are reported. Run after finalize such that the target cluster
+uses the source cluster ports, or use "gpupgrade finalize --validate" to
+validate before finalize removes the backups.
+
+Usage: gpupgrade validate
+
+Optional Flags:
+
+  -h, --help            displays help output for validate
+      --snapshot        the snapshot of the source cluster. Defaults to the one
+                        in the state directory. Required after finalize since
+                        the state directory is removed. Finalize archives the
+                        snapshot as source_snapshot.json in the log archive
+                        directory.
+      --target-gphome   path for the target Greenplum installation. Defaults to
+                        the one used by the upgrade.
+      --target-port     master port of the target cluster. Defaults to the one
+                        of the finalized target cluster which is the source
+                        cluster port.
+      --json            prints the differences as JSON
+`
 const ConfigHelp = `
 The config subcommand allows one to view configuration parameters only after 
 initialize has started. It is useful for starting or connecting to the 
@@ -291,6 +338,18 @@ Optional Commands:
 
   apply           applies data migration SQL scripts
 
+  status          shows the status of each step and substep, and the next
+                  command to run
+
+  check           runs the pre-upgrade checks without making any changes
+
+  cancel          cancels the running step
+
+  attach          follows the running step from a new session
+
+  validate        compares the target cluster against the snapshot of the
+                  source cluster taken during initialize
+
   config show     shows configuration parameters. 
                   One can only view the configuration parameters only 
                   after initialize has started. The config subcommand is
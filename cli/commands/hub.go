@@ -28,9 +28,6 @@ func Hub() *cobra.Command {
 		Hidden: true,
 		Args:   cobra.MaximumNArgs(0), //no positional args allowed
 		RunE: func(cmd *cobra.Command, args []string) error {
-			logger.Initialize("hub")
-			defer logger.WritePanics()
-
 			exist, err := upgrade.PathExist(utils.GetStateDir())
 			if err != nil {
 				return err
@@ -47,6 +44,11 @@ func Hub() *cobra.Command {
 				return err
 			}
 
+			logger.Initialize("hub", conf.LogFormat)
+			logger.InitializeTracing("hub")
+			logger.SetUpgradeID(conf.UpgradeID)
+			defer logger.WritePanics()
+
 			// allow command line args precedence over config file values
 			if cmd.Flag("port").Changed {
 				conf.HubPort = hubPort
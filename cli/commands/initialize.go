@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -27,6 +28,9 @@ import (
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/logger"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
 )
 
 func initialize() *cobra.Command {
@@ -36,19 +40,34 @@ func initialize() *cobra.Command {
 	var sourcePort int
 	var hubPort int
 	var agentPort int
+	var hubListenAddress string
+	var agentListenOnHostname bool
+	var tlsConfig mtls.Config
+	var remoteExecutor string
+	var logFormat string
 	var parentBackupDirs string
 	var diskFreeRatio float64
+	var estimateDiskSpace bool
 	var stopBeforeClusterCreation bool
 	var verbose bool
 	var pgUpgradeVerbose bool
 	var skipVersionCheck bool
 	var skipPgUpgradeChecks bool
 	var pgUpgradeJobs uint
+	var concurrency config.Concurrency
+	var executeRsyncOptions, finalizeRsyncOptions, revertRsyncOptions string
+	var heartbeatInterval time.Duration
+	var pauseBefore string
+	var validationRowCountSample uint
+	var hooksDir string
+	var metricsPort int
 	var ports string
 	var mode string
 	var useHbaHostnames bool
 	var dynamicLibraryPath string
 	var dataMigrationSeedDir string
+	var dryRun bool
+	var recoverSubsteps bool
 
 	subInit := &cobra.Command{
 		Use:   "initialize",
@@ -71,12 +90,13 @@ func initialize() *cobra.Command {
 			}
 
 			// If the file flag is set ensure no other flags are set except
-			// optionally verbose, pg-upgrade-verbose, and non-interactive.
+			// optionally verbose, pg-upgrade-verbose, non-interactive, dry-run,
+			// and recover.
 			if cmd.Flag("file").Changed {
 				var err error
 				cmd.Flags().Visit(func(flag *pflag.Flag) {
-					if flag.Name != "file" && flag.Name != "verbose" && flag.Name != "pg-upgrade-verbose" && flag.Name != "non-interactive" {
-						err = errors.New("The file flag cannot be used with any other flag except verbose and non-interactive.")
+					if flag.Name != "file" && flag.Name != "verbose" && flag.Name != "pg-upgrade-verbose" && flag.Name != "non-interactive" && flag.Name != "dry-run" && flag.Name != "recover" {
+						err = errors.New("The file flag cannot be used with any other flag except verbose, non-interactive, dry-run, and recover.")
 					}
 				})
 				return err
@@ -127,6 +147,23 @@ func initialize() *cobra.Command {
 				return err
 			}
 
+			rsyncPhases, err := config.ParseRsyncPhases(executeRsyncOptions, finalizeRsyncOptions, revertRsyncOptions)
+			if err != nil {
+				return err
+			}
+
+			parsedPauseBefore, err := step.ParsePauseBefore(pauseBefore, idl.Step_execute, idl.Step_finalize)
+			if err != nil {
+				return err
+			}
+
+			if hooksDir != "" {
+				hooksDir, err = filepath.Abs(hooksDir)
+				if err != nil {
+					return err
+				}
+			}
+
 			// if diskFreeRatio is not explicitly set, use defaults
 			if !cmd.Flag("disk-free-ratio").Changed {
 				diskFreeRatio = 0.2
@@ -159,22 +196,37 @@ func initialize() *cobra.Command {
 
 			// Create the state directory outside the step framework to ensure
 			// we can write to the status file. The step framework assumes valid
-			// working state directory.
-			err = commanders.CreateStateDir()
-			if err != nil {
-				return err
+			// working state directory. A dry run does not create anything.
+			if !dryRun {
+				err = commanders.CreateStateDir()
+				if err != nil {
+					return err
+				}
 			}
 
 			confirmationText := fmt.Sprintf(initializeConfirmationText,
 				cases.Title(language.English).String(idl.Step_initialize.String()),
 				initializeSubsteps, logdir, configPath,
-				sourcePort, sourceGPHome, targetGPHome, mode, diskFreeRatio, pgUpgradeJobs, useHbaHostnames, dynamicLibraryPath, ports, hubPort, agentPort)
+				sourcePort, sourceGPHome, targetGPHome, mode, diskFreeRatio, estimateDiskSpace, pgUpgradeJobs, concurrency.PgUpgrade, concurrency.Rsync, concurrency.Hosts, executeRsyncOptions, finalizeRsyncOptions, revertRsyncOptions, heartbeatInterval, pauseBefore, validationRowCountSample, hooksDir, metricsPort, useHbaHostnames, dynamicLibraryPath, ports, hubPort, agentPort,
+				hubListenAddress, agentListenOnHostname, tlsConfig.CertFile, tlsConfig.KeyFile, tlsConfig.CAFile, remoteExecutor, logFormat)
 
-			st, err := clistep.Begin(idl.Step_initialize, verbose, nonInteractive, confirmationText)
+			st, err := beginStep(idl.Step_initialize, verbose, nonInteractive, dryRun, confirmationText)
 			if err != nil {
 				return err
 			}
 
+			if recoverSubsteps {
+				st.Recover()
+			}
+
+			// The upgrade ID is not known until the source cluster config is
+			// saved.
+			st.RunHooks(step.Hooks{Dir: hooksDir, Env: step.HookEnv{
+				SourcePort:   sourcePort,
+				SourceGPHome: filepath.Clean(sourceGPHome),
+				TargetGPHome: filepath.Clean(targetGPHome),
+			}})
+
 			st.RunConditionally(idl.Substep_verify_gpdb_versions, !skipVersionCheck, func(streams step.OutStreams) error {
 				return greenplum.VerifyCompatibleGPDBVersions(sourceGPHome, targetGPHome)
 			})
@@ -199,12 +251,13 @@ func initialize() *cobra.Command {
 					filepath.Clean(sourceGPHome),
 					filepath.Clean(targetGPHome),
 					mode, useHbaHostnames, parsedPorts, pgUpgradeJobs,
-					parentBackupDirs,
+					parentBackupDirs, hubListenAddress, agentListenOnHostname, tlsConfig, remoteExecutor, logFormat, concurrency, rsyncPhases, heartbeatInterval, parsedPauseBefore, validationRowCountSample, hooksDir, metricsPort,
 				)
 				if err != nil {
 					return err
 				}
 
+				logger.SetUpgradeID(conf.UpgradeID)
 				return conf.Write()
 			})
 
@@ -250,6 +303,39 @@ func initialize() *cobra.Command {
 				return clistep.Prompt(utils.StdinReader, prompt)
 			})
 
+			initializeRequest := &idl.InitializeRequest{
+				DiskFreeRatio:     diskFreeRatio,
+				ParentBackupDirs:  parentBackupDirs,
+				DryRun:            dryRun,
+				Recover:           recoverSubsteps,
+				EstimateDiskSpace: estimateDiskSpace,
+			}
+
+			createClusterRequest := &idl.InitializeCreateClusterRequest{
+				DynamicLibraryPath:  dynamicLibraryPath,
+				PgUpgradeVerbose:    pgUpgradeVerbose,
+				SkipPgUpgradeChecks: skipPgUpgradeChecks,
+				DryRun:              dryRun,
+				Recover:             recoverSubsteps,
+			}
+
+			if dryRun {
+				st.RunHubSubstep(func(streams step.OutStreams) error {
+					conf, err := dryRunConfig(sourceGPHome, targetGPHome, sourcePort, hubPort, agentPort, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, hubListenAddress, agentListenOnHostname, tlsConfig, remoteExecutor, logFormat, concurrency, rsyncPhases, heartbeatInterval, parsedPauseBefore, validationRowCountSample, hooksDir, metricsPort)
+					if err != nil {
+						return err
+					}
+
+					if stopBeforeClusterCreation {
+						createClusterRequest = nil
+					}
+
+					return planInitializeHubSubsteps(conf, initializeRequest, createClusterRequest, verbose)
+				})
+
+				return st.Complete("")
+			}
+
 			var client idl.CliToHubClient
 			st.RunHubSubstep(func(streams step.OutStreams) error {
 				client, err = connectToHub()
@@ -257,11 +343,7 @@ func initialize() *cobra.Command {
 					return err
 				}
 
-				request := &idl.InitializeRequest{
-					DiskFreeRatio:    diskFreeRatio,
-					ParentBackupDirs: parentBackupDirs,
-				}
-				err = commanders.Initialize(client, request, verbose)
+				err = commanders.Initialize(client, initializeRequest, verbose)
 				if err != nil {
 					return err
 				}
@@ -275,12 +357,7 @@ func initialize() *cobra.Command {
 					return step.Skip
 				}
 
-				request := &idl.InitializeCreateClusterRequest{
-					DynamicLibraryPath:  dynamicLibraryPath,
-					PgUpgradeVerbose:    pgUpgradeVerbose,
-					SkipPgUpgradeChecks: skipPgUpgradeChecks,
-				}
-				response, err = commanders.InitializeCreateCluster(client, request, verbose)
+				response, err = commanders.InitializeCreateCluster(client, createClusterRequest, verbose)
 				if err != nil {
 					return err
 				}
@@ -302,6 +379,17 @@ func initialize() *cobra.Command {
 	subInit.Flags().BoolVar(&skipPgUpgradeChecks, "skip-pg-upgrade-checks", false, "skips pg_upgrade checks")
 	subInit.Flags().MarkHidden("skip-pg-upgrade-checks") //nolint
 	subInit.Flags().UintVar(&pgUpgradeJobs, "pg-upgrade-jobs", 4, "databases to upgrade in parallel based on the number of specified threads. Defaults to 4.")
+	subInit.Flags().UintVar(&concurrency.PgUpgrade, "pg-upgrade-concurrency", 0, "the maximum number of pg_upgrade processes to run at once on each host. Defaults to 0 which is unlimited.")
+	subInit.Flags().UintVar(&concurrency.Rsync, "rsync-concurrency", 0, "the maximum number of rsync processes to run at once on each host. Defaults to 0 which is unlimited.")
+	subInit.Flags().UintVar(&concurrency.Hosts, "host-concurrency", 0, "the maximum number of hosts gpupgrade hub sends requests to at once. Defaults to 0 which is unlimited.")
+	subInit.Flags().StringVar(&executeRsyncOptions, "execute-rsync-options", "", "comma separated rsync settings used when copying the coordinator during execute. Any of bandwidth_limit=<KiB per second>, checksum, partial, and verify.")
+	subInit.Flags().StringVar(&finalizeRsyncOptions, "finalize-rsync-options", "", "comma separated rsync settings used when upgrading the mirrors during finalize. Any of bandwidth_limit=<KiB per second>, checksum, partial, and verify.")
+	subInit.Flags().StringVar(&revertRsyncOptions, "revert-rsync-options", "", "comma separated rsync settings used when restoring the source cluster during revert. Any of bandwidth_limit=<KiB per second>, checksum, partial, and verify.")
+	subInit.Flags().DurationVar(&heartbeatInterval, "agent-heartbeat-interval", 15*time.Second, "how often the hub checks the health of the agents during long running substeps. Defaults to 15s. Set to 0 to disable.")
+	subInit.Flags().StringVar(&pauseBefore, "pause-before", "", "the execute or finalize substep to pause before such that its state can be inspected. Re-run the step to continue. Defaults to not pausing.")
+	subInit.Flags().UintVar(&validationRowCountSample, "validation-row-count-sample", 100, "the number of tables in each database whose rows are counted in the source snapshot used to validate the target cluster. Defaults to 100. Set to 0 to count all tables.")
+	subInit.Flags().StringVar(&hooksDir, "hooks-dir", "", "the directory of executables run before and after steps and substeps named pre-<step or substep> and post-<step or substep>. Defaults to not running hooks.")
+	subInit.Flags().IntVar(&metricsPort, "metrics-port", 0, "the port gpupgrade hub serves Prometheus metrics on at /metrics. Defaults to 0 which does not serve metrics.")
 	subInit.Flags().StringVarP(&file, "file", "f", "", "the configuration file to use")
 	subInit.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt for confirmation to proceed")
 	subInit.Flags().MarkHidden("non-interactive") //nolint
@@ -314,11 +402,19 @@ func initialize() *cobra.Command {
 		"To specify a single directory across all hosts set /dir."+
 		"To specify different directories for each host use the form \"host1:/dir1,host2:/dir2,host3:/dir3\" where the first host must be the coordinator.")
 	subInit.Flags().Float64Var(&diskFreeRatio, "disk-free-ratio", 0.60, "percentage of disk space that must be available (from 0.0 - 1.0)")
+	subInit.Flags().BoolVar(&estimateDiskSpace, "estimate-disk-space", false, "check the disk space needed based on the size of the source cluster data, tablespaces, and backups for the mode rather than disk-free-ratio")
 	subInit.Flags().BoolVar(&useHbaHostnames, "use-hba-hostnames", false, "use hostnames in pg_hba.conf")
 	subInit.Flags().StringVar(&dynamicLibraryPath, "dynamic-library-path", upgrade.DefaultDynamicLibraryPath, "sets the dynamic_library_path GUC to correctly find extensions installed outside their default location. Defaults to '$dynamic_library_path'.")
 	subInit.Flags().StringVar(&ports, "temp-port-range", "50432-65535", "set of ports to use when initializing the target cluster")
 	subInit.Flags().IntVar(&hubPort, "hub-port", upgrade.DefaultHubPort, "the port gpupgrade hub uses to listen for commands on")
 	subInit.Flags().IntVar(&agentPort, "agent-port", upgrade.DefaultAgentPort, "the port gpupgrade agent uses to listen for commands on")
+	subInit.Flags().StringVar(&hubListenAddress, "hub-listen-address", "", "the address gpupgrade hub listens on. Defaults to all interfaces.")
+	subInit.Flags().BoolVar(&agentListenOnHostname, "agent-listen-on-hostname", false, "gpupgrade agents only listen on the address their hostname resolves to rather than all interfaces")
+	subInit.Flags().StringVar(&tlsConfig.CertFile, "tls-cert-file", "", "the certificate on all hosts used by gpupgrade hub and agents for mutual TLS")
+	subInit.Flags().StringVar(&tlsConfig.KeyFile, "tls-key-file", "", "the private key of the certificate on all hosts used for mutual TLS")
+	subInit.Flags().StringVar(&tlsConfig.CAFile, "tls-ca-file", "", "the certificate authority on all hosts used to verify peers for mutual TLS")
+	subInit.Flags().StringVar(&logFormat, "log-format", logger.TextFormat, "the format of the gpupgrade log files on all hosts. Either text or json. Defaults to text.")
+	subInit.Flags().StringVar(&remoteExecutor, "remote-executor", remote.SSHExecutor, "how gpupgrade hub runs commands on the hosts. Either ssh, agent to use already running agents, or local to run all hosts on this machine. Defaults to ssh.")
 	subInit.Flags().BoolVar(&stopBeforeClusterCreation, "stop-before-cluster-creation", false, "only run up to pre-init")
 	subInit.Flags().MarkHidden("stop-before-cluster-creation") //nolint
 	subInit.Flags().BoolVar(&skipVersionCheck, "skip-version-check", false, "disable source and target version check")
@@ -326,6 +422,8 @@ func initialize() *cobra.Command {
 	// seed-dir is a hidden flag used for internal testing.
 	subInit.Flags().StringVar(&dataMigrationSeedDir, "seed-dir", utils.GetDataMigrationSeedDir(), "path to the seed scripts")
 	subInit.Flags().MarkHidden("seed-dir") //nolint
+	subInit.Flags().BoolVar(&recoverSubsteps, "recover", false, "recover a substep left running by a previous attempt, such as when the hub crashed, and continue")
+	subInit.Flags().BoolVar(&dryRun, "dry-run", false, "print the substeps that would run, be skipped, or be bypassed along with the commands they would issue without making any changes")
 
 	return addHelpToCommand(subInit, InitializeHelp)
 }
@@ -0,0 +1,84 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
+)
+
+// RemoteShell is the remote shell given to rsync by the agent and local
+// executors in place of ssh. rsync runs it with the host followed by the
+// command to run on the host such as "rsync --server ...". Since its stdin
+// and stdout carry the rsync protocol nothing else may be written to stdout.
+func RemoteShell() *cobra.Command {
+	var agentPort int
+	var tlsConfig mtls.Config
+	var local bool
+
+	cmd := &cobra.Command{
+		Use:    "remote-shell <host> <command>...",
+		Short:  "run a command on a host through its agent",
+		Long:   "run a command on a host through its agent",
+		Hidden: true,
+		Args:   cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			host, command := args[0], args[1:]
+
+			if local {
+				shell := exec.Command(command[0], command[1:]...)
+				shell.Stdin = os.Stdin
+				shell.Stdout = os.Stdout
+				shell.Stderr = os.Stderr
+				return shell.Run()
+			}
+
+			creds, err := tlsConfig.ClientCredentials()
+			if err != nil {
+				return err
+			}
+
+			address := net.JoinHostPort(host, strconv.Itoa(agentPort))
+			conn, err := grpc.DialContext(cmd.Context(), address, grpc.WithTransportCredentials(creds))
+			if err != nil {
+				return xerrors.Errorf("connecting to agent on host %s: %w", host, err)
+			}
+			defer conn.Close()
+
+			code, err := remote.RunShell(cmd.Context(), idl.NewAgentClient(conn), command, os.Stdin, os.Stdout, os.Stderr)
+			if err != nil {
+				return err
+			}
+
+			if code != 0 {
+				return xerrors.Errorf("%q on host %s exited with code %d", strings.Join(command, " "), host, code)
+			}
+
+			return nil
+		},
+	}
+
+	// Stop parsing flags at the host such that those of the command are
+	// passed through.
+	cmd.Flags().SetInterspersed(false)
+	cmd.Flags().IntVar(&agentPort, "port", upgrade.DefaultAgentPort, "the port of the agents")
+	cmd.Flags().StringVar(&tlsConfig.CertFile, "tls-cert-file", "", "the certificate used for mutual TLS")
+	cmd.Flags().StringVar(&tlsConfig.KeyFile, "tls-key-file", "", "the private key of the certificate used for mutual TLS")
+	cmd.Flags().StringVar(&tlsConfig.CAFile, "tls-ca-file", "", "the certificate authority used to verify peers for mutual TLS")
+	cmd.Flags().BoolVar(&local, "local", false, "run the command on this machine ignoring the host")
+
+	return cmd
+}
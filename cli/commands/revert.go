@@ -12,10 +12,11 @@ import (
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
-	"github.com/greenplum-db/gpupgrade/cli/clistep"
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
 	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/report"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
@@ -24,6 +25,9 @@ import (
 func revert() *cobra.Command {
 	var verbose bool
 	var nonInteractive bool
+	var dryRun bool
+	var recoverSubsteps bool
+	var htmlReport bool
 
 	cmd := &cobra.Command{
 		Use:   "revert",
@@ -32,6 +36,11 @@ func revert() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			var response *idl.RevertResponse
 
+			conf, err := hubConfig()
+			if err != nil {
+				return err
+			}
+
 			logdir, err := utils.GetLogDir()
 			if err != nil {
 				return err
@@ -41,7 +50,7 @@ func revert() *cobra.Command {
 				cases.Title(language.English).String(idl.Step_revert.String()),
 				revertSubsteps, logdir)
 
-			st, err := clistep.Begin(idl.Step_revert, verbose, nonInteractive, confirmationText)
+			st, err := beginStep(idl.Step_revert, verbose, nonInteractive, dryRun, confirmationText)
 			if err != nil {
 				if errors.Is(err, step.Quit) {
 					// If user cancels don't return an error to main to avoid
@@ -51,6 +60,12 @@ func revert() *cobra.Command {
 				return err
 			}
 
+			if recoverSubsteps {
+				st.Recover()
+			}
+
+			st.RunHooks(hub.Hooks(conf, idl.Step_revert))
+
 			source := &greenplum.Cluster{}
 			st.RunHubSubstep(func(streams step.OutStreams) error {
 				client, err := connectToHub()
@@ -58,7 +73,7 @@ func revert() *cobra.Command {
 					return err
 				}
 
-				response, err = commanders.Revert(client, verbose)
+				response, err = commanders.Revert(client, &idl.RevertRequest{DryRun: dryRun, Recover: recoverSubsteps, HtmlReport: htmlReport}, verbose)
 				if err != nil {
 					return err
 				}
@@ -81,7 +96,12 @@ func revert() *cobra.Command {
 				}
 
 				currentDir := filepath.Join(response.GetLogArchiveDirectory(), "data-migration-scripts", "current")
-				return commanders.ApplyDataMigrationScripts(streams, nonInteractive, source.GPHome, source.CoordinatorPort(), response.GetLogArchiveDirectory(), utils.System.DirFS(currentDir), currentDir, idl.Step_revert)
+				err := commanders.ApplyDataMigrationScripts(streams, nonInteractive, source.GPHome, source.CoordinatorPort(), response.GetLogArchiveDirectory(), utils.System.DirFS(currentDir), currentDir, idl.Step_revert)
+				if err != nil {
+					return err
+				}
+
+				return report.UpdateDataMigrationScripts(response.GetLogArchiveDirectory())
 			})
 
 			st.Run(idl.Substep_delete_master_statedir, func(streams step.OutStreams) error {
@@ -96,6 +116,7 @@ func revert() *cobra.Command {
 				source.Version,
 				filepath.Join(source.GPHome, "greenplum_path.sh"), source.CoordinatorDataDir(), source.CoordinatorPort(),
 				response.GetLogArchiveDirectory(),
+				filepath.Join(response.GetLogArchiveDirectory(), report.JSONFileName),
 				idl.Step_revert,
 				source.GPHome, source.CoordinatorPort(), filepath.Join(response.GetLogArchiveDirectory(), "data-migration-scripts"), idl.Step_revert))
 		},
@@ -104,6 +125,9 @@ func revert() *cobra.Command {
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "print the output stream from all substeps")
 	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt for confirmation to proceed")
 	cmd.Flags().MarkHidden("non-interactive") //nolint
+	cmd.Flags().BoolVar(&recoverSubsteps, "recover", false, "recover a substep left running by a previous attempt, such as when the hub crashed, and continue")
+	cmd.Flags().BoolVar(&htmlReport, "html-report", false, "also write the upgrade report as HTML alongside the JSON report in the log archive directory")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the substeps that would run, be skipped, or be bypassed along with the commands they would issue without making any changes")
 
 	return addHelpToCommand(cmd, RevertHelp)
 }
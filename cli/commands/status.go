@@ -0,0 +1,94 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/upgradestatus"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func status() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "shows the status of each step and substep",
+		Long:  StatusHelp,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "" && format != "text" && format != "json" {
+				return fmt.Errorf(`invalid format %q. Please specify either "text" or "json".`, format)
+			}
+
+			cmd.SilenceUsage = true
+
+			exist, err := upgrade.PathExist(utils.GetStateDir())
+			if err != nil {
+				return err
+			}
+
+			if !exist {
+				if format == "json" {
+					return printStatusJSON(&idl.GetStatusReply{NextStep: idl.Step_initialize, ValidSteps: []idl.Step{idl.Step_initialize}})
+				}
+
+				fmt.Println(commanders.NoUpgradeInProgressText)
+				return nil
+			}
+
+			reply, err := getStatus()
+			if err != nil {
+				return err
+			}
+
+			if format == "json" {
+				return printStatusJSON(reply)
+			}
+
+			fmt.Print(commanders.StatusText(reply))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "", `specify the output format as either "text" or "json". Default is text.`)
+
+	return addHelpToCommand(cmd, StatusHelp)
+}
+
+// getStatus asks the hub for the status. If the hub is not running the state
+// files are read directly.
+func getStatus() (*idl.GetStatusReply, error) {
+	client, err := connectToHub()
+	if err == nil {
+		reply, err := client.GetStatus(context.Background(), &idl.GetStatusRequest{})
+		if err == nil {
+			return reply, nil
+		}
+
+		log.Printf("getting status from hub: %v", err)
+	} else {
+		log.Printf("connecting to hub: %v", err)
+	}
+
+	log.Print("reading status from the state directory")
+	return upgradestatus.Read()
+}
+
+func printStatusJSON(reply *idl.GetStatusReply) error {
+	output, err := commanders.StatusJSON(reply)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(output))
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/greenplum/connection"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/snapshot"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func validate() *cobra.Command {
+	var snapshotPath string
+	var targetGPHome string
+	var targetPort int
+	var outputJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "compares the target cluster against the snapshot of the source cluster taken during initialize",
+		Long:  ValidateHelp,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if !cmd.Flag("target-gphome").Changed || !cmd.Flag("target-port").Changed {
+				exist, err := upgrade.PathExist(config.GetConfigFile())
+				if err != nil {
+					return err
+				}
+
+				if !exist {
+					return errors.New(`expected --target-gphome and --target-port since the configuration does not exist such as after finalize`)
+				}
+
+				conf, err := config.Read()
+				if err != nil {
+					return err
+				}
+
+				// Default to the target cluster as finalized rather than the
+				// intermediate cluster whose temporary ports and missing
+				// mirrors would differ from the source.
+				if !cmd.Flag("target-gphome").Changed {
+					targetGPHome = conf.Target.GPHome
+				}
+
+				if !cmd.Flag("target-port").Changed {
+					targetPort = conf.Target.CoordinatorPort()
+				}
+			}
+
+			cmd.SilenceUsage = true
+
+			sourceSnapshot, err := snapshot.Read(snapshotPath)
+			if err != nil {
+				return err
+			}
+
+			target, err := targetCluster(filepath.Clean(targetGPHome), targetPort)
+			if err != nil {
+				return err
+			}
+
+			targetSnapshot, err := snapshot.Take(&target, 0, &sourceSnapshot)
+			if err != nil {
+				return err
+			}
+
+			diffs := snapshot.Compare(sourceSnapshot, targetSnapshot)
+			if outputJSON {
+				data, err := diffs.JSON()
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(string(data))
+			} else {
+				fmt.Print(diffs.String())
+			}
+
+			if len(diffs) > 0 {
+				cmd.SilenceErrors = true
+				return fmt.Errorf("found %d differences between the source and target clusters", len(diffs))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&snapshotPath, "snapshot", snapshot.SourcePath(), "the snapshot of the source cluster taken during initialize. Defaults to the one in the state directory.")
+	cmd.Flags().StringVar(&targetGPHome, "target-gphome", "", "path for the target Greenplum installation. Defaults to the one used by the upgrade.")
+	cmd.Flags().IntVar(&targetPort, "target-port", 0, "master port for the target cluster. Defaults to the one of the finalized target cluster which is the source cluster port.")
+	cmd.Flags().BoolVar(&outputJSON, "json", false, "print the differences as JSON")
+
+	return addHelpToCommand(cmd, ValidateHelp)
+}
+
+func targetCluster(gphome string, port int) (_ greenplum.Cluster, err error) {
+	db, err := connection.Bootstrap(idl.ClusterDestination_target, gphome, port)
+	if err != nil {
+		return greenplum.Cluster{}, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return greenplum.ClusterFromDB(db, gphome, idl.ClusterDestination_target)
+}
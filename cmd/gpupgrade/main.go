@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/greenplum-db/gpupgrade/cli/commands"
+	"github.com/greenplum-db/gpupgrade/config"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/daemon"
@@ -21,7 +22,15 @@ import (
 func main() {
 	debug.SetTraceback("all")
 
-	logger.Initialize("cli")
+	// Use the log format and upgrade ID from the configuration if gpupgrade
+	// has been initialized.
+	conf, err := config.Read()
+	if err != nil {
+		conf = &config.Config{}
+	}
+
+	logger.Initialize("cli", conf.LogFormat)
+	logger.SetUpgradeID(conf.UpgradeID)
 	defer logger.WritePanics()
 
 	root := commands.BuildRootCommand()
@@ -29,7 +38,7 @@ func main() {
 	// "unknown flag" errors.
 	root.SilenceUsage = true
 
-	err := root.Execute()
+	err = root.Execute()
 	if err != nil && err != daemon.ErrSuccessfullyDaemonized {
 		if strings.HasPrefix(err.Error(), "unknown flag") {
 			cmd := strings.TrimSpace(os.Args[1])
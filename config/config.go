@@ -17,6 +17,10 @@ import (
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/logger"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
 )
 
 const ConfigFileName = "config.json"
@@ -55,6 +59,99 @@ type Config struct {
 	UseHbaHostnames bool
 	UpgradeID       string
 	PgUpgradeJobs   uint
+
+	// HubListenAddress is the address the hub listens on. When empty the hub
+	// listens on all interfaces.
+	HubListenAddress string
+
+	// AgentListenOnHostname restricts each agent to listen only on the
+	// address its segment hostname resolves to rather than all interfaces.
+	AgentListenOnHostname bool
+
+	// TLS enables mutual TLS between the CLI, hub, and agents when set.
+	TLS mtls.Config
+
+	// RemoteExecutor is how the hub runs commands on the hosts such as
+	// starting the agents. One of ssh, agent, or local. Defaults to ssh.
+	RemoteExecutor string
+
+	// LogFormat is the format of the CLI, hub, and agent log files. Either
+	// text or json. Defaults to text.
+	LogFormat string
+
+	// Concurrency limits how much work the hub and agents run at once.
+	Concurrency Concurrency
+
+	// Rsync configures the rsync transfers of each phase that copies data.
+	Rsync RsyncPhases
+
+	// HeartbeatInterval is how often the hub polls the agents during long
+	// running substeps. Zero disables polling.
+	HeartbeatInterval time.Duration
+
+	// PauseBefore is the execute or finalize substep to pause before unless
+	// overridden with --pause-before. Defaults to unknown_substep which does
+	// not pause.
+	PauseBefore idl.Substep
+
+	// ValidationRowCountSample is the number of tables in each database whose
+	// rows are counted when snapshotting the source cluster for validating the
+	// target cluster. Zero counts all tables.
+	ValidationRowCountSample uint
+
+	// HooksDir is the directory of the user defined hooks run before and after
+	// steps and substeps. Empty does not run hooks.
+	HooksDir string
+
+	// MetricsPort is the port the hub serves Prometheus metrics on at
+	// /metrics using the TLS configuration of the hub. Zero does not serve
+	// metrics.
+	MetricsPort int
+}
+
+// Concurrency caps the number of concurrent operations. Zero is unlimited.
+type Concurrency struct {
+	// PgUpgrade is the number of pg_upgrade processes run at once per host.
+	PgUpgrade uint
+
+	// Rsync is the number of rsync processes run at once per host.
+	Rsync uint
+
+	// Hosts is the number of hosts the hub sends requests to at once.
+	Hosts uint
+}
+
+// RsyncPhases contains the rsync settings of each phase. Execute copies the
+// coordinator data directory, finalize upgrades the mirrors using rsync, and
+// revert restores the source cluster.
+type RsyncPhases struct {
+	Execute  rsync.Settings
+	Finalize rsync.Settings
+	Revert   rsync.Settings
+}
+
+// ParseRsyncPhases parses the execute_rsync_options, finalize_rsync_options,
+// and revert_rsync_options parameters.
+func ParseRsyncPhases(execute string, finalize string, revert string) (RsyncPhases, error) {
+	var phases RsyncPhases
+	for _, phase := range []struct {
+		name     string
+		input    string
+		settings *rsync.Settings
+	}{
+		{"execute_rsync_options", execute, &phases.Execute},
+		{"finalize_rsync_options", finalize, &phases.Finalize},
+		{"revert_rsync_options", revert, &phases.Revert},
+	} {
+		settings, err := rsync.ParseSettings(phase.input)
+		if err != nil {
+			return RsyncPhases{}, xerrors.Errorf("%s: %w", phase.name, err)
+		}
+
+		*phase.settings = settings
+	}
+
+	return phases, nil
 }
 
 func (conf *Config) Write() error {
@@ -85,7 +182,19 @@ func GetConfigFile() string {
 	return filepath.Join(utils.GetStateDir(), ConfigFileName)
 }
 
-func Create(db *sql.DB, hubPort int, agentPort int, sourceGPHome string, targetGPHome string, mode idl.Mode, useHbaHostnames bool, ports []int, pgUpgradeJobs uint, parentBackupDirs string) (Config, error) {
+func Create(db *sql.DB, hubPort int, agentPort int, sourceGPHome string, targetGPHome string, mode idl.Mode, useHbaHostnames bool, ports []int, pgUpgradeJobs uint, parentBackupDirs string, hubListenAddress string, agentListenOnHostname bool, tlsConfig mtls.Config, remoteExecutor string, logFormat string, concurrency Concurrency, rsyncPhases RsyncPhases, heartbeatInterval time.Duration, pauseBefore idl.Substep, validationRowCountSample uint, hooksDir string, metricsPort int) (Config, error) {
+	if err := tlsConfig.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	if err := remote.ValidateExecutor(remoteExecutor); err != nil {
+		return Config{}, err
+	}
+
+	if err := logger.ValidateFormat(logFormat); err != nil {
+		return Config{}, err
+	}
+
 	source, err := greenplum.ClusterFromDB(db, sourceGPHome, idl.ClusterDestination_source)
 	if err != nil {
 		return Config{}, xerrors.Errorf("retrieve source configuration: %w", err)
@@ -109,6 +218,18 @@ func Create(db *sql.DB, hubPort int, agentPort int, sourceGPHome string, targetG
 	config.UseHbaHostnames = useHbaHostnames
 	config.UpgradeID = upgrade.NewID()
 	config.PgUpgradeJobs = pgUpgradeJobs
+	config.HubListenAddress = hubListenAddress
+	config.AgentListenOnHostname = agentListenOnHostname
+	config.TLS = tlsConfig
+	config.RemoteExecutor = remoteExecutor
+	config.LogFormat = logFormat
+	config.Concurrency = concurrency
+	config.Rsync = rsyncPhases
+	config.HeartbeatInterval = heartbeatInterval
+	config.PauseBefore = pauseBefore
+	config.ValidationRowCountSample = validationRowCountSample
+	config.HooksDir = hooksDir
+	config.MetricsPort = metricsPort
 	config.BackupDirs, err = backupdir.ParseParentBackupDirs(parentBackupDirs, source)
 	if err != nil {
 		return Config{}, err
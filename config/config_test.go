@@ -17,6 +17,10 @@ import (
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/utils/logger"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
 )
 
 func TestConfig(t *testing.T) {
@@ -80,6 +84,8 @@ func TestCreate(t *testing.T) {
 	const useHbaHostnames = false
 	const parentBackupDirs = ""
 	const pgUpgradeJobs = 1
+	const hubListenAddress = "10.0.0.1"
+	const agentListenOnHostname = true
 	ports, err := commands.ParsePorts("50432-65535")
 	if err != nil {
 		t.Fatal(err)
@@ -100,7 +106,7 @@ func TestCreate(t *testing.T) {
 			expectPgStatReplicationToReturn(mock)
 			expectPgTablespace(mock)
 
-			conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs)
+			conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, hubListenAddress, agentListenOnHostname, mtls.Config{}, remote.SSHExecutor, logger.JSONFormat, config.Concurrency{}, config.RsyncPhases{}, 0, idl.Substep_unknown_substep, 100, "", 0)
 			if err != nil {
 				t.Fatalf("unexpected error %#v", err)
 			}
@@ -126,7 +132,7 @@ func TestCreate(t *testing.T) {
 			expectPgStatReplicationToReturn(mock)
 			expectPgTablespace(mock)
 
-			conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs)
+			conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, hubListenAddress, agentListenOnHostname, mtls.Config{}, remote.SSHExecutor, logger.JSONFormat, config.Concurrency{}, config.RsyncPhases{}, 0, idl.Substep_unknown_substep, 100, "", 0)
 			if err != nil {
 				t.Fatalf("unexpected error %#v", err)
 			}
@@ -151,7 +157,7 @@ func TestCreate(t *testing.T) {
 			expectPgStatReplicationToReturn(mock)
 			expectPgTablespace(mock)
 
-			conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs)
+			conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, hubListenAddress, agentListenOnHostname, mtls.Config{}, remote.SSHExecutor, logger.JSONFormat, config.Concurrency{}, config.RsyncPhases{}, 0, idl.Substep_unknown_substep, 100, "", 0)
 			if err != nil {
 				t.Fatalf("unexpected error %#v", err)
 			}
@@ -168,7 +174,8 @@ func TestCreate(t *testing.T) {
 		expectPgStatReplicationToReturn(mock)
 		expectPgTablespace(mock)
 
-		conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs)
+		concurrency := config.Concurrency{PgUpgrade: 2, Rsync: 3, Hosts: 4}
+		conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, hubListenAddress, agentListenOnHostname, mtls.Config{}, remote.SSHExecutor, logger.JSONFormat, concurrency, config.RsyncPhases{}, 0, idl.Substep_upgrade_primaries, 25, "/home/gpadmin/hooks", 9187)
 		if err != nil {
 			t.Fatalf("unexpected error %#v", err)
 		}
@@ -214,10 +221,97 @@ func TestCreate(t *testing.T) {
 			t.Errorf("got %d want %d", conf.PgUpgradeJobs, pgUpgradeJobs)
 		}
 
+		if conf.HubListenAddress != hubListenAddress {
+			t.Errorf("got %q want %q", conf.HubListenAddress, hubListenAddress)
+		}
+
+		if conf.AgentListenOnHostname != agentListenOnHostname {
+			t.Errorf("got %t want %t", conf.AgentListenOnHostname, agentListenOnHostname)
+		}
+
+		if conf.RemoteExecutor != remote.SSHExecutor {
+			t.Errorf("got %q want %q", conf.RemoteExecutor, remote.SSHExecutor)
+		}
+
+		if conf.LogFormat != logger.JSONFormat {
+			t.Errorf("got %q want %q", conf.LogFormat, logger.JSONFormat)
+		}
+
+		if conf.Concurrency != concurrency {
+			t.Errorf("got %+v want %+v", conf.Concurrency, concurrency)
+		}
+
+		if conf.PauseBefore != idl.Substep_upgrade_primaries {
+			t.Errorf("got %s want %s", conf.PauseBefore, idl.Substep_upgrade_primaries)
+		}
+
+		if conf.ValidationRowCountSample != 25 {
+			t.Errorf("got %d want %d", conf.ValidationRowCountSample, 25)
+		}
+
+		if conf.HooksDir != "/home/gpadmin/hooks" {
+			t.Errorf("got %q want %q", conf.HooksDir, "/home/gpadmin/hooks")
+		}
+
+		if conf.MetricsPort != 9187 {
+			t.Errorf("got %d want %d", conf.MetricsPort, 9187)
+		}
+
 		if conf.UpgradeID == "" {
 			t.Errorf("expected non-empty UpgradeID")
 		}
 	})
+
+	t.Run("create errors when the tls configuration is incomplete", func(t *testing.T) {
+		tlsConfig := mtls.Config{CertFile: "/tmp/cert.pem"}
+
+		_, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, hubListenAddress, agentListenOnHostname, tlsConfig, remote.SSHExecutor, logger.JSONFormat, config.Concurrency{}, config.RsyncPhases{}, 0, idl.Substep_unknown_substep, 100, "", 0)
+		expected := "tls_cert_file, tls_key_file, and tls_ca_file must all be set to enable TLS"
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+
+	t.Run("create errors when the log format is invalid", func(t *testing.T) {
+		_, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, hubListenAddress, agentListenOnHostname, mtls.Config{}, remote.SSHExecutor, "xml", config.Concurrency{}, config.RsyncPhases{}, 0, idl.Substep_unknown_substep, 100, "", 0)
+		expected := `invalid log_format "xml". Expected either text or json.`
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+
+	t.Run("create errors when the remote executor is invalid", func(t *testing.T) {
+		_, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, hubListenAddress, agentListenOnHostname, mtls.Config{}, "rsh", logger.TextFormat, config.Concurrency{}, config.RsyncPhases{}, 0, idl.Substep_unknown_substep, 100, "", 0)
+		expected := `invalid remote_executor "rsh". Expected one of ssh, agent, or local.`
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+}
+
+func TestParseRsyncPhases(t *testing.T) {
+	t.Run("parses the settings of each phase", func(t *testing.T) {
+		phases, err := config.ParseRsyncPhases("bandwidth_limit=100000,partial", "", "checksum,verify")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := config.RsyncPhases{
+			Execute: rsync.Settings{BandwidthLimit: 100000, Partial: true},
+			Revert:  rsync.Settings{Checksum: true, Verify: true},
+		}
+		if phases != expected {
+			t.Errorf("got %+v want %+v", phases, expected)
+		}
+	})
+
+	t.Run("errors with the parameter name of invalid settings", func(t *testing.T) {
+		_, err := config.ParseRsyncPhases("", "compress", "")
+		expected := `finalize_rsync_options: invalid rsync setting "compress". Expected bandwidth_limit=<KiB per second>, checksum, partial, or verify.`
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
 }
 
 func expectGpSegmentConfigurationToReturnCluster(mock sqlmock.Sqlmock, cluster *greenplum.Cluster) {
@@ -5,6 +5,7 @@ package greenplum
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/gob"
 	"fmt"
@@ -25,6 +26,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/trace"
 )
 
 const CoordinatorDbid = 1
@@ -425,7 +427,10 @@ func (c *Cluster) runGreenplumCommand(streams step.OutStreams, utility string, a
 	cmd.Stderr = streams.Stderr()
 
 	log.Printf("Executing: %q", cmd.String())
-	return cmd.Run()
+	_, span := trace.Start(context.Background(), utility, "command", cmd.String())
+	err := cmd.Run()
+	span.End(err)
+	return err
 }
 
 func (c *Cluster) RunCmd(streams step.OutStreams, command string, args ...string) error {
@@ -12,13 +12,13 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils"
 )
 
-func AddReplicationEntriesOnPrimaries(agentConns []*idl.Connection, intermediate *greenplum.Cluster, useHbaHostnames bool) error {
+func AddReplicationEntriesOnPrimaries(ctx context.Context, agentConns []*idl.Connection, hostConcurrency uint, intermediate *greenplum.Cluster, useHbaHostnames bool) error {
 	user, err := utils.System.Current()
 	if err != nil {
 		return err
 	}
 
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		intermediatePrimaries := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsCoordinator() && seg.IsPrimary()
 		})
@@ -47,11 +47,11 @@ func AddReplicationEntriesOnPrimaries(agentConns []*idl.Connection, intermediate
 		}
 
 		req := &idl.AddReplicationEntriesRequest{Entries: entries}
-		_, err := conn.AgentClient.AddReplicationEntries(context.Background(), req)
+		_, err := conn.AgentClient.AddReplicationEntries(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, hostConcurrency, request)
 }
 
 // getIpAddresses returns a list of ip addresses with CIDR notation for use in
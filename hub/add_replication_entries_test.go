@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"net"
 	"os/user"
@@ -71,7 +72,7 @@ func TestAddReplicationEntriesOnPrimaries(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, false)
+		err := hub.AddReplicationEntriesOnPrimaries(context.Background(), agentConns, 0, intermediate, false)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -123,7 +124,7 @@ func TestAddReplicationEntriesOnPrimaries(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, true)
+		err := hub.AddReplicationEntriesOnPrimaries(context.Background(), agentConns, 0, intermediate, true)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -151,7 +152,7 @@ func TestAddReplicationEntriesOnPrimaries(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, false)
+		err := hub.AddReplicationEntriesOnPrimaries(context.Background(), agentConns, 0, intermediate, false)
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Fatalf("error %#v does not contain type %T", err, errs)
@@ -177,7 +178,7 @@ func TestAddReplicationEntriesOnPrimaries(t *testing.T) {
 			utils.System.Current = user.Current
 		}()
 
-		err := hub.AddReplicationEntriesOnPrimaries(nil, intermediate, true)
+		err := hub.AddReplicationEntriesOnPrimaries(context.Background(), nil, 0, intermediate, true)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
@@ -200,7 +201,7 @@ func TestAddReplicationEntriesOnPrimaries(t *testing.T) {
 			{AgentClient: nil, Hostname: "sdw2"},
 		}
 
-		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, true)
+		err := hub.AddReplicationEntriesOnPrimaries(context.Background(), agentConns, 0, intermediate, true)
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Fatalf("error %#v does not contain type %T", err, errs)
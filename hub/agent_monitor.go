@@ -0,0 +1,340 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+var HeartbeatTimeout = 10 * time.Second
+
+// HeartbeatMisses is the number of consecutive heartbeats an agent must miss
+// before it is considered unreachable such that a brief network blip does not
+// fail a long running substep.
+var HeartbeatMisses = 3
+
+type AgentUnreachableError struct {
+	Host string
+	Err  error
+}
+
+func (e *AgentUnreachableError) Error() string {
+	return fmt.Sprintf("gpupgrade agent on host %s is not responding: %v", e.Host, e.Err)
+}
+
+func (e *AgentUnreachableError) Unwrap() error {
+	return e.Err
+}
+
+// Heartbeat sends a heartbeat to each agent recording the results in the
+// store. An AgentUnreachableError is returned for each agent that does not
+// respond.
+func Heartbeat(agentConns []*idl.Connection, hostConcurrency uint, store *step.AgentFileStore) error {
+	return forEachHost(agentConns, hostConcurrency, func(conn *idl.Connection) error {
+		return heartbeat(conn, store)
+	})
+}
+
+func heartbeat(conn *idl.Connection, store *step.AgentFileStore) error {
+	ctx, cancel := context.WithTimeout(context.Background(), HeartbeatTimeout)
+	defer cancel()
+
+	reply, err := conn.AgentClient.Heartbeat(ctx, &idl.HeartbeatRequest{})
+	if err != nil {
+		if wErr := store.WriteError(conn.Hostname, err); wErr != nil {
+			log.Printf("record heartbeat of agent on host %s: %v", conn.Hostname, wErr)
+		}
+
+		return &AgentUnreachableError{Host: conn.Hostname, Err: err}
+	}
+
+	if wErr := store.WriteHealth(conn.Hostname, reply.GetHealth(), utils.System.Now()); wErr != nil {
+		log.Printf("record heartbeat of agent on host %s: %v", conn.Hostname, wErr)
+	}
+
+	return nil
+}
+
+// AgentMonitor polls the agents with heartbeats during long running substeps.
+// When an agent stops responding its in-flight requests are cancelled such
+// that the substep fails right away with an AgentUnreachableError rather than
+// with an opaque error once the request eventually times out. An agent is only
+// considered to have stopped responding after HeartbeatMisses consecutive
+// missed heartbeats. The interceptors must be installed on the agent
+// connections for requests to be cancelled.
+type AgentMonitor struct {
+	mutex           sync.Mutex
+	agents          map[string]*agentState
+	hostConcurrency uint
+}
+
+type agentState struct {
+	down   chan struct{} // closed once the agent stops responding
+	err    error
+	misses int // consecutive missed heartbeats
+}
+
+// NewAgentMonitor returns a monitor sending heartbeats to at most
+// hostConcurrency agents at once. Zero is unlimited.
+func NewAgentMonitor(hostConcurrency uint) *AgentMonitor {
+	return &AgentMonitor{agents: make(map[string]*agentState), hostConcurrency: hostConcurrency}
+}
+
+// Start sends heartbeats to the agents right away and then every interval
+// until the returned stop function is called. Any agents previously found
+// unreachable are assumed to be reachable again since they are restarted at
+// the start of each step.
+func (m *AgentMonitor) Start(agentConns []*idl.Connection, interval time.Duration, store *step.AgentFileStore) (stop func()) {
+	m.mutex.Lock()
+	for _, conn := range agentConns {
+		m.agents[conn.Hostname] = &agentState{down: make(chan struct{})}
+	}
+	m.mutex.Unlock()
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		m.poll(agentConns, store)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				m.poll(agentConns, store)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// monitorAgents runs f while the agent monitor polls the agents every
+// heartbeat interval. It is used for long running substeps that make requests
+// to the agents.
+func (s *Server) monitorAgents(f func() error) error {
+	if s.HeartbeatInterval <= 0 || s.agentMonitor == nil {
+		return f()
+	}
+
+	store, err := step.NewAgentFileStore()
+	if err != nil {
+		return err
+	}
+
+	stop := s.agentMonitor.Start(s.agentConns, s.HeartbeatInterval, store)
+	err = f()
+	stop()
+
+	if hosts := s.agentMonitor.Unreachable(); err != nil && len(hosts) > 0 {
+		nextAction := fmt.Sprintf(`Check that the gpupgrade agent process is running on %s and the network between the master and segment hosts.
+Then try restarting the hub and agents with "gpupgrade kill-services && gpupgrade restart-services" and re-run the step.`, strings.Join(hosts, ", "))
+		return utils.NewNextActionErr(err, nextAction)
+	}
+
+	return err
+}
+
+func (m *AgentMonitor) poll(agentConns []*idl.Connection, store *step.AgentFileStore) {
+	err := forEachHost(agentConns, m.hostConcurrency, func(conn *idl.Connection) error {
+		err := heartbeat(conn, store)
+		if err != nil {
+			m.missed(conn.Hostname, err)
+		} else {
+			m.responded(conn.Hostname)
+		}
+
+		return err
+	})
+	if err != nil {
+		log.Printf("agent heartbeat: %v", err)
+	}
+}
+
+// missed records a missed heartbeat marking the agent unreachable and
+// cancelling its in-flight requests once it has missed HeartbeatMisses in a
+// row.
+func (m *AgentMonitor) missed(host string, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	state := m.state(host)
+	state.misses++
+	if state.err != nil || state.misses < HeartbeatMisses {
+		return
+	}
+
+	state.err = err
+	close(state.down)
+}
+
+// responded resets the missed heartbeats of the agent. An agent previously
+// marked unreachable is reachable again such that new requests are not
+// cancelled.
+func (m *AgentMonitor) responded(host string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	state := m.state(host)
+	state.misses = 0
+	if state.err != nil {
+		m.agents[host] = &agentState{down: make(chan struct{})}
+	}
+}
+
+// state returns the state of the agent on the host. Callers must hold the
+// mutex.
+func (m *AgentMonitor) state(host string) *agentState {
+	state, ok := m.agents[host]
+	if !ok {
+		state = &agentState{down: make(chan struct{})}
+		m.agents[host] = state
+	}
+
+	return state
+}
+
+// Unreachable returns the hosts whose agents stopped responding.
+func (m *AgentMonitor) Unreachable() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var hosts []string
+	for host, state := range m.agents {
+		if state.err != nil {
+			hosts = append(hosts, host)
+		}
+	}
+
+	sort.Strings(hosts)
+	return hosts
+}
+
+// Err returns an AgentUnreachableError if the agent on the host stopped
+// responding.
+func (m *AgentMonitor) Err(host string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	state, ok := m.agents[host]
+	if !ok || state.err == nil {
+		return nil
+	}
+
+	return &AgentUnreachableError{Host: host, Err: state.err}
+}
+
+// watch returns a context that is cancelled once the agent on the host stops
+// responding.
+func (m *AgentMonitor) watch(ctx context.Context, host string) (context.Context, context.CancelFunc) {
+	m.mutex.Lock()
+	down := m.state(host).down
+	m.mutex.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-down:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// UnaryClientInterceptor cancels requests to the agent on the host once it
+// stops responding returning an AgentUnreachableError. Heartbeats are not
+// cancelled such that the agent can be found reachable again.
+func (m *AgentMonitor) UnaryClientInterceptor(host string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if m == nil || method == idl.Agent_Heartbeat_FullMethodName {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		ctx, cancel := m.watch(ctx, host)
+		defer cancel()
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err != nil {
+			if unreachable := m.Err(host); unreachable != nil {
+				return unreachable
+			}
+		}
+
+		return err
+	}
+}
+
+// StreamClientInterceptor is the streaming counterpart of
+// UnaryClientInterceptor.
+func (m *AgentMonitor) StreamClientInterceptor(host string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if m == nil {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+
+		ctx, cancel := m.watch(ctx, host)
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			cancel()
+
+			if unreachable := m.Err(host); unreachable != nil {
+				return nil, unreachable
+			}
+
+			return nil, err
+		}
+
+		return &monitoredStream{ClientStream: stream, monitor: m, host: host, cancel: cancel}, nil
+	}
+}
+
+// monitoredStream releases the watch on the agent once the stream ends.
+type monitoredStream struct {
+	grpc.ClientStream
+	monitor *AgentMonitor
+	host    string
+	cancel  context.CancelFunc
+}
+
+func (s *monitoredStream) RecvMsg(msg interface{}) error {
+	err := s.ClientStream.RecvMsg(msg)
+	if err == nil {
+		return nil
+	}
+
+	s.cancel()
+
+	if err != io.EOF {
+		if unreachable := s.monitor.Err(s.host); unreachable != nil {
+			return unreachable
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestHeartbeat(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	path := filepath.Join(dir, step.AgentsFileName)
+	testutils.MustWriteToFile(t, path, "{}")
+	store := step.NewAgentStoreUsingFile(path)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sdw1Client := mock_idl.NewMockAgentClient(ctrl)
+	sdw1Client.EXPECT().Heartbeat(
+		gomock.Any(),
+		&idl.HeartbeatRequest{},
+	).Return(&idl.HeartbeatReply{Health: &idl.AgentHealth{Version: "1.2.3", Pid: 42}}, nil)
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	sdw2Client := mock_idl.NewMockAgentClient(ctrl)
+	sdw2Client.EXPECT().Heartbeat(
+		gomock.Any(),
+		&idl.HeartbeatRequest{},
+	).Return(nil, unavailable)
+
+	agentConns := []*idl.Connection{
+		{AgentClient: sdw1Client, Hostname: "sdw1"},
+		{AgentClient: sdw2Client, Hostname: "sdw2"},
+	}
+
+	err := hub.Heartbeat(agentConns, 0, store)
+	var unreachable *hub.AgentUnreachableError
+	if !errors.As(err, &unreachable) {
+		t.Fatalf("got error %#v want type %T", err, unreachable)
+	}
+
+	if unreachable.Host != "sdw2" || !errors.Is(unreachable, unavailable) {
+		t.Errorf("got %+v want host sdw2 with error %v", unreachable, unavailable)
+	}
+
+	agents, err := store.ReadAll()
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	if agents["sdw1"].Version != "1.2.3" || agents["sdw1"].Pid != 42 || agents["sdw1"].Error != "" {
+		t.Errorf("got %+v want the health of sdw1", agents["sdw1"])
+	}
+
+	if agents["sdw2"].Error != unavailable.Error() {
+		t.Errorf("got error %q want %q", agents["sdw2"].Error, unavailable.Error())
+	}
+}
+
+func TestAgentMonitor(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	path := filepath.Join(dir, step.AgentsFileName)
+	testutils.MustWriteToFile(t, path, "{}")
+	store := step.NewAgentStoreUsingFile(path)
+
+	// blockUntilCancelled simulates a long running request to an agent that
+	// has stopped responding.
+	blockUntilCancelled := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-time.After(10 * time.Second):
+			return errors.New("expected the request to be cancelled")
+		}
+	}
+
+	t.Run("cancels in-flight requests once an agent stops responding", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1Client := mock_idl.NewMockAgentClient(ctrl)
+		sdw1Client.EXPECT().Heartbeat(gomock.Any(), gomock.Any()).
+			Return(&idl.HeartbeatReply{Health: &idl.AgentHealth{}}, nil).AnyTimes()
+
+		sdw2Client := mock_idl.NewMockAgentClient(ctrl)
+		sdw2Client.EXPECT().Heartbeat(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.Unavailable, "connection refused")).AnyTimes()
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1Client, Hostname: "sdw1"},
+			{AgentClient: sdw2Client, Hostname: "sdw2"},
+		}
+
+		monitor := hub.NewAgentMonitor(0)
+		stop := monitor.Start(agentConns, time.Millisecond, store)
+		defer stop()
+
+		interceptor := monitor.UnaryClientInterceptor("sdw2")
+		err := interceptor(context.Background(), idl.Agent_RsyncDataDirectories_FullMethodName, nil, nil, nil, blockUntilCancelled)
+
+		var unreachable *hub.AgentUnreachableError
+		if !errors.As(err, &unreachable) {
+			t.Fatalf("got error %#v want type %T", err, unreachable)
+		}
+
+		if unreachable.Host != "sdw2" {
+			t.Errorf("got host %q want %q", unreachable.Host, "sdw2")
+		}
+
+		expected := []string{"sdw2"}
+		if !reflect.DeepEqual(monitor.Unreachable(), expected) {
+			t.Errorf("got unreachable hosts %v want %v", monitor.Unreachable(), expected)
+		}
+
+		if monitor.Err("sdw1") != nil {
+			t.Errorf("unexpected error %#v", monitor.Err("sdw1"))
+		}
+	})
+
+	t.Run("does not cancel requests to agents that miss fewer heartbeats in a row than allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		unavailable := status.Error(codes.Unavailable, "connection refused")
+		healthy := &idl.HeartbeatReply{Health: &idl.AgentHealth{}}
+
+		// The agent misses two heartbeats, responds, and misses two more
+		// before responding again which signals responded.
+		responded := make(chan struct{}, 1)
+
+		sdw1Client := mock_idl.NewMockAgentClient(ctrl)
+		gomock.InOrder(
+			sdw1Client.EXPECT().Heartbeat(gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(2),
+			sdw1Client.EXPECT().Heartbeat(gomock.Any(), gomock.Any()).Return(healthy, nil),
+			sdw1Client.EXPECT().Heartbeat(gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(2),
+			sdw1Client.EXPECT().Heartbeat(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, in *idl.HeartbeatRequest, opts ...grpc.CallOption) (*idl.HeartbeatReply, error) {
+					select {
+					case responded <- struct{}{}:
+					default:
+					}
+
+					return healthy, nil
+				}).AnyTimes(),
+		)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1Client, Hostname: "sdw1"}}
+
+		monitor := hub.NewAgentMonitor(0)
+		stop := monitor.Start(agentConns, time.Millisecond, store)
+		defer stop()
+
+		interceptor := monitor.UnaryClientInterceptor("sdw1")
+		err := interceptor(context.Background(), idl.Agent_RsyncDataDirectories_FullMethodName, nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				<-responded
+				return ctx.Err()
+			})
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		if len(monitor.Unreachable()) != 0 {
+			t.Errorf("got unreachable hosts %v want none", monitor.Unreachable())
+		}
+	})
+
+	t.Run("does not cancel requests to responding agents", func(t *testing.T) {
+		monitor := hub.NewAgentMonitor(0)
+
+		called := false
+		interceptor := monitor.UnaryClientInterceptor("sdw1")
+		err := interceptor(context.Background(), idl.Agent_RsyncDataDirectories_FullMethodName, nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				called = true
+				return ctx.Err()
+			})
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		if !called {
+			t.Errorf("expected the request to be sent")
+		}
+	})
+
+	t.Run("returns errors from requests unchanged", func(t *testing.T) {
+		monitor := hub.NewAgentMonitor(0)
+
+		expected := errors.New("permission denied")
+		interceptor := monitor.UnaryClientInterceptor("sdw1")
+		err := interceptor(context.Background(), idl.Agent_RsyncDataDirectories_FullMethodName, nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return expected
+			})
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
+	t.Run("a nil monitor passes requests through", func(t *testing.T) {
+		var monitor *hub.AgentMonitor
+
+		expected := errors.New("permission denied")
+		interceptor := monitor.UnaryClientInterceptor("sdw1")
+		err := interceptor(context.Background(), idl.Agent_RsyncDataDirectories_FullMethodName, nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return expected
+			})
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
@@ -14,7 +14,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils"
 )
 
-func ArchiveLogDirectories(logDir string, logArchiveDir string, agentConns []*idl.Connection, targetCoordinatorHost string) error {
+func ArchiveLogDirectories(ctx context.Context, logDir string, logArchiveDir string, agentConns []*idl.Connection, hostConcurrency uint, targetCoordinatorHost string) error {
 	// Archive log directory on coordinator
 	log.Printf("archiving log directory %q to %q", logDir, logArchiveDir)
 	err := utils.Move(logDir, logArchiveDir)
@@ -23,22 +23,22 @@ func ArchiveLogDirectories(logDir string, logArchiveDir string, agentConns []*id
 	}
 
 	// Archive log directory on segments
-	return ArchiveSegmentLogDirectories(agentConns, targetCoordinatorHost, logArchiveDir)
+	return ArchiveSegmentLogDirectories(ctx, agentConns, hostConcurrency, targetCoordinatorHost, logArchiveDir)
 
 }
 
-func ArchiveSegmentLogDirectories(agentConns []*idl.Connection, excludeHostname, logArchiveDir string) error {
-	request := func(conn *idl.Connection) error {
+func ArchiveSegmentLogDirectories(ctx context.Context, agentConns []*idl.Connection, hostConcurrency uint, excludeHostname, logArchiveDir string) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		if conn.Hostname == excludeHostname {
 			return nil
 		}
 
 		req := &idl.ArchiveLogDirectoryRequest{LogArchiveDir: logArchiveDir}
-		_, err := conn.AgentClient.ArchiveLogDirectory(context.Background(), req)
+		_, err := conn.AgentClient.ArchiveLogDirectory(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, hostConcurrency, request)
 }
 
 // GetLogArchiveDir returns the name of the file to be used to store logs
@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -44,7 +45,7 @@ func TestArchiveLogDirectories(t *testing.T) {
 			{AgentClient: sdwClient, Hostname: "sdw"},
 		}
 
-		err := hub.ArchiveLogDirectories(logDir, logArchiveDir, agentConns, targetCoordinatorHost)
+		err := hub.ArchiveLogDirectories(context.Background(), logDir, logArchiveDir, agentConns, 0, targetCoordinatorHost)
 		if err != nil {
 			t.Errorf("unexpected err %+v", err)
 		}
@@ -73,7 +74,7 @@ func TestArchiveLogDirectories(t *testing.T) {
 			{AgentClient: sdwClient, Hostname: "sdw"},
 		}
 
-		err := hub.ArchiveLogDirectories(logDir, logArchiveDir, agentConns, targetCoordinatorHost)
+		err := hub.ArchiveLogDirectories(context.Background(), logDir, logArchiveDir, agentConns, 0, targetCoordinatorHost)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -96,7 +97,7 @@ func TestArchiveLogDirectories(t *testing.T) {
 			{AgentClient: sdwClient, Hostname: "sdw"},
 		}
 
-		err = hub.ArchiveLogDirectories(logDir, logArchiveDir, agentConns, targetCoordinatorHost)
+		err = hub.ArchiveLogDirectories(context.Background(), logDir, logArchiveDir, agentConns, 0, targetCoordinatorHost)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -119,7 +120,7 @@ func TestArchiveLogDirectories(t *testing.T) {
 			{AgentClient: sdwClient, Hostname: "sdw"},
 		}
 
-		err = hub.ArchiveLogDirectories(logDir, logArchiveDir, agentConns, targetCoordinatorHost)
+		err = hub.ArchiveLogDirectories(context.Background(), logDir, logArchiveDir, agentConns, 0, targetCoordinatorHost)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -153,7 +154,7 @@ func TestArchiveSegmentLogDirectories(t *testing.T) {
 			{AgentClient: sdwClient, Hostname: "sdw"},
 		}
 
-		err := hub.ArchiveSegmentLogDirectories(agentConns, targetCoordinatorHost, logArchiveDir)
+		err := hub.ArchiveSegmentLogDirectories(context.Background(), agentConns, 0, targetCoordinatorHost, logArchiveDir)
 		if err != nil {
 			t.Errorf("unexpected err %+v", err)
 		}
@@ -180,7 +181,7 @@ func TestArchiveSegmentLogDirectories(t *testing.T) {
 			{AgentClient: failedClient, Hostname: "sdw"},
 		}
 
-		err := hub.ArchiveSegmentLogDirectories(agentConns, targetCoordinatorHost, logArchiveDir)
+		err := hub.ArchiveSegmentLogDirectories(context.Background(), agentConns, 0, targetCoordinatorHost, logArchiveDir)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
@@ -0,0 +1,278 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+)
+
+var ErrViewerBehind = errors.New(`detached since too far behind the running step. Re-run "gpupgrade attach" to follow it again`)
+
+// viewerBuffer is the number of messages buffered for each attached viewer.
+// A viewer that falls further behind is detached rather than slowing down the
+// step.
+const viewerBuffer = 4096
+
+// historyOutput is the number of the most recent output messages, such as the
+// chunks of pg_upgrade and rsync output, replayed to viewers that attach. The
+// other messages such as the substep statuses and plans are always replayed
+// such that viewers show every substep.
+const historyOutput = 4096
+
+// stepMessages records the messages of the running step and sends them to the
+// CLI which began the step along with any attached viewers. The step continues
+// when the CLI goes away such as when its ssh session drops, and can be
+// followed again with attach. Like the viewers, the CLI is sent its messages
+// through a buffered channel such that a CLI that stops reading without its
+// stream closing does not stall the step, and is detached once it falls too
+// far behind.
+type stepMessages struct {
+	mutex      sync.Mutex
+	client     chan *idl.Message
+	clientDone chan struct{} // closed once the client is sent its last message
+	sequence   int
+	messages   []sequencedMessage // always replayed
+	output     []sequencedMessage // ring of the most recent output
+	oldest     int                // index of the oldest output once full
+	viewers    map[chan *idl.Message]bool
+	done       bool
+	err        error
+}
+
+// sequencedMessage orders the messages and output when replaying them.
+type sequencedMessage struct {
+	sequence int
+	msg      *idl.Message
+}
+
+func newStepMessages(sender idl.MessageSender) *stepMessages {
+	m := &stepMessages{
+		viewers:    make(map[chan *idl.Message]bool),
+		clientDone: make(chan struct{}),
+	}
+
+	if sender == nil {
+		close(m.clientDone)
+		return m
+	}
+
+	m.client = make(chan *idl.Message, viewerBuffer)
+	go m.sendToClient(sender, m.client)
+
+	return m
+}
+
+// Send never errors such that the step keeps sending messages for viewers to
+// attach to after the CLI which began the step goes away.
+func (m *stepMessages) Send(msg *idl.Message) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.record(msg)
+
+	for viewer := range m.viewers {
+		select {
+		case viewer <- msg:
+		default:
+			log.Print("detaching viewer which fell too far behind")
+			delete(m.viewers, viewer)
+			close(viewer)
+		}
+	}
+
+	if m.client == nil {
+		return nil
+	}
+
+	select {
+	case m.client <- msg:
+	default:
+		log.Print("halting client sender which fell too far behind. The step continues and can be followed with \"gpupgrade attach\".")
+		m.detachClient(m.client)
+	}
+
+	return nil
+}
+
+// sendToClient sends the messages to the CLI which began the step until the
+// client channel is closed or sending fails.
+func (m *stepMessages) sendToClient(sender idl.MessageSender, client chan *idl.Message) {
+	defer close(m.clientDone)
+
+	for msg := range client {
+		if err := sender.Send(msg); err != nil {
+			log.Printf("halting client sender: %v. The step continues and can be followed with \"gpupgrade attach\".", err)
+
+			m.mutex.Lock()
+			m.detachClient(client)
+			m.mutex.Unlock()
+			return
+		}
+	}
+}
+
+// detachClient stops sending messages to the client. The mutex must be held.
+func (m *stepMessages) detachClient(client chan *idl.Message) {
+	if m.client == client {
+		m.client = nil
+		close(client)
+	}
+}
+
+func (m *stepMessages) record(msg *idl.Message) {
+	m.sequence++
+	entry := sequencedMessage{sequence: m.sequence, msg: msg}
+
+	switch msg.GetContents().(type) {
+	case *idl.Message_Chunk, *idl.Message_Progress:
+		if len(m.output) < historyOutput {
+			m.output = append(m.output, entry)
+			return
+		}
+
+		m.output[m.oldest] = entry
+		m.oldest = (m.oldest + 1) % historyOutput
+	default:
+		m.messages = append(m.messages, entry)
+	}
+}
+
+// history returns the recorded messages in the order they were sent.
+func (m *stepMessages) history() []*idl.Message {
+	output := append(append([]sequencedMessage(nil), m.output[m.oldest:]...), m.output[:m.oldest]...)
+
+	history := make([]*idl.Message, 0, len(m.messages)+len(output))
+	i, j := 0, 0
+	for i < len(m.messages) || j < len(output) {
+		if j == len(output) || (i < len(m.messages) && m.messages[i].sequence < output[j].sequence) {
+			history = append(history, m.messages[i].msg)
+			i++
+		} else {
+			history = append(history, output[j].msg)
+			j++
+		}
+	}
+
+	return history
+}
+
+// attach returns the messages sent so far, limited to the most recent output,
+// along with a channel of the
+// messages to come which is closed when the step ends.
+func (m *stepMessages) attach() ([]*idl.Message, chan *idl.Message) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	viewer := make(chan *idl.Message, viewerBuffer)
+	if m.done {
+		close(viewer)
+	} else {
+		m.viewers[viewer] = true
+	}
+
+	return m.history(), viewer
+}
+
+func (m *stepMessages) detach(viewer chan *idl.Message) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.viewers[viewer] {
+		delete(m.viewers, viewer)
+		close(viewer)
+	}
+}
+
+// finish closes the viewers returning the error of the step to them. It waits
+// for the client to be sent the remaining messages such as the response of the
+// step since its stream ends once the step returns.
+func (m *stepMessages) finish(err error) {
+	m.mutex.Lock()
+	m.done = true
+	m.err = err
+	for viewer := range m.viewers {
+		delete(m.viewers, viewer)
+		close(viewer)
+	}
+
+	if m.client != nil {
+		close(m.client)
+		m.client = nil
+	}
+	m.mutex.Unlock()
+
+	<-m.clientDone
+}
+
+// result returns the error of the step once it has ended. Otherwise the
+// viewer was detached for falling too far behind.
+func (m *stepMessages) result() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if !m.done {
+		return ErrViewerBehind
+	}
+
+	return m.err
+}
+
+// Attach replays the messages of the running step and then follows it until
+// it ends returning its error. Any number of viewers can attach at once.
+func (s *Server) Attach(req *idl.AttachRequest, stream idl.CliToHub_AttachServer) error {
+	s.stepMutex.Lock()
+	running := s.running
+	s.stepMutex.Unlock()
+
+	if running == nil || running.messages == nil {
+		return ErrNoStepRunning
+	}
+
+	log.Printf("attaching viewer to %s", running.name)
+	replay, viewer := running.messages.attach()
+	defer running.messages.detach(viewer)
+
+	for _, msg := range replay {
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case msg, ok := <-viewer:
+			if !ok {
+				return running.messages.result()
+			}
+
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+
+		case <-stream.Context().Done():
+			log.Printf("detaching viewer from %s", running.name)
+			return nil
+		}
+	}
+}
+
+// sendResponse sends the response of the step through its messages such that
+// attached viewers receive it along with the CLI. The response of a dry run,
+// which cannot be attached to, is sent to the CLI directly.
+func (s *Server) sendResponse(st *step.Step, stream idl.MessageSender, msg *idl.Message) error {
+	s.stepMutex.Lock()
+	running := s.running
+	s.stepMutex.Unlock()
+
+	if running != nil && running.step == st && running.messages != nil {
+		return running.messages.Send(msg)
+	}
+
+	return stream.Send(msg)
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func chunk(s string) *idl.Message {
+	return &idl.Message{Contents: &idl.Message_Chunk{Chunk: &idl.Chunk{Buffer: []byte(s), Type: idl.Chunk_stdout}}}
+}
+
+func TestStepMessages(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("replays the messages so far and then follows the step", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sender := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		sender.EXPECT().Send(gomock.Any()).Times(2)
+
+		messages := newStepMessages(sender)
+		first, second := chunk("first"), chunk("second")
+
+		_ = messages.Send(first)
+		replay, viewer := messages.attach()
+		_ = messages.Send(second)
+
+		if !reflect.DeepEqual(replay, []*idl.Message{first}) {
+			t.Errorf("got replay %v want %v", replay, []*idl.Message{first})
+		}
+
+		if msg := <-viewer; msg != second {
+			t.Errorf("got message %v want %v", msg, second)
+		}
+
+		expected := errors.New("permission denied")
+		messages.finish(expected)
+
+		if _, ok := <-viewer; ok {
+			t.Errorf("expected viewer to be closed")
+		}
+
+		if err := messages.result(); !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
+	t.Run("continues after the sender goes away", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sender := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		sender.EXPECT().Send(gomock.Any()).Return(errors.New("transport is closing")).Times(1)
+
+		messages := newStepMessages(sender)
+		for _, msg := range []*idl.Message{chunk("first"), chunk("second")} {
+			if err := messages.Send(msg); err != nil {
+				t.Errorf("unexpected error %#v", err)
+			}
+		}
+
+		replay, _ := messages.attach()
+		if len(replay) != 2 {
+			t.Errorf("got %d messages want %d", len(replay), 2)
+		}
+
+		messages.finish(nil)
+	})
+
+	t.Run("does not stall the step when the sender stops reading without closing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		unblock := make(chan struct{})
+		sender := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		sender.EXPECT().Send(gomock.Any()).DoAndReturn(func(msg *idl.Message) error {
+			<-unblock
+			return nil
+		}).AnyTimes()
+
+		messages := newStepMessages(sender)
+		for i := 0; i <= viewerBuffer+1; i++ {
+			_ = messages.Send(chunk("output"))
+		}
+
+		// Attaching would hang if the lock were held while sending.
+		replay, _ := messages.attach()
+		if len(replay) != historyOutput {
+			t.Errorf("got %d messages want %d", len(replay), historyOutput)
+		}
+
+		close(unblock)
+		messages.finish(nil)
+	})
+
+	t.Run("detaches viewers which fall too far behind", func(t *testing.T) {
+		messages := newStepMessages(nil)
+		_, viewer := messages.attach()
+
+		for i := 0; i <= viewerBuffer; i++ {
+			_ = messages.Send(chunk("output"))
+		}
+
+		for range viewer {
+		}
+
+		if err := messages.result(); !errors.Is(err, ErrViewerBehind) {
+			t.Errorf("got error %#v want %#v", err, ErrViewerBehind)
+		}
+	})
+
+	t.Run("replays only the most recent output along with every status", func(t *testing.T) {
+		messages := newStepMessages(nil)
+
+		status := &idl.Message{Contents: &idl.Message_Status{Status: &idl.SubstepStatus{Step: idl.Substep_upgrade_primaries, Status: idl.Status_running}}}
+		_ = messages.Send(chunk("dropped"))
+		_ = messages.Send(status)
+		for i := 0; i < historyOutput; i++ {
+			_ = messages.Send(chunk(fmt.Sprintf("output %d", i)))
+		}
+
+		replay, _ := messages.attach()
+		if len(replay) != historyOutput+1 {
+			t.Fatalf("got %d messages want %d", len(replay), historyOutput+1)
+		}
+
+		if replay[0] != status {
+			t.Errorf("got first message %v want %v", replay[0], status)
+		}
+
+		for i, msg := range replay[1:] {
+			expected := fmt.Sprintf("output %d", i)
+			if string(msg.GetChunk().GetBuffer()) != expected {
+				t.Fatalf("got message %d %q want %q", i, msg.GetChunk().GetBuffer(), expected)
+			}
+		}
+
+		// Further output replaces the oldest.
+		_ = messages.Send(chunk("latest"))
+		replay, _ = messages.attach()
+		if len(replay) != historyOutput+1 || replay[0] != status {
+			t.Fatalf("got %d messages starting with %v", len(replay), replay[0])
+		}
+
+		if string(replay[1].GetChunk().GetBuffer()) != "output 1" || string(replay[historyOutput].GetChunk().GetBuffer()) != "latest" {
+			t.Errorf("got replay from %q to %q", replay[1].GetChunk().GetBuffer(), replay[historyOutput].GetChunk().GetBuffer())
+		}
+	})
+
+	t.Run("closes viewers attaching after the step ended", func(t *testing.T) {
+		messages := newStepMessages(nil)
+		_ = messages.Send(chunk("output"))
+		messages.finish(nil)
+
+		replay, viewer := messages.attach()
+		if len(replay) != 1 {
+			t.Errorf("got %d messages want %d", len(replay), 1)
+		}
+
+		if _, ok := <-viewer; ok {
+			t.Errorf("expected viewer to be closed")
+		}
+	})
+}
+
+func TestAttach(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	t.Run("errors when no step is running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := New(&config.Config{})
+		err := server.Attach(&idl.AttachRequest{}, mock_idl.NewMockCliToHub_AttachServer(ctrl))
+		if !errors.Is(err, ErrNoStepRunning) {
+			t.Errorf("got error %#v want %#v", err, ErrNoStepRunning)
+		}
+	})
+
+	t.Run("several viewers follow the running step until it ends", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sender := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		sender.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		server := New(&config.Config{})
+		st, err := server.beginStep(idl.Step_execute, sender, false, noPlan)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		messages := server.running.messages
+
+		expected := errors.New("permission denied")
+		st.Run(idl.Substep_check_active_connections_on_source_cluster, func(streams step.OutStreams) error {
+			return expected
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			viewer := mock_idl.NewMockCliToHub_AttachServer(ctrl)
+			viewer.EXPECT().Context().Return(context.Background()).AnyTimes()
+
+			var mutex sync.Mutex
+			var received []*idl.Message
+			attached := make(chan struct{})
+			viewer.EXPECT().Send(gomock.Any()).DoAndReturn(func(msg *idl.Message) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				if len(received) == 0 {
+					close(attached)
+				}
+
+				received = append(received, msg)
+				return nil
+			}).AnyTimes()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				err := server.Attach(&idl.AttachRequest{}, viewer)
+				if !errors.Is(err, expected) {
+					t.Errorf("got error %#v want %#v", err, expected)
+				}
+
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				replay, _ := messages.attach()
+				if !reflect.DeepEqual(received, replay) {
+					t.Errorf("got messages %v want %v", received, replay)
+				}
+			}()
+
+			<-attached
+		}
+
+		server.endStep(st)
+		wg.Wait()
+	})
+
+	t.Run("viewers receive the response of the step", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		response := &idl.Message{Contents: &idl.Message_Response{Response: &idl.Response{Contents: &idl.Response_RevertResponse{
+			RevertResponse: &idl.RevertResponse{LogArchiveDirectory: "/home/gpadmin/gpAdminLogs/gpupgrade-ABC123"},
+		}}}}
+
+		sender := mock_idl.NewMockCliToHub_RevertServer(ctrl)
+		sender.EXPECT().Send(response)
+		sender.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		server := New(&config.Config{})
+		st, err := server.beginStep(idl.Step_revert, sender, false, noPlan)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		_, viewer := server.running.messages.attach()
+
+		if err := server.sendResponse(st, sender, response); err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		server.endStep(st)
+
+		var received []*idl.Message
+		for msg := range viewer {
+			received = append(received, msg)
+		}
+
+		if len(received) == 0 || received[len(received)-1] != response {
+			t.Errorf("got messages %v want the last to be %v", received, response)
+		}
+	})
+}
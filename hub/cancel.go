@@ -0,0 +1,167 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils/trace"
+)
+
+var ErrNoStepRunning = errors.New("no gpupgrade step is running")
+
+var ErrStepRunning = errors.New("another gpupgrade step is running")
+
+// runningStep is the step being run which can be cancelled and attached to.
+type runningStep struct {
+	name     idl.Step
+	step     *step.Step
+	messages *stepMessages
+	ctx      context.Context
+	cancel   context.CancelFunc
+	span     *trace.Span
+}
+
+// Cancel cancels the running step. The running substep is interrupted
+// terminating its pg_upgrade and rsync processes on the hub and agents, and is
+// marked failed such that re-running the step resumes from it.
+func (s *Server) Cancel(ctx context.Context, req *idl.CancelRequest) (*idl.CancelReply, error) {
+	s.stepMutex.Lock()
+	defer s.stepMutex.Unlock()
+
+	if s.running == nil {
+		return &idl.CancelReply{}, ErrNoStepRunning
+	}
+
+	log.Printf("cancelling %s", s.running.name)
+	s.running.cancel()
+
+	return &idl.CancelReply{Step: s.running.name}, nil
+}
+
+// startStep returns the context of a step about to begin which is cancelled
+// by Cancel. Only one step can run at a time.
+func (s *Server) startStep(name idl.Step) (*runningStep, error) {
+	s.stepMutex.Lock()
+	defer s.stepMutex.Unlock()
+
+	if s.running != nil {
+		return nil, xerrors.Errorf("starting %s while %s is running: %w", name, s.running.name, ErrStepRunning)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx, span := trace.Start(ctx, name.String())
+	s.running = &runningStep{name: name, ctx: ctx, cancel: cancel, span: span}
+
+	return s.running, nil
+}
+
+// endStep is deferred by each step once begun such that it can no longer be
+// cancelled. It ignores dry runs which cannot be cancelled.
+func (s *Server) endStep(st *step.Step) {
+	s.stepMutex.Lock()
+	running := s.running
+	if running == nil || running.step != st {
+		s.stepMutex.Unlock()
+		return
+	}
+
+	s.running = nil
+	s.stepMutex.Unlock()
+
+	// The messages of a step that failed to begin are already finished. The
+	// messages are finished without holding the step mutex since it waits for
+	// the CLI to be sent the remaining messages.
+	var err error
+	if st != nil {
+		err = st.Err()
+		if running.messages != nil {
+			running.messages.finish(err)
+		}
+	}
+
+	running.span.End(err)
+	running.cancel()
+}
+
+// withStep returns a context that is also cancelled when the running step is
+// cancelled.
+func (s *Server) withStep(ctx context.Context) (context.Context, context.CancelFunc) {
+	s.stepMutex.Lock()
+	running := s.running
+	s.stepMutex.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	if running == nil {
+		return ctx, cancel
+	}
+
+	stop := context.AfterFunc(running.ctx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// cancellable returns whether requests for the method are cancelled with the
+// step. Heartbeats are not such that the agents are not reported unreachable,
+// nor are requests to stop the agents.
+func cancellable(method string) bool {
+	return method != idl.Agent_Heartbeat_FullMethodName && method != idl.Agent_StopAgent_FullMethodName
+}
+
+// stepUnaryClientInterceptor cancels requests to the agents when the running
+// step is cancelled. This terminates the processes the agents started for the
+// request, such as rsync, since the context of the agent's request handler is
+// cancelled.
+func (s *Server) stepUnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !cancellable(method) {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	ctx, cancel := s.withStep(ctx)
+	defer cancel()
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// stepStreamClientInterceptor is the streaming counterpart of
+// stepUnaryClientInterceptor.
+func (s *Server) stepStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if !cancellable(method) {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+
+	ctx, cancel := s.withStep(ctx)
+
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &cancelOnEndStream{ClientStream: stream, cancel: cancel}, nil
+}
+
+// cancelOnEndStream releases the context of the stream once it ends.
+type cancelOnEndStream struct {
+	grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+func (s *cancelOnEndStream) RecvMsg(msg interface{}) error {
+	err := s.ClientStream.RecvMsg(msg)
+	if err != nil {
+		s.cancel()
+	}
+
+	return err
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestCancel(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	// blockUntilCancelled simulates a long running request to an agent.
+	blockUntilCancelled := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-time.After(10 * time.Second):
+			return errors.New("expected the request to be cancelled")
+		}
+	}
+
+	t.Run("errors when no step is running", func(t *testing.T) {
+		server := New(&config.Config{})
+
+		_, err := server.Cancel(context.Background(), &idl.CancelRequest{})
+		if !errors.Is(err, ErrNoStepRunning) {
+			t.Errorf("got error %#v want %#v", err, ErrNoStepRunning)
+		}
+	})
+
+	t.Run("cancels the running step and its requests to the agents", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sender := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		sender.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		server := New(&config.Config{})
+		st, err := server.beginStep(idl.Step_execute, sender, false, noPlan)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		time.AfterFunc(100*time.Millisecond, func() {
+			reply, err := server.Cancel(context.Background(), &idl.CancelRequest{})
+			if err != nil {
+				t.Errorf("unexpected error %#v", err)
+			}
+
+			if reply.GetStep() != idl.Step_execute {
+				t.Errorf("got step %s want %s", reply.GetStep(), idl.Step_execute)
+			}
+		})
+
+		err = server.stepUnaryClientInterceptor(context.Background(), idl.Agent_RsyncDataDirectories_FullMethodName, nil, nil, nil, blockUntilCancelled)
+		if status.Code(err) != codes.Canceled {
+			t.Errorf("got error %#v want code %s", err, codes.Canceled)
+		}
+
+		if !errors.Is(st.Context().Err(), context.Canceled) {
+			t.Errorf("got error %#v want %#v", st.Context().Err(), context.Canceled)
+		}
+
+		server.endStep(st)
+
+		_, err = server.Cancel(context.Background(), &idl.CancelRequest{})
+		if !errors.Is(err, ErrNoStepRunning) {
+			t.Errorf("got error %#v want %#v once the step ended", err, ErrNoStepRunning)
+		}
+	})
+
+	t.Run("does not cancel heartbeats", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sender := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		sender.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		server := New(&config.Config{})
+		st, err := server.beginStep(idl.Step_execute, sender, false, noPlan)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+		defer server.endStep(st)
+
+		_, err = server.Cancel(context.Background(), &idl.CancelRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		err = server.stepUnaryClientInterceptor(context.Background(), idl.Agent_Heartbeat_FullMethodName, nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return ctx.Err()
+			})
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("a dry run cannot be cancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sender := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		sender.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		server := New(&config.Config{})
+		st, err := server.beginStep(idl.Step_execute, sender, true, noPlan)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+		defer server.endStep(st)
+
+		_, err = server.Cancel(context.Background(), &idl.CancelRequest{})
+		if !errors.Is(err, ErrNoStepRunning) {
+			t.Errorf("got error %#v want %#v", err, ErrNoStepRunning)
+		}
+	})
+}
+
+func TestStartStep(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	t.Run("errors when starting a step while another is running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sender := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		sender.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		server := New(&config.Config{})
+
+		var wg sync.WaitGroup
+		steps := make([]*step.Step, 2)
+		errs := make([]error, 2)
+		for i, name := range []idl.Step{idl.Step_execute, idl.Step_revert} {
+			i, name := i, name
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				steps[i], errs[i] = server.beginStep(name, sender, false, noPlan)
+			}()
+		}
+		wg.Wait()
+
+		started := 0
+		if errs[1] == nil {
+			started = 1
+		}
+		other := 1 - started
+
+		if errs[started] != nil {
+			t.Fatalf("unexpected error %#v", errs[started])
+		}
+
+		if !errors.Is(errs[other], ErrStepRunning) {
+			t.Errorf("got error %#v want %#v", errs[other], ErrStepRunning)
+		}
+
+		reply, err := server.Cancel(context.Background(), &idl.CancelRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if reply.GetStep() != server.running.name || server.running.step != steps[started] {
+			t.Errorf("cancelled %s want the started step", reply.GetStep())
+		}
+
+		server.endStep(steps[started])
+
+		st, err := server.beginStep(idl.Step_execute, sender, false, noPlan)
+		if err != nil {
+			t.Fatalf("unexpected error %#v once the running step ended", err)
+		}
+		server.endStep(st)
+	})
+}
@@ -17,7 +17,7 @@ import (
 
 var checkDiskUsage = disk.CheckUsage
 
-func CheckDiskSpace(streams step.OutStreams, agentConns []*idl.Connection, diskFreeRatio float64, source *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces) error {
+func CheckDiskSpace(ctx context.Context, streams step.OutStreams, agentConns []*idl.Connection, diskFreeRatio float64, source *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces) error {
 	var wg sync.WaitGroup
 	errs := make(chan error, len(agentConns)+1)
 	usagesChan := make(chan disk.FileSystemDiskUsage, len(agentConns)+1)
@@ -35,7 +35,7 @@ func CheckDiskSpace(streams step.OutStreams, agentConns []*idl.Connection, diskF
 		usagesChan <- usage
 	}()
 
-	checkDiskSpaceOnStandbyAndSegments(agentConns, errs, usagesChan, diskFreeRatio, source, sourceTablespaces)
+	checkDiskSpaceOnStandbyAndSegments(ctx, agentConns, errs, usagesChan, diskFreeRatio, source, sourceTablespaces)
 
 	wg.Wait()
 	close(errs)
@@ -66,7 +66,7 @@ func CheckDiskSpace(streams step.OutStreams, agentConns []*idl.Connection, diskF
 	return nil
 }
 
-func checkDiskSpaceOnStandbyAndSegments(agentConns []*idl.Connection, errs chan<- error, usages chan<- disk.FileSystemDiskUsage, diskFreeRatio float64, source *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces) {
+func checkDiskSpaceOnStandbyAndSegments(ctx context.Context, agentConns []*idl.Connection, errs chan<- error, usages chan<- disk.FileSystemDiskUsage, diskFreeRatio float64, source *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces) {
 	var wg sync.WaitGroup
 
 	for _, conn := range agentConns {
@@ -95,7 +95,7 @@ func checkDiskSpaceOnStandbyAndSegments(agentConns []*idl.Connection, errs chan<
 				Dirs:          dirs,
 			}
 
-			reply, err := conn.AgentClient.CheckDiskSpace(context.Background(), req)
+			reply, err := conn.AgentClient.CheckDiskSpace(ctx, req)
 			errs <- err
 			if reply != nil {
 				usages <- reply.GetUsages()
@@ -6,6 +6,7 @@ package hub_test
 import (
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
 	"strings"
 	"testing"
@@ -16,6 +17,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
 )
 
 func TestCheckEnvironmentOnSegments(t *testing.T) {
@@ -42,26 +44,18 @@ func TestCheckEnvironmentOnSegments(t *testing.T) {
 	intermediate.GPHome = "/usr/local/greenplum-db-target"
 
 	t.Run("checks environment on segments", func(t *testing.T) {
-		hub.SetPathCommand(exectest.NewCommand(hub.PathMain))
-		defer hub.ResetPathCommand()
+		executor := remote.SSH{Command: exectest.NewCommand(hub.EchoMain)}
 
-		hub.SetLdLibraryPathCommand(exectest.NewCommand(hub.LdLibraryPathMain))
-		defer hub.ResetLdLibraryPathCommand()
-
-		err := hub.CheckEnvironment(append(hub.AgentHosts(source), source.CoordinatorHostname()), source.GPHome, intermediate.GPHome)
+		err := hub.CheckEnvironment(executor, append(hub.AgentHosts(source), source.CoordinatorHostname()), source.GPHome, intermediate.GPHome)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
 	})
 
 	t.Run("returns error when failing to check PATH on segments", func(t *testing.T) {
-		hub.SetPathCommand(exectest.NewCommand(hub.Failure))
-		defer hub.ResetPathCommand()
-
-		hub.SetLdLibraryPathCommand(exectest.NewCommand(hub.LdLibraryPathMain))
-		defer hub.ResetLdLibraryPathCommand()
+		executor := failingExecutor{remote.SSH{Command: exectest.NewCommand(hub.EchoMain)}, "$PATH"}
 
-		err := hub.CheckEnvironment(append(hub.AgentHosts(source), source.CoordinatorHostname()), source.GPHome, intermediate.GPHome)
+		err := hub.CheckEnvironment(executor, append(hub.AgentHosts(source), source.CoordinatorHostname()), source.GPHome, intermediate.GPHome)
 		var expected utils.NextActionErr
 		if !errors.As(err, &expected) {
 			t.Fatalf("got type %T, want type %T", err, expected)
@@ -73,13 +67,9 @@ func TestCheckEnvironmentOnSegments(t *testing.T) {
 	})
 
 	t.Run("returns error when failing to check LD_LIBRARY_PATH on segments", func(t *testing.T) {
-		hub.SetPathCommand(exectest.NewCommand(hub.PathMain))
-		defer hub.ResetPathCommand()
-
-		hub.SetLdLibraryPathCommand(exectest.NewCommand(hub.Failure))
-		defer hub.ResetLdLibraryPathCommand()
+		executor := failingExecutor{remote.SSH{Command: exectest.NewCommand(hub.EchoMain)}, "$LD_LIBRARY_PATH"}
 
-		err := hub.CheckEnvironment(append(hub.AgentHosts(source), source.CoordinatorHostname()), source.GPHome, intermediate.GPHome)
+		err := hub.CheckEnvironment(executor, append(hub.AgentHosts(source), source.CoordinatorHostname()), source.GPHome, intermediate.GPHome)
 		var expected utils.NextActionErr
 		if !errors.As(err, &expected) {
 			t.Fatalf("got type %T, want type %T", err, expected)
@@ -118,11 +108,7 @@ func TestCheckEnvironmentOnSegment(t *testing.T) {
 			return host, nil
 		}
 
-		hub.SetPathCommand(exectest.NewCommand(hub.PathMain))
-		defer hub.ResetPathCommand()
-
-		hub.SetLdLibraryPathCommand(exectest.NewCommand(hub.LdLibraryPathMain))
-		defer hub.ResetLdLibraryPathCommand()
+		executor := remote.SSH{Command: exectest.NewCommand(hub.EchoMain)}
 
 		for _, c := range cases {
 			t.Run(c.name, func(t *testing.T) {
@@ -132,7 +118,7 @@ func TestCheckEnvironmentOnSegment(t *testing.T) {
 				resetLDLibraryPath := testutils.SetEnv(t, "LD_LIBRARY_PATH", c.ldLibraryPath)
 				defer resetLDLibraryPath()
 
-				err := hub.CheckEnvironmentOnSegment(host, sourceGphome, intermediateGphome)
+				err := hub.CheckEnvironmentOnSegment(executor, host, sourceGphome, intermediateGphome)
 				if err != nil {
 					t.Errorf("unexpected error %#v", err)
 				}
@@ -174,11 +160,7 @@ func TestCheckEnvironmentOnSegment(t *testing.T) {
 			return host, nil
 		}
 
-		hub.SetPathCommand(exectest.NewCommand(hub.PathMain))
-		defer hub.ResetPathCommand()
-
-		hub.SetLdLibraryPathCommand(exectest.NewCommand(hub.LdLibraryPathMain))
-		defer hub.ResetLdLibraryPathCommand()
+		executor := remote.SSH{Command: exectest.NewCommand(hub.EchoMain)}
 
 		for _, c := range errorCases {
 			t.Run(c.name, func(t *testing.T) {
@@ -188,7 +170,7 @@ func TestCheckEnvironmentOnSegment(t *testing.T) {
 				resetLDLibraryPath := testutils.SetEnv(t, "LD_LIBRARY_PATH", c.ldLibraryPath)
 				defer resetLDLibraryPath()
 
-				err := hub.CheckEnvironmentOnSegment(host, sourceGphome, intermediateGphome)
+				err := hub.CheckEnvironmentOnSegment(executor, host, sourceGphome, intermediateGphome)
 				if !strings.Contains(err.Error(), c.expected) {
 					t.Errorf("got %+v, want %+v", err, c.expected)
 				}
@@ -196,3 +178,17 @@ func TestCheckEnvironmentOnSegment(t *testing.T) {
 		}
 	})
 }
+
+// failingExecutor fails when echoing the given environment variable.
+type failingExecutor struct {
+	remote.Executor
+	variable string
+}
+
+func (f failingExecutor) Output(host string, name string, args ...string) ([]byte, error) {
+	if len(args) > 0 && args[0] == f.variable {
+		return nil, os.ErrPermission
+	}
+
+	return f.Executor.Output(host, name, args...)
+}
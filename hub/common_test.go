@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
@@ -24,8 +25,7 @@ func init() {
 	exectest.RegisterMains(
 		Success,
 		Failure,
-		PathMain,
-		LdLibraryPathMain,
+		EchoMain,
 		StreamingMain,
 		EnvironmentMain,
 	)
@@ -38,12 +38,13 @@ func Failure() {
 	os.Exit(1)
 }
 
-func PathMain() {
-	fmt.Println(os.Getenv("PATH"))
-}
-
-func LdLibraryPathMain() {
-	fmt.Println(os.Getenv("LD_LIBRARY_PATH"))
+// EchoMain expands the environment variables of the echo command run by the
+// ssh executor such as `bash -c "echo $PATH"`.
+func EchoMain() {
+	command := os.Args[len(os.Args)-1]
+	command = strings.TrimPrefix(command, `bash -c "echo `)
+	command = strings.TrimSuffix(command, `"`)
+	fmt.Println(os.ExpandEnv(command))
 }
 
 const StreamingMainStdout = "expected\nstdout\n"
@@ -84,6 +85,14 @@ func ResetCheckDiskUsage() {
 	checkDiskUsage = disk.CheckUsage
 }
 
+func SetCheckRequiredDiskSpace(requiredFunc disk.CheckRequiredType) {
+	checkRequiredDiskSpace = requiredFunc
+}
+
+func ResetCheckRequiredDiskSpace() {
+	checkRequiredDiskSpace = disk.CheckRequired
+}
+
 // MustCreateCluster creates a utils.Cluster and calls t.Fatalf() if there is
 // any error.
 func MustCreateCluster(t *testing.T, segments greenplum.SegConfigs) *greenplum.Cluster {
@@ -5,10 +5,10 @@ package hub
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"path/filepath"
-	"sync"
 
 	"github.com/blang/semver/v4"
 	"golang.org/x/xerrors"
@@ -19,6 +19,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 	"github.com/greenplum-db/gpupgrade/utils/rsync"
+	"github.com/greenplum-db/gpupgrade/utils/workerpool"
 )
 
 type Result struct {
@@ -27,40 +28,32 @@ type Result struct {
 	err    error
 }
 
-func Copy(streams step.OutStreams, sourceDirs []string, agentHostsToBackupDir backupdir.AgentHostsToBackupDir) error {
+func Copy(ctx context.Context, streams step.OutStreams, sourceDirs []string, agentHostsToBackupDir backupdir.AgentHostsToBackupDir, hostConcurrency uint, settings rsync.Settings, stats *rsync.Collector) error {
 	/*
 	 * Copy the directories once per host.
 	 */
-	var wg sync.WaitGroup
+	pool := workerpool.New(int(hostConcurrency))
 
 	results := make(chan *Result, len(agentHostsToBackupDir))
 
 	for hostname, backupDir := range agentHostsToBackupDir {
+		hostname, backupDir := hostname, backupDir
 
-		wg.Add(1)
-		go func(hostname string, backupDir string) {
-			defer wg.Done()
-
+		pool.Go(func() {
 			stream := &step.BufferedStreams{}
 
-			options := []rsync.Option{
-				rsync.WithSources(sourceDirs...),
-				rsync.WithDestinationHost(hostname),
-				rsync.WithDestination(backupDir),
-				rsync.WithOptions("--archive", "--compress", "--delete", "--stats"),
-				rsync.WithStream(stream),
-			}
+			options := append(copyOptions(sourceDirs, hostname, backupDir), rsync.WithStream(stream), rsync.WithStats(stats), rsync.WithContext(ctx))
 
-			err := rsync.Rsync(options...)
+			err := settings.Rsync(options...)
 			if err != nil {
 				err = xerrors.Errorf("copying source %q to destination %q on host %s: %w", sourceDirs, backupDir, hostname, err)
 			}
 			result := Result{stdout: stream.StdoutBuf, stderr: stream.StderrBuf, err: err}
 			results <- &result
-		}(hostname, backupDir)
+		})
 	}
 
-	wg.Wait()
+	pool.Wait()
 	close(results)
 
 	var errs error
@@ -82,7 +75,16 @@ func Copy(streams step.OutStreams, sourceDirs []string, agentHostsToBackupDir ba
 	return errs
 }
 
-func CopyCoordinatorDataDir(streams step.OutStreams, coordinatorDataDir string, agentHostsToBackupDir backupdir.AgentHostsToBackupDir) error {
+func copyOptions(sourceDirs []string, hostname string, backupDir string) []rsync.Option {
+	return []rsync.Option{
+		rsync.WithSources(sourceDirs...),
+		rsync.WithDestinationHost(hostname),
+		rsync.WithDestination(backupDir),
+		rsync.WithOptions("--archive", "--compress", "--delete", "--stats"),
+	}
+}
+
+func CopyCoordinatorDataDir(ctx context.Context, streams step.OutStreams, coordinatorDataDir string, agentHostsToBackupDir backupdir.AgentHostsToBackupDir, hostConcurrency uint, settings rsync.Settings, stats *rsync.Collector) error {
 	// Make sure sourceDir ends with a trailing slash so that rsync will
 	// transfer the directory contents and not the directory itself.
 	source := []string{filepath.Clean(coordinatorDataDir) + string(filepath.Separator)}
@@ -92,22 +94,15 @@ func CopyCoordinatorDataDir(streams step.OutStreams, coordinatorDataDir string,
 		destinationHostToBackupDir[host] = utils.GetCoordinatorPostUpgradeBackupDir(backupDir)
 	}
 
-	return Copy(streams, source, destinationHostToBackupDir)
+	return Copy(ctx, streams, source, destinationHostToBackupDir, hostConcurrency, settings, stats)
 }
 
-func CopyCoordinatorTablespaces(streams step.OutStreams, sourceVersion semver.Version, tablespaces greenplum.Tablespaces, agentHostsToBackupDir backupdir.AgentHostsToBackupDir) error {
-	if tablespaces == nil && sourceVersion.Major != 5 {
+func CopyCoordinatorTablespaces(ctx context.Context, streams step.OutStreams, sourceVersion semver.Version, tablespaces greenplum.Tablespaces, agentHostsToBackupDir backupdir.AgentHostsToBackupDir, hostConcurrency uint, settings rsync.Settings, stats *rsync.Collector) error {
+	sourcePaths := coordinatorTablespacesSources(sourceVersion, tablespaces)
+	if sourcePaths == nil {
 		return nil
 	}
 
-	var sourcePaths []string
-	if sourceVersion.Major == 5 {
-		// 5X always needs to include the --old-tablespaces-file
-		sourcePaths = append(sourcePaths, utils.GetStateDirOldTablespacesFile())
-	}
-
-	sourcePaths = append(sourcePaths, tablespaces.GetCoordinatorTablespaces().UserDefinedTablespacesLocations()...)
-
 	destinationHostToBackupDir := make(backupdir.AgentHostsToBackupDir)
 	for host, backupDir := range agentHostsToBackupDir {
 		// ensure the destination backup directory has a trailing slash so rsync
@@ -115,5 +110,21 @@ func CopyCoordinatorTablespaces(streams step.OutStreams, sourceVersion semver.Ve
 		destinationHostToBackupDir[host] = utils.GetTablespaceBackupDir(backupDir) + string(os.PathSeparator)
 	}
 
-	return Copy(streams, sourcePaths, destinationHostToBackupDir)
+	return Copy(ctx, streams, sourcePaths, destinationHostToBackupDir, hostConcurrency, settings, stats)
+}
+
+// coordinatorTablespacesSources returns the coordinator tablespace paths to
+// copy, or nil when there is nothing to copy.
+func coordinatorTablespacesSources(sourceVersion semver.Version, tablespaces greenplum.Tablespaces) []string {
+	if tablespaces == nil && sourceVersion.Major != 5 {
+		return nil
+	}
+
+	var sourcePaths []string
+	if sourceVersion.Major == 5 {
+		// 5X always needs to include the --old-tablespaces-file
+		sourcePaths = append(sourcePaths, utils.GetStateDirOldTablespacesFile())
+	}
+
+	return append(sourcePaths, tablespaces.GetCoordinatorTablespaces().UserDefinedTablespacesLocations()...)
 }
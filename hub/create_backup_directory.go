@@ -16,7 +16,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils"
 )
 
-func CreateBackupDirectories(streams step.OutStreams, agentConns []*idl.Connection, backupDirs backupdir.BackupDirs) error {
+func CreateBackupDirectories(ctx context.Context, streams step.OutStreams, agentConns []*idl.Connection, hostConcurrency uint, backupDirs backupdir.BackupDirs) error {
 	_, err := fmt.Fprintf(streams.Stdout(), "creating backup directory on all hosts\n")
 	if err != nil {
 		return err
@@ -27,17 +27,17 @@ func CreateBackupDirectories(streams step.OutStreams, agentConns []*idl.Connecti
 		return err
 	}
 
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		if _, ok := backupDirs.AgentHostsToBackupDir[conn.Hostname]; !ok {
 			return nil
 		}
 
 		req := &idl.CreateBackupDirectoryRequest{BackupDir: backupDirs.AgentHostsToBackupDir[conn.Hostname]}
-		_, err = conn.AgentClient.CreateBackupDirectory(context.Background(), req)
+		_, err = conn.AgentClient.CreateBackupDirectory(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, hostConcurrency, request)
 }
 
 func CreateBackupDirectory(backupDir string) error {
@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"os/user"
 	"testing"
@@ -72,7 +73,7 @@ func TestCreateRecoveryConfOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.CreateRecoveryConfOnSegments(agentConns, intermediate)
+		err := hub.CreateRecoveryConfOnSegments(context.Background(), agentConns, 0, intermediate)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -100,7 +101,7 @@ func TestCreateRecoveryConfOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.CreateRecoveryConfOnSegments(agentConns, intermediate)
+		err := hub.CreateRecoveryConfOnSegments(context.Background(), agentConns, 0, intermediate)
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Fatalf("error %#v does not contain type %T", err, errs)
@@ -126,7 +127,7 @@ func TestCreateRecoveryConfOnSegments(t *testing.T) {
 			utils.System.Current = user.Current
 		}()
 
-		err := hub.CreateRecoveryConfOnSegments(nil, intermediate)
+		err := hub.CreateRecoveryConfOnSegments(context.Background(), nil, 0, intermediate)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
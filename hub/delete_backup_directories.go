@@ -12,21 +12,21 @@ import (
 	"github.com/greenplum-db/gpupgrade/upgrade"
 )
 
-func DeleteBackupDirectories(streams step.OutStreams, agentConns []*idl.Connection, backupDirs backupdir.BackupDirs) error {
+func DeleteBackupDirectories(ctx context.Context, streams step.OutStreams, agentConns []*idl.Connection, hostConcurrency uint, backupDirs backupdir.BackupDirs) error {
 	err := upgrade.DeleteDirectories([]string{backupDirs.CoordinatorBackupDir}, []string{}, streams)
 	if err != nil {
 		return err
 	}
 
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		if _, ok := backupDirs.AgentHostsToBackupDir[conn.Hostname]; !ok {
 			return nil
 		}
 
 		req := &idl.DeleteBackupDirectoryRequest{BackupDir: backupDirs.AgentHostsToBackupDir[conn.Hostname]}
-		_, err := conn.AgentClient.DeleteBackupDirectory(context.Background(), req)
+		_, err := conn.AgentClient.DeleteBackupDirectory(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, hostConcurrency, request)
 }
@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"os"
 	"testing"
@@ -45,7 +46,7 @@ func TestDeleteBackupDirectories(t *testing.T) {
 		backupDirs := backupdir.BackupDirs{}
 		backupDirs.CoordinatorBackupDir = coordinatorBackupDir
 
-		err := hub.DeleteBackupDirectories(step.DevNullStream, nil, backupDirs)
+		err := hub.DeleteBackupDirectories(context.Background(), step.DevNullStream, nil, 0, backupDirs)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -60,7 +61,7 @@ func TestDeleteBackupDirectories(t *testing.T) {
 		}
 		defer utils.ResetSystemFunctions()
 
-		err := hub.DeleteBackupDirectories(step.DevNullStream, nil, backupdir.BackupDirs{})
+		err := hub.DeleteBackupDirectories(context.Background(), step.DevNullStream, nil, 0, backupdir.BackupDirs{})
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v want %#v", err, expected)
 		}
@@ -97,7 +98,7 @@ func TestDeleteBackupDirectories(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.DeleteBackupDirectories(step.DevNullStream, agentConns, backupDirs)
+		err := hub.DeleteBackupDirectories(context.Background(), step.DevNullStream, agentConns, 0, backupDirs)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -140,7 +141,7 @@ func TestDeleteBackupDirectories(t *testing.T) {
 			{AgentClient: sdw3, Hostname: "sdw3"},
 		}
 
-		err := hub.DeleteBackupDirectories(step.DevNullStream, agentConns, backupDirs)
+		err := hub.DeleteBackupDirectories(context.Background(), step.DevNullStream, agentConns, 0, backupDirs)
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Fatalf("error %#v does not contain type %T", err, errs)
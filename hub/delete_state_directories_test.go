@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -45,7 +46,7 @@ func TestDeleteStateDirectories(t *testing.T) {
 				{AgentClient: coordinatorHostClient, Hostname: excludeHostname},
 			}
 
-			err := hub.DeleteStateDirectories(agentConns, excludeHostname)
+			err := hub.DeleteStateDirectories(context.Background(), agentConns, 0, excludeHostname)
 			if err != nil {
 				t.Errorf("unexpected err %#v", err)
 			}
@@ -73,7 +74,7 @@ func TestDeleteStateDirectories(t *testing.T) {
 				{AgentClient: sdw2ClientFailed, Hostname: "sdw2"},
 			}
 
-			err := hub.DeleteStateDirectories(agentConns, "")
+			err := hub.DeleteStateDirectories(context.Background(), agentConns, 0, "")
 
 			if !errors.Is(err, expected) {
 				t.Errorf("got error %#v, want %#v", err, expected)
@@ -0,0 +1,290 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/config/backupdir"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/snapshot"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+var checkRequiredDiskSpace = disk.CheckRequired
+
+// SegmentSize is the size in bytes of a primary segment or the coordinator.
+type SegmentSize struct {
+	// Data is the size of the default and global tablespaces within the data
+	// directory.
+	Data uint64
+
+	// Catalog estimates the size of the system catalogs as the size of
+	// template1 times the number of databases.
+	Catalog uint64
+
+	// Tablespaces is the size of each user defined tablespace keyed by oid.
+	Tablespaces map[int]uint64
+}
+
+// SegmentSizes is the size of each primary segment keyed by content ID.
+type SegmentSizes map[int]*SegmentSize
+
+func (s SegmentSizes) segment(contentID int) *SegmentSize {
+	if _, ok := s[contentID]; !ok {
+		s[contentID] = &SegmentSize{Tablespaces: make(map[int]uint64)}
+	}
+
+	return s[contentID]
+}
+
+// EstimateDiskSpace checks each filesystem has enough space for the upgrade
+// based on the actual size of the source cluster rather than a ratio. See
+// RequiredDiskSpace for what is estimated.
+func EstimateDiskSpace(ctx context.Context, streams step.OutStreams, agentConns []*idl.Connection, hostConcurrency uint, mode idl.Mode, source *greenplum.Cluster, backupDirs backupdir.BackupDirs) (err error) {
+	db, err := sql.Open("pgx", source.Connection())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	sizes, err := QuerySegmentSizes(db)
+	if err != nil {
+		return err
+	}
+
+	tablespaces, err := snapshot.TablespaceLocations(db, source.Version)
+	if err != nil {
+		return xerrors.Errorf("query tablespace locations: %w", err)
+	}
+
+	required := RequiredDiskSpace(mode, source, tablespaces, backupDirs, sizes)
+	return CheckRequiredDiskSpace(ctx, streams, agentConns, hostConcurrency, source.CoordinatorHostname(), required)
+}
+
+// QuerySegmentSizes queries the size of the tablespaces and system catalogs on
+// the coordinator and each primary segment.
+func QuerySegmentSizes(db *sql.DB) (SegmentSizes, error) {
+	sizes := make(SegmentSizes)
+
+	rows, err := db.Query(`
+	SELECT -1, oid, spcname IN ('pg_default', 'pg_global'), pg_tablespace_size(oid) FROM pg_tablespace
+	UNION ALL
+	SELECT gp_segment_id, oid, spcname IN ('pg_default', 'pg_global'), pg_tablespace_size(oid) FROM gp_dist_random('pg_tablespace')`)
+	if err != nil {
+		return nil, xerrors.Errorf("query tablespace sizes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var contentID, oid int
+		var builtin bool
+		var size uint64
+		if err := rows.Scan(&contentID, &oid, &builtin, &size); err != nil {
+			return nil, xerrors.Errorf("scanning tablespace sizes: %w", err)
+		}
+
+		segment := sizes.segment(contentID)
+		if builtin {
+			segment.Data += size
+			continue
+		}
+
+		segment.Tablespaces[oid] = size
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating tablespace sizes: %w", err)
+	}
+
+	var databases uint64
+	err = db.QueryRow("SELECT count(*) FROM pg_database").Scan(&databases)
+	if err != nil {
+		return nil, xerrors.Errorf("query number of databases: %w", err)
+	}
+
+	templateRows, err := db.Query(`
+	SELECT -1, pg_database_size('template1')
+	UNION ALL
+	SELECT gp_segment_id, pg_database_size('template1') FROM gp_dist_random('gp_id')`)
+	if err != nil {
+		return nil, xerrors.Errorf("query catalog sizes: %w", err)
+	}
+	defer templateRows.Close()
+
+	for templateRows.Next() {
+		var contentID int
+		var size uint64
+		if err := templateRows.Scan(&contentID, &size); err != nil {
+			return nil, xerrors.Errorf("scanning catalog sizes: %w", err)
+		}
+
+		sizes.segment(contentID).Catalog = size * databases
+	}
+
+	if err := templateRows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating catalog sizes: %w", err)
+	}
+
+	return sizes, nil
+}
+
+// RequiredDiskSpace returns the kilobytes required in each directory keyed by
+// host and directory.
+//   - Primaries and the coordinator need the size of their data and user
+//     defined tablespaces in copy mode, and the size of the catalog in link
+//     mode since the data files are hard linked.
+//   - Mirrors and the standby need the size of their primary since they are
+//     recreated from the upgraded primaries in both modes.
+//   - The coordinator backup directory needs the size of the catalog for the
+//     backup of the target coordinator.
+//   - Each backup directory needs the size of the coordinator for the copy of
+//     the upgraded coordinator, and on GPDB 5 the size of the coordinator
+//     tablespaces.
+func RequiredDiskSpace(mode idl.Mode, source *greenplum.Cluster, tablespaces []snapshot.Tablespace, backupDirs backupdir.BackupDirs, sizes SegmentSizes) map[string]map[string]uint64 {
+	required := make(map[string]map[string]uint64)
+	add := func(host, dir string, bytes uint64) {
+		if bytes == 0 {
+			return
+		}
+
+		if _, ok := required[host]; !ok {
+			required[host] = make(map[string]uint64)
+		}
+
+		required[host][dir] += kilobytes(bytes)
+	}
+
+	locations := make(map[int]map[int]string)
+	for _, tablespace := range tablespaces {
+		if !tablespace.UserDefined {
+			continue
+		}
+
+		if _, ok := locations[tablespace.DbID]; !ok {
+			locations[tablespace.DbID] = make(map[int]string)
+		}
+
+		locations[tablespace.DbID][tablespace.Oid] = tablespace.Location
+	}
+
+	segments := source.SelectSegments(func(*greenplum.SegConfig) bool { return true })
+	for _, seg := range segments {
+		size, ok := sizes[seg.ContentID]
+		if !ok {
+			continue
+		}
+
+		// Copy mode and the mirrors and standby copy the data while link mode
+		// only writes the catalog of the upgraded primaries and coordinator.
+		copyData := mode == idl.Mode_copy || seg.Role == greenplum.MirrorRole
+		if copyData {
+			add(seg.Hostname, seg.DataDir, size.Data)
+		} else {
+			add(seg.Hostname, seg.DataDir, size.Catalog)
+		}
+
+		if !copyData {
+			continue
+		}
+
+		// GPDB 6 and later only return the tablespace locations of the
+		// primaries which mirrors share.
+		segLocations, ok := locations[seg.DbID]
+		if !ok {
+			primary := source.Primaries[seg.ContentID]
+			segLocations = locations[primary.DbID]
+		}
+
+		for oid, location := range segLocations {
+			add(seg.Hostname, location, size.Tablespaces[oid])
+		}
+	}
+
+	coordinator, ok := sizes[-1]
+	if !ok {
+		return required
+	}
+
+	add(source.CoordinatorHostname(), backupDirs.CoordinatorBackupDir, coordinator.Catalog)
+
+	for host, backupDir := range backupDirs.AgentHostsToBackupDir {
+		add(host, backupDir, coordinator.Data)
+
+		if source.Version.Major != 5 {
+			continue
+		}
+
+		for oid := range locations[source.Coordinator().DbID] {
+			add(host, backupDir, coordinator.Tablespaces[oid])
+		}
+	}
+
+	return required
+}
+
+// kilobytes rounds up the bytes to kilobytes which is the unit used to
+// report disk usage.
+func kilobytes(bytes uint64) uint64 {
+	return (bytes + 1023) / 1024
+}
+
+// CheckRequiredDiskSpace checks the required space of the directories on the
+// coordinator host locally and the remaining hosts through their agents.
+func CheckRequiredDiskSpace(ctx context.Context, streams step.OutStreams, agentConns []*idl.Connection, hostConcurrency uint, coordinatorHost string, required map[string]map[string]uint64) error {
+	var mutex sync.Mutex
+	totalUsage := make(map[disk.FilesystemHost]*idl.CheckDiskSpaceReply_DiskUsage)
+	addUsage := func(usages disk.FileSystemDiskUsage) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		for _, usage := range usages {
+			totalUsage[disk.FilesystemHost{Filesystem: usage.GetFs(), Host: usage.GetHost()}] = usage
+		}
+	}
+
+	var err error
+	if dirs, ok := required[coordinatorHost]; ok {
+		var usages disk.FileSystemDiskUsage
+		usages, err = checkRequiredDiskSpace(streams, disk.Local, dirs)
+		addUsage(usages)
+	}
+
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		dirs, ok := required[conn.Hostname]
+		if !ok || conn.Hostname == coordinatorHost {
+			return nil
+		}
+
+		reply, err := conn.AgentClient.CheckDiskSpace(ctx, &idl.CheckSegmentDiskSpaceRequest{Required: dirs})
+		if err != nil {
+			return err
+		}
+
+		addUsage(reply.GetUsages())
+		return nil
+	}
+
+	err = errorlist.Append(err, ExecuteRPC(ctx, agentConns, hostConcurrency, request))
+	if err != nil {
+		return err
+	}
+
+	if len(totalUsage) > 0 {
+		return disk.NewSpaceUsageError(totalUsage)
+	}
+
+	return nil
+}
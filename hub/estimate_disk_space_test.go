@@ -0,0 +1,251 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/config/backupdir"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/snapshot"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+func TestQuerySegmentSizes(t *testing.T) {
+	t.Run("sums the default and global tablespaces and estimates the catalog", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery("pg_tablespace_size").
+			WillReturnRows(sqlmock.NewRows([]string{"content", "oid", "builtin", "size"}).
+				AddRow(-1, 1663, true, 1000).
+				AddRow(-1, 1664, true, 24).
+				AddRow(-1, 16384, false, 10).
+				AddRow(0, 1663, true, 5000).
+				AddRow(0, 16384, false, 2048))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM pg_database`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery(`pg_database_size\('template1'\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "size"}).
+				AddRow(-1, 100).
+				AddRow(0, 200))
+
+		sizes, err := hub.QuerySegmentSizes(db)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := hub.SegmentSizes{
+			-1: {Data: 1024, Catalog: 300, Tablespaces: map[int]uint64{16384: 10}},
+			0:  {Data: 5000, Catalog: 600, Tablespaces: map[int]uint64{16384: 2048}},
+		}
+
+		if !reflect.DeepEqual(sizes, expected) {
+			t.Errorf("got %+v want %+v", sizes, expected)
+		}
+	})
+
+	t.Run("errors when querying fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		expected := errors.New("permission denied")
+		mock.ExpectQuery("pg_tablespace_size").WillReturnError(expected)
+
+		_, err = hub.QuerySegmentSizes(db)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestRequiredDiskSpace(t *testing.T) {
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "scdw", DataDir: "/data/standby", Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/primary/seg0", Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/mirror/seg0", Role: greenplum.MirrorRole},
+	})
+	source.Version = semver.MustParse("6.20.0")
+
+	tablespaces := []snapshot.Tablespace{
+		{DbID: 1, Oid: 1663, Name: "pg_default", UserDefined: false},
+		{DbID: 1, Oid: 16384, Name: "fast", Location: "/fast", UserDefined: true},
+		{DbID: 3, Oid: 16384, Name: "fast", Location: "/fast", UserDefined: true},
+	}
+
+	backupDirs := backupdir.BackupDirs{
+		CoordinatorBackupDir:  "/data/backup",
+		AgentHostsToBackupDir: backupdir.AgentHostsToBackupDir{"sdw1": "/data/backup", "sdw2": "/data/backup"},
+	}
+
+	sizes := hub.SegmentSizes{
+		-1: {Data: 10 * 1024, Catalog: 2 * 1024, Tablespaces: map[int]uint64{16384: 1024}},
+		0:  {Data: 100 * 1024, Catalog: 4 * 1024, Tablespaces: map[int]uint64{16384: 50 * 1024}},
+	}
+
+	t.Run("copy mode requires the size of the data and tablespaces", func(t *testing.T) {
+		required := hub.RequiredDiskSpace(idl.Mode_copy, source, tablespaces, backupDirs, sizes)
+
+		expected := map[string]map[string]uint64{
+			"cdw":  {"/data/qddir/seg-1": 10, "/fast": 1, "/data/backup": 2},
+			"scdw": {"/data/standby": 10, "/fast": 1},
+			"sdw1": {"/data/primary/seg0": 100, "/fast": 50, "/data/backup": 10},
+			"sdw2": {"/data/mirror/seg0": 100, "/fast": 50, "/data/backup": 10},
+		}
+
+		if !reflect.DeepEqual(required, expected) {
+			t.Errorf("got %v want %v", required, expected)
+		}
+	})
+
+	t.Run("link mode only requires the catalog of the primaries", func(t *testing.T) {
+		required := hub.RequiredDiskSpace(idl.Mode_link, source, tablespaces, backupDirs, sizes)
+
+		expected := map[string]map[string]uint64{
+			"cdw":  {"/data/qddir/seg-1": 2, "/data/backup": 2},
+			"scdw": {"/data/standby": 10, "/fast": 1},
+			"sdw1": {"/data/primary/seg0": 4, "/data/backup": 10},
+			"sdw2": {"/data/mirror/seg0": 100, "/fast": 50, "/data/backup": 10},
+		}
+
+		if !reflect.DeepEqual(required, expected) {
+			t.Errorf("got %v want %v", required, expected)
+		}
+	})
+
+	t.Run("backup directories require the coordinator tablespaces on GPDB 5", func(t *testing.T) {
+		source5 := *source
+		source5.Version = semver.MustParse("5.29.0")
+
+		required := hub.RequiredDiskSpace(idl.Mode_link, &source5, tablespaces, backupDirs, sizes)
+		if required["sdw1"]["/data/backup"] != 11 {
+			t.Errorf("got %d want %d", required["sdw1"]["/data/backup"], 11)
+		}
+	})
+
+	t.Run("rounds up to kilobytes", func(t *testing.T) {
+		sizes := hub.SegmentSizes{-1: {Data: 1, Catalog: 1025}}
+
+		required := hub.RequiredDiskSpace(idl.Mode_copy, source, nil, backupdir.BackupDirs{CoordinatorBackupDir: "/data/backup"}, sizes)
+
+		expected := map[string]map[string]uint64{
+			"cdw":  {"/data/qddir/seg-1": 1, "/data/backup": 2},
+			"scdw": {"/data/standby": 1},
+		}
+
+		if !reflect.DeepEqual(required, expected) {
+			t.Errorf("got %v want %v", required, expected)
+		}
+	})
+}
+
+func TestCheckRequiredDiskSpace(t *testing.T) {
+	required := map[string]map[string]uint64{
+		"cdw":  {"/data/qddir/seg-1": 10},
+		"sdw1": {"/data/primary/seg0": 100},
+	}
+
+	t.Run("checks the coordinator host locally and the remaining hosts through the agents", func(t *testing.T) {
+		var local map[string]uint64
+		hub.SetCheckRequiredDiskSpace(func(streams step.OutStreams, d disk.Disk, required map[string]uint64) (disk.FileSystemDiskUsage, error) {
+			local = required
+			return nil, nil
+		})
+		defer hub.ResetCheckRequiredDiskSpace()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckDiskSpace(
+			gomock.Any(),
+			gomock.Eq(&idl.CheckSegmentDiskSpaceRequest{Required: map[string]uint64{"/data/primary/seg0": 100}}),
+		).Return(&idl.CheckDiskSpaceReply{}, nil)
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().CheckDiskSpace(gomock.Any(), gomock.Any()).Times(0)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hub.CheckRequiredDiskSpace(context.Background(), step.DevNullStream, agentConns, 0, "cdw", required)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		if !reflect.DeepEqual(local, required["cdw"]) {
+			t.Errorf("got %v want %v", local, required["cdw"])
+		}
+	})
+
+	t.Run("returns the shortfalls of all hosts", func(t *testing.T) {
+		cdwUsage := &idl.CheckDiskSpaceReply_DiskUsage{Fs: "/", Host: "cdw", Available: 5, Required: 10}
+		hub.SetCheckRequiredDiskSpace(func(streams step.OutStreams, d disk.Disk, required map[string]uint64) (disk.FileSystemDiskUsage, error) {
+			return disk.FileSystemDiskUsage{cdwUsage}, nil
+		})
+		defer hub.ResetCheckRequiredDiskSpace()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1Usage := &idl.CheckDiskSpaceReply_DiskUsage{Fs: "/data", Host: "sdw1", Available: 50, Required: 100}
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckDiskSpace(gomock.Any(), gomock.Any()).
+			Return(&idl.CheckDiskSpaceReply{Usages: disk.FileSystemDiskUsage{sdw1Usage}}, nil)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		err := hub.CheckRequiredDiskSpace(context.Background(), step.DevNullStream, agentConns, 0, "cdw", required)
+		var usageErr *disk.SpaceUsageErr
+		if !errors.As(err, &usageErr) {
+			t.Fatalf("got error %#v want %T", err, usageErr)
+		}
+
+		if len(usageErr.Table()) != 3 {
+			t.Errorf("got %d rows want a header and 2 shortfalls: %v", len(usageErr.Table()), usageErr.Table())
+		}
+	})
+
+	t.Run("errors when checking an agent fails", func(t *testing.T) {
+		hub.SetCheckRequiredDiskSpace(func(streams step.OutStreams, d disk.Disk, required map[string]uint64) (disk.FileSystemDiskUsage, error) {
+			return nil, nil
+		})
+		defer hub.ResetCheckRequiredDiskSpace()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckDiskSpace(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		err := hub.CheckRequiredDiskSpace(context.Background(), step.DevNullStream, agentConns, 0, "cdw", required)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
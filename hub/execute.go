@@ -11,16 +11,29 @@ import (
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
 )
 
 func (s *Server) Execute(req *idl.ExecuteRequest, stream idl.CliToHub_ExecuteServer) (err error) {
-	st, err := step.Begin(idl.Step_execute, stream)
+	pgUpgradeTimestamp := utils.System.Now().Format(TimeStringFormat)
+
+	st, err := s.beginStep(idl.Step_execute, stream, req.GetDryRun(), func() (step.Plan, error) {
+		return s.ExecutePlan(req, pgUpgradeTimestamp)
+	})
 	if err != nil {
 		return err
 	}
 
+	defer s.endStep(st)
+
+	st.PauseBefore(s.pauseBefore(req.GetPauseBefore()))
+
+	if req.GetRecover() {
+		st.Recover(s.Recoveries())
+	}
+
 	st.AlwaysRun(idl.Substep_ensure_gpupgrade_agents_are_running, func(_ step.OutStreams) error {
-		_, err := RestartAgents(context.Background(), nil, AgentHosts(s.Source), s.AgentPort, utils.GetStateDir())
+		_, err := RestartAgents(context.Background(), nil, s.executor(), AgentHosts(s.Source), s.AgentPort, s.AgentListenOnHostname, s.TLS, s.LogFormat, utils.GetStateDir())
 		if err != nil {
 			return err
 		}
@@ -53,9 +66,8 @@ func (s *Server) Execute(req *idl.ExecuteRequest, stream idl.CliToHub_ExecuteSer
 		return s.Source.Stop(streams)
 	})
 
-	pgUpgradeTimestamp := utils.System.Now().Format(TimeStringFormat)
 	st.Run(idl.Substep_upgrade_master, func(streams step.OutStreams) error {
-		return UpgradeCoordinator(streams, s.BackupDirs.CoordinatorBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_upgrade, s.Mode, pgUpgradeTimestamp)
+		return UpgradeCoordinator(st.Context(), streams, s.BackupDirs.CoordinatorBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_upgrade, s.Mode, pgUpgradeTimestamp)
 	})
 
 	st.Run(idl.Substep_copy_master, func(streams step.OutStreams) error {
@@ -64,7 +76,7 @@ func (s *Server) Execute(req *idl.ExecuteRequest, stream idl.CliToHub_ExecuteSer
 		// to set the backup directory where it is used without needing to
 		// revert and re-run initialize and execute.
 		if req.GetParentBackupDirs() != "" {
-			err = DeleteBackupDirectories(streams, s.agentConns, s.BackupDirs)
+			err = DeleteBackupDirectories(st.Context(), streams, s.agentConns, s.Concurrency.Hosts, s.BackupDirs)
 			if err != nil {
 				return err
 			}
@@ -79,7 +91,7 @@ func (s *Server) Execute(req *idl.ExecuteRequest, stream idl.CliToHub_ExecuteSer
 				return fmt.Errorf("save backup directories: %w", err)
 			}
 
-			err = CreateBackupDirectories(streams, s.agentConns, s.BackupDirs)
+			err = CreateBackupDirectories(st.Context(), streams, s.agentConns, s.Concurrency.Hosts, s.BackupDirs)
 			if err != nil {
 				return err
 			}
@@ -100,12 +112,20 @@ single directory such as /dir. To specify different directories for each host
 use the form "host1:/dir1,host2:/dir2,host3:/dir3" where the first host must be 
 the master.`
 
-		err := CopyCoordinatorDataDir(streams, s.Intermediate.CoordinatorDataDir(), s.BackupDirs.AgentHostsToBackupDir)
+		stats := &rsync.Collector{}
+		defer recordRsyncStats(idl.Step_execute, idl.Substep_copy_master, stats)
+
+		settings, err := s.rsyncSettings(s.Rsync.Execute)
+		if err != nil {
+			return err
+		}
+
+		err = CopyCoordinatorDataDir(st.Context(), streams, s.Intermediate.CoordinatorDataDir(), s.BackupDirs.AgentHostsToBackupDir, s.Concurrency.Hosts, settings, stats)
 		if err != nil {
 			return utils.NewNextActionErr(err, nextAction)
 		}
 
-		err = CopyCoordinatorTablespaces(streams, s.Source.Version, s.Source.Tablespaces, s.BackupDirs.AgentHostsToBackupDir)
+		err = CopyCoordinatorTablespaces(st.Context(), streams, s.Source.Version, s.Source.Tablespaces, s.BackupDirs.AgentHostsToBackupDir, s.Concurrency.Hosts, settings, stats)
 		if err != nil {
 			return utils.NewNextActionErr(err, nextAction)
 		}
@@ -114,7 +134,14 @@ the master.`
 	})
 
 	st.Run(idl.Substep_upgrade_primaries, func(streams step.OutStreams) error {
-		return UpgradePrimaries(s.agentConns, s.BackupDirs.AgentHostsToBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_upgrade, s.Mode, pgUpgradeTimestamp)
+		segments, err := step.NewSegmentFileStore()
+		if err != nil {
+			return err
+		}
+
+		return s.monitorAgents(func() error {
+			return UpgradePrimaries(st.Context(), streams, s.agentConns, s.Concurrency.Hosts, segments, s.BackupDirs.AgentHostsToBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Concurrency.PgUpgrade, s.Source, s.Intermediate, idl.PgOptions_upgrade, s.Mode, pgUpgradeTimestamp)
+		})
 	})
 
 	st.AlwaysRun(idl.Substep_start_target_cluster, func(streams step.OutStreams) error {
@@ -132,7 +159,7 @@ the master.`
 		},
 	}}}}
 
-	if err = stream.Send(message); err != nil {
+	if err = s.sendResponse(st, stream, message); err != nil {
 		return err
 	}
 
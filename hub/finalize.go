@@ -8,19 +8,29 @@ import (
 	"time"
 
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/snapshot"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
 )
 
 func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_FinalizeServer) (err error) {
-	st, err := step.Begin(idl.Step_finalize, stream)
+	st, err := s.beginStep(idl.Step_finalize, stream, req.GetDryRun(), s.FinalizePlan)
 	if err != nil {
 		return err
 	}
 
+	defer s.endStep(st)
+
+	st.PauseBefore(s.pauseBefore(req.GetPauseBefore()))
+
+	if req.GetRecover() {
+		st.Recover(s.Recoveries())
+	}
+
 	st.AlwaysRun(idl.Substep_ensure_gpupgrade_agents_are_running, func(_ step.OutStreams) error {
-		_, err := RestartAgents(context.Background(), nil, AgentHosts(s.Source), s.AgentPort, utils.GetStateDir())
+		_, err := RestartAgents(context.Background(), nil, s.executor(), AgentHosts(s.Source), s.AgentPort, s.AgentListenOnHostname, s.TLS, s.LogFormat, utils.GetStateDir())
 		if err != nil {
 			return err
 		}
@@ -38,15 +48,29 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 	})
 
 	st.RunConditionally(idl.Substep_upgrade_mirrors, s.Source.HasMirrors() && s.Mode == idl.Mode_link, func(streams step.OutStreams) error {
-		return UpgradeMirrorsUsingRsync(s.agentConns, s.Source, s.Intermediate, s.UseHbaHostnames)
-	})
+		stats := &rsync.Collector{}
+		defer recordRsyncStats(idl.Step_finalize, idl.Substep_upgrade_mirrors, stats)
+
+		settings, err := s.rsyncSettings(s.Rsync.Finalize)
+		if err != nil {
+			return err
+		}
 
-	st.RunConditionally(idl.Substep_upgrade_mirrors, s.Source.HasMirrors() && s.Mode != idl.Mode_link, func(streams step.OutStreams) error {
-		return UpgradeMirrorsUsingGpAddMirrors(streams, s.Intermediate, s.UseHbaHostnames)
+		return s.monitorAgents(func() error {
+			return UpgradeMirrorsUsingRsync(st.Context(), s.agentConns, s.Concurrency.Hosts, s.Source, s.Intermediate, s.UseHbaHostnames, s.Concurrency.Rsync, settings, stats)
+		})
 	})
 
-	st.RunConditionally(idl.Substep_upgrade_standby, s.Source.HasStandby(), func(streams step.OutStreams) error {
-		return UpgradeStandby(streams, s.Intermediate, s.UseHbaHostnames)
+	// Upgrading the mirrors using rsync stops the cluster, so the standby is
+	// only upgraded concurrently when the mirrors are added by gpaddmirrors.
+	st.RunConcurrently(func(group *step.Group) {
+		group.RunConditionally(idl.Substep_upgrade_mirrors, s.Source.HasMirrors() && s.Mode != idl.Mode_link, func(_ context.Context, streams step.OutStreams) error {
+			return UpgradeMirrorsUsingGpAddMirrors(streams, s.Intermediate, s.UseHbaHostnames)
+		})
+
+		group.RunConditionally(idl.Substep_upgrade_standby, s.Source.HasStandby(), func(_ context.Context, streams step.OutStreams) error {
+			return UpgradeStandby(streams, s.Intermediate, s.UseHbaHostnames)
+		})
 	})
 
 	st.Run(idl.Substep_wait_for_cluster_to_be_ready_after_adding_mirrors_and_standby, func(streams step.OutStreams) error {
@@ -70,11 +94,11 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 	})
 
 	st.Run(idl.Substep_update_data_directories, func(_ step.OutStreams) error {
-		return RenameDataDirectories(s.agentConns, s.Source, s.Intermediate)
+		return RenameDataDirectories(st.Context(), s.agentConns, s.Concurrency.Hosts, s.Source, s.Intermediate)
 	})
 
 	st.Run(idl.Substep_update_target_conf_files, func(streams step.OutStreams) error {
-		return UpdateConfFiles(s.agentConns, streams,
+		return UpdateConfFiles(st.Context(), s.agentConns, s.Concurrency.Hosts, streams,
 			s.Target.Version,
 			s.Intermediate,
 			s.Target,
@@ -89,6 +113,15 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 		return s.Target.WaitForClusterToBeReady()
 	})
 
+	st.RunConditionally(idl.Substep_validate_target_cluster, req.GetValidate(), func(streams step.OutStreams) error {
+		logDir, err := utils.GetLogDir()
+		if err != nil {
+			return err
+		}
+
+		return ValidateTargetCluster(streams, s.Target, logDir)
+	})
+
 	var logArchiveDir string
 	st.AlwaysRun(idl.Substep_archive_log_directories, func(_ step.OutStreams) error {
 		logDir, err := utils.GetLogDir()
@@ -96,18 +129,27 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 			return err
 		}
 
+		if err := snapshot.ArchiveSource(logDir); err != nil {
+			return err
+		}
+
 		logArchiveDir = GetLogArchiveDir(logDir, s.UpgradeID, time.Now())
-		return ArchiveLogDirectories(logDir, logArchiveDir, s.agentConns, s.Config.Target.CoordinatorHostname())
+		return ArchiveLogDirectories(st.Context(), logDir, logArchiveDir, s.agentConns, s.Concurrency.Hosts, s.Config.Target.CoordinatorHostname())
 	})
 
 	st.Run(idl.Substep_delete_backupdir, func(streams step.OutStreams) error {
-		return DeleteBackupDirectories(streams, s.agentConns, s.BackupDirs)
+		return DeleteBackupDirectories(st.Context(), streams, s.agentConns, s.Concurrency.Hosts, s.BackupDirs)
 	})
 
 	st.AlwaysRun(idl.Substep_delete_segment_statedirs, func(_ step.OutStreams) error {
-		return DeleteStateDirectories(s.agentConns, s.Source.CoordinatorHostname())
+		return DeleteStateDirectories(st.Context(), s.agentConns, s.Concurrency.Hosts, s.Source.CoordinatorHostname())
 	})
 
+	upgradeReport, err := s.WriteUpgradeReport(idl.Step_finalize, logArchiveDir, req.GetHtmlReport())
+	if err != nil {
+		return err
+	}
+
 	encodedTarget, err := s.Target.Encode()
 	if err != nil {
 		return err
@@ -119,10 +161,11 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 			LogArchiveDirectory:                    logArchiveDir,
 			ArchivedSourceCoordinatorDataDirectory: s.Config.Intermediate.CoordinatorDataDir() + upgrade.OldSuffix,
 			UpgradeID:                              s.Config.UpgradeID,
+			Report:                                 upgradeReport,
 		},
 	}}}}
 
-	if err = stream.Send(message); err != nil {
+	if err = s.sendResponse(st, stream, message); err != nil {
 		return err
 	}
 
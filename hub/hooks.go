@@ -0,0 +1,42 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+)
+
+// Hooks returns the hooks of the configured hooks directory along with the
+// upgrade and clusters they are run for during the step.
+func Hooks(conf *config.Config, currentStep idl.Step) step.Hooks {
+	hooks := step.Hooks{Dir: conf.HooksDir}
+	hooks.Env.UpgradeID = conf.UpgradeID
+
+	if conf.Source != nil {
+		hooks.Env.SourcePort = conf.Source.CoordinatorPort()
+		hooks.Env.SourceGPHome = conf.Source.GPHome
+	}
+
+	if target := runningTarget(conf, currentStep); target != nil {
+		hooks.Env.TargetPort = target.CoordinatorPort()
+		hooks.Env.TargetGPHome = target.GPHome
+	}
+
+	return hooks
+}
+
+// runningTarget returns the target cluster as it runs during the step. Until
+// finalize the target cluster is the intermediate cluster on the temporary
+// ports, whereas the configured target cluster is a copy of the source
+// cluster that takes its place once finalized.
+func runningTarget(conf *config.Config, currentStep idl.Step) *greenplum.Cluster {
+	if currentStep == idl.Step_finalize || conf.Intermediate == nil {
+		return conf.Target
+	}
+
+	return conf.Intermediate
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+)
+
+func TestHooks(t *testing.T) {
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+	})
+	source.GPHome = "/usr/local/source"
+
+	target := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: "/data/qddir/seg-1", Port: 6000, Role: greenplum.PrimaryRole},
+	})
+	target.GPHome = "/usr/local/target"
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: "/data/qddir/seg.ABC123.-1", Port: 50432, Role: greenplum.PrimaryRole},
+	})
+	intermediate.GPHome = "/usr/local/target"
+
+	conf := &config.Config{HooksDir: "/home/gpadmin/hooks", UpgradeID: "ABC123", Source: source, Intermediate: intermediate, Target: target}
+
+	t.Run("describes the running target cluster at each step", func(t *testing.T) {
+		cases := []struct {
+			step       idl.Step
+			targetPort int
+		}{
+			{step: idl.Step_initialize, targetPort: 50432},
+			{step: idl.Step_execute, targetPort: 50432},
+			{step: idl.Step_revert, targetPort: 50432},
+			{step: idl.Step_finalize, targetPort: 6000},
+		}
+
+		for _, c := range cases {
+			hooks := hub.Hooks(conf, c.step)
+
+			expected := step.Hooks{Dir: "/home/gpadmin/hooks", Env: step.HookEnv{
+				UpgradeID:    "ABC123",
+				SourcePort:   15432,
+				SourceGPHome: "/usr/local/source",
+				TargetPort:   c.targetPort,
+				TargetGPHome: "/usr/local/target",
+			}}
+			if !reflect.DeepEqual(hooks, expected) {
+				t.Errorf("got %+v want %+v during %s", hooks, expected, c.step)
+			}
+		}
+	})
+
+	t.Run("leaves the target empty before it is created", func(t *testing.T) {
+		hooks := hub.Hooks(&config.Config{HooksDir: "/home/gpadmin/hooks", Source: source}, idl.Step_initialize)
+
+		expected := step.Hooks{Dir: "/home/gpadmin/hooks", Env: step.HookEnv{
+			SourcePort:   15432,
+			SourceGPHome: "/usr/local/source",
+		}}
+		if !reflect.DeepEqual(hooks, expected) {
+			t.Errorf("got %+v want %+v", hooks, expected)
+		}
+	})
+}
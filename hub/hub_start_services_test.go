@@ -25,6 +25,8 @@ import (
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
 )
 
 func gpupgrade_agent() {
@@ -60,15 +62,14 @@ func TestRestartAgent(t *testing.T) {
 	stateDir := "/not/existent/directory"
 	ctx := context.Background()
 
-	hub.SetExecCommand(exectest.NewCommand(gpupgrade_agent))
-	defer hub.ResetExecCommand()
+	executor := remote.SSH{Command: exectest.NewCommand(gpupgrade_agent)}
 
 	t.Run("does not start running agents", func(t *testing.T) {
 		dialer := func(ctx context.Context, address string) (net.Conn, error) {
 			return listener.Dial()
 		}
 
-		restartedHosts, err := hub.RestartAgents(ctx, dialer, hostnames, port, stateDir)
+		restartedHosts, err := hub.RestartAgents(ctx, dialer, executor, hostnames, port, false, mtls.Config{}, "", stateDir)
 		if err != nil {
 			t.Errorf("returned %#v", err)
 		}
@@ -88,7 +89,7 @@ func TestRestartAgent(t *testing.T) {
 			return listener.Dial()
 		}
 
-		restartedHosts, err := hub.RestartAgents(ctx, dialer, hostnames, port, stateDir)
+		restartedHosts, err := hub.RestartAgents(ctx, dialer, executor, hostnames, port, false, mtls.Config{}, "", stateDir)
 		if err != nil {
 			t.Errorf("returned %#v", err)
 		}
@@ -103,7 +104,7 @@ func TestRestartAgent(t *testing.T) {
 	})
 
 	t.Run("returns an error when gpupgrade agent fails", func(t *testing.T) {
-		hub.SetExecCommand(exectest.NewCommand(gpupgrade_agent_Errors))
+		executor := remote.SSH{Command: exectest.NewCommand(gpupgrade_agent_Errors)}
 
 		// we fail all connections here so that RestartAgents will run the
 		//  (error producing) gpupgrade_agent_Errors
@@ -111,7 +112,7 @@ func TestRestartAgent(t *testing.T) {
 			return nil, immediateFailure{}
 		}
 
-		restartedHosts, err := hub.RestartAgents(ctx, dialer, hostnames, port, stateDir)
+		restartedHosts, err := hub.RestartAgents(ctx, dialer, executor, hostnames, port, false, mtls.Config{}, "", stateDir)
 		if err == nil {
 			t.Errorf("expected restart agents to fail")
 		}
@@ -146,13 +147,12 @@ func TestRestartAgent(t *testing.T) {
 			}
 
 			cmd := fmt.Sprintf("bash -c \"%s/gpupgrade agent --daemonize --port %d --state-directory %s\"", testutils.MustGetExecutablePath(t), port, stateDir)
-			expected := []string{host, cmd}
+			expected := []string{"-q", host, cmd}
 			if !reflect.DeepEqual(args, expected) {
 				t.Errorf("got %q want %q", args, expected)
 			}
 		})
-		hub.SetExecCommand(execCmd)
-		defer hub.ResetExecCommand()
+		executor := remote.SSH{Command: execCmd}
 
 		dialer := func(ctx context.Context, address string) (net.Conn, error) {
 			if strings.HasPrefix(address, host) { // fail connection attempts to host
@@ -162,11 +162,51 @@ func TestRestartAgent(t *testing.T) {
 			return listener.Dial()
 		}
 
-		_, err := hub.RestartAgents(ctx, dialer, hostnames, port, stateDir)
+		_, err := hub.RestartAgents(ctx, dialer, executor, hostnames, port, false, mtls.Config{}, "", stateDir)
 		if err != nil {
 			t.Errorf("unexpected errr %#v", err)
 		}
 	})
+
+	t.Run("starts agents listening on their hostname with tls", func(t *testing.T) {
+		host := "host1"
+
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		tlsConfig := testutils.MustCreateCertificates(t, dir)
+
+		execCmd := exectest.NewCommandWithVerifier(gpupgrade_agent, func(name string, args ...string) {
+			cmd := fmt.Sprintf("bash -c \"%s/gpupgrade agent --daemonize --port %d --state-directory %s --listen-address %s --tls-cert-file %s --tls-key-file %s --tls-ca-file %s\"",
+				testutils.MustGetExecutablePath(t), port, stateDir, host, tlsConfig.CertFile, tlsConfig.KeyFile, tlsConfig.CAFile)
+			expected := []string{"-q", host, cmd}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got %q want %q", args, expected)
+			}
+		})
+		executor := remote.SSH{Command: execCmd}
+
+		// fail all connection attempts since the test agent server does not
+		// serve with tls
+		dialer := func(ctx context.Context, address string) (net.Conn, error) {
+			return nil, immediateFailure{}
+		}
+
+		_, err := hub.RestartAgents(ctx, dialer, executor, []string{host}, port, true, tlsConfig, "", stateDir)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("errors when the tls configuration cannot be loaded", func(t *testing.T) {
+		tlsConfig := mtls.Config{CertFile: "/does/not/exist", KeyFile: "/does/not/exist", CAFile: "/does/not/exist"}
+
+		_, err := hub.RestartAgents(ctx, nil, executor, hostnames, port, false, tlsConfig, "", stateDir)
+		var pathErr *os.PathError
+		if !errors.As(err, &pathErr) {
+			t.Errorf("got error %#v want type %T", err, pathErr)
+		}
+	})
 }
 
 // immediateFailure is an error that is explicitly marked non-temporary for
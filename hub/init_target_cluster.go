@@ -5,6 +5,7 @@ package hub
 
 import (
 	"bufio"
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
@@ -56,7 +57,7 @@ func (s *Server) writeConf(db *sql.DB) error {
 	return WriteInitsystemFile(gpinitsystemConfig, utils.GetInitsystemConfig())
 }
 
-func (s *Server) RemoveIntermediateCluster(streams step.OutStreams) error {
+func (s *Server) RemoveIntermediateCluster(ctx context.Context, streams step.OutStreams) error {
 	if reflect.DeepEqual(s.Intermediate, greenplum.Cluster{}) {
 		return nil
 	}
@@ -69,7 +70,7 @@ func (s *Server) RemoveIntermediateCluster(streams step.OutStreams) error {
 		return err
 	}
 
-	err := DeleteCoordinatorAndPrimaryDataDirectories(streams, s.agentConns, s.Intermediate)
+	err := DeleteCoordinatorAndPrimaryDataDirectories(ctx, streams, s.agentConns, s.Concurrency.Hosts, s.Intermediate)
 	if err != nil {
 		return xerrors.Errorf("deleting target cluster data directories: %w", err)
 	}
@@ -91,6 +92,10 @@ func InitTargetCluster(stream step.OutStreams, intermediate *greenplum.Cluster)
 		"LANG",
 	})
 
+	return intermediate.RunGreenplumCmdWithEnvironment(stream, "gpinitsystem", initTargetClusterArgs(intermediate), env)
+}
+
+func initTargetClusterArgs(intermediate *greenplum.Cluster) []string {
 	args := []string{"-a", "-I", utils.GetInitsystemConfig()}
 	if intermediate.Version.Major >= 5 && intermediate.Version.Major < 7 {
 		// For 6X we add --ignore-warnings to gpinitsystem to return 0 on
@@ -98,7 +103,7 @@ func InitTargetCluster(stream step.OutStreams, intermediate *greenplum.Cluster)
 		args = append(args, "--ignore-warnings")
 	}
 
-	return intermediate.RunGreenplumCmdWithEnvironment(stream, "gpinitsystem", args, env)
+	return args
 }
 
 func GetCheckpointSegmentsAndEncoding(gpinitsystemConfig []string, version semver.Version, db *sql.DB) ([]string, error) {
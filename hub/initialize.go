@@ -14,18 +14,25 @@ import (
 )
 
 func (s *Server) Initialize(req *idl.InitializeRequest, stream idl.CliToHub_InitializeServer) (err error) {
-	st, err := step.Begin(idl.Step_initialize, stream)
+	st, err := s.beginStep(idl.Step_initialize, stream, req.GetDryRun(), noPlan)
 	if err != nil {
 		return err
 	}
 
-	// Since the agents might not be up if gpupgrade is not properly installed, check it early on using ssh.
+	defer s.endStep(st)
+
+	if req.GetRecover() {
+		st.Recover(s.Recoveries())
+	}
+
+	// Since the agents might not be up if gpupgrade is not properly installed,
+	// check it early on using the remote executor which defaults to ssh.
 	st.Run(idl.Substep_verify_gpupgrade_is_installed_across_all_hosts, func(streams step.OutStreams) error {
-		return upgrade.EnsureGpupgradeVersionsMatch(AgentHosts(s.Source))
+		return upgrade.EnsureGpupgradeVersionsMatch(s.executor(), AgentHosts(s.Source))
 	})
 
 	st.AlwaysRun(idl.Substep_start_agents, func(_ step.OutStreams) error {
-		_, err := RestartAgents(context.Background(), nil, AgentHosts(s.Source), s.AgentPort, utils.GetStateDir())
+		_, err := RestartAgents(context.Background(), nil, s.executor(), AgentHosts(s.Source), s.AgentPort, s.AgentListenOnHostname, s.TLS, s.LogFormat, utils.GetStateDir())
 		if err != nil {
 			return err
 		}
@@ -39,11 +46,11 @@ func (s *Server) Initialize(req *idl.InitializeRequest, stream idl.CliToHub_Init
 	})
 
 	st.AlwaysRun(idl.Substep_check_environment, func(streams step.OutStreams) error {
-		return CheckEnvironment(append(AgentHosts(s.Source), s.Source.CoordinatorHostname()), s.Source.GPHome, s.Intermediate.GPHome)
+		return CheckEnvironment(s.executor(), append(AgentHosts(s.Source), s.Source.CoordinatorHostname()), s.Source.GPHome, s.Intermediate.GPHome)
 	})
 
 	st.Run(idl.Substep_create_backupdirs, func(streams step.OutStreams) error {
-		err = CreateBackupDirectories(streams, s.agentConns, s.BackupDirs)
+		err = CreateBackupDirectories(st.Context(), streams, s.agentConns, s.Concurrency.Hosts, s.BackupDirs)
 		if err != nil {
 			nextAction := `1. Run "gpupgrade revert"
 
@@ -70,25 +77,41 @@ the master.
 		return nil
 	})
 
-	st.RunConditionally(idl.Substep_check_disk_space, req.GetDiskFreeRatio() > 0, func(streams step.OutStreams) error {
-		return CheckDiskSpace(streams, s.agentConns, req.GetDiskFreeRatio(), s.Source, s.Source.Tablespaces)
+	st.RunConditionally(idl.Substep_check_disk_space, req.GetDiskFreeRatio() > 0 || req.GetEstimateDiskSpace(), func(streams step.OutStreams) error {
+		if req.GetEstimateDiskSpace() {
+			return EstimateDiskSpace(st.Context(), streams, s.agentConns, s.Concurrency.Hosts, s.Mode, s.Source, s.BackupDirs)
+		}
+
+		return CheckDiskSpace(st.Context(), streams, s.agentConns, req.GetDiskFreeRatio(), s.Source, s.Source.Tablespaces)
+	})
+
+	st.Run(idl.Substep_snapshot_source_cluster, func(_ step.OutStreams) error {
+		return SnapshotSourceCluster(s.Source, s.ValidationRowCountSample)
 	})
 
 	return st.Err()
 }
 
 func (s *Server) InitializeCreateCluster(req *idl.InitializeCreateClusterRequest, stream idl.CliToHub_InitializeCreateClusterServer) (err error) {
-	st, err := step.Begin(idl.Step_initialize, stream)
+	st, err := s.beginStep(idl.Step_initialize, stream, req.GetDryRun(), func() (step.Plan, error) {
+		return s.InitializeCreateClusterPlan(req)
+	})
 	if err != nil {
 		return err
 	}
 
+	defer s.endStep(st)
+
+	if req.GetRecover() {
+		st.Recover(s.Recoveries())
+	}
+
 	st.Run(idl.Substep_generate_target_config, func(_ step.OutStreams) error {
 		return s.GenerateInitsystemConfig(s.Source)
 	})
 
 	st.Run(idl.Substep_init_target_cluster, func(stream step.OutStreams) error {
-		err := s.RemoveIntermediateCluster(stream)
+		err := s.RemoveIntermediateCluster(st.Context(), stream)
 		if err != nil {
 			return err
 		}
@@ -122,7 +145,7 @@ func (s *Server) InitializeCreateCluster(req *idl.InitializeCreateClusterRequest
 		sourceDir := s.Intermediate.CoordinatorDataDir()
 		targetDir := utils.GetCoordinatorPreUpgradeBackupDir(s.BackupDirs.CoordinatorBackupDir)
 
-		return RsyncCoordinatorDataDir(stream, sourceDir, targetDir)
+		return RsyncCoordinatorDataDir(st.Context(), stream, sourceDir, targetDir)
 	})
 
 	st.AlwaysRun(idl.Substep_initialize_wait_for_cluster_to_be_ready, func(streams step.OutStreams) error {
@@ -137,11 +160,13 @@ func (s *Server) InitializeCreateCluster(req *idl.InitializeCreateClusterRequest
 
 		pgUpgradeTimestamp := utils.System.Now().Format(TimeStringFormat)
 
-		if err := UpgradeCoordinator(stream, s.BackupDirs.CoordinatorBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_check, s.Mode, pgUpgradeTimestamp); err != nil {
+		if err := UpgradeCoordinator(st.Context(), stream, s.BackupDirs.CoordinatorBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_check, s.Mode, pgUpgradeTimestamp); err != nil {
 			return err
 		}
 
-		return UpgradePrimaries(s.agentConns, s.BackupDirs.AgentHostsToBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_check, s.Mode, pgUpgradeTimestamp)
+		return s.monitorAgents(func() error {
+			return UpgradePrimaries(st.Context(), stream, s.agentConns, s.Concurrency.Hosts, nil, s.BackupDirs.AgentHostsToBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Concurrency.PgUpgrade, s.Source, s.Intermediate, idl.PgOptions_check, s.Mode, pgUpgradeTimestamp)
+		})
 	})
 
 	message := &idl.Message{Contents: &idl.Message_Response{Response: &idl.Response{Contents: &idl.Response_InitializeResponse{
@@ -150,7 +175,7 @@ func (s *Server) InitializeCreateCluster(req *idl.InitializeCreateClusterRequest
 		},
 	}}}}
 
-	if err = stream.Send(message); err != nil {
+	if err = s.sendResponse(st, stream, message); err != nil {
 		return err
 	}
 
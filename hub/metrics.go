@@ -0,0 +1,243 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+const MetricsPath = "/metrics"
+
+// startMetrics serves the metrics in the Prometheus text format on the
+// metrics port when set. The metrics are read from the state directory when
+// scraped such that they include the CLI substeps and survive hub restarts.
+// When mutual TLS is configured the metrics are served over HTTPS requiring
+// the same client certificates as the hub's gRPC server.
+func (s *Server) startMetrics() (*http.Server, error) {
+	if s.MetricsPort == 0 {
+		return nil, nil
+	}
+
+	tlsConfig, err := s.TLS.ServerTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("metrics TLS: %w", err)
+	}
+
+	address := net.JoinHostPort(s.HubListenAddress, strconv.Itoa(s.MetricsPort))
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("listen on metrics port %d: %w", s.MetricsPort, err)
+	}
+
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(MetricsPath, s.serveMetrics)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("serve metrics: %v", err)
+		}
+	}()
+
+	log.Printf("serving metrics on %s%s", address, MetricsPath)
+	return server, nil
+}
+
+func (s *Server) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	err := s.WriteMetrics(&buf, time.Now())
+	if err != nil {
+		log.Printf("write metrics: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Printf("send metrics: %v", err)
+	}
+}
+
+// WriteMetrics writes the current step and substep, the status and duration
+// of each substep, the connectivity of the agents, the bytes transferred by
+// rsync, and the segments upgraded by pg_upgrade.
+func (s *Server) WriteMetrics(w io.Writer, now time.Time) error {
+	m := &metricsWriter{w: w}
+
+	s.stepMutex.Lock()
+	var running idl.Step
+	if s.running != nil {
+		running = s.running.name
+	}
+	s.stepMutex.Unlock()
+
+	m.metric("gpupgrade_current_step", "The step being run by the hub.")
+	if running != idl.Step_unknown_step {
+		m.sample(1, "step", running.String())
+	}
+
+	// The state files do not exist until initialize creates them.
+	substeps, err := step.NewSubstepStoreUsingFile(filepath.Join(utils.GetStateDir(), step.SubstepsFileName)).ReadAll()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	m.metric("gpupgrade_current_substep", "The substeps being run.")
+	for stepName, entries := range substeps {
+		for substepName, entry := range entries {
+			if entry.Status.Status == idl.Status_running {
+				m.sample(1, "step", stepName, "substep", substepName)
+			}
+		}
+	}
+
+	m.metric("gpupgrade_substep_status", "The status of each substep.")
+	for stepName, entries := range substeps {
+		for substepName, entry := range entries {
+			m.sample(1, "step", stepName, "substep", substepName, "status", entry.Status.Status.String())
+		}
+	}
+
+	m.metric("gpupgrade_substep_duration_seconds", "The duration of each substep, or how long it has been running.")
+	for stepName, entries := range substeps {
+		for substepName, entry := range entries {
+			duration := entry.Duration.Duration
+			if entry.Status.Status == idl.Status_running && entry.StartTime != nil {
+				duration = now.Sub(*entry.StartTime)
+			}
+
+			m.sample(duration.Seconds(), "step", stepName, "substep", substepName)
+		}
+	}
+
+	agents := AgentsGrpcStatus{}
+	for _, conn := range s.agentConnsSnapshot() {
+		agents[conn.Hostname] = conn.Conn.GetState()
+	}
+
+	m.metric("gpupgrade_agent_ready", "Whether the hub is connected to the agent on each host along with the gRPC connectivity state.")
+	for host, state := range agents {
+		ready := 0.0
+		if state == connectivity.Ready {
+			ready = 1
+		}
+
+		m.sample(ready, "host", host, "state", strings.ToLower(state.String()))
+	}
+
+	stats, err := rsync.NewStatsStoreUsingFile(filepath.Join(utils.GetStateDir(), rsync.StatsFileName)).ReadAll()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	m.metric("gpupgrade_rsync_transferred_bytes", "The bytes sent and received by rsync for each substep that transfers data.")
+	for stepName, substepStats := range stats {
+		for substepName, stat := range substepStats {
+			m.sample(float64(stat.BytesSent), "step", stepName, "substep", substepName, "direction", "sent")
+			m.sample(float64(stat.BytesReceived), "step", stepName, "substep", substepName, "direction", "received")
+		}
+	}
+
+	segments, err := step.NewSegmentStoreUsingFile(filepath.Join(utils.GetStateDir(), step.SegmentsFileName)).ReadAll()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	m.metric("gpupgrade_pg_upgrade_segments", "The number of segments upgraded by pg_upgrade with each status.")
+	for stepName, substepSegments := range segments {
+		for substepName, entries := range substepSegments {
+			counts := make(map[string]int)
+			for _, entry := range entries {
+				counts[entry.Status.Status.String()]++
+			}
+
+			for status, count := range counts {
+				m.sample(float64(count), "step", stepName, "substep", substepName, "status", status)
+			}
+		}
+	}
+
+	m.flush()
+	return m.err
+}
+
+// metricsWriter writes gauges in the Prometheus text format. The samples of
+// each metric are sorted such that the output is stable.
+type metricsWriter struct {
+	w       io.Writer
+	name    string
+	samples []string
+	err     error
+}
+
+// metric flushes the samples of the previous metric and starts the next.
+func (m *metricsWriter) metric(name string, help string) {
+	m.flush()
+
+	m.name = name
+	m.printf("# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+// sample adds a value of the current metric with the labels given as name and
+// value pairs.
+func (m *metricsWriter) sample(value float64, labels ...string) {
+	var pairs []string
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, labels[i]+"="+quoteLabel(labels[i+1]))
+	}
+
+	name := m.name
+	if len(pairs) > 0 {
+		name += "{" + strings.Join(pairs, ",") + "}"
+	}
+
+	m.samples = append(m.samples, name+" "+strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func (m *metricsWriter) flush() {
+	sort.Strings(m.samples)
+	for _, sample := range m.samples {
+		m.printf("%s\n", sample)
+	}
+
+	m.samples = nil
+}
+
+func (m *metricsWriter) printf(format string, args ...interface{}) {
+	if m.err != nil {
+		return
+	}
+
+	_, m.err = fmt.Fprintf(m.w, format, args...)
+}
+
+// quoteLabel quotes a label value escaping backslashes, quotes, and newlines.
+func quoteLabel(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	t.Run("writes no samples before initialize creates the state files", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := hub.New(&config.Config{}).WriteMetrics(&buf, time.Now())
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if !strings.HasPrefix(line, "#") {
+				t.Errorf("unexpected sample %q", line)
+			}
+		}
+	})
+
+	t.Run("writes the substeps, rsync stats, and pg_upgrade segments", func(t *testing.T) {
+		testutils.MustWriteToFile(t, filepath.Join(stateDir, step.SubstepsFileName), `{
+  "execute": {
+    "upgrade_master": {"Status": "complete", "Duration": "1m30s"},
+    "upgrade_primaries": {"Status": "running", "StartTime": "2023-01-01T10:00:00Z", "Duration": "0s"}
+  }
+}`)
+		testutils.MustWriteToFile(t, filepath.Join(stateDir, rsync.StatsFileName), `{
+  "execute": {"copy_coordinator": {"BytesSent": 2048, "BytesReceived": 64}}
+}`)
+		testutils.MustWriteToFile(t, filepath.Join(stateDir, step.SegmentsFileName), `{
+  "execute": {
+    "upgrade_primaries": {
+      "0": {"Host": "sdw1", "Status": "complete"},
+      "1": {"Host": "sdw1", "Status": "complete"},
+      "2": {"Host": "sdw2", "Status": "failed"}
+    }
+  }
+}`)
+
+		var buf bytes.Buffer
+		now := time.Date(2023, 1, 1, 10, 5, 0, 0, time.UTC)
+		err := hub.New(&config.Config{}).WriteMetrics(&buf, now)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := []string{
+			`gpupgrade_current_substep{step="execute",substep="upgrade_primaries"} 1`,
+			`gpupgrade_substep_status{step="execute",substep="upgrade_master",status="complete"} 1`,
+			`gpupgrade_substep_status{step="execute",substep="upgrade_primaries",status="running"} 1`,
+			`gpupgrade_substep_duration_seconds{step="execute",substep="upgrade_master"} 90`,
+			`gpupgrade_substep_duration_seconds{step="execute",substep="upgrade_primaries"} 300`,
+			`gpupgrade_rsync_transferred_bytes{step="execute",substep="copy_coordinator",direction="sent"} 2048`,
+			`gpupgrade_rsync_transferred_bytes{step="execute",substep="copy_coordinator",direction="received"} 64`,
+			`gpupgrade_pg_upgrade_segments{step="execute",substep="upgrade_primaries",status="complete"} 2`,
+			`gpupgrade_pg_upgrade_segments{step="execute",substep="upgrade_primaries",status="failed"} 1`,
+		}
+
+		for _, sample := range expected {
+			if !strings.Contains(buf.String(), sample+"\n") {
+				t.Errorf("expected sample %q in metrics:\n%s", sample, buf.String())
+			}
+		}
+
+		if strings.Contains(buf.String(), "gpupgrade_current_step{") {
+			t.Errorf("expected no current step when the hub is not running a step:\n%s", buf.String())
+		}
+	})
+
+	t.Run("errors when a state file cannot be parsed", func(t *testing.T) {
+		testutils.MustWriteToFile(t, filepath.Join(stateDir, step.SubstepsFileName), "{")
+
+		var buf bytes.Buffer
+		err := hub.New(&config.Config{}).WriteMetrics(&buf, time.Now())
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	t.Run("serves the metrics over HTTP", func(t *testing.T) {
+		metricsPort := testutils.MustGetPort(t)
+		mustStartHub(t, &config.Config{HubListenAddress: "localhost", MetricsPort: metricsPort})
+
+		expectMetrics(t, http.DefaultClient, fmt.Sprintf("http://localhost:%d%s", metricsPort, hub.MetricsPath))
+	})
+
+	t.Run("serves the metrics over HTTPS requiring a client certificate when TLS is configured", func(t *testing.T) {
+		conf := testutils.MustCreateCertificates(t, stateDir)
+
+		metricsPort := testutils.MustGetPort(t)
+		mustStartHub(t, &config.Config{HubListenAddress: "localhost", MetricsPort: metricsPort, TLS: conf})
+
+		tlsConfig, err := conf.ClientTLSConfig()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+		expectMetrics(t, client, fmt.Sprintf("https://localhost:%d%s", metricsPort, hub.MetricsPath))
+
+		resp, err := http.Get(fmt.Sprintf("http://localhost:%d%s", metricsPort, hub.MetricsPath)) //nolint
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				t.Errorf("expected plain HTTP to not be served")
+			}
+		}
+
+		withoutCert := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: tlsConfig.RootCAs, MinVersion: tls.VersionTLS12}}}
+		resp, err = withoutCert.Get(fmt.Sprintf("https://localhost:%d%s", metricsPort, hub.MetricsPath))
+		if err == nil {
+			resp.Body.Close()
+			t.Errorf("expected a client without a certificate to be rejected")
+		}
+	})
+}
+
+// mustStartHub starts the hub and waits for it to serve. The hub is stopped
+// when the test finishes.
+func mustStartHub(t *testing.T, conf *config.Config) {
+	t.Helper()
+
+	hubPort := testutils.MustGetPort(t)
+	server := hub.New(conf)
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- server.Start(hubPort, false)
+	}()
+	t.Cleanup(func() {
+		server.Stop(false)
+		if err := <-errChan; err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	creds, err := conf.TLS.ClientCredentials()
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	// Wait for the hub to serve such that stopping it does not race starting.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, fmt.Sprintf("localhost:%d", hubPort), grpc.WithTransportCredentials(creds), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+	conn.Close()
+}
+
+func expectMetrics(t *testing.T, client *http.Client, url string) {
+	t.Helper()
+
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("got status %d want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	if !strings.Contains(string(body), "# TYPE gpupgrade_substep_status gauge") {
+		t.Errorf("unexpected metrics %q", body)
+	}
+}
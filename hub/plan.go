@@ -0,0 +1,333 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/kballard/go-shellquote"
+
+	"github.com/greenplum-db/gpupgrade/config/backupdir"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+// beginStep begins the step. When dryRun is set the substeps are not run and
+// instead their plan along with the commands from plan are sent. Otherwise the
+// step can be cancelled until endStep is called.
+func (s *Server) beginStep(currentStep idl.Step, sender idl.MessageSender, dryRun bool, plan func() (step.Plan, error)) (*step.Step, error) {
+	if !dryRun {
+		running, err := s.startStep(currentStep)
+		if err != nil {
+			return nil, err
+		}
+
+		running.messages = newStepMessages(sender)
+
+		st, err := step.Begin(running.ctx, currentStep, running.messages)
+		if err != nil {
+			running.messages.finish(err)
+			s.endStep(nil)
+			return nil, err
+		}
+
+		st.RunHooks(Hooks(s.Config, currentStep))
+		running.step = st
+		return st, nil
+	}
+
+	p, err := plan()
+	if err != nil {
+		return nil, err
+	}
+
+	return step.BeginDryRun(currentStep, sender, p)
+}
+
+// pauseBefore returns the substep to pause before preferring the one requested
+// over the one set during initialize.
+func (s *Server) pauseBefore(requested idl.Substep) idl.Substep {
+	if requested != idl.Substep_unknown_substep {
+		return requested
+	}
+
+	return s.PauseBefore
+}
+
+func noPlan() (step.Plan, error) {
+	return step.Plan{}, nil
+}
+
+func (s *Server) InitializeCreateClusterPlan(req *idl.InitializeCreateClusterRequest) (step.Plan, error) {
+	plan := step.Plan{}
+
+	coordinatorHost := s.Intermediate.CoordinatorHostname()
+	plan[idl.Substep_init_target_cluster] = []*idl.PlannedCommand{
+		newPlannedCommand(coordinatorHost, filepath.Join(s.Intermediate.GPHome, "bin", "gpinitsystem"), initTargetClusterArgs(s.Intermediate)),
+	}
+
+	backupTarget, err := rsyncPlannedCommand(coordinatorHost, rsyncCoordinatorDataDirOptions(s.Intermediate.CoordinatorDataDir(), utils.GetCoordinatorPreUpgradeBackupDir(s.BackupDirs.CoordinatorBackupDir))...)
+	if err != nil {
+		return nil, err
+	}
+	plan[idl.Substep_backup_target_master] = []*idl.PlannedCommand{backupTarget}
+
+	if !req.GetSkipPgUpgradeChecks() {
+		pgUpgradeTimestamp := utils.System.Now().Format(TimeStringFormat)
+		plan[idl.Substep_check_upgrade], err = s.pgUpgradePlan(req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), idl.PgOptions_check, pgUpgradeTimestamp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+func (s *Server) ExecutePlan(req *idl.ExecuteRequest, pgUpgradeTimestamp string) (step.Plan, error) {
+	plan := step.Plan{}
+
+	upgradeCoordinator, err := s.pgUpgradeCoordinatorPlan(req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), idl.PgOptions_upgrade, pgUpgradeTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	plan[idl.Substep_upgrade_master] = upgradeCoordinator
+
+	settings, err := s.rsyncSettings(s.Rsync.Execute)
+	if err != nil {
+		return nil, err
+	}
+
+	var copyCoordinator []*idl.PlannedCommand
+	dataDir := []string{filepath.Clean(s.Intermediate.CoordinatorDataDir()) + string(filepath.Separator)}
+	tablespaces := coordinatorTablespacesSources(s.Source.Version, s.Source.Tablespaces)
+	for _, host := range sortedHosts(s.BackupDirs.AgentHostsToBackupDir) {
+		backupDir := s.BackupDirs.AgentHostsToBackupDir[host]
+
+		cmd, err := rsyncPlannedCommand(s.Source.CoordinatorHostname(), append(copyOptions(dataDir, host, utils.GetCoordinatorPostUpgradeBackupDir(backupDir)), settings.Options()...)...)
+		if err != nil {
+			return nil, err
+		}
+		copyCoordinator = append(copyCoordinator, cmd)
+
+		if len(tablespaces) == 0 {
+			continue
+		}
+
+		cmd, err = rsyncPlannedCommand(s.Source.CoordinatorHostname(), append(copyOptions(tablespaces, host, utils.GetTablespaceBackupDir(backupDir)+string(filepath.Separator)), settings.Options()...)...)
+		if err != nil {
+			return nil, err
+		}
+		copyCoordinator = append(copyCoordinator, cmd)
+	}
+	plan[idl.Substep_copy_master] = copyCoordinator
+
+	upgradePrimaries, err := s.pgUpgradePrimariesPlan(req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), idl.PgOptions_upgrade, pgUpgradeTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	plan[idl.Substep_upgrade_primaries] = upgradePrimaries
+
+	return plan, nil
+}
+
+func (s *Server) FinalizePlan() (step.Plan, error) {
+	plan := step.Plan{}
+
+	if s.Mode != idl.Mode_link {
+		args := []string{"-a", "-i", utils.GetAddMirrorsConfig()}
+		if s.UseHbaHostnames {
+			args = append(args, "--hba-hostnames")
+		}
+
+		plan[idl.Substep_upgrade_mirrors] = []*idl.PlannedCommand{
+			newPlannedCommand(s.Intermediate.CoordinatorHostname(), filepath.Join(s.Intermediate.GPHome, "bin", "gpaddmirrors"), args),
+		}
+
+		return plan, nil
+	}
+
+	settings, err := s.rsyncSettings(s.Rsync.Finalize)
+	if err != nil {
+		return nil, err
+	}
+
+	var upgradeMirrors []*idl.PlannedCommand
+	for _, host := range sortedAgentHosts(s.Source) {
+		cmds, err := rsyncRequestPlannedCommands(host, rsyncMirrorDataDirsOptions(host, s.Source, s.Intermediate, settings))
+		if err != nil {
+			return nil, err
+		}
+
+		upgradeMirrors = append(upgradeMirrors, cmds...)
+	}
+	plan[idl.Substep_upgrade_mirrors] = upgradeMirrors
+
+	return plan, nil
+}
+
+func (s *Server) RevertPlan() (step.Plan, error) {
+	plan := step.Plan{}
+
+	if !s.Source.HasAllMirrorsAndStandby() {
+		return plan, nil
+	}
+
+	settings, err := s.rsyncSettings(s.Rsync.Revert)
+	if err != nil {
+		return nil, err
+	}
+
+	restoreCoordinator, err := rsyncPlannedCommand(s.Source.CoordinatorHostname(), append(rsyncCoordinatorOptions(s.Source.Standby(), s.Source.Coordinator()), settings.Options()...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	restoreSource := []*idl.PlannedCommand{restoreCoordinator}
+	for _, host := range sortedAgentHosts(s.Source) {
+		cmds, err := rsyncRequestPlannedCommands(host, rsyncPrimariesOptions(host, s.Source, settings))
+		if err != nil {
+			return nil, err
+		}
+
+		restoreSource = append(restoreSource, cmds...)
+	}
+	plan[idl.Substep_restore_source_cluster] = restoreSource
+
+	return plan, nil
+}
+
+// pgUpgradePlan returns the commands to upgrade or check the coordinator and
+// primaries.
+func (s *Server) pgUpgradePlan(pgUpgradeVerbose bool, skipPgUpgradeChecks bool, action idl.PgOptions_Action, pgUpgradeTimestamp string) ([]*idl.PlannedCommand, error) {
+	coordinator, err := s.pgUpgradeCoordinatorPlan(pgUpgradeVerbose, skipPgUpgradeChecks, action, pgUpgradeTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	primaries, err := s.pgUpgradePrimariesPlan(pgUpgradeVerbose, skipPgUpgradeChecks, action, pgUpgradeTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(coordinator, primaries...), nil
+}
+
+func (s *Server) pgUpgradeCoordinatorPlan(pgUpgradeVerbose bool, skipPgUpgradeChecks bool, action idl.PgOptions_Action, pgUpgradeTimestamp string) ([]*idl.PlannedCommand, error) {
+	host := s.Source.CoordinatorHostname()
+	backupDir := s.BackupDirs.CoordinatorBackupDir
+
+	restore, err := rsyncPlannedCommand(host, rsyncCoordinatorDataDirOptions(utils.GetCoordinatorPreUpgradeBackupDir(backupDir), s.Intermediate.CoordinatorDataDir())...)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := CoordinatorPgOptions(backupDir, pgUpgradeVerbose, skipPgUpgradeChecks, s.PgUpgradeJobs, s.Source, s.Intermediate, action, s.Mode, pgUpgradeTimestamp)
+	pgUpgrade, err := pgUpgradePlannedCommand(host, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*idl.PlannedCommand{restore, pgUpgrade}, nil
+}
+
+func (s *Server) pgUpgradePrimariesPlan(pgUpgradeVerbose bool, skipPgUpgradeChecks bool, action idl.PgOptions_Action, pgUpgradeTimestamp string) ([]*idl.PlannedCommand, error) {
+	// Exclude the primaries already upgraded by a previous run without
+	// creating the segment status file.
+	segments := step.NewSegmentStoreUsingFile(filepath.Join(utils.GetStateDir(), step.SegmentsFileName))
+	tracker, err := newSegmentTracker(segments, action)
+	if errors.Is(err, os.ErrNotExist) {
+		tracker, err = &segmentTracker{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var cmds []*idl.PlannedCommand
+	for _, host := range sortedAgentHosts(s.Source) {
+		opts := PrimariesPgOptions(host, s.BackupDirs.AgentHostsToBackupDir, pgUpgradeVerbose, skipPgUpgradeChecks, s.PgUpgradeJobs, s.Source, s.Intermediate, action, s.Mode, pgUpgradeTimestamp)
+		for _, opt := range tracker.excludeCompleted(host, opts) {
+			cmd, err := pgUpgradePlannedCommand(host, opt)
+			if err != nil {
+				return nil, err
+			}
+
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	return cmds, nil
+}
+
+func newPlannedCommand(host string, utility string, args []string) *idl.PlannedCommand {
+	return &idl.PlannedCommand{
+		Host:    host,
+		Command: shellquote.Join(append([]string{utility}, args...)...),
+	}
+}
+
+func pgUpgradePlannedCommand(host string, opts *idl.PgOptions) (*idl.PlannedCommand, error) {
+	utility, args, err := upgrade.Command(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPlannedCommand(host, utility, args), nil
+}
+
+func rsyncPlannedCommand(host string, options ...rsync.Option) (*idl.PlannedCommand, error) {
+	utility, args, err := rsync.Command(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPlannedCommand(host, utility, args), nil
+}
+
+// rsyncRequestPlannedCommands returns the rsync commands the agent on host
+// issues for the request options.
+func rsyncRequestPlannedCommands(host string, opts []*idl.RsyncRequest_RsyncOptions) ([]*idl.PlannedCommand, error) {
+	var cmds []*idl.PlannedCommand
+	for _, opt := range opts {
+		options := []rsync.Option{
+			rsync.WithSources(opt.GetSources()...),
+			rsync.WithDestinationHost(opt.GetDestinationHost()),
+			rsync.WithDestination(opt.GetDestination()),
+			rsync.WithOptions(opt.GetOptions()...),
+			rsync.WithExcludedFiles(opt.GetExcludedFiles()...),
+		}
+
+		cmd, err := rsyncPlannedCommand(host, append(options, rsync.RequestSettings(opt).Options()...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		cmds = append(cmds, cmd)
+	}
+
+	return cmds, nil
+}
+
+func sortedAgentHosts(cluster *greenplum.Cluster) []string {
+	hosts := AgentHosts(cluster)
+	sort.Strings(hosts)
+	return hosts
+}
+
+func sortedHosts(agentHostsToBackupDir backupdir.AgentHostsToBackupDir) []string {
+	var hosts []string
+	for host := range agentHostsToBackupDir {
+		hosts = append(hosts, host)
+	}
+
+	sort.Strings(hosts)
+	return hosts
+}
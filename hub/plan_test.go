@@ -0,0 +1,107 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
+)
+
+func TestFinalizePlan(t *testing.T) {
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Port: 25433, Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Port: 25434, Role: greenplum.MirrorRole},
+		{DbID: 4, ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Port: 25435, Role: greenplum.PrimaryRole},
+		{DbID: 5, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg2", Port: 25436, Role: greenplum.MirrorRole},
+	})
+	source.GPHome = "/usr/local/source"
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: "/data/qddir/seg.HqtFHX54y0o.-1", Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Port: 50434, Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg.HqtFHX54y0o.1", Port: 50435, Role: greenplum.MirrorRole},
+		{DbID: 4, ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg.HqtFHX54y0o.2", Port: 50436, Role: greenplum.PrimaryRole},
+		{DbID: 5, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg.HqtFHX54y0o.2", Port: 50437, Role: greenplum.MirrorRole},
+	})
+	intermediate.GPHome = "/usr/local/target"
+
+	t.Run("plans gpaddmirrors in copy mode", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+		defer resetEnv()
+
+		server := hub.New(&config.Config{Source: source, Intermediate: intermediate, Mode: idl.Mode_copy, UseHbaHostnames: true})
+
+		plan, err := server.FinalizePlan()
+		if err != nil {
+			t.Fatalf("unexpected err %#v", err)
+		}
+
+		expected := step.Plan{idl.Substep_upgrade_mirrors: {
+			{Host: "coordinator", Command: "/usr/local/target/bin/gpaddmirrors -a -i " + stateDir + "/add_mirrors_config --hba-hostnames"},
+		}}
+		if !reflect.DeepEqual(plan, expected) {
+			t.Errorf("got %v want %v", plan, expected)
+		}
+	})
+
+	t.Run("plans rsync of the mirrors on each host in link mode", func(t *testing.T) {
+		server := hub.New(&config.Config{Source: source, Intermediate: intermediate, Mode: idl.Mode_link})
+
+		plan, err := server.FinalizePlan()
+		if err != nil {
+			t.Fatalf("unexpected err %#v", err)
+		}
+
+		expected := step.Plan{idl.Substep_upgrade_mirrors: {
+			{Host: "sdw1", Command: "rsync --archive --delete --hard-links --size-only --no-inc-recursive /data/dbfast1/seg1 /data/dbfast1/seg.HqtFHX54y0o.1 sdw2:/data/dbfast_mirror1"},
+			{Host: "sdw2", Command: "rsync --archive --delete --hard-links --size-only --no-inc-recursive /data/dbfast2/seg2 /data/dbfast2/seg.HqtFHX54y0o.2 sdw1:/data/dbfast_mirror2"},
+		}}
+		if !reflect.DeepEqual(plan, expected) {
+			t.Errorf("got %v want %v", plan, expected)
+		}
+	})
+
+	t.Run("plans rsync of the mirrors through the agents rather than ssh when using the agent executor", func(t *testing.T) {
+		server := hub.New(&config.Config{Source: source, Intermediate: intermediate, Mode: idl.Mode_link, RemoteExecutor: remote.AgentExecutor, AgentPort: 6416})
+
+		plan, err := server.FinalizePlan()
+		if err != nil {
+			t.Fatalf("unexpected err %#v", err)
+		}
+
+		path, err := utils.GetGpupgradePath()
+		if err != nil {
+			t.Fatalf("unexpected err %#v", err)
+		}
+
+		shell := "'--rsh=" + path + " remote-shell --port 6416'"
+		expected := step.Plan{idl.Substep_upgrade_mirrors: {
+			{Host: "sdw1", Command: "rsync --archive --delete --hard-links --size-only --no-inc-recursive " + shell + " /data/dbfast1/seg1 /data/dbfast1/seg.HqtFHX54y0o.1 sdw2:/data/dbfast_mirror1"},
+			{Host: "sdw2", Command: "rsync --archive --delete --hard-links --size-only --no-inc-recursive " + shell + " /data/dbfast2/seg2 /data/dbfast2/seg.HqtFHX54y0o.2 sdw1:/data/dbfast_mirror2"},
+		}}
+		if !reflect.DeepEqual(plan, expected) {
+			t.Errorf("got %v want %v", plan, expected)
+		}
+
+		for _, cmd := range plan[idl.Substep_upgrade_mirrors] {
+			if strings.Contains(cmd.GetCommand(), "ssh") {
+				t.Errorf("got ssh in command %q", cmd.GetCommand())
+			}
+		}
+	})
+}
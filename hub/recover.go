@@ -0,0 +1,54 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+// Recoveries returns the functions that clean up the hub substeps which need
+// more than simply being run again when they were left running. See
+// substeps.RecoveryDescriptions for the substeps that can be recovered. They
+// are only run for a substep whose status shows it was left running, and use
+// the agent connections made by the step's first substep.
+func (s *Server) Recoveries() step.Recoveries {
+	return step.Recoveries{
+		idl.Substep_init_target_cluster:     s.RemoveIntermediateCluster,
+		idl.Substep_update_data_directories: s.recoverUpdateDataDirectories,
+	}
+}
+
+// recoverUpdateDataDirectories verifies the rename state of the master and
+// segment data directories. Renaming is idempotent so running the substep
+// again finishes renaming any remaining data directories.
+func (s *Server) recoverUpdateDataDirectories(ctx context.Context, streams step.OutStreams) error {
+	intermediate := s.Intermediate.CoordinatorDataDir()
+	renamed, err := upgrade.VerifyRenameDirectories(intermediate)
+	if err != nil {
+		return xerrors.Errorf("verifying master data directory rename: %w", err)
+	}
+
+	if renamed {
+		text := fmt.Sprintf("Master data directory %q already renamed.", intermediate)
+		log.Print(text)
+		if _, err := fmt.Fprintln(streams.Stdout(), text); err != nil {
+			return err
+		}
+	}
+
+	err = VerifySegmentDataDirRenames(ctx, s.agentConns, s.Concurrency.Hosts, getRenameMap(s.Source, s.Intermediate))
+	if err != nil {
+		return xerrors.Errorf("verifying segment data directory renames: %w", err)
+	}
+
+	return nil
+}
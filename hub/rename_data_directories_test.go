@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -89,12 +90,40 @@ func TestRenameSegmentDataDirs(t *testing.T) {
 			{AgentClient: client3, Hostname: "standby"},
 		}
 
-		err := hub.RenameSegmentDataDirs(agentConns, m)
+		err := hub.RenameSegmentDataDirs(context.Background(), agentConns, 0, m)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
 	})
 
+	t.Run("verifies the pairs without renaming them", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		client1 := mock_idl.NewMockAgentClient(ctrl)
+		client1.EXPECT().RenameDirectories(
+			gomock.Any(),
+			&idl.RenameDirectoriesRequest{Dirs: m["sdw1"], VerifyOnly: true},
+		).Return(&idl.RenameDirectoriesReply{}, nil)
+
+		expected := errors.New("permission denied")
+		client2 := mock_idl.NewMockAgentClient(ctrl)
+		client2.EXPECT().RenameDirectories(
+			gomock.Any(),
+			&idl.RenameDirectoriesRequest{Dirs: m["sdw2"], VerifyOnly: true},
+		).Return(nil, expected)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: client1, Hostname: "sdw1"},
+			{AgentClient: client2, Hostname: "sdw2"},
+		}
+
+		err := hub.VerifySegmentDataDirRenames(context.Background(), agentConns, 0, m)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v, want %#v", err, expected)
+		}
+	})
+
 	t.Run("returns error on failure", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -117,7 +146,7 @@ func TestRenameSegmentDataDirs(t *testing.T) {
 			{AgentClient: failedClient, Hostname: "sdw2"},
 		}
 
-		err := hub.RenameSegmentDataDirs(agentConns, m)
+		err := hub.RenameSegmentDataDirs(context.Background(), agentConns, 0, m)
 
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -188,7 +217,7 @@ func TestUpdateDataDirectories(t *testing.T) {
 			}
 		}()
 
-		err := hub.RenameDataDirectories(nil, conf.Source, conf.Intermediate)
+		err := hub.RenameDataDirectories(context.Background(), nil, 0, conf.Source, conf.Intermediate)
 		if err != nil {
 			t.Errorf("UpdateDataDirectories() returned error: %+v", err)
 		}
@@ -207,7 +236,7 @@ func TestUpdateDataDirectories(t *testing.T) {
 			}
 		}()
 
-		err := hub.RenameDataDirectories(nil, conf.Source, conf.Intermediate)
+		err := hub.RenameDataDirectories(context.Background(), nil, 0, conf.Source, conf.Intermediate)
 		if !errors.Is(err, expected) {
 			t.Errorf("got %#v want %#v", err, expected)
 		}
@@ -264,7 +293,7 @@ func TestUpdateDataDirectories(t *testing.T) {
 			{AgentClient: standby, Hostname: "standby"},
 		}
 
-		err := hub.RenameDataDirectories(agentConns, conf.Source, conf.Intermediate)
+		err := hub.RenameDataDirectories(context.Background(), agentConns, 0, conf.Source, conf.Intermediate)
 		if err != nil {
 			t.Errorf("RenameDataDirectories() returned error: %+v", err)
 		}
@@ -320,7 +349,7 @@ func TestUpdateDataDirectories(t *testing.T) {
 			{AgentClient: standby, Hostname: "standby"},
 		}
 
-		err := hub.RenameDataDirectories(agentConns, conf.Source, conf.Intermediate)
+		err := hub.RenameDataDirectories(context.Background(), agentConns, 0, conf.Source, conf.Intermediate)
 		if err != nil {
 			t.Errorf("RenameDataDirectories() returned error: %+v", err)
 		}
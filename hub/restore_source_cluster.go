@@ -16,17 +16,17 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils/rsync"
 )
 
-func RsyncCoordinatorAndPrimaries(stream step.OutStreams, agentConns []*idl.Connection, source *greenplum.Cluster) error {
+func RsyncCoordinatorAndPrimaries(ctx context.Context, stream step.OutStreams, agentConns []*idl.Connection, hostConcurrency uint, source *greenplum.Cluster, rsyncConcurrency uint, settings rsync.Settings, stats *rsync.Collector) error {
 	var wg sync.WaitGroup
 	errs := make(chan error, 2)
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		errs <- RsyncCoordinator(stream, source.Standby(), source.Coordinator())
+		errs <- RsyncCoordinator(ctx, stream, source.Standby(), source.Coordinator(), settings, stats)
 	}()
 
-	errs <- RsyncPrimaries(agentConns, source)
+	errs <- RsyncPrimaries(ctx, agentConns, hostConcurrency, source, rsyncConcurrency, settings, stats)
 
 	wg.Wait()
 	close(errs)
@@ -39,17 +39,17 @@ func RsyncCoordinatorAndPrimaries(stream step.OutStreams, agentConns []*idl.Conn
 	return err
 }
 
-func RsyncCoordinatorAndPrimariesTablespaces(stream step.OutStreams, agentConns []*idl.Connection, source *greenplum.Cluster) error {
+func RsyncCoordinatorAndPrimariesTablespaces(ctx context.Context, stream step.OutStreams, agentConns []*idl.Connection, hostConcurrency uint, source *greenplum.Cluster, rsyncConcurrency uint, settings rsync.Settings, stats *rsync.Collector) error {
 	var wg sync.WaitGroup
 	errs := make(chan error, 2)
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		errs <- RsyncCoordinatorTablespaces(stream, source.StandbyHostname(), source.Tablespaces[int32(source.Coordinator().DbID)], source.Tablespaces[int32(source.Standby().DbID)])
+		errs <- RsyncCoordinatorTablespaces(ctx, stream, source.StandbyHostname(), source.Tablespaces[int32(source.Coordinator().DbID)], source.Tablespaces[int32(source.Standby().DbID)], settings, stats)
 	}()
 
-	errs <- RsyncPrimariesTablespaces(agentConns, source, source.Tablespaces)
+	errs <- RsyncPrimariesTablespaces(ctx, agentConns, hostConcurrency, source, source.Tablespaces, rsyncConcurrency, settings, stats)
 
 	wg.Wait()
 	close(errs)
@@ -71,20 +71,22 @@ func Recoverseg(stream step.OutStreams, cluster *greenplum.Cluster, useHbaHostna
 	return cluster.RunGreenplumCmd(stream, "gprecoverseg", args...)
 }
 
-func RsyncCoordinator(stream step.OutStreams, standby greenplum.SegConfig, coordinator greenplum.SegConfig) error {
-	opts := []rsync.Option{
+func RsyncCoordinator(ctx context.Context, stream step.OutStreams, standby greenplum.SegConfig, coordinator greenplum.SegConfig, settings rsync.Settings, stats *rsync.Collector) error {
+	opts := append(rsyncCoordinatorOptions(standby, coordinator), rsync.WithStream(stream), rsync.WithStats(stats), rsync.WithContext(ctx))
+	return settings.Rsync(opts...)
+}
+
+func rsyncCoordinatorOptions(standby greenplum.SegConfig, coordinator greenplum.SegConfig) []rsync.Option {
+	return []rsync.Option{
 		rsync.WithSources(standby.DataDir + string(os.PathSeparator)),
 		rsync.WithSourceHost(standby.Hostname),
 		rsync.WithDestination(coordinator.DataDir),
 		rsync.WithOptions(rsync.Options...),
 		rsync.WithExcludedFiles(rsync.Excludes...),
-		rsync.WithStream(stream),
 	}
-
-	return rsync.Rsync(opts...)
 }
 
-func RsyncCoordinatorTablespaces(stream step.OutStreams, standbyHostname string, coordinatorTablespaces greenplum.SegmentTablespaces, standbyTablespaces greenplum.SegmentTablespaces) error {
+func RsyncCoordinatorTablespaces(ctx context.Context, stream step.OutStreams, standbyHostname string, coordinatorTablespaces greenplum.SegmentTablespaces, standbyTablespaces greenplum.SegmentTablespaces, settings rsync.Settings, stats *rsync.Collector) error {
 	for oid, coordinatorTsInfo := range coordinatorTablespaces {
 		if !coordinatorTsInfo.GetUserDefined() {
 			continue
@@ -96,9 +98,11 @@ func RsyncCoordinatorTablespaces(stream step.OutStreams, standbyHostname string,
 			rsync.WithDestination(coordinatorTsInfo.GetLocation()),
 			rsync.WithOptions(rsync.Options...),
 			rsync.WithStream(stream),
+			rsync.WithStats(stats),
+			rsync.WithContext(ctx),
 		}
 
-		err := rsync.Rsync(opts...)
+		err := settings.Rsync(opts...)
 		if err != nil {
 			return err
 		}
@@ -107,38 +111,46 @@ func RsyncCoordinatorTablespaces(stream step.OutStreams, standbyHostname string,
 	return nil
 }
 
-func RsyncPrimaries(agentConns []*idl.Connection, source *greenplum.Cluster) error {
-	request := func(conn *idl.Connection) error {
-		mirrors := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
-			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
-		})
-
-		if len(mirrors) == 0 {
+func RsyncPrimaries(ctx context.Context, agentConns []*idl.Connection, hostConcurrency uint, source *greenplum.Cluster, rsyncConcurrency uint, settings rsync.Settings, stats *rsync.Collector) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		opts := rsyncPrimariesOptions(conn.Hostname, source, settings)
+		if len(opts) == 0 {
 			return nil
 		}
 
-		var opts []*idl.RsyncRequest_RsyncOptions
-		for _, mirror := range mirrors {
-			opt := &idl.RsyncRequest_RsyncOptions{
-				Sources:         []string{mirror.DataDir + string(os.PathSeparator)},
-				DestinationHost: source.Primaries[mirror.ContentID].Hostname,
-				Destination:     source.Primaries[mirror.ContentID].DataDir,
-				Options:         rsync.Options,
-				ExcludedFiles:   rsync.Excludes,
-			}
-			opts = append(opts, opt)
-		}
-
-		req := &idl.RsyncRequest{Options: opts}
-		_, err := conn.AgentClient.RsyncDataDirectories(context.Background(), req)
+		req := &idl.RsyncRequest{Options: opts, Concurrency: uint32(rsyncConcurrency)}
+		reply, err := conn.AgentClient.RsyncDataDirectories(ctx, req)
+		stats.Add(rsync.StatsFromProto(reply.GetStats()))
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, hostConcurrency, request)
+}
+
+// rsyncPrimariesOptions returns the options to restore the primaries from the
+// mirrors on the given host.
+func rsyncPrimariesOptions(hostname string, source *greenplum.Cluster, settings rsync.Settings) []*idl.RsyncRequest_RsyncOptions {
+	mirrors := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
+		return seg.IsOnHost(hostname) && !seg.IsStandby() && seg.IsMirror()
+	})
+
+	var opts []*idl.RsyncRequest_RsyncOptions
+	for _, mirror := range mirrors {
+		opt := &idl.RsyncRequest_RsyncOptions{
+			Sources:         []string{mirror.DataDir + string(os.PathSeparator)},
+			DestinationHost: source.Primaries[mirror.ContentID].Hostname,
+			Destination:     source.Primaries[mirror.ContentID].DataDir,
+			Options:         rsync.Options,
+			ExcludedFiles:   rsync.Excludes,
+		}
+		opts = append(opts, opt)
+	}
+
+	return rsync.SetRequestSettings(opts, settings)
 }
 
-func RsyncPrimariesTablespaces(agentConns []*idl.Connection, source *greenplum.Cluster, tablespaces greenplum.Tablespaces) error {
-	request := func(conn *idl.Connection) error {
+func RsyncPrimariesTablespaces(ctx context.Context, agentConns []*idl.Connection, hostConcurrency uint, source *greenplum.Cluster, tablespaces greenplum.Tablespaces, rsyncConcurrency uint, settings rsync.Settings, stats *rsync.Collector) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		mirrors := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
 		})
@@ -169,15 +181,16 @@ func RsyncPrimariesTablespaces(agentConns []*idl.Connection, source *greenplum.C
 			}
 		}
 
-		req := &idl.RsyncRequest{Options: opts}
-		_, err := conn.AgentClient.RsyncTablespaceDirectories(context.Background(), req)
+		req := &idl.RsyncRequest{Options: rsync.SetRequestSettings(opts, settings), Concurrency: uint32(rsyncConcurrency)}
+		reply, err := conn.AgentClient.RsyncTablespaceDirectories(ctx, req)
+		stats.Add(rsync.StatsFromProto(reply.GetStats()))
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, hostConcurrency, request)
 }
 
-func RestoreCoordinatorAndPrimariesPgControl(streams step.OutStreams, agentConns []*idl.Connection, source *greenplum.Cluster) error {
+func RestoreCoordinatorAndPrimariesPgControl(ctx context.Context, streams step.OutStreams, agentConns []*idl.Connection, hostConcurrency uint, source *greenplum.Cluster) error {
 	var wg sync.WaitGroup
 	errs := make(chan error, 2)
 
@@ -187,7 +200,7 @@ func RestoreCoordinatorAndPrimariesPgControl(streams step.OutStreams, agentConns
 		errs <- upgrade.RestorePgControl(source.CoordinatorDataDir(), streams)
 	}()
 
-	errs <- restorePrimariesPgControl(agentConns, source)
+	errs <- restorePrimariesPgControl(ctx, agentConns, hostConcurrency, source)
 
 	wg.Wait()
 	close(errs)
@@ -200,8 +213,8 @@ func RestoreCoordinatorAndPrimariesPgControl(streams step.OutStreams, agentConns
 	return err
 }
 
-func restorePrimariesPgControl(agentConns []*idl.Connection, source *greenplum.Cluster) error {
-	request := func(conn *idl.Connection) error {
+func restorePrimariesPgControl(ctx context.Context, agentConns []*idl.Connection, hostConcurrency uint, source *greenplum.Cluster) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		primaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsPrimary()
 		})
@@ -219,9 +232,9 @@ func restorePrimariesPgControl(agentConns []*idl.Connection, source *greenplum.C
 			Datadirs: dataDirs,
 		}
 
-		_, err := conn.AgentClient.RestorePrimariesPgControl(context.Background(), req)
+		_, err := conn.AgentClient.RestorePrimariesPgControl(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, hostConcurrency, request)
 }
@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -76,7 +77,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 			}
 		}))
 
-		err := hub.RsyncCoordinator(step.DevNullStream, cluster.Standby(), cluster.Coordinator())
+		err := hub.RsyncCoordinator(context.Background(), step.DevNullStream, cluster.Standby(), cluster.Coordinator(), rsync.Settings{}, nil)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -107,7 +108,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 			}
 		}))
 
-		err := hub.RsyncCoordinatorTablespaces(step.DevNullStream, cluster.StandbyHostname(), tablespaces[int32(cluster.Coordinator().DbID)], tablespaces[int32(cluster.Standby().DbID)])
+		err := hub.RsyncCoordinatorTablespaces(context.Background(), step.DevNullStream, cluster.StandbyHostname(), tablespaces[int32(cluster.Coordinator().DbID)], tablespaces[int32(cluster.Standby().DbID)], rsync.Settings{}, nil)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -128,6 +129,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 					Options:         rsync.Options,
 					ExcludedFiles:   rsync.Excludes,
 				}},
+				Concurrency: 2,
 			},
 		).Return(&idl.RsyncReply{}, nil)
 
@@ -142,6 +144,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 					Options:         rsync.Options,
 					ExcludedFiles:   rsync.Excludes,
 				}},
+				Concurrency: 2,
 			},
 		).Return(&idl.RsyncReply{}, nil)
 
@@ -153,7 +156,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 			{AgentClient: standby, Hostname: "standby"},
 		}
 
-		err := hub.RsyncPrimaries(agentConns, cluster)
+		err := hub.RsyncPrimaries(context.Background(), agentConns, 0, cluster, 2, rsync.Settings{}, nil)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -199,7 +202,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 			{AgentClient: standby, Hostname: "standby"},
 		}
 
-		err := hub.RsyncPrimariesTablespaces(agentConns, cluster, tablespaces)
+		err := hub.RsyncPrimariesTablespaces(context.Background(), agentConns, 0, cluster, tablespaces, 0, rsync.Settings{}, nil)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -209,7 +212,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 		rsync.SetRsyncCommand(exectest.NewCommand(hub.Failure))
 		defer rsync.ResetRsyncCommand()
 
-		err := hub.RsyncCoordinator(step.DevNullStream, cluster.Standby(), cluster.Coordinator())
+		err := hub.RsyncCoordinator(context.Background(), step.DevNullStream, cluster.Standby(), cluster.Coordinator(), rsync.Settings{}, nil)
 		if err == nil {
 			t.Error("unexpected nil error")
 		}
@@ -219,7 +222,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 		rsync.SetRsyncCommand(exectest.NewCommand(hub.Failure))
 		defer rsync.ResetRsyncCommand()
 
-		err := hub.RsyncCoordinatorTablespaces(step.DevNullStream, cluster.CoordinatorHostname(), tablespaces[int32(greenplum.CoordinatorDbid)], tablespaces[int32(cluster.Standby().DbID)])
+		err := hub.RsyncCoordinatorTablespaces(context.Background(), step.DevNullStream, cluster.CoordinatorHostname(), tablespaces[int32(greenplum.CoordinatorDbid)], tablespaces[int32(cluster.Standby().DbID)], rsync.Settings{}, nil)
 		if err == nil {
 			t.Error("unexpected nil error")
 		}
@@ -255,7 +258,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 			{AgentClient: failedClient, Hostname: "msdw2"},
 		}
 
-		err := hub.RsyncPrimaries(agentConns, cluster)
+		err := hub.RsyncPrimaries(context.Background(), agentConns, 0, cluster, 0, rsync.Settings{}, nil)
 
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -284,7 +287,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 			{AgentClient: failedClient, Hostname: "msdw2"},
 		}
 
-		err := hub.RsyncPrimariesTablespaces(agentConns, cluster, tablespaces)
+		err := hub.RsyncPrimariesTablespaces(context.Background(), agentConns, 0, cluster, tablespaces, 0, rsync.Settings{}, nil)
 
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -333,7 +336,7 @@ func TestRestoreCoordinatorAndPrimariesPgControl(t *testing.T) {
 			{AgentClient: failedClient, Hostname: "sdw2"},
 		}
 
-		err := hub.RestoreCoordinatorAndPrimariesPgControl(step.DevNullStream, agentConns, cluster)
+		err := hub.RestoreCoordinatorAndPrimariesPgControl(context.Background(), step.DevNullStream, agentConns, 0, cluster)
 
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
@@ -402,7 +405,7 @@ func TestRestoreCoordinatorAndPrimariesPgControl(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err = hub.RestoreCoordinatorAndPrimariesPgControl(step.DevNullStream, agentConns, cluster)
+		err = hub.RestoreCoordinatorAndPrimariesPgControl(context.Background(), step.DevNullStream, agentConns, 0, cluster)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -12,16 +12,24 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/snapshot"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
 )
 
-func (s *Server) Revert(_ *idl.RevertRequest, stream idl.CliToHub_RevertServer) (err error) {
-	st, err := step.Begin(idl.Step_revert, stream)
+func (s *Server) Revert(req *idl.RevertRequest, stream idl.CliToHub_RevertServer) (err error) {
+	st, err := s.beginStep(idl.Step_revert, stream, req.GetDryRun(), s.RevertPlan)
 	if err != nil {
 		return err
 	}
 
+	defer s.endStep(st)
+
+	if req.GetRecover() {
+		st.Recover(s.Recoveries())
+	}
+
 	hasExecuteStarted, err := step.HasStarted(idl.Step_execute)
 	if err != nil {
 		return err
@@ -45,7 +53,7 @@ Cannot revert and restore the source cluster. Please contact support.`)
 	}
 
 	st.RunConditionally(idl.Substep_ensure_gpupgrade_agents_are_running, configCreated && agentsStarted, func(_ step.OutStreams) error {
-		_, err := RestartAgents(context.Background(), nil, AgentHosts(s.Source), s.AgentPort, utils.GetStateDir())
+		_, err := RestartAgents(context.Background(), nil, s.executor(), AgentHosts(s.Source), s.AgentPort, s.AgentListenOnHostname, s.TLS, s.LogFormat, utils.GetStateDir())
 		if err != nil {
 			return err
 		}
@@ -66,25 +74,37 @@ Cannot revert and restore the source cluster. Please contact support.`)
 		return s.Intermediate.Stop(streams)
 	})
 
-	st.RunConditionally(idl.Substep_delete_target_cluster_datadirs, configCreated, func(streams step.OutStreams) error {
-		return DeleteCoordinatorAndPrimaryDataDirectories(streams, s.agentConns, s.Intermediate)
-	})
+	st.RunConcurrently(func(group *step.Group) {
+		group.RunConditionally(idl.Substep_delete_target_cluster_datadirs, configCreated, func(ctx context.Context, streams step.OutStreams) error {
+			return DeleteCoordinatorAndPrimaryDataDirectories(ctx, streams, s.agentConns, s.Concurrency.Hosts, s.Intermediate)
+		})
 
-	st.RunConditionally(idl.Substep_delete_tablespaces, configCreated, func(streams step.OutStreams) error {
-		return DeleteTargetTablespaces(streams, s.agentConns, s.Config.Intermediate, s.Intermediate.CatalogVersion, s.Source.Tablespaces)
+		group.RunConditionally(idl.Substep_delete_tablespaces, configCreated, func(ctx context.Context, streams step.OutStreams) error {
+			return DeleteTargetTablespaces(ctx, streams, s.agentConns, s.Concurrency.Hosts, s.Config.Intermediate, s.Intermediate.CatalogVersion, s.Source.Tablespaces)
+		})
 	})
 
 	// See "Reverting to old cluster" from https://www.postgresql.org/docs/9.4/pgupgrade.html
 	st.RunConditionally(idl.Substep_restore_pgcontrol, configCreated && s.Mode == idl.Mode_link, func(streams step.OutStreams) error {
-		return RestoreCoordinatorAndPrimariesPgControl(streams, s.agentConns, s.Source)
+		return RestoreCoordinatorAndPrimariesPgControl(st.Context(), streams, s.agentConns, s.Concurrency.Hosts, s.Source)
 	})
 
 	st.RunConditionally(idl.Substep_restore_source_cluster, configCreated && s.Mode == idl.Mode_link && s.Source.HasAllMirrorsAndStandby(), func(stream step.OutStreams) error {
-		if err := RsyncCoordinatorAndPrimaries(stream, s.agentConns, s.Source); err != nil {
+		stats := &rsync.Collector{}
+		defer recordRsyncStats(idl.Step_revert, idl.Substep_restore_source_cluster, stats)
+
+		settings, err := s.rsyncSettings(s.Rsync.Revert)
+		if err != nil {
 			return err
 		}
 
-		return RsyncCoordinatorAndPrimariesTablespaces(stream, s.agentConns, s.Source)
+		return s.monitorAgents(func() error {
+			if err := RsyncCoordinatorAndPrimaries(st.Context(), stream, s.agentConns, s.Concurrency.Hosts, s.Source, s.Concurrency.Rsync, settings, stats); err != nil {
+				return err
+			}
+
+			return RsyncCoordinatorAndPrimariesTablespaces(st.Context(), stream, s.agentConns, s.Concurrency.Hosts, s.Source, s.Concurrency.Rsync, settings, stats)
+		})
 	})
 
 	primariesUpgraded, err := step.HasRun(idl.Step_execute, idl.Substep_upgrade_primaries)
@@ -128,18 +148,27 @@ Cannot revert and restore the source cluster. Please contact support.`)
 			return err
 		}
 
+		if err := snapshot.ArchiveSource(logDir); err != nil {
+			return err
+		}
+
 		logArchiveDir = GetLogArchiveDir(logDir, s.UpgradeID, time.Now())
-		return ArchiveLogDirectories(logDir, logArchiveDir, s.agentConns, s.Config.Source.CoordinatorHostname())
+		return ArchiveLogDirectories(st.Context(), logDir, logArchiveDir, s.agentConns, s.Concurrency.Hosts, s.Config.Source.CoordinatorHostname())
 	})
 
 	st.RunConditionally(idl.Substep_delete_backupdir, configCreated, func(streams step.OutStreams) error {
-		return DeleteBackupDirectories(streams, s.agentConns, s.BackupDirs)
+		return DeleteBackupDirectories(st.Context(), streams, s.agentConns, s.Concurrency.Hosts, s.BackupDirs)
 	})
 
 	st.AlwaysRun(idl.Substep_delete_segment_statedirs, func(_ step.OutStreams) error {
-		return DeleteStateDirectories(s.agentConns, s.Source.CoordinatorHostname())
+		return DeleteStateDirectories(st.Context(), s.agentConns, s.Concurrency.Hosts, s.Source.CoordinatorHostname())
 	})
 
+	upgradeReport, err := s.WriteUpgradeReport(idl.Step_revert, logArchiveDir, req.GetHtmlReport())
+	if err != nil {
+		return err
+	}
+
 	encodedSource, err := s.Source.Encode()
 	if err != nil {
 		return err
@@ -149,10 +178,11 @@ Cannot revert and restore the source cluster. Please contact support.`)
 		RevertResponse: &idl.RevertResponse{
 			Source:              encodedSource,
 			LogArchiveDirectory: logArchiveDir,
+			Report:              upgradeReport,
 		},
 	}}}}
 
-	if err := stream.Send(message); err != nil {
+	if err := s.sendResponse(st, stream, message); err != nil {
 		return xerrors.Errorf("sending response message: %w", err)
 	}
 
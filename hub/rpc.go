@@ -4,32 +4,47 @@
 package hub
 
 import (
-	"sync"
+	"context"
+	"strconv"
 
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/trace"
+	"github.com/greenplum-db/gpupgrade/utils/workerpool"
 )
 
-func ExecuteRPC(agentConns []*idl.Connection, executeRequest func(conn *idl.Connection) error) error {
-	var wg sync.WaitGroup
+// ExecuteRPC sends the request to at most hostConcurrency hosts at once. Zero
+// is unlimited. The fan-out is traced as a child of the span in the context,
+// and each request is given the context of its host's span.
+func ExecuteRPC(ctx context.Context, agentConns []*idl.Connection, hostConcurrency uint, executeRequest func(ctx context.Context, conn *idl.Connection) error) (err error) {
+	ctx, span := trace.Start(ctx, "ExecuteRPC", "hosts", strconv.Itoa(len(agentConns)))
+	defer func() { span.End(err) }()
+
+	return forEachHost(agentConns, hostConcurrency, func(conn *idl.Connection) error {
+		ctx, hostSpan := trace.Start(ctx, "ExecuteRPC "+conn.Hostname, "host", conn.Hostname)
+		err := executeRequest(ctx, conn)
+		hostSpan.End(err)
+		return err
+	})
+}
+
+// forEachHost calls f for each host at most hostConcurrency at once without
+// tracing them such as for heartbeats.
+func forEachHost(agentConns []*idl.Connection, hostConcurrency uint, f func(conn *idl.Connection) error) (err error) {
+	pool := workerpool.New(int(hostConcurrency))
 	errs := make(chan error, len(agentConns))
 
 	for _, conn := range agentConns {
 		conn := conn
 
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-
-			err := executeRequest(conn)
-			errs <- err
-		}()
+		pool.Go(func() {
+			errs <- f(conn)
+		})
 	}
 
-	wg.Wait()
+	pool.Wait()
 	close(errs)
 
-	var err error
 	for e := range errs {
 		err = errorlist.Append(err, e)
 	}
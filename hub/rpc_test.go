@@ -4,13 +4,16 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"sort"
+	"sync/atomic"
 	"testing"
 
 	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils/trace"
 )
 
 func TestExecuteRPC(t *testing.T) {
@@ -21,12 +24,12 @@ func TestExecuteRPC(t *testing.T) {
 		}
 
 		hosts := make(chan string, len(agentConns))
-		request := func(conn *idl.Connection) error {
+		request := func(_ context.Context, conn *idl.Connection) error {
 			hosts <- conn.Hostname
 			return nil
 		}
 
-		err := hub.ExecuteRPC(agentConns, request)
+		err := hub.ExecuteRPC(context.Background(), agentConns, 0, request)
 		if err != nil {
 			t.Errorf("ExecuteRPC returned error %+v", err)
 		}
@@ -52,7 +55,7 @@ func TestExecuteRPC(t *testing.T) {
 		}
 
 		expected := errors.New("permission denied")
-		request := func(conn *idl.Connection) error {
+		request := func(_ context.Context, conn *idl.Connection) error {
 			if conn.Hostname == "mdw" {
 				return expected
 			}
@@ -60,10 +63,71 @@ func TestExecuteRPC(t *testing.T) {
 			return nil
 		}
 
-		err := hub.ExecuteRPC(agentConns, request)
+		err := hub.ExecuteRPC(context.Background(), agentConns, 0, request)
 
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
 	})
+	t.Run("limits the number of hosts requests are sent to at once", func(t *testing.T) {
+		agentConns := []*idl.Connection{
+			{Hostname: "sdw1"},
+			{Hostname: "sdw2"},
+			{Hostname: "sdw3"},
+		}
+
+		var running, maxRunning int32
+		request := func(_ context.Context, conn *idl.Connection) error {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+
+			if n > atomic.LoadInt32(&maxRunning) {
+				atomic.StoreInt32(&maxRunning, n)
+			}
+
+			return nil
+		}
+
+		err := hub.ExecuteRPC(context.Background(), agentConns, 1, request)
+		if err != nil {
+			t.Errorf("ExecuteRPC returned error %+v", err)
+		}
+
+		if maxRunning != 1 {
+			t.Errorf("got %d requests at once want 1", maxRunning)
+		}
+	})
+
+	t.Run("traces the requests as children of the span in the context", func(t *testing.T) {
+		agentConns := []*idl.Connection{
+			{Hostname: "mdw"},
+			{Hostname: "sdw"},
+		}
+
+		ctx, parent := trace.Start(context.Background(), "substep")
+		defer parent.End(nil)
+
+		spans := make(chan *trace.Span, len(agentConns))
+		request := func(ctx context.Context, conn *idl.Connection) error {
+			spans <- trace.FromContext(ctx)
+			return nil
+		}
+
+		err := hub.ExecuteRPC(ctx, agentConns, 0, request)
+		if err != nil {
+			t.Errorf("ExecuteRPC returned error %+v", err)
+		}
+
+		close(spans)
+
+		for span := range spans {
+			if span == nil || span.TraceID != parent.TraceID || span.ParentID == parent.SpanID {
+				t.Errorf("got span %+v want a host span under the fan-out span of %q", span, parent.SpanID)
+			}
+		}
+
+		if trace.Current() != nil {
+			t.Errorf("expected ExecuteRPC to not set the current span")
+		}
+	})
 }
@@ -0,0 +1,25 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"log"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+// recordRsyncStats persists the aggregated rsync stats of the substep such
+// that they are shown in the status and upgrade report. The stats are
+// informational so failing to record them does not fail the substep.
+func recordRsyncStats(st idl.Step, substep idl.Substep, stats *rsync.Collector) {
+	store, err := rsync.NewStatsFileStore()
+	if err == nil {
+		err = store.Write(st, substep, stats.Stats())
+	}
+
+	if err != nil {
+		log.Printf("recording rsync stats for %s %s: %v", st, substep, err)
+	}
+}
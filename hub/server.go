@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -18,7 +19,6 @@ import (
 	"golang.org/x/xerrors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/greenplum-db/gpupgrade/config"
@@ -28,6 +28,10 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils/daemon"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 	"github.com/greenplum-db/gpupgrade/utils/logger"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+	"github.com/greenplum-db/gpupgrade/utils/trace"
 )
 
 var DialTimeout = 3 * time.Second
@@ -38,10 +42,16 @@ var ErrHubStopped = errors.New("hub is stopped")
 type Server struct {
 	*config.Config
 
-	agentConns []*idl.Connection
-	mutex      sync.Mutex
+	agentConns   []*idl.Connection
+	agentMonitor *AgentMonitor
+	mutex        sync.Mutex
+	connsMutex   sync.Mutex // guards agentConns for readers that must not wait on dialing
+
+	stepMutex  sync.Mutex
+	running    *runningStep // the step being run which can be cancelled
 	gRPCserver *grpc.Server
 	listener   net.Listener
+	metrics    *http.Server // serves /metrics when a metrics port is set
 
 	// This is used both as a channel to communicate from Start() to
 	// Stop() to indicate to Stop() that it can finally terminate
@@ -55,13 +65,19 @@ type Server struct {
 
 func New(conf *config.Config) *Server {
 	return &Server{
-		Config:  conf,
-		stopped: make(chan struct{}, 1),
+		Config:       conf,
+		agentMonitor: NewAgentMonitor(conf.Concurrency.Hosts),
+		stopped:      make(chan struct{}, 1),
 	}
 }
 
 func (s *Server) Start(port int, daemonize bool) error {
-	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	creds, err := s.TLS.ServerCredentials()
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.HubListenAddress, strconv.Itoa(port)))
 	if err != nil {
 		return fmt.Errorf("listen on port %d: %w", port, err)
 	}
@@ -70,16 +86,29 @@ func (s *Server) Start(port int, daemonize bool) error {
 		defer logger.WritePanics()
 		return handler(ctx, req)
 	}
-	gRPCserver := grpc.NewServer(grpc.UnaryInterceptor(interceptor))
+	gRPCserver := grpc.NewServer(grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(interceptor, trace.UnaryServerInterceptor),
+		grpc.StreamInterceptor(trace.StreamServerInterceptor))
+
+	metrics, err := s.startMetrics()
+	if err != nil {
+		return err
+	}
 
 	s.mutex.Lock()
 	if s.stopped == nil {
 		// Stop() has already been called; return without serving.
 		s.mutex.Unlock()
+		if metrics != nil {
+			if err := metrics.Close(); err != nil {
+				log.Printf("stop metrics: %v", err)
+			}
+		}
 		return ErrHubStopped
 	}
 	s.gRPCserver = gRPCserver
 	s.listener = listener
+	s.metrics = metrics
 	s.mutex.Unlock()
 
 	idl.RegisterCliToHubServer(gRPCserver, s)
@@ -112,8 +141,8 @@ func (s *Server) StopServices(ctx context.Context, in *idl.StopServicesRequest)
 
 // TODO: Add unit tests which is currently tricky due to h.AgentConns() mutating global state
 func (s *Server) StopAgents() error {
-	request := func(conn *idl.Connection) error {
-		_, err := conn.AgentClient.StopAgent(context.Background(), &idl.StopAgentRequest{})
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		_, err := conn.AgentClient.StopAgent(ctx, &idl.StopAgentRequest{})
 		if err == nil { // no error means the agent did not terminate as expected
 			return xerrors.Errorf("failed to stop agent on host: %s", conn.Hostname)
 		}
@@ -135,7 +164,7 @@ func (s *Server) StopAgents() error {
 	if err != nil {
 		return err
 	}
-	return ExecuteRPC(s.agentConns, request)
+	return ExecuteRPC(context.Background(), s.agentConns, s.Concurrency.Hosts, request)
 }
 
 func (s *Server) Stop(closeAgentConns bool) {
@@ -147,6 +176,12 @@ func (s *Server) Stop(closeAgentConns bool) {
 		s.closeAgentConns()
 	}
 
+	if s.metrics != nil {
+		if err := s.metrics.Close(); err != nil {
+			log.Printf("stop metrics: %v", err)
+		}
+	}
+
 	if s.gRPCserver != nil {
 		s.gRPCserver.Stop()
 		<-s.stopped // block until it is OK to stop
@@ -158,7 +193,7 @@ func (s *Server) Stop(closeAgentConns bool) {
 }
 
 func (s *Server) RestartAgents(ctx context.Context, in *idl.RestartAgentsRequest) (*idl.RestartAgentsReply, error) {
-	restartedHosts, err := RestartAgents(ctx, nil, AgentHosts(s.Source), s.AgentPort, utils.GetStateDir())
+	restartedHosts, err := RestartAgents(ctx, nil, s.executor(), AgentHosts(s.Source), s.AgentPort, s.AgentListenOnHostname, s.TLS, s.LogFormat, utils.GetStateDir())
 	if err != nil {
 		return &idl.RestartAgentsReply{}, err
 	}
@@ -171,12 +206,24 @@ func (s *Server) RestartAgents(ctx context.Context, in *idl.RestartAgentsRequest
 	return &idl.RestartAgentsReply{AgentHosts: restartedHosts}, err
 }
 
+// RestartAgents starts agents on the hosts that cannot be connected to. When
+// listenOnHostname is set each agent only listens on the address its hostname
+// resolves to.
 func RestartAgents(ctx context.Context,
 	dialer func(context.Context, string) (net.Conn, error),
+	executor remote.Executor,
 	hostnames []string,
 	port int,
+	listenOnHostname bool,
+	tlsConfig mtls.Config,
+	logFormat string,
 	stateDir string) ([]string, error) {
 
+	creds, err := tlsConfig.ClientCredentials()
+	if err != nil {
+		return nil, err
+	}
+
 	var wg sync.WaitGroup
 	restartedHosts := make(chan string, len(hostnames))
 	errs := make(chan error, len(hostnames))
@@ -190,7 +237,7 @@ func RestartAgents(ctx context.Context,
 			timeoutCtx, cancelFunc := context.WithTimeout(ctx, 3*time.Second)
 			opts := []grpc.DialOption{
 				grpc.WithBlock(),
-				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithTransportCredentials(creds),
 				grpc.FailOnNonTempDialError(true),
 			}
 			if dialer != nil {
@@ -214,15 +261,22 @@ func RestartAgents(ctx context.Context,
 				errs <- err
 				return
 			}
-			cmd := ExecCommand("ssh", host,
-				fmt.Sprintf("bash -c \"%s agent --daemonize --port %d --state-directory %s\"", path, port, stateDir))
-			stdout, err := cmd.Output()
+
+			args := []string{"agent", "--daemonize", "--port", strconv.Itoa(port), "--state-directory", stateDir}
+			if listenOnHostname {
+				args = append(args, "--listen-address", host)
+			}
+			args = append(args, tlsConfig.Args()...)
+			if logFormat != "" {
+				args = append(args, "--log-format", logFormat)
+			}
+
+			_, err = executor.Output(host, path, args...)
 			if err != nil {
 				errs <- err
 				return
 			}
 
-			log.Print(string(stdout))
 			restartedHosts <- host
 		}(host)
 	}
@@ -236,7 +290,6 @@ func RestartAgents(ctx context.Context,
 		hosts = append(hosts, h)
 	}
 
-	var err error
 	for e := range errs {
 		err = errorlist.Append(err, e)
 	}
@@ -272,27 +325,63 @@ func (s *Server) AgentConns() ([]*idl.Connection, error) {
 		return s.agentConns, nil
 	}
 
+	creds, err := s.TLS.ClientCredentials()
+	if err != nil {
+		return nil, xerrors.Errorf("agent connections: %w", err)
+	}
+
 	hostnames := AgentHosts(s.Source)
 	for _, host := range hostnames {
 		ctx, cancelFunc := context.WithTimeout(context.Background(), DialTimeout)
 		conn, err := gRPCDialer(ctx,
 			host+":"+strconv.Itoa(s.AgentPort),
-			grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+			grpc.WithTransportCredentials(creds), grpc.WithBlock(),
+			grpc.WithChainUnaryInterceptor(logger.UnaryClientInterceptor, trace.UnaryClientInterceptor, s.stepUnaryClientInterceptor, s.agentMonitor.UnaryClientInterceptor(host)),
+			grpc.WithChainStreamInterceptor(logger.StreamClientInterceptor, trace.StreamClientInterceptor, s.stepStreamClientInterceptor, s.agentMonitor.StreamClientInterceptor(host)))
 		if err != nil {
 			cancelFunc()
 			return nil, xerrors.Errorf("agent connections: %w", err)
 		}
+		s.connsMutex.Lock()
 		s.agentConns = append(s.agentConns, &idl.Connection{
 			Conn:          conn,
 			AgentClient:   idl.NewAgentClient(conn),
 			Hostname:      host,
 			CancelContext: cancelFunc,
 		})
+		s.connsMutex.Unlock()
 	}
 
 	return s.agentConns, nil
 }
 
+// agentConnsSnapshot returns the agent connections made so far without
+// waiting for AgentConns to finish dialing, such as for metrics.
+func (s *Server) agentConnsSnapshot() []*idl.Connection {
+	s.connsMutex.Lock()
+	defer s.connsMutex.Unlock()
+
+	return append([]*idl.Connection(nil), s.agentConns...)
+}
+
+// executor returns the configured remote executor used to run commands on the
+// hosts.
+func (s *Server) executor() remote.Executor {
+	return remote.New(s.RemoteExecutor, s.AgentConns, s.AgentPort, s.TLS)
+}
+
+// rsyncSettings returns the settings with the remote shell of the remote
+// executor such that rsync reaches the hosts the same way commands are run.
+func (s *Server) rsyncSettings(settings rsync.Settings) (rsync.Settings, error) {
+	shell, err := s.executor().Shell()
+	if err != nil {
+		return rsync.Settings{}, err
+	}
+
+	settings.RemoteShell = rsync.RemoteShell(shell)
+	return settings, nil
+}
+
 type AgentsGrpcStatus map[string]connectivity.State
 
 func (a AgentsGrpcStatus) String() string {
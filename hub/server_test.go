@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
 	"reflect"
 	"sort"
 	"strings"
@@ -26,6 +27,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/testutils/mock_agent"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
 )
 
 const timeout = 1 * time.Second
@@ -96,6 +98,28 @@ func TestHubStart(t *testing.T) {
 		}
 	})
 
+	t.Run("start returns an error when the tls configuration cannot be loaded", func(t *testing.T) {
+		tlsConf := *conf
+		tlsConf.TLS = mtls.Config{CertFile: "/does/not/exist", KeyFile: "/does/not/exist", CAFile: "/does/not/exist"}
+		hubServer := hub.New(&tlsConf)
+
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- hubServer.Start(testutils.MustGetPort(t), false)
+		}()
+
+		select {
+		case err := <-errChan:
+			var pathErr *os.PathError
+			if !errors.As(err, &pathErr) {
+				t.Errorf("got error %#v want type %T", err, pathErr)
+			}
+		case <-time.After(timeout):
+			t.Error("timeout exceeded")
+			hubServer.Stop(false)
+		}
+	})
+
 	// This is inherently testing a race. It will give false successes instead
 	// of false failures, so DO NOT ignore transient failures in this test!
 	t.Run("will return from Start() if Stop is called concurrently", func(t *testing.T) {
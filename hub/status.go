@@ -0,0 +1,15 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgradestatus"
+)
+
+func (s *Server) GetStatus(ctx context.Context, req *idl.GetStatusRequest) (*idl.GetStatusReply, error) {
+	return upgradestatus.Read()
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestGetStatus(t *testing.T) {
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	stepStore, err := step.NewStepFileStore()
+	if err != nil {
+		t.Fatalf("NewStepFileStore: %v", err)
+	}
+
+	substepStore, err := step.NewSubstepFileStore()
+	if err != nil {
+		t.Fatalf("NewSubstepFileStore: %v", err)
+	}
+
+	for _, s := range []idl.Step{idl.Step_initialize, idl.Step_execute} {
+		if err := stepStore.Write(s, idl.Status_complete); err != nil {
+			t.Fatalf("writing step status: %v", err)
+		}
+	}
+
+	// written out of order to check that the hub reports them as run
+	for _, substep := range []idl.Substep{idl.Substep_upgrade_primaries, idl.Substep_upgrade_master} {
+		if err := substepStore.Write(idl.Step_execute, substep, idl.Status_complete); err != nil {
+			t.Fatalf("writing substep status: %v", err)
+		}
+	}
+
+	reply, err := hub.New(&config.Config{}).GetStatus(context.Background(), &idl.GetStatusRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	if reply.GetNextStep() != idl.Step_finalize {
+		t.Errorf("got next step %s want %s", reply.GetNextStep(), idl.Step_finalize)
+	}
+
+	var steps []idl.Step
+	var substeps []idl.Substep
+	for _, status := range reply.GetSteps() {
+		steps = append(steps, status.GetStep())
+		if status.GetStep() != idl.Step_execute {
+			continue
+		}
+
+		for _, details := range status.GetSubsteps() {
+			substeps = append(substeps, details.GetSubstep())
+		}
+	}
+
+	expectedSteps := []idl.Step{idl.Step_initialize, idl.Step_execute}
+	if !reflect.DeepEqual(steps, expectedSteps) {
+		t.Errorf("got steps %v want %v", steps, expectedSteps)
+	}
+
+	expectedSubsteps := []idl.Substep{idl.Substep_upgrade_master, idl.Substep_upgrade_primaries}
+	if !reflect.DeepEqual(substeps, expectedSubsteps) {
+		t.Errorf("got substeps %v want %v", substeps, expectedSubsteps)
+	}
+}
@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os/exec"
@@ -102,7 +103,7 @@ func TestUpdatePostgresqlConfOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.UpdatePostgresqlConfOnSegments(agentConns, intermediate, target)
+		err := hub.UpdatePostgresqlConfOnSegments(context.Background(), agentConns, 0, intermediate, target)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -143,7 +144,7 @@ func TestUpdatePostgresqlConfOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.UpdatePostgresqlConfOnSegments(agentConns, intermediate, target)
+		err := hub.UpdatePostgresqlConfOnSegments(context.Background(), agentConns, 0, intermediate, target)
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Fatalf("error %#v does not contain type %T", err, errs)
@@ -249,7 +250,7 @@ func TestUpdateRecoveryConfOnSegments(t *testing.T) {
 				{AgentClient: sdw2, Hostname: "sdw2"},
 			}
 
-			err := hub.UpdateRecoveryConfOnSegments(agentConns, c.version, intermediate, target)
+			err := hub.UpdateRecoveryConfOnSegments(context.Background(), agentConns, 0, c.version, intermediate, target)
 			if err != nil {
 				t.Errorf("unexpected err %#v", err)
 			}
@@ -291,7 +292,7 @@ func TestUpdateRecoveryConfOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.UpdateRecoveryConfOnSegments(agentConns, semver.MustParse("6.0.0"), intermediate, target)
+		err := hub.UpdateRecoveryConfOnSegments(context.Background(), agentConns, 0, semver.MustParse("6.0.0"), intermediate, target)
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Fatalf("error %#v does not contain type %T", err, errs)
@@ -357,7 +358,7 @@ func TestUpdateInternalAutoConfOnMirrors(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.UpdateInternalAutoConfOnMirrors(agentConns, intermediate)
+		err := hub.UpdateInternalAutoConfOnMirrors(context.Background(), agentConns, 0, intermediate)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -392,7 +393,7 @@ func TestUpdateInternalAutoConfOnMirrors(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.UpdateInternalAutoConfOnMirrors(agentConns, intermediate)
+		err := hub.UpdateInternalAutoConfOnMirrors(context.Background(), agentConns, 0, intermediate)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
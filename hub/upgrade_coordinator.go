@@ -4,6 +4,7 @@
 package hub
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -23,42 +24,15 @@ import (
 // format of yyyyMMddTHHmmss
 const TimeStringFormat = "20060102T150405"
 
-func UpgradeCoordinator(streams step.OutStreams, backupDir string, pgUpgradeVerbose bool, skipPgUpgradeChecks bool, pgUpgradeJobs uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, action idl.PgOptions_Action, mode idl.Mode, pgUpgradeTimestamp string) error {
-	oldOptions := ""
-	// When upgrading from 5 the coordinator must be provided with its standby's dbid to allow WAL to sync.
-	if source.Version.Major == 5 && source.HasStandby() {
-		oldOptions = fmt.Sprintf("-x %d", source.Standby().DbID)
-	}
+func UpgradeCoordinator(ctx context.Context, streams step.OutStreams, backupDir string, pgUpgradeVerbose bool, skipPgUpgradeChecks bool, pgUpgradeJobs uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, action idl.PgOptions_Action, mode idl.Mode, pgUpgradeTimestamp string) error {
+	opts := CoordinatorPgOptions(backupDir, pgUpgradeVerbose, skipPgUpgradeChecks, pgUpgradeJobs, source, intermediate, action, mode, pgUpgradeTimestamp)
 
-	opts := &idl.PgOptions{
-		BackupDir:           backupDir,
-		PgUpgradeVerbose:    pgUpgradeVerbose,
-		SkipPgUpgradeChecks: skipPgUpgradeChecks,
-		PgUpgradeJobs:       strconv.FormatUint(uint64(pgUpgradeJobs), 10),
-		Action:              action,
-		Role:                intermediate.Coordinator().Role,
-		ContentID:           int32(intermediate.Coordinator().ContentID),
-		PgUpgradeMode:       idl.PgOptions_dispatcher,
-		OldOptions:          oldOptions,
-		Mode:                mode,
-		TargetVersion:       intermediate.Version.String(),
-		OldBinDir:           filepath.Join(source.GPHome, "bin"),
-		OldDataDir:          source.CoordinatorDataDir(),
-		OldPort:             strconv.Itoa(source.CoordinatorPort()),
-		OldDBID:             strconv.Itoa(source.Coordinator().DbID),
-		NewBinDir:           filepath.Join(intermediate.GPHome, "bin"),
-		NewDataDir:          intermediate.CoordinatorDataDir(),
-		NewPort:             strconv.Itoa(intermediate.CoordinatorPort()),
-		NewDBID:             strconv.Itoa(intermediate.Coordinator().DbID),
-		PgUpgradeTimestamp:  pgUpgradeTimestamp,
-	}
-
-	err := RsyncCoordinatorDataDir(streams, utils.GetCoordinatorPreUpgradeBackupDir(backupDir), intermediate.CoordinatorDataDir())
+	err := RsyncCoordinatorDataDir(ctx, streams, utils.GetCoordinatorPreUpgradeBackupDir(backupDir), intermediate.CoordinatorDataDir())
 	if err != nil {
 		return err
 	}
 
-	err = upgrade.Run(streams.Stdout(), streams.Stderr(), opts)
+	err = upgrade.Run(ctx, streams.Stdout(), streams.Stderr(), opts)
 	if err != nil {
 		if opts.Action != idl.PgOptions_check {
 			return xerrors.Errorf("%s master: %v", action, err)
@@ -93,16 +67,43 @@ PGPORT=%d`, pgUpgradeDir,
 	return nil
 }
 
-func RsyncCoordinatorDataDir(stream step.OutStreams, sourceDir, targetDir string) error {
-	sourceDirRsync := filepath.Clean(sourceDir) + string(os.PathSeparator)
+// CoordinatorPgOptions returns the pg_upgrade options used to upgrade the
+// coordinator.
+func CoordinatorPgOptions(backupDir string, pgUpgradeVerbose bool, skipPgUpgradeChecks bool, pgUpgradeJobs uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, action idl.PgOptions_Action, mode idl.Mode, pgUpgradeTimestamp string) *idl.PgOptions {
+	oldOptions := ""
+	// When upgrading from 5 the coordinator must be provided with its standby's dbid to allow WAL to sync.
+	if source.Version.Major == 5 && source.HasStandby() {
+		oldOptions = fmt.Sprintf("-x %d", source.Standby().DbID)
+	}
 
-	options := []rsync.Option{
-		rsync.WithSources(sourceDirRsync),
-		rsync.WithDestination(targetDir),
-		rsync.WithOptions("--archive", "--delete"),
-		rsync.WithExcludedFiles("pg_log/*"),
-		rsync.WithStream(stream),
+	return &idl.PgOptions{
+		BackupDir:           backupDir,
+		PgUpgradeVerbose:    pgUpgradeVerbose,
+		SkipPgUpgradeChecks: skipPgUpgradeChecks,
+		PgUpgradeJobs:       strconv.FormatUint(uint64(pgUpgradeJobs), 10),
+		Action:              action,
+		Role:                intermediate.Coordinator().Role,
+		ContentID:           int32(intermediate.Coordinator().ContentID),
+		PgUpgradeMode:       idl.PgOptions_dispatcher,
+		OldOptions:          oldOptions,
+		Mode:                mode,
+		TargetVersion:       intermediate.Version.String(),
+		OldBinDir:           filepath.Join(source.GPHome, "bin"),
+		OldDataDir:          source.CoordinatorDataDir(),
+		OldPort:             strconv.Itoa(source.CoordinatorPort()),
+		OldDBID:             strconv.Itoa(source.Coordinator().DbID),
+		NewBinDir:           filepath.Join(intermediate.GPHome, "bin"),
+		NewDataDir:          intermediate.CoordinatorDataDir(),
+		NewPort:             strconv.Itoa(intermediate.CoordinatorPort()),
+		NewDBID:             strconv.Itoa(intermediate.Coordinator().DbID),
+		PgUpgradeTimestamp:  pgUpgradeTimestamp,
 	}
+}
+
+func RsyncCoordinatorDataDir(ctx context.Context, stream step.OutStreams, sourceDir, targetDir string) error {
+	sourceDirRsync := filepath.Clean(sourceDir) + string(os.PathSeparator)
+
+	options := append(rsyncCoordinatorDataDirOptions(sourceDir, targetDir), rsync.WithStream(stream), rsync.WithContext(ctx))
 
 	err := rsync.Rsync(options...)
 	if err != nil {
@@ -111,3 +112,12 @@ func RsyncCoordinatorDataDir(stream step.OutStreams, sourceDir, targetDir string
 
 	return nil
 }
+
+func rsyncCoordinatorDataDirOptions(sourceDir, targetDir string) []rsync.Option {
+	return []rsync.Option{
+		rsync.WithSources(filepath.Clean(sourceDir) + string(os.PathSeparator)),
+		rsync.WithDestination(targetDir),
+		rsync.WithOptions("--archive", "--delete"),
+		rsync.WithExcludedFiles("pg_log/*"),
+	}
+}
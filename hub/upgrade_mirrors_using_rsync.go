@@ -14,9 +14,10 @@ import (
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
 )
 
-func UpgradeMirrorsUsingRsync(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, useHbaHostnames bool) error {
+func UpgradeMirrorsUsingRsync(ctx context.Context, agentConns []*idl.Connection, hostConcurrency uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, useHbaHostnames bool, rsyncConcurrency uint, settings rsync.Settings, stats *rsync.Collector) error {
 	db, err := sql.Open("pgx", intermediate.Connection())
 	if err != nil {
 		return err
@@ -35,27 +36,27 @@ func UpgradeMirrorsUsingRsync(agentConns []*idl.Connection, source *greenplum.Cl
 		return err
 	}
 
-	if err := RsyncMirrorDataDirsOnSegments(agentConns, source, intermediate); err != nil {
+	if err := RsyncMirrorDataDirsOnSegments(ctx, agentConns, hostConcurrency, source, intermediate, rsyncConcurrency, settings, stats); err != nil {
 		return err
 	}
 
-	if err := RsyncMirrorTablespacesOnSegments(agentConns, source, intermediate); err != nil {
+	if err := RsyncMirrorTablespacesOnSegments(ctx, agentConns, hostConcurrency, source, intermediate, rsyncConcurrency, settings, stats); err != nil {
 		return err
 	}
 
-	if err := RenameMirrorTablespacesOnSegments(agentConns, source, intermediate); err != nil {
+	if err := RenameMirrorTablespacesOnSegments(ctx, agentConns, hostConcurrency, source, intermediate); err != nil {
 		return err
 	}
 
-	if err := CreateRecoveryConfOnSegments(agentConns, intermediate); err != nil {
+	if err := CreateRecoveryConfOnSegments(ctx, agentConns, hostConcurrency, intermediate); err != nil {
 		return err
 	}
 
-	if err := AddReplicationEntriesOnPrimaries(agentConns, intermediate, useHbaHostnames); err != nil {
+	if err := AddReplicationEntriesOnPrimaries(ctx, agentConns, hostConcurrency, intermediate, useHbaHostnames); err != nil {
 		return err
 	}
 
-	if err := UpdateInternalAutoConfOnMirrors(agentConns, intermediate); err != nil {
+	if err := UpdateInternalAutoConfOnMirrors(ctx, agentConns, hostConcurrency, intermediate); err != nil {
 		return err
 	}
 
@@ -78,39 +79,44 @@ func UpgradeMirrorsUsingRsync(agentConns []*idl.Connection, source *greenplum.Cl
 	return nil
 }
 
-func RsyncMirrorDataDirsOnSegments(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
-	request := func(conn *idl.Connection) error {
-		sourcePrimaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
-			return seg.IsOnHost(conn.Hostname) && !seg.IsCoordinator() && seg.IsPrimary()
-		})
-
-		var opts []*idl.RsyncRequest_RsyncOptions
-		for _, sourcePrimary := range sourcePrimaries {
-			intermediatePrimary := intermediate.Primaries[sourcePrimary.ContentID]
-			intermediateMirror := intermediate.Mirrors[sourcePrimary.ContentID]
+func RsyncMirrorDataDirsOnSegments(ctx context.Context, agentConns []*idl.Connection, hostConcurrency uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, rsyncConcurrency uint, settings rsync.Settings, stats *rsync.Collector) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		req := &idl.RsyncRequest{Options: rsyncMirrorDataDirsOptions(conn.Hostname, source, intermediate, settings), Concurrency: uint32(rsyncConcurrency)}
+		reply, err := conn.AgentClient.RsyncDataDirectories(ctx, req)
+		stats.Add(rsync.StatsFromProto(reply.GetStats()))
+		return err
+	}
 
-			// On the source primary host rsync to the intermediate mirror host
-			// copy both the source & intermediate primary data directories to the intermediate mirror data directory.
-			opt := &idl.RsyncRequest_RsyncOptions{
-				Sources:         []string{sourcePrimary.DataDir, intermediatePrimary.DataDir},
-				Destination:     filepath.Dir(intermediateMirror.DataDir), // FIXME: Do we really want filepath.Dir here
-				DestinationHost: intermediateMirror.Hostname,
-				Options:         []string{"--archive", "--delete", "--hard-links", "--size-only", "--no-inc-recursive"},
-			}
+	return ExecuteRPC(ctx, agentConns, hostConcurrency, request)
+}
 
-			opts = append(opts, opt)
+func rsyncMirrorDataDirsOptions(hostname string, source *greenplum.Cluster, intermediate *greenplum.Cluster, settings rsync.Settings) []*idl.RsyncRequest_RsyncOptions {
+	sourcePrimaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
+		return seg.IsOnHost(hostname) && !seg.IsCoordinator() && seg.IsPrimary()
+	})
+
+	var opts []*idl.RsyncRequest_RsyncOptions
+	for _, sourcePrimary := range sourcePrimaries {
+		intermediatePrimary := intermediate.Primaries[sourcePrimary.ContentID]
+		intermediateMirror := intermediate.Mirrors[sourcePrimary.ContentID]
+
+		// On the source primary host rsync to the intermediate mirror host
+		// copy both the source & intermediate primary data directories to the intermediate mirror data directory.
+		opt := &idl.RsyncRequest_RsyncOptions{
+			Sources:         []string{sourcePrimary.DataDir, intermediatePrimary.DataDir},
+			Destination:     filepath.Dir(intermediateMirror.DataDir), // FIXME: Do we really want filepath.Dir here
+			DestinationHost: intermediateMirror.Hostname,
+			Options:         []string{"--archive", "--delete", "--hard-links", "--size-only", "--no-inc-recursive"},
 		}
 
-		req := &idl.RsyncRequest{Options: opts}
-		_, err := conn.AgentClient.RsyncDataDirectories(context.Background(), req)
-		return err
+		opts = append(opts, opt)
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return rsync.SetRequestSettings(opts, settings)
 }
 
-func RsyncMirrorTablespacesOnSegments(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
-	request := func(conn *idl.Connection) error {
+func RsyncMirrorTablespacesOnSegments(ctx context.Context, agentConns []*idl.Connection, hostConcurrency uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, rsyncConcurrency uint, settings rsync.Settings, stats *rsync.Collector) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		sourcePrimaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsCoordinator() && seg.IsPrimary()
 		})
@@ -139,15 +145,16 @@ func RsyncMirrorTablespacesOnSegments(agentConns []*idl.Connection, source *gree
 			}
 		}
 
-		_, err := conn.AgentClient.RsyncTablespaceDirectories(context.Background(), &idl.RsyncRequest{Options: opts})
+		reply, err := conn.AgentClient.RsyncTablespaceDirectories(ctx, &idl.RsyncRequest{Options: rsync.SetRequestSettings(opts, settings), Concurrency: uint32(rsyncConcurrency)})
+		stats.Add(rsync.StatsFromProto(reply.GetStats()))
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, hostConcurrency, request)
 }
 
-func RenameMirrorTablespacesOnSegments(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
-	request := func(conn *idl.Connection) error {
+func RenameMirrorTablespacesOnSegments(ctx context.Context, agentConns []*idl.Connection, hostConcurrency uint, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		intermediateMirrors := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
 		})
@@ -177,9 +184,9 @@ func RenameMirrorTablespacesOnSegments(agentConns []*idl.Connection, source *gre
 			}
 		}
 
-		_, err := conn.AgentClient.RenameTablespaces(context.Background(), &idl.RenameTablespacesRequest{RenamePairs: pairs})
+		_, err := conn.AgentClient.RenameTablespaces(ctx, &idl.RenameTablespacesRequest{RenamePairs: pairs})
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, hostConcurrency, request)
 }
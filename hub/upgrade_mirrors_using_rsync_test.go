@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
 	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
 )
 
 func TestRsyncMirrorDataDirsOnSegments(t *testing.T) {
@@ -73,12 +75,51 @@ func TestRsyncMirrorDataDirsOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.RsyncMirrorDataDirsOnSegments(agentConns, intermediate, source)
+		err := hub.RsyncMirrorDataDirsOnSegments(context.Background(), agentConns, 0, intermediate, source, 0, rsync.Settings{}, nil)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
 	})
 
+	t.Run("sends the rsync settings and collects the stats", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().RsyncDataDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options: []*idl.RsyncRequest_RsyncOptions{
+					{
+						Sources:         []string{"/data/dbfast1/seg.HqtFHX54y0o.1", "/data/dbfast1/seg1"},
+						Destination:     "/data/dbfast_mirror1",
+						DestinationHost: "sdw2",
+						Options:         []string{"--archive", "--delete", "--hard-links", "--size-only", "--no-inc-recursive"},
+						BandwidthLimit:  10000,
+						Partial:         true,
+						Verify:          true,
+					}},
+				Concurrency: 2,
+			},
+		).Return(&idl.RsyncReply{Stats: &idl.RsyncStats{FilesTransferred: 10, BytesSent: 2048}}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		settings := rsync.Settings{BandwidthLimit: 10000, Partial: true, Verify: true}
+		stats := &rsync.Collector{}
+		err := hub.RsyncMirrorDataDirsOnSegments(context.Background(), agentConns, 0, intermediate, source, 2, settings, stats)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		expected := rsync.Stats{FilesTransferred: 10, BytesSent: 2048}
+		if stats.Stats() != expected {
+			t.Errorf("got stats %+v want %+v", stats.Stats(), expected)
+		}
+	})
+
 	t.Run("returns errors when failing on segments", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -101,7 +142,7 @@ func TestRsyncMirrorDataDirsOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.RsyncMirrorDataDirsOnSegments(agentConns, intermediate, source)
+		err := hub.RsyncMirrorDataDirsOnSegments(context.Background(), agentConns, 0, intermediate, source, 0, rsync.Settings{}, nil)
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Fatalf("error %#v does not contain type %T", err, errs)
@@ -176,7 +217,7 @@ func TestRsyncAndRenameMirrorTablespacesOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.RsyncMirrorTablespacesOnSegments(agentConns, source, intermediate)
+		err := hub.RsyncMirrorTablespacesOnSegments(context.Background(), agentConns, 0, source, intermediate, 0, rsync.Settings{}, nil)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -212,7 +253,7 @@ func TestRsyncAndRenameMirrorTablespacesOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.RsyncMirrorTablespacesOnSegments(agentConns, source, intermediate)
+		err := hub.RsyncMirrorTablespacesOnSegments(context.Background(), agentConns, 0, source, intermediate, 0, rsync.Settings{}, nil)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
@@ -259,7 +300,7 @@ func TestRenameMirrorTablespacesOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.RenameMirrorTablespacesOnSegments(agentConns, source, intermediate)
+		err := hub.RenameMirrorTablespacesOnSegments(context.Background(), agentConns, 0, source, intermediate)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -286,7 +327,7 @@ func TestRenameMirrorTablespacesOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.RenameMirrorTablespacesOnSegments(agentConns, source, intermediate)
+		err := hub.RenameMirrorTablespacesOnSegments(context.Background(), agentConns, 0, source, intermediate)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
@@ -5,59 +5,215 @@ package hub
 
 import (
 	"context"
+	"errors"
+	"io"
+	"log"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/config/backupdir"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-func UpgradePrimaries(agentConns []*idl.Connection, agentHostToBackupDir backupdir.AgentHostsToBackupDir, pgUpgradeVerbose bool, skipPgUpgradeChecks bool, pgUpgradeJobs uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, action idl.PgOptions_Action, mode idl.Mode, pgUpgradeTimestamp string) error {
-	request := func(conn *idl.Connection) error {
-		intermediatePrimaries := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
-			return seg.IsOnHost(conn.Hostname) && seg.IsPrimary() && !seg.IsCoordinator()
+// UpgradePrimaries upgrades the primaries on each host in parallel. When a
+// segment store is given the status of each segment is persisted such that
+// re-running only upgrades the segments that did not complete.
+func UpgradePrimaries(ctx context.Context, streams step.OutStreams, agentConns []*idl.Connection, hostConcurrency uint, segments *step.SegmentFileStore, agentHostToBackupDir backupdir.AgentHostsToBackupDir, pgUpgradeVerbose bool, skipPgUpgradeChecks bool, pgUpgradeJobs uint, pgUpgradeConcurrency uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, action idl.PgOptions_Action, mode idl.Mode, pgUpgradeTimestamp string) error {
+	tracker, err := newSegmentTracker(segments, action)
+	if err != nil {
+		return err
+	}
+
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		opts := PrimariesPgOptions(conn.Hostname, agentHostToBackupDir, pgUpgradeVerbose, skipPgUpgradeChecks, pgUpgradeJobs, source, intermediate, action, mode, pgUpgradeTimestamp)
+		opts = tracker.excludeCompleted(conn.Hostname, opts)
+		if len(opts) == 0 {
+			return nil
+		}
+
+		err := tracker.start(conn.Hostname, opts)
+		if err != nil {
+			return err
+		}
+
+		req := &idl.UpgradePrimariesRequest{Action: action, Opts: opts, Concurrency: uint32(pgUpgradeConcurrency)}
+		stream, err := conn.AgentClient.UpgradePrimaries(ctx, req)
+		if err != nil {
+			return errorlist.Append(xerrors.Errorf("%s primary segment on host %s: %w", action, conn.Hostname, err), tracker.fail(conn.Hostname, opts))
+		}
+
+		err = receiveUpgradePrimaries(streams, stream, func(progress *idl.SegmentProgress) error {
+			return tracker.progress(conn.Hostname, progress)
 		})
+		if err != nil {
+			return errorlist.Append(xerrors.Errorf("%s primary segment on host %s: %w", action, conn.Hostname, err), tracker.fail(conn.Hostname, opts))
+		}
 
-		var opts []*idl.PgOptions
-		for _, intermediatePrimary := range intermediatePrimaries {
-			sourcePrimary := source.Primaries[intermediatePrimary.ContentID]
-
-			opt := &idl.PgOptions{
-				BackupDir:           agentHostToBackupDir[conn.Hostname],
-				PgUpgradeVerbose:    pgUpgradeVerbose,
-				SkipPgUpgradeChecks: skipPgUpgradeChecks,
-				PgUpgradeJobs:       strconv.Itoa(int(pgUpgradeJobs)),
-				Action:              action,
-				Role:                intermediatePrimary.Role,
-				ContentID:           int32(intermediatePrimary.ContentID),
-				PgUpgradeMode:       idl.PgOptions_segment,
-				Mode:                mode,
-				TargetVersion:       intermediate.Version.String(),
-				OldBinDir:           filepath.Join(source.GPHome, "bin"),
-				OldDataDir:          sourcePrimary.DataDir,
-				OldPort:             strconv.Itoa(sourcePrimary.Port),
-				OldDBID:             strconv.Itoa(sourcePrimary.DbID),
-				NewBinDir:           filepath.Join(intermediate.GPHome, "bin"),
-				NewDataDir:          intermediatePrimary.DataDir,
-				NewPort:             strconv.Itoa(intermediatePrimary.Port),
-				NewDBID:             strconv.Itoa(intermediatePrimary.DbID),
-				Tablespaces:         source.Tablespaces[int32(intermediatePrimary.DbID)],
-				PgUpgradeTimestamp:  pgUpgradeTimestamp,
-			}
-			opts = append(opts, opt)
+		return nil
+	}
+
+	return ExecuteRPC(ctx, agentConns, hostConcurrency, request)
+}
+
+// receiveUpgradePrimaries forwards the pg_upgrade output and segment progress
+// streamed from an agent onto the step streams. Each segment progress is also
+// passed to onProgress.
+func receiveUpgradePrimaries(streams step.OutStreams, stream idl.Agent_UpgradePrimariesClient, onProgress func(*idl.SegmentProgress) error) error {
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
 		}
 
-		req := &idl.UpgradePrimariesRequest{Action: action, Opts: opts}
-		_, err := conn.AgentClient.UpgradePrimaries(context.Background(), req)
 		if err != nil {
-			return xerrors.Errorf("%s primary segment on host %s: %w", action, conn.Hostname, err)
+			return err
+		}
+
+		switch x := reply.GetContents().(type) {
+		case *idl.UpgradePrimariesReply_Chunk:
+			writer := streams.Stdout()
+			if x.Chunk.GetType() == idl.Chunk_stderr {
+				writer = streams.Stderr()
+			}
+
+			if _, err := writer.Write(x.Chunk.GetBuffer()); err != nil {
+				return err
+			}
+
+		case *idl.UpgradePrimariesReply_Progress:
+			step.SendProgress(streams, x.Progress)
+
+			if err := onProgress(x.Progress); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// PrimariesPgOptions returns the pg_upgrade options used to upgrade the
+// primaries on the given host.
+func PrimariesPgOptions(hostname string, agentHostToBackupDir backupdir.AgentHostsToBackupDir, pgUpgradeVerbose bool, skipPgUpgradeChecks bool, pgUpgradeJobs uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, action idl.PgOptions_Action, mode idl.Mode, pgUpgradeTimestamp string) []*idl.PgOptions {
+	intermediatePrimaries := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
+		return seg.IsOnHost(hostname) && seg.IsPrimary() && !seg.IsCoordinator()
+	})
+
+	var opts []*idl.PgOptions
+	for _, intermediatePrimary := range intermediatePrimaries {
+		sourcePrimary := source.Primaries[intermediatePrimary.ContentID]
+
+		opt := &idl.PgOptions{
+			BackupDir:           agentHostToBackupDir[hostname],
+			PgUpgradeVerbose:    pgUpgradeVerbose,
+			SkipPgUpgradeChecks: skipPgUpgradeChecks,
+			PgUpgradeJobs:       strconv.Itoa(int(pgUpgradeJobs)),
+			Action:              action,
+			Role:                intermediatePrimary.Role,
+			ContentID:           int32(intermediatePrimary.ContentID),
+			PgUpgradeMode:       idl.PgOptions_segment,
+			Mode:                mode,
+			TargetVersion:       intermediate.Version.String(),
+			OldBinDir:           filepath.Join(source.GPHome, "bin"),
+			OldDataDir:          sourcePrimary.DataDir,
+			OldPort:             strconv.Itoa(sourcePrimary.Port),
+			OldDBID:             strconv.Itoa(sourcePrimary.DbID),
+			NewBinDir:           filepath.Join(intermediate.GPHome, "bin"),
+			NewDataDir:          intermediatePrimary.DataDir,
+			NewPort:             strconv.Itoa(intermediatePrimary.Port),
+			NewDBID:             strconv.Itoa(intermediatePrimary.DbID),
+			Tablespaces:         source.Tablespaces[int32(intermediatePrimary.DbID)],
+			PgUpgradeTimestamp:  pgUpgradeTimestamp,
+		}
+		opts = append(opts, opt)
+	}
+
+	return opts
+}
+
+// segmentTracker records the status of each primary being upgraded. Only
+// upgrading is tracked since checking is cheap to re-run and must check every
+// segment. A tracker without a store does nothing.
+type segmentTracker struct {
+	store     *step.SegmentFileStore
+	completed map[int32]bool
+	done      sync.Map // content ID to whether the agent reported it finished
+}
+
+func newSegmentTracker(store *step.SegmentFileStore, action idl.PgOptions_Action) (*segmentTracker, error) {
+	if store == nil || action != idl.PgOptions_upgrade {
+		return &segmentTracker{}, nil
+	}
+
+	completed, err := store.Completed(idl.Step_execute, idl.Substep_upgrade_primaries)
+	if err != nil {
+		return nil, xerrors.Errorf("read segment status: %w", err)
+	}
+
+	return &segmentTracker{store: store, completed: completed}, nil
+}
+
+// excludeCompleted filters out the segments already upgraded by a previous
+// run.
+func (t *segmentTracker) excludeCompleted(host string, opts []*idl.PgOptions) []*idl.PgOptions {
+	var remaining []*idl.PgOptions
+	for _, opt := range opts {
+		if t.completed[opt.GetContentID()] {
+			log.Printf("skipping primary on host %s with content %d since it was already upgraded", host, opt.GetContentID())
+			continue
 		}
 
+		remaining = append(remaining, opt)
+	}
+
+	return remaining
+}
+
+func (t *segmentTracker) start(host string, opts []*idl.PgOptions) error {
+	return t.write(host, opts, idl.Status_running)
+}
+
+func (t *segmentTracker) progress(host string, progress *idl.SegmentProgress) error {
+	if t.store == nil || !progress.GetDone() {
+		return nil
+	}
+
+	t.done.Store(progress.GetContentID(), true)
+
+	status := idl.Status_complete
+	if progress.GetFailed() {
+		status = idl.Status_failed
+	}
+
+	return t.store.Write(idl.Step_execute, idl.Substep_upgrade_primaries, host, progress.GetContentID(), status)
+}
+
+// fail marks the segments the agent did not report finishing as failed such
+// as when the connection to the agent is lost.
+func (t *segmentTracker) fail(host string, opts []*idl.PgOptions) error {
+	var unfinished []*idl.PgOptions
+	for _, opt := range opts {
+		if _, ok := t.done.Load(opt.GetContentID()); !ok {
+			unfinished = append(unfinished, opt)
+		}
+	}
+
+	return t.write(host, unfinished, idl.Status_failed)
+}
+
+func (t *segmentTracker) write(host string, opts []*idl.PgOptions, status idl.Status) error {
+	if t.store == nil {
 		return nil
 	}
 
-	return ExecuteRPC(agentConns, request)
+	var err error
+	for _, opt := range opts {
+		err = errorlist.Append(err, t.store.Write(idl.Step_execute, idl.Substep_upgrade_primaries, host, opt.GetContentID(), status))
+	}
+
+	return err
 }
@@ -4,9 +4,12 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"testing"
@@ -14,12 +17,15 @@ import (
 
 	"github.com/blang/semver/v4"
 	"github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/greenplum-db/gpupgrade/config/backupdir"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
@@ -122,7 +128,7 @@ func TestUpgradePrimaries(t *testing.T) {
 					},
 				},
 			}),
-		).Return(&idl.UpgradePrimariesReply{}, nil)
+		).Return(eofUpgradePrimariesStream(ctrl), nil)
 
 		sdw2 := mock_idl.NewMockAgentClient(ctrl)
 		sdw2.EXPECT().UpgradePrimaries(
@@ -176,19 +182,174 @@ func TestUpgradePrimaries(t *testing.T) {
 					},
 				},
 			}),
-		).Return(&idl.UpgradePrimariesReply{}, nil)
+		).Return(eofUpgradePrimariesStream(ctrl), nil)
 
 		agentConns := []*idl.Connection{
 			{AgentClient: sdw1, Hostname: "sdw1"},
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.UpgradePrimaries(agentConns, backupDirs.AgentHostsToBackupDir, true, true, 1, source, intermediate, idl.PgOptions_check, idl.Mode_copy, pgUpgradeTimestamp)
+		err := hub.UpgradePrimaries(context.Background(), step.DevNullStream, agentConns, 0, nil, backupDirs.AgentHostsToBackupDir, true, true, 1, 0, source, intermediate, idl.PgOptions_check, idl.Mode_copy, pgUpgradeTimestamp)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
 	})
 
+	t.Run("forwards the output and progress streamed from the agents", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		progress := &idl.SegmentProgress{Host: "sdw1", ContentID: 0, Stage: "Copying user relation files", CompletedStages: 3}
+
+		stream := mock_idl.NewMockAgent_UpgradePrimariesClient(ctrl)
+		gomock.InOrder(
+			stream.EXPECT().Recv().Return(&idl.UpgradePrimariesReply{Contents: &idl.UpgradePrimariesReply_Chunk{Chunk: &idl.Chunk{Buffer: []byte("stdout\n"), Type: idl.Chunk_stdout}}}, nil),
+			stream.EXPECT().Recv().Return(&idl.UpgradePrimariesReply{Contents: &idl.UpgradePrimariesReply_Chunk{Chunk: &idl.Chunk{Buffer: []byte("stderr\n"), Type: idl.Chunk_stderr}}}, nil),
+			stream.EXPECT().Recv().Return(&idl.UpgradePrimariesReply{Contents: &idl.UpgradePrimariesReply_Progress{Progress: progress}}, nil),
+			stream.EXPECT().Recv().Return(nil, io.EOF),
+		)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().UpgradePrimaries(gomock.Any(), gomock.Any()).Return(stream, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		streams := &progressStreams{}
+		err := hub.UpgradePrimaries(context.Background(), streams, agentConns, 0, nil, backupDirs.AgentHostsToBackupDir, false, false, 1, 0, source, intermediate, idl.PgOptions_upgrade, idl.Mode_copy, pgUpgradeTimestamp)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		if streams.StdoutBuf.String() != "stdout\n" {
+			t.Errorf("got stdout %q want %q", streams.StdoutBuf.String(), "stdout\n")
+		}
+
+		if streams.StderrBuf.String() != "stderr\n" {
+			t.Errorf("got stderr %q want %q", streams.StderrBuf.String(), "stderr\n")
+		}
+
+		if len(streams.progress) != 1 || !proto.Equal(streams.progress[0], progress) {
+			t.Errorf("got progress %v want %v", streams.progress, progress)
+		}
+	})
+
+	t.Run("only upgrades the primaries that did not complete during a previous run and records their status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		segments := step.NewSegmentStoreUsingFile(filepath.Join(dir, step.SegmentsFileName))
+		testutils.MustWriteToFile(t, filepath.Join(dir, step.SegmentsFileName), "{}")
+
+		err := segments.Write(idl.Step_execute, idl.Substep_upgrade_primaries, "sdw1", 0, idl.Status_complete)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		sdw1Stream := mock_idl.NewMockAgent_UpgradePrimariesClient(ctrl)
+		sdw1Stream.EXPECT().Recv().Return(nil, os.ErrPermission)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().UpgradePrimaries(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, req *idl.UpgradePrimariesRequest, _ ...interface{}) (idl.Agent_UpgradePrimariesClient, error) {
+				if len(req.GetOpts()) != 1 || req.GetOpts()[0].GetContentID() != 2 {
+					t.Errorf("got opts %v want only content 2", req.GetOpts())
+				}
+
+				return sdw1Stream, nil
+			})
+
+		sdw2Stream := mock_idl.NewMockAgent_UpgradePrimariesClient(ctrl)
+		gomock.InOrder(
+			sdw2Stream.EXPECT().Recv().Return(&idl.UpgradePrimariesReply{Contents: &idl.UpgradePrimariesReply_Progress{Progress: &idl.SegmentProgress{ContentID: 1, Done: true}}}, nil),
+			sdw2Stream.EXPECT().Recv().Return(&idl.UpgradePrimariesReply{Contents: &idl.UpgradePrimariesReply_Progress{Progress: &idl.SegmentProgress{ContentID: 3, Done: true, Failed: true}}}, nil),
+			sdw2Stream.EXPECT().Recv().Return(nil, io.EOF),
+		)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().UpgradePrimaries(gomock.Any(), gomock.Any()).Return(sdw2Stream, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		err = hub.UpgradePrimaries(context.Background(), step.DevNullStream, agentConns, 0, segments, backupDirs.AgentHostsToBackupDir, false, false, 1, 0, source, intermediate, idl.PgOptions_upgrade, idl.Mode_copy, pgUpgradeTimestamp)
+		if !errors.Is(err, os.ErrPermission) {
+			t.Errorf("got error %#v want %#v", err, os.ErrPermission)
+		}
+
+		entries, err := segments.Read(idl.Step_execute, idl.Substep_upgrade_primaries)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := map[int32]step.SegmentEntry{
+			0: {Host: "sdw1", Status: step.PrettyStatus{Status: idl.Status_complete}},
+			1: {Host: "sdw2", Status: step.PrettyStatus{Status: idl.Status_complete}},
+			2: {Host: "sdw1", Status: step.PrettyStatus{Status: idl.Status_failed}},
+			3: {Host: "sdw2", Status: step.PrettyStatus{Status: idl.Status_failed}},
+		}
+		if !reflect.DeepEqual(entries, expected) {
+			t.Errorf("got %v want %v", entries, expected)
+		}
+	})
+
+	t.Run("skips hosts whose primaries all completed during a previous run", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		segments := step.NewSegmentStoreUsingFile(filepath.Join(dir, step.SegmentsFileName))
+		testutils.MustWriteToFile(t, filepath.Join(dir, step.SegmentsFileName), "{}")
+
+		for _, content := range []int32{0, 2} {
+			err := segments.Write(idl.Step_execute, idl.Substep_upgrade_primaries, "sdw1", content, idl.Status_complete)
+			if err != nil {
+				t.Fatalf("unexpected error %#v", err)
+			}
+		}
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().UpgradePrimaries(gomock.Any(), gomock.Any()).Times(0)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hub.UpgradePrimaries(context.Background(), step.DevNullStream, agentConns, 0, segments, backupDirs.AgentHostsToBackupDir, false, false, 1, 0, source, intermediate, idl.PgOptions_upgrade, idl.Mode_copy, pgUpgradeTimestamp)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("returns error when receiving from the agent fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := os.ErrPermission
+		stream := mock_idl.NewMockAgent_UpgradePrimariesClient(ctrl)
+		stream.EXPECT().Recv().Return(nil, expected)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().UpgradePrimaries(gomock.Any(), gomock.Any()).Return(stream, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hub.UpgradePrimaries(context.Background(), step.DevNullStream, agentConns, 0, nil, backupDirs.AgentHostsToBackupDir, false, false, 1, 0, source, intermediate, idl.PgOptions_upgrade, idl.Mode_copy, pgUpgradeTimestamp)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
 	errCases := []struct {
 		name   string
 		Action idl.PgOptions_Action
@@ -229,7 +390,7 @@ func TestUpgradePrimaries(t *testing.T) {
 				{AgentClient: sdw2, Hostname: "sdw2"},
 			}
 
-			err := hub.UpgradePrimaries(agentConns, backupDirs.AgentHostsToBackupDir, false, false, 1, source, intermediate, c.Action, idl.Mode_link, pgUpgradeTimestamp)
+			err := hub.UpgradePrimaries(context.Background(), step.DevNullStream, agentConns, 0, nil, backupDirs.AgentHostsToBackupDir, false, false, 1, 0, source, intermediate, c.Action, idl.Mode_link, pgUpgradeTimestamp)
 			var errs errorlist.Errors
 			if !errors.As(err, &errs) {
 				t.Fatalf("error %#v does not contain type %T", err, errs)
@@ -256,6 +417,23 @@ func TestUpgradePrimaries(t *testing.T) {
 	}
 }
 
+func eofUpgradePrimariesStream(ctrl *gomock.Controller) idl.Agent_UpgradePrimariesClient {
+	stream := mock_idl.NewMockAgent_UpgradePrimariesClient(ctrl)
+	stream.EXPECT().Recv().Return(nil, io.EOF)
+	return stream
+}
+
+// progressStreams buffers the output and records the segment progress sent
+// to it.
+type progressStreams struct {
+	step.BufferedStreams
+	progress []*idl.SegmentProgress
+}
+
+func (p *progressStreams) SendProgress(progress *idl.SegmentProgress) {
+	p.progress = append(p.progress, progress)
+}
+
 // equivalentUpgradePrimariesRequest is a Matcher that can handle differences in order between
 // two instances of DeleteTablespaceRequest.Dirs
 func equivalentUpgradePrimariesRequest(req *idl.UpgradePrimariesRequest) gomock.Matcher {
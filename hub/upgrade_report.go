@@ -0,0 +1,54 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/report"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+// WriteUpgradeReport writes the upgrade report for the step to the log archive
+// directory and returns it as JSON. Nothing is written if the logs were not
+// archived such as when an earlier substep failed.
+func (s *Server) WriteUpgradeReport(st idl.Step, logArchiveDir string, html bool) (string, error) {
+	if logArchiveDir == "" {
+		return "", nil
+	}
+
+	store, err := step.NewSubstepFileStore()
+	if err != nil {
+		return "", err
+	}
+
+	substeps, err := store.ReadAll()
+	if err != nil {
+		return "", xerrors.Errorf("read substeps: %w", err)
+	}
+
+	statsStore, err := rsync.NewStatsFileStore()
+	if err != nil {
+		return "", err
+	}
+
+	rsyncStats, err := statsStore.ReadAll()
+	if err != nil {
+		return "", xerrors.Errorf("read rsync stats: %w", err)
+	}
+
+	r, err := report.New(st, s.Config, substeps, rsyncStats, logArchiveDir)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := r.Write(logArchiveDir, html)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/snapshot"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// SnapshotSourceCluster saves a snapshot of the source cluster to the state
+// directory which the target cluster is validated against.
+func SnapshotSourceCluster(source *greenplum.Cluster, sample uint) error {
+	sourceSnapshot, err := snapshot.Take(source, sample, nil)
+	if err != nil {
+		return xerrors.Errorf("snapshot source cluster: %w", err)
+	}
+
+	return sourceSnapshot.Write(snapshot.SourcePath())
+}
+
+// ValidateTargetCluster compares the target cluster against the snapshot of
+// the source cluster. The differences are written to the log directory and
+// returned as an error such that finalize stops before removing the backups.
+func ValidateTargetCluster(streams step.OutStreams, target *greenplum.Cluster, logDir string) error {
+	sourceSnapshot, err := snapshot.Read(snapshot.SourcePath())
+	if err != nil {
+		return err
+	}
+
+	targetSnapshot, err := snapshot.Take(target, 0, &sourceSnapshot)
+	if err != nil {
+		return xerrors.Errorf("snapshot target cluster: %w", err)
+	}
+
+	diffs := snapshot.Compare(sourceSnapshot, targetSnapshot)
+	if err := diffs.Write(logDir); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(streams.Stdout(), diffs.String())
+	if err != nil {
+		return err
+	}
+
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	return utils.NewNextActionErr(
+		fmt.Errorf("found %d differences between the source and target clusters", len(diffs)),
+		fmt.Sprintf(`The differences are listed in %s.
+Review them before continuing as the backups are removed once finalize completes.
+To finish finalize without validating re-run "gpupgrade finalize" without --validate.`, filepath.Join(logDir, snapshot.ValidationFileName)))
+}
@@ -12,6 +12,8 @@ package idl
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -134,6 +136,8 @@ const (
 	Substep_verify_gpupgrade_is_installed_across_all_hosts                Substep = 47
 	Substep_initialize_wait_for_cluster_to_be_ready                       Substep = 48
 	Substep_wait_for_cluster_to_be_ready_before_upgrade_master            Substep = 49
+	Substep_snapshot_source_cluster                                       Substep = 50
+	Substep_validate_target_cluster                                       Substep = 51
 )
 
 // Enum value maps for Substep.
@@ -189,6 +193,8 @@ var (
 		47: "verify_gpupgrade_is_installed_across_all_hosts",
 		48: "initialize_wait_for_cluster_to_be_ready",
 		49: "wait_for_cluster_to_be_ready_before_upgrade_master",
+		50: "snapshot_source_cluster",
+		51: "validate_target_cluster",
 	}
 	Substep_value = map[string]int32{
 		"unknown_substep":                0,
@@ -241,6 +247,8 @@ var (
 		"verify_gpupgrade_is_installed_across_all_hosts":                47,
 		"initialize_wait_for_cluster_to_be_ready":                       48,
 		"wait_for_cluster_to_be_ready_before_upgrade_master":            49,
+		"snapshot_source_cluster":                                       50,
+		"validate_target_cluster":                                       51,
 	}
 )
 
@@ -280,6 +288,7 @@ const (
 	Status_failed         Status = 3
 	Status_skipped        Status = 4
 	Status_quit           Status = 5
+	Status_paused         Status = 6
 )
 
 // Enum value maps for Status.
@@ -291,6 +300,7 @@ var (
 		3: "failed",
 		4: "skipped",
 		5: "quit",
+		6: "paused",
 	}
 	Status_value = map[string]int32{
 		"unknown_status": 0,
@@ -299,6 +309,7 @@ var (
 		"failed":         3,
 		"skipped":        4,
 		"quit":           5,
+		"paused":         6,
 	}
 )
 
@@ -329,53 +340,56 @@ func (Status) EnumDescriptor() ([]byte, []int) {
 	return file_cli_to_hub_proto_rawDescGZIP(), []int{2}
 }
 
-type Chunk_Type int32
+type SubstepPlan_Action int32
 
 const (
-	Chunk_unknown Chunk_Type = 0
-	Chunk_stdout  Chunk_Type = 1
-	Chunk_stderr  Chunk_Type = 2
+	SubstepPlan_unknown_action SubstepPlan_Action = 0
+	SubstepPlan_run            SubstepPlan_Action = 1
+	SubstepPlan_skip           SubstepPlan_Action = 2 // already completed
+	SubstepPlan_bypass         SubstepPlan_Action = 3 // run condition not met
 )
 
-// Enum value maps for Chunk_Type.
+// Enum value maps for SubstepPlan_Action.
 var (
-	Chunk_Type_name = map[int32]string{
-		0: "unknown",
-		1: "stdout",
-		2: "stderr",
-	}
-	Chunk_Type_value = map[string]int32{
-		"unknown": 0,
-		"stdout":  1,
-		"stderr":  2,
+	SubstepPlan_Action_name = map[int32]string{
+		0: "unknown_action",
+		1: "run",
+		2: "skip",
+		3: "bypass",
+	}
+	SubstepPlan_Action_value = map[string]int32{
+		"unknown_action": 0,
+		"run":            1,
+		"skip":           2,
+		"bypass":         3,
 	}
 )
 
-func (x Chunk_Type) Enum() *Chunk_Type {
-	p := new(Chunk_Type)
+func (x SubstepPlan_Action) Enum() *SubstepPlan_Action {
+	p := new(SubstepPlan_Action)
 	*p = x
 	return p
 }
 
-func (x Chunk_Type) String() string {
+func (x SubstepPlan_Action) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (Chunk_Type) Descriptor() protoreflect.EnumDescriptor {
+func (SubstepPlan_Action) Descriptor() protoreflect.EnumDescriptor {
 	return file_cli_to_hub_proto_enumTypes[3].Descriptor()
 }
 
-func (Chunk_Type) Type() protoreflect.EnumType {
+func (SubstepPlan_Action) Type() protoreflect.EnumType {
 	return &file_cli_to_hub_proto_enumTypes[3]
 }
 
-func (x Chunk_Type) Number() protoreflect.EnumNumber {
+func (x SubstepPlan_Action) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use Chunk_Type.Descriptor instead.
-func (Chunk_Type) EnumDescriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{12, 0}
+// Deprecated: Use SubstepPlan_Action.Descriptor instead.
+func (SubstepPlan_Action) EnumDescriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{19, 0}
 }
 
 type InitializeRequest struct {
@@ -383,8 +397,11 @@ type InitializeRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DiskFreeRatio    float64 `protobuf:"fixed64,1,opt,name=diskFreeRatio,proto3" json:"diskFreeRatio,omitempty"`
-	ParentBackupDirs string  `protobuf:"bytes,2,opt,name=parentBackupDirs,proto3" json:"parentBackupDirs,omitempty"`
+	DiskFreeRatio     float64 `protobuf:"fixed64,1,opt,name=diskFreeRatio,proto3" json:"diskFreeRatio,omitempty"`
+	ParentBackupDirs  string  `protobuf:"bytes,2,opt,name=parentBackupDirs,proto3" json:"parentBackupDirs,omitempty"`
+	DryRun            bool    `protobuf:"varint,3,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	Recover           bool    `protobuf:"varint,4,opt,name=recover,proto3" json:"recover,omitempty"`
+	EstimateDiskSpace bool    `protobuf:"varint,5,opt,name=estimateDiskSpace,proto3" json:"estimateDiskSpace,omitempty"`
 }
 
 func (x *InitializeRequest) Reset() {
@@ -433,6 +450,27 @@ func (x *InitializeRequest) GetParentBackupDirs() string {
 	return ""
 }
 
+func (x *InitializeRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *InitializeRequest) GetRecover() bool {
+	if x != nil {
+		return x.Recover
+	}
+	return false
+}
+
+func (x *InitializeRequest) GetEstimateDiskSpace() bool {
+	if x != nil {
+		return x.EstimateDiskSpace
+	}
+	return false
+}
+
 type InitializeCreateClusterRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -441,6 +479,8 @@ type InitializeCreateClusterRequest struct {
 	DynamicLibraryPath  string `protobuf:"bytes,1,opt,name=dynamicLibraryPath,proto3" json:"dynamicLibraryPath,omitempty"`
 	PgUpgradeVerbose    bool   `protobuf:"varint,2,opt,name=pgUpgradeVerbose,proto3" json:"pgUpgradeVerbose,omitempty"`
 	SkipPgUpgradeChecks bool   `protobuf:"varint,3,opt,name=skipPgUpgradeChecks,proto3" json:"skipPgUpgradeChecks,omitempty"`
+	DryRun              bool   `protobuf:"varint,4,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	Recover             bool   `protobuf:"varint,5,opt,name=recover,proto3" json:"recover,omitempty"`
 }
 
 func (x *InitializeCreateClusterRequest) Reset() {
@@ -496,14 +536,31 @@ func (x *InitializeCreateClusterRequest) GetSkipPgUpgradeChecks() bool {
 	return false
 }
 
+func (x *InitializeCreateClusterRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *InitializeCreateClusterRequest) GetRecover() bool {
+	if x != nil {
+		return x.Recover
+	}
+	return false
+}
+
 type ExecuteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PgUpgradeVerbose    bool   `protobuf:"varint,1,opt,name=pgUpgradeVerbose,proto3" json:"pgUpgradeVerbose,omitempty"`
-	SkipPgUpgradeChecks bool   `protobuf:"varint,2,opt,name=skipPgUpgradeChecks,proto3" json:"skipPgUpgradeChecks,omitempty"`
-	ParentBackupDirs    string `protobuf:"bytes,3,opt,name=parentBackupDirs,proto3" json:"parentBackupDirs,omitempty"`
+	PgUpgradeVerbose    bool    `protobuf:"varint,1,opt,name=pgUpgradeVerbose,proto3" json:"pgUpgradeVerbose,omitempty"`
+	SkipPgUpgradeChecks bool    `protobuf:"varint,2,opt,name=skipPgUpgradeChecks,proto3" json:"skipPgUpgradeChecks,omitempty"`
+	ParentBackupDirs    string  `protobuf:"bytes,3,opt,name=parentBackupDirs,proto3" json:"parentBackupDirs,omitempty"`
+	DryRun              bool    `protobuf:"varint,4,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	Recover             bool    `protobuf:"varint,5,opt,name=recover,proto3" json:"recover,omitempty"`
+	PauseBefore         Substep `protobuf:"varint,6,opt,name=pauseBefore,proto3,enum=idl.Substep" json:"pauseBefore,omitempty"`
 }
 
 func (x *ExecuteRequest) Reset() {
@@ -559,10 +616,37 @@ func (x *ExecuteRequest) GetParentBackupDirs() string {
 	return ""
 }
 
+func (x *ExecuteRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ExecuteRequest) GetRecover() bool {
+	if x != nil {
+		return x.Recover
+	}
+	return false
+}
+
+func (x *ExecuteRequest) GetPauseBefore() Substep {
+	if x != nil {
+		return x.PauseBefore
+	}
+	return Substep_unknown_substep
+}
+
 type FinalizeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	DryRun      bool    `protobuf:"varint,1,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	Recover     bool    `protobuf:"varint,2,opt,name=recover,proto3" json:"recover,omitempty"`
+	HtmlReport  bool    `protobuf:"varint,3,opt,name=htmlReport,proto3" json:"htmlReport,omitempty"`
+	PauseBefore Substep `protobuf:"varint,4,opt,name=pauseBefore,proto3,enum=idl.Substep" json:"pauseBefore,omitempty"`
+	Validate    bool    `protobuf:"varint,5,opt,name=validate,proto3" json:"validate,omitempty"`
 }
 
 func (x *FinalizeRequest) Reset() {
@@ -597,10 +681,49 @@ func (*FinalizeRequest) Descriptor() ([]byte, []int) {
 	return file_cli_to_hub_proto_rawDescGZIP(), []int{3}
 }
 
+func (x *FinalizeRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *FinalizeRequest) GetRecover() bool {
+	if x != nil {
+		return x.Recover
+	}
+	return false
+}
+
+func (x *FinalizeRequest) GetHtmlReport() bool {
+	if x != nil {
+		return x.HtmlReport
+	}
+	return false
+}
+
+func (x *FinalizeRequest) GetPauseBefore() Substep {
+	if x != nil {
+		return x.PauseBefore
+	}
+	return Substep_unknown_substep
+}
+
+func (x *FinalizeRequest) GetValidate() bool {
+	if x != nil {
+		return x.Validate
+	}
+	return false
+}
+
 type RevertRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	DryRun     bool `protobuf:"varint,1,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	Recover    bool `protobuf:"varint,2,opt,name=recover,proto3" json:"recover,omitempty"`
+	HtmlReport bool `protobuf:"varint,3,opt,name=htmlReport,proto3" json:"htmlReport,omitempty"`
 }
 
 func (x *RevertRequest) Reset() {
@@ -635,6 +758,27 @@ func (*RevertRequest) Descriptor() ([]byte, []int) {
 	return file_cli_to_hub_proto_rawDescGZIP(), []int{4}
 }
 
+func (x *RevertRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *RevertRequest) GetRecover() bool {
+	if x != nil {
+		return x.Recover
+	}
+	return false
+}
+
+func (x *RevertRequest) GetHtmlReport() bool {
+	if x != nil {
+		return x.HtmlReport
+	}
+	return false
+}
+
 type RestartAgentsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -788,40 +932,681 @@ func (x *StopServicesReply) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopServicesReply.ProtoReflect.Descriptor instead.
+func (*StopServicesReply) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{8}
+}
+
+type CancelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CancelRequest) Reset() {
+	*x = CancelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cli_to_hub_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRequest) ProtoMessage() {}
+
+func (x *CancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cli_to_hub_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRequest.ProtoReflect.Descriptor instead.
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{9}
+}
+
+type CancelReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Step Step `protobuf:"varint,1,opt,name=step,proto3,enum=idl.Step" json:"step,omitempty"`
+}
+
+func (x *CancelReply) Reset() {
+	*x = CancelReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cli_to_hub_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReply) ProtoMessage() {}
+
+func (x *CancelReply) ProtoReflect() protoreflect.Message {
+	mi := &file_cli_to_hub_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReply.ProtoReflect.Descriptor instead.
+func (*CancelReply) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelReply) GetStep() Step {
+	if x != nil {
+		return x.Step
+	}
+	return Step_unknown_step
+}
+
+type AttachRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AttachRequest) Reset() {
+	*x = AttachRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cli_to_hub_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AttachRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachRequest) ProtoMessage() {}
+
+func (x *AttachRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cli_to_hub_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachRequest.ProtoReflect.Descriptor instead.
+func (*AttachRequest) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{11}
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cli_to_hub_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cli_to_hub_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{12}
+}
+
+type GetStatusReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Steps      []*StepStatus  `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	ValidSteps []Step         `protobuf:"varint,2,rep,packed,name=validSteps,proto3,enum=idl.Step" json:"validSteps,omitempty"`
+	NextStep   Step           `protobuf:"varint,3,opt,name=nextStep,proto3,enum=idl.Step" json:"nextStep,omitempty"`
+	Agents     []*AgentStatus `protobuf:"bytes,4,rep,name=agents,proto3" json:"agents,omitempty"`
+}
+
+func (x *GetStatusReply) Reset() {
+	*x = GetStatusReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cli_to_hub_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatusReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusReply) ProtoMessage() {}
+
+func (x *GetStatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_cli_to_hub_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusReply.ProtoReflect.Descriptor instead.
+func (*GetStatusReply) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetStatusReply) GetSteps() []*StepStatus {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *GetStatusReply) GetValidSteps() []Step {
+	if x != nil {
+		return x.ValidSteps
+	}
+	return nil
+}
+
+func (x *GetStatusReply) GetNextStep() Step {
+	if x != nil {
+		return x.NextStep
+	}
+	return Step_unknown_step
+}
+
+func (x *GetStatusReply) GetAgents() []*AgentStatus {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+type StepStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Step     Step              `protobuf:"varint,1,opt,name=step,proto3,enum=idl.Step" json:"step,omitempty"`
+	Status   Status            `protobuf:"varint,2,opt,name=status,proto3,enum=idl.Status" json:"status,omitempty"`
+	Substeps []*SubstepDetails `protobuf:"bytes,3,rep,name=substeps,proto3" json:"substeps,omitempty"`
+}
+
+func (x *StepStatus) Reset() {
+	*x = StepStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cli_to_hub_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StepStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepStatus) ProtoMessage() {}
+
+func (x *StepStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_cli_to_hub_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepStatus.ProtoReflect.Descriptor instead.
+func (*StepStatus) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StepStatus) GetStep() Step {
+	if x != nil {
+		return x.Step
+	}
+	return Step_unknown_step
+}
+
+func (x *StepStatus) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_unknown_status
+}
+
+func (x *StepStatus) GetSubsteps() []*SubstepDetails {
+	if x != nil {
+		return x.Substeps
+	}
+	return nil
+}
+
+type SubstepDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Substep    Substep                `protobuf:"varint,1,opt,name=substep,proto3,enum=idl.Substep" json:"substep,omitempty"`
+	Status     Status                 `protobuf:"varint,2,opt,name=status,proto3,enum=idl.Status" json:"status,omitempty"`
+	StartTime  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	Duration   *durationpb.Duration   `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	Segments   []*SegmentStatus       `protobuf:"bytes,5,rep,name=segments,proto3" json:"segments,omitempty"`
+	RsyncStats *RsyncStats            `protobuf:"bytes,6,opt,name=rsyncStats,proto3" json:"rsyncStats,omitempty"`
+}
+
+func (x *SubstepDetails) Reset() {
+	*x = SubstepDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cli_to_hub_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubstepDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubstepDetails) ProtoMessage() {}
+
+func (x *SubstepDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_cli_to_hub_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubstepDetails.ProtoReflect.Descriptor instead.
+func (*SubstepDetails) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SubstepDetails) GetSubstep() Substep {
+	if x != nil {
+		return x.Substep
+	}
+	return Substep_unknown_substep
+}
+
+func (x *SubstepDetails) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_unknown_status
+}
+
+func (x *SubstepDetails) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *SubstepDetails) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *SubstepDetails) GetSegments() []*SegmentStatus {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *SubstepDetails) GetRsyncStats() *RsyncStats {
+	if x != nil {
+		return x.RsyncStats
+	}
+	return nil
+}
+
+// AgentStatus is the result of the latest heartbeat sent to the agent on the
+// host. The health is from the last successful heartbeat and error is set when
+// the latest heartbeat failed.
+type AgentStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	LastHeartbeat *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=lastHeartbeat,proto3" json:"lastHeartbeat,omitempty"`
+	Health        *AgentHealth           `protobuf:"bytes,3,opt,name=health,proto3" json:"health,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *AgentStatus) Reset() {
+	*x = AgentStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cli_to_hub_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AgentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentStatus) ProtoMessage() {}
+
+func (x *AgentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_cli_to_hub_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentStatus.ProtoReflect.Descriptor instead.
+func (*AgentStatus) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AgentStatus) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *AgentStatus) GetLastHeartbeat() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastHeartbeat
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetHealth() *AgentHealth {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// SegmentStatus is the status of a segment for substeps such as upgrading the
+// primaries that track each segment individually.
+type SegmentStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host      string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	ContentID int32  `protobuf:"varint,2,opt,name=contentID,proto3" json:"contentID,omitempty"`
+	Status    Status `protobuf:"varint,3,opt,name=status,proto3,enum=idl.Status" json:"status,omitempty"`
+}
+
+func (x *SegmentStatus) Reset() {
+	*x = SegmentStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cli_to_hub_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SegmentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SegmentStatus) ProtoMessage() {}
+
+func (x *SegmentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_cli_to_hub_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SegmentStatus.ProtoReflect.Descriptor instead.
+func (*SegmentStatus) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SegmentStatus) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *SegmentStatus) GetContentID() int32 {
+	if x != nil {
+		return x.ContentID
+	}
+	return 0
+}
+
+func (x *SegmentStatus) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_unknown_status
+}
+
+type SubstepStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Step   Substep `protobuf:"varint,1,opt,name=step,proto3,enum=idl.Substep" json:"step,omitempty"`
+	Status Status  `protobuf:"varint,2,opt,name=status,proto3,enum=idl.Status" json:"status,omitempty"`
+}
+
+func (x *SubstepStatus) Reset() {
+	*x = SubstepStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cli_to_hub_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubstepStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubstepStatus) ProtoMessage() {}
+
+func (x *SubstepStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_cli_to_hub_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubstepStatus.ProtoReflect.Descriptor instead.
+func (*SubstepStatus) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SubstepStatus) GetStep() Substep {
+	if x != nil {
+		return x.Step
+	}
+	return Substep_unknown_substep
+}
+
+func (x *SubstepStatus) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_unknown_status
+}
+
+// SubstepPlan describes what a substep would do when running a step with
+// --dry-run.
+type SubstepPlan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Substep  Substep            `protobuf:"varint,1,opt,name=substep,proto3,enum=idl.Substep" json:"substep,omitempty"`
+	Action   SubstepPlan_Action `protobuf:"varint,2,opt,name=action,proto3,enum=idl.SubstepPlan_Action" json:"action,omitempty"`
+	Commands []*PlannedCommand  `protobuf:"bytes,3,rep,name=commands,proto3" json:"commands,omitempty"`
+}
+
+func (x *SubstepPlan) Reset() {
+	*x = SubstepPlan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cli_to_hub_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubstepPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubstepPlan) ProtoMessage() {}
+
+func (x *SubstepPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_cli_to_hub_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubstepPlan.ProtoReflect.Descriptor instead.
+func (*SubstepPlan) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SubstepPlan) GetSubstep() Substep {
+	if x != nil {
+		return x.Substep
+	}
+	return Substep_unknown_substep
+}
+
+func (x *SubstepPlan) GetAction() SubstepPlan_Action {
+	if x != nil {
+		return x.Action
+	}
+	return SubstepPlan_unknown_action
 }
 
-// Deprecated: Use StopServicesReply.ProtoReflect.Descriptor instead.
-func (*StopServicesReply) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{8}
+func (x *SubstepPlan) GetCommands() []*PlannedCommand {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
 }
 
-type SubstepStatus struct {
+type PlannedCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Step   Substep `protobuf:"varint,1,opt,name=step,proto3,enum=idl.Substep" json:"step,omitempty"`
-	Status Status  `protobuf:"varint,2,opt,name=status,proto3,enum=idl.Status" json:"status,omitempty"`
+	Host    string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Command string `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
 }
 
-func (x *SubstepStatus) Reset() {
-	*x = SubstepStatus{}
+func (x *PlannedCommand) Reset() {
+	*x = PlannedCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[9]
+		mi := &file_cli_to_hub_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SubstepStatus) String() string {
+func (x *PlannedCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubstepStatus) ProtoMessage() {}
+func (*PlannedCommand) ProtoMessage() {}
 
-func (x *SubstepStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[9]
+func (x *PlannedCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_cli_to_hub_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -832,23 +1617,23 @@ func (x *SubstepStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubstepStatus.ProtoReflect.Descriptor instead.
-func (*SubstepStatus) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use PlannedCommand.ProtoReflect.Descriptor instead.
+func (*PlannedCommand) Descriptor() ([]byte, []int) {
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *SubstepStatus) GetStep() Substep {
+func (x *PlannedCommand) GetHost() string {
 	if x != nil {
-		return x.Step
+		return x.Host
 	}
-	return Substep_unknown_substep
+	return ""
 }
 
-func (x *SubstepStatus) GetStatus() Status {
+func (x *PlannedCommand) GetCommand() string {
 	if x != nil {
-		return x.Status
+		return x.Command
 	}
-	return Status_unknown_status
+	return ""
 }
 
 type PrepareInitClusterRequest struct {
@@ -860,7 +1645,7 @@ type PrepareInitClusterRequest struct {
 func (x *PrepareInitClusterRequest) Reset() {
 	*x = PrepareInitClusterRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[10]
+		mi := &file_cli_to_hub_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -873,7 +1658,7 @@ func (x *PrepareInitClusterRequest) String() string {
 func (*PrepareInitClusterRequest) ProtoMessage() {}
 
 func (x *PrepareInitClusterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[10]
+	mi := &file_cli_to_hub_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -886,7 +1671,7 @@ func (x *PrepareInitClusterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PrepareInitClusterRequest.ProtoReflect.Descriptor instead.
 func (*PrepareInitClusterRequest) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{10}
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{21}
 }
 
 type PrepareInitClusterReply struct {
@@ -898,7 +1683,7 @@ type PrepareInitClusterReply struct {
 func (x *PrepareInitClusterReply) Reset() {
 	*x = PrepareInitClusterReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[11]
+		mi := &file_cli_to_hub_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -911,7 +1696,7 @@ func (x *PrepareInitClusterReply) String() string {
 func (*PrepareInitClusterReply) ProtoMessage() {}
 
 func (x *PrepareInitClusterReply) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[11]
+	mi := &file_cli_to_hub_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -924,62 +1709,7 @@ func (x *PrepareInitClusterReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PrepareInitClusterReply.ProtoReflect.Descriptor instead.
 func (*PrepareInitClusterReply) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{11}
-}
-
-type Chunk struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Buffer []byte     `protobuf:"bytes,1,opt,name=buffer,proto3" json:"buffer,omitempty"`
-	Type   Chunk_Type `protobuf:"varint,2,opt,name=type,proto3,enum=idl.Chunk_Type" json:"type,omitempty"`
-}
-
-func (x *Chunk) Reset() {
-	*x = Chunk{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[12]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *Chunk) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Chunk) ProtoMessage() {}
-
-func (x *Chunk) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[12]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Chunk.ProtoReflect.Descriptor instead.
-func (*Chunk) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *Chunk) GetBuffer() []byte {
-	if x != nil {
-		return x.Buffer
-	}
-	return nil
-}
-
-func (x *Chunk) GetType() Chunk_Type {
-	if x != nil {
-		return x.Type
-	}
-	return Chunk_unknown
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{22}
 }
 
 type Message struct {
@@ -992,13 +1722,15 @@ type Message struct {
 	//	*Message_Chunk
 	//	*Message_Status
 	//	*Message_Response
+	//	*Message_Plan
+	//	*Message_Progress
 	Contents isMessage_Contents `protobuf_oneof:"contents"`
 }
 
 func (x *Message) Reset() {
 	*x = Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[13]
+		mi := &file_cli_to_hub_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1011,7 +1743,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[13]
+	mi := &file_cli_to_hub_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1024,7 +1756,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{13}
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{23}
 }
 
 func (m *Message) GetContents() isMessage_Contents {
@@ -1055,6 +1787,20 @@ func (x *Message) GetResponse() *Response {
 	return nil
 }
 
+func (x *Message) GetPlan() *SubstepPlan {
+	if x, ok := x.GetContents().(*Message_Plan); ok {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *Message) GetProgress() *SegmentProgress {
+	if x, ok := x.GetContents().(*Message_Progress); ok {
+		return x.Progress
+	}
+	return nil
+}
+
 type isMessage_Contents interface {
 	isMessage_Contents()
 }
@@ -1071,12 +1817,24 @@ type Message_Response struct {
 	Response *Response `protobuf:"bytes,3,opt,name=response,proto3,oneof"`
 }
 
+type Message_Plan struct {
+	Plan *SubstepPlan `protobuf:"bytes,4,opt,name=plan,proto3,oneof"`
+}
+
+type Message_Progress struct {
+	Progress *SegmentProgress `protobuf:"bytes,5,opt,name=progress,proto3,oneof"`
+}
+
 func (*Message_Chunk) isMessage_Contents() {}
 
 func (*Message_Status) isMessage_Contents() {}
 
 func (*Message_Response) isMessage_Contents() {}
 
+func (*Message_Plan) isMessage_Contents() {}
+
+func (*Message_Progress) isMessage_Contents() {}
+
 type Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1094,7 +1852,7 @@ type Response struct {
 func (x *Response) Reset() {
 	*x = Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[14]
+		mi := &file_cli_to_hub_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1107,7 +1865,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[14]
+	mi := &file_cli_to_hub_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1120,7 +1878,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{14}
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{24}
 }
 
 func (m *Response) GetContents() isResponse_Contents {
@@ -1197,7 +1955,7 @@ type InitializeResponse struct {
 func (x *InitializeResponse) Reset() {
 	*x = InitializeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[15]
+		mi := &file_cli_to_hub_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1210,7 +1968,7 @@ func (x *InitializeResponse) String() string {
 func (*InitializeResponse) ProtoMessage() {}
 
 func (x *InitializeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[15]
+	mi := &file_cli_to_hub_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1223,7 +1981,7 @@ func (x *InitializeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InitializeResponse.ProtoReflect.Descriptor instead.
 func (*InitializeResponse) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{15}
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *InitializeResponse) GetHasAllMirrorsAndStandby() bool {
@@ -1244,7 +2002,7 @@ type ExecuteResponse struct {
 func (x *ExecuteResponse) Reset() {
 	*x = ExecuteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[16]
+		mi := &file_cli_to_hub_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1257,7 +2015,7 @@ func (x *ExecuteResponse) String() string {
 func (*ExecuteResponse) ProtoMessage() {}
 
 func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[16]
+	mi := &file_cli_to_hub_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1270,7 +2028,7 @@ func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteResponse.ProtoReflect.Descriptor instead.
 func (*ExecuteResponse) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{16}
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *ExecuteResponse) GetIntermediate() []byte {
@@ -1289,12 +2047,13 @@ type FinalizeResponse struct {
 	LogArchiveDirectory                    string `protobuf:"bytes,2,opt,name=LogArchiveDirectory,proto3" json:"LogArchiveDirectory,omitempty"`
 	ArchivedSourceCoordinatorDataDirectory string `protobuf:"bytes,3,opt,name=ArchivedSourceCoordinatorDataDirectory,proto3" json:"ArchivedSourceCoordinatorDataDirectory,omitempty"`
 	UpgradeID                              string `protobuf:"bytes,4,opt,name=UpgradeID,proto3" json:"UpgradeID,omitempty"`
+	Report                                 string `protobuf:"bytes,5,opt,name=Report,proto3" json:"Report,omitempty"`
 }
 
 func (x *FinalizeResponse) Reset() {
 	*x = FinalizeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[17]
+		mi := &file_cli_to_hub_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1307,7 +2066,7 @@ func (x *FinalizeResponse) String() string {
 func (*FinalizeResponse) ProtoMessage() {}
 
 func (x *FinalizeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[17]
+	mi := &file_cli_to_hub_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1320,7 +2079,7 @@ func (x *FinalizeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FinalizeResponse.ProtoReflect.Descriptor instead.
 func (*FinalizeResponse) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{17}
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *FinalizeResponse) GetTarget() []byte {
@@ -1351,6 +2110,13 @@ func (x *FinalizeResponse) GetUpgradeID() string {
 	return ""
 }
 
+func (x *FinalizeResponse) GetReport() string {
+	if x != nil {
+		return x.Report
+	}
+	return ""
+}
+
 type RevertResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1358,12 +2124,13 @@ type RevertResponse struct {
 
 	Source              []byte `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
 	LogArchiveDirectory string `protobuf:"bytes,2,opt,name=LogArchiveDirectory,proto3" json:"LogArchiveDirectory,omitempty"`
+	Report              string `protobuf:"bytes,3,opt,name=Report,proto3" json:"Report,omitempty"`
 }
 
 func (x *RevertResponse) Reset() {
 	*x = RevertResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[18]
+		mi := &file_cli_to_hub_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1376,7 +2143,7 @@ func (x *RevertResponse) String() string {
 func (*RevertResponse) ProtoMessage() {}
 
 func (x *RevertResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[18]
+	mi := &file_cli_to_hub_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1389,7 +2156,7 @@ func (x *RevertResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevertResponse.ProtoReflect.Descriptor instead.
 func (*RevertResponse) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{18}
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *RevertResponse) GetSource() []byte {
@@ -1406,6 +2173,13 @@ func (x *RevertResponse) GetLogArchiveDirectory() string {
 	return ""
 }
 
+func (x *RevertResponse) GetReport() string {
+	if x != nil {
+		return x.Report
+	}
+	return ""
+}
+
 type GetConfigRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1417,7 +2191,7 @@ type GetConfigRequest struct {
 func (x *GetConfigRequest) Reset() {
 	*x = GetConfigRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[19]
+		mi := &file_cli_to_hub_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1430,7 +2204,7 @@ func (x *GetConfigRequest) String() string {
 func (*GetConfigRequest) ProtoMessage() {}
 
 func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[19]
+	mi := &file_cli_to_hub_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1443,7 +2217,7 @@ func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
 func (*GetConfigRequest) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{19}
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *GetConfigRequest) GetName() string {
@@ -1464,7 +2238,7 @@ type GetConfigReply struct {
 func (x *GetConfigReply) Reset() {
 	*x = GetConfigReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[20]
+		mi := &file_cli_to_hub_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1477,7 +2251,7 @@ func (x *GetConfigReply) String() string {
 func (*GetConfigReply) ProtoMessage() {}
 
 func (x *GetConfigReply) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[20]
+	mi := &file_cli_to_hub_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1490,7 +2264,7 @@ func (x *GetConfigReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConfigReply.ProtoReflect.Descriptor instead.
 func (*GetConfigReply) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{20}
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *GetConfigReply) GetValue() string {
@@ -1513,7 +2287,7 @@ type NextActions struct {
 func (x *NextActions) Reset() {
 	*x = NextActions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cli_to_hub_proto_msgTypes[21]
+		mi := &file_cli_to_hub_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1526,7 +2300,7 @@ func (x *NextActions) String() string {
 func (*NextActions) ProtoMessage() {}
 
 func (x *NextActions) ProtoReflect() protoreflect.Message {
-	mi := &file_cli_to_hub_proto_msgTypes[21]
+	mi := &file_cli_to_hub_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1539,7 +2313,7 @@ func (x *NextActions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NextActions.ProtoReflect.Descriptor instead.
 func (*NextActions) Descriptor() ([]byte, []int) {
-	return file_cli_to_hub_proto_rawDescGZIP(), []int{21}
+	return file_cli_to_hub_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *NextActions) GetNextActions() string {
@@ -1553,13 +2327,24 @@ var File_cli_to_hub_proto protoreflect.FileDescriptor
 
 var file_cli_to_hub_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x63, 0x6c, 0x69, 0x5f, 0x74, 0x6f, 0x5f, 0x68, 0x75, 0x62, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x03, 0x69, 0x64, 0x6c, 0x22, 0x65, 0x0a, 0x11, 0x49, 0x6e, 0x69, 0x74, 0x69,
+	0x74, 0x6f, 0x12, 0x03, 0x69, 0x64, 0x6c, 0x1a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc5, 0x01, 0x0a, 0x11, 0x49, 0x6e, 0x69, 0x74, 0x69,
 	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d,
 	0x64, 0x69, 0x73, 0x6b, 0x46, 0x72, 0x65, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x01, 0x52, 0x0d, 0x64, 0x69, 0x73, 0x6b, 0x46, 0x72, 0x65, 0x65, 0x52, 0x61, 0x74,
 	0x69, 0x6f, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x63, 0x6b,
 	0x75, 0x70, 0x44, 0x69, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x70, 0x61,
-	0x72, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x73, 0x22, 0xae,
+	0x72, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72,
+	0x12, 0x2c, 0x0a, 0x11, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x44, 0x69, 0x73, 0x6b,
+	0x53, 0x70, 0x61, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x65, 0x73, 0x74,
+	0x69, 0x6d, 0x61, 0x74, 0x65, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x22, 0xe0,
 	0x01, 0x0a, 0x1e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x43, 0x72, 0x65,
 	0x61, 0x74, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x2e, 0x0a, 0x12, 0x64, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x4c, 0x69, 0x62, 0x72,
@@ -1570,252 +2355,371 @@ var file_cli_to_hub_proto_rawDesc = []byte{
 	0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x56, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x12, 0x30, 0x0a,
 	0x13, 0x73, 0x6b, 0x69, 0x70, 0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x43, 0x68,
 	0x65, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x73, 0x6b, 0x69, 0x70,
-	0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x22,
-	0x9a, 0x01, 0x0a, 0x0e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x56,
-	0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x70, 0x67,
-	0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x56, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x12, 0x30,
-	0x0a, 0x13, 0x73, 0x6b, 0x69, 0x70, 0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x43,
-	0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x73, 0x6b, 0x69,
-	0x70, 0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73,
-	0x12, 0x2a, 0x0a, 0x10, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70,
-	0x44, 0x69, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x70, 0x61, 0x72, 0x65,
-	0x6e, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x73, 0x22, 0x11, 0x0a, 0x0f,
-	0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
-	0x0f, 0x0a, 0x0d, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x34, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x74,
-	0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e,
-	0x0a, 0x0a, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x0a, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x15,
-	0x0a, 0x13, 0x53, 0x74, 0x6f, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x13, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x70, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x56, 0x0a, 0x0d, 0x53, 0x75,
-	0x62, 0x73, 0x74, 0x65, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x04, 0x73,
-	0x74, 0x65, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
-	0x53, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x23, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e,
-	0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x22, 0x1b, 0x0a, 0x19, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x49, 0x6e, 0x69,
-	0x74, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
-	0x19, 0x0a, 0x17, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x43, 0x6c,
-	0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x71, 0x0a, 0x05, 0x43, 0x68,
-	0x75, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x06, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
-	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x22, 0x2b, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x75, 0x6e, 0x6b, 0x6e,
-	0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x10,
-	0x01, 0x12, 0x0a, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x10, 0x02, 0x22, 0x94, 0x01,
-	0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x63, 0x68, 0x75,
-	0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43,
-	0x68, 0x75, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x2c, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x69, 0x64, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x72,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x08,
-	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x73, 0x22, 0xa7, 0x02, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x49, 0x0a, 0x12, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x69, 0x64, 0x6c, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x12, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61,
-	0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0f,
-	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x45, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x65,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43,
-	0x0a, 0x10, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x46,
-	0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
-	0x00, 0x52, 0x10, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0e, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x64,
-	0x6c, 0x2e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x48, 0x00, 0x52, 0x0e, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x4e,
-	0x0a, 0x12, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x17, 0x48, 0x61, 0x73, 0x41, 0x6c, 0x6c, 0x4d, 0x69,
-	0x72, 0x72, 0x6f, 0x72, 0x73, 0x41, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x62, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x48, 0x61, 0x73, 0x41, 0x6c, 0x6c, 0x4d, 0x69, 0x72,
-	0x72, 0x6f, 0x72, 0x73, 0x41, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x62, 0x79, 0x22, 0x35,
-	0x0a, 0x0f, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6d, 0x65,
-	0x64, 0x69, 0x61, 0x74, 0x65, 0x22, 0xd2, 0x01, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69,
-	0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x12, 0x30, 0x0a, 0x13, 0x4c, 0x6f, 0x67, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
-	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x13, 0x4c, 0x6f, 0x67, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x79, 0x12, 0x56, 0x0a, 0x26, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64,
-	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f,
-	0x72, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x26, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x44,
-	0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09,
-	0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x49, 0x44, 0x22, 0x5a, 0x0a, 0x0e, 0x52, 0x65,
-	0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x4c, 0x6f, 0x67, 0x41, 0x72, 0x63, 0x68, 0x69,
-	0x76, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x13, 0x4c, 0x6f, 0x67, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x44, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x26, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x26,
-	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x2f, 0x0a, 0x0b, 0x4e, 0x65, 0x78, 0x74, 0x41, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x41, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x65, 0x78, 0x74,
-	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2a, 0x5a, 0x0a, 0x04, 0x53, 0x74, 0x65, 0x70, 0x12,
-	0x10, 0x0a, 0x0c, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x73, 0x74, 0x65, 0x70, 0x10,
-	0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x10,
-	0x01, 0x12, 0x0b, 0x0a, 0x07, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x10, 0x02, 0x12, 0x0c,
-	0x0a, 0x08, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06,
-	0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
-	0x73, 0x10, 0x05, 0x2a, 0xb4, 0x0c, 0x0a, 0x07, 0x53, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x12,
-	0x13, 0x0a, 0x0f, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x73, 0x75, 0x62, 0x73, 0x74,
-	0x65, 0x70, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x73, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x5f, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
-	0x68, 0x75, 0x62, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x73, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x63, 0x68, 0x65, 0x63, 0x6b,
-	0x5f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x10, 0x04, 0x12, 0x14,
-	0x0a, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x10, 0x05, 0x12, 0x1a, 0x0a, 0x16, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0x06,
-	0x12, 0x17, 0x0a, 0x13, 0x69, 0x6e, 0x69, 0x74, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
-	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x07, 0x12, 0x1b, 0x0a, 0x17, 0x73, 0x68, 0x75,
-	0x74, 0x64, 0x6f, 0x77, 0x6e, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x6c, 0x75,
-	0x73, 0x74, 0x65, 0x72, 0x10, 0x08, 0x12, 0x18, 0x0a, 0x14, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70,
-	0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x10, 0x09,
-	0x12, 0x11, 0x0a, 0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64,
-	0x65, 0x10, 0x0a, 0x12, 0x1b, 0x0a, 0x17, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x5f,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x0b,
-	0x12, 0x12, 0x0a, 0x0e, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x74,
-	0x65, 0x72, 0x10, 0x0c, 0x12, 0x0f, 0x0a, 0x0b, 0x63, 0x6f, 0x70, 0x79, 0x5f, 0x6d, 0x61, 0x73,
-	0x74, 0x65, 0x72, 0x10, 0x0d, 0x12, 0x15, 0x0a, 0x11, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
-	0x5f, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x10, 0x0e, 0x12, 0x18, 0x0a, 0x14,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x6c, 0x75,
-	0x73, 0x74, 0x65, 0x72, 0x10, 0x0f, 0x12, 0x19, 0x0a, 0x15, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x10,
-	0x10, 0x12, 0x1b, 0x0a, 0x17, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61,
-	0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x10, 0x11, 0x12, 0x1c,
-	0x0a, 0x18, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
-	0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x10, 0x12, 0x12, 0x13, 0x0a, 0x0f,
-	0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x62, 0x79, 0x10,
-	0x13, 0x12, 0x13, 0x0a, 0x0f, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x5f, 0x6d, 0x69, 0x72,
-	0x72, 0x6f, 0x72, 0x73, 0x10, 0x14, 0x12, 0x16, 0x0a, 0x12, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x10, 0x15, 0x12, 0x22,
-	0x0a, 0x1e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
-	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x64, 0x69, 0x72, 0x73,
-	0x10, 0x16, 0x12, 0x1c, 0x0a, 0x18, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x73, 0x65, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x64, 0x69, 0x72, 0x73, 0x10, 0x17,
-	0x12, 0x17, 0x0a, 0x13, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x68, 0x75, 0x62, 0x5f, 0x61, 0x6e, 0x64,
-	0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x10, 0x18, 0x12, 0x1a, 0x0a, 0x16, 0x64, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x64, 0x69, 0x72, 0x10, 0x19, 0x12, 0x1b, 0x0a, 0x17, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
-	0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73,
-	0x10, 0x1a, 0x12, 0x1a, 0x0a, 0x16, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x1b, 0x12, 0x18,
-	0x0a, 0x14, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63,
-	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x1c, 0x12, 0x15, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x74,
-	0x6f, 0x72, 0x65, 0x5f, 0x70, 0x67, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x10, 0x1d, 0x12,
-	0x1d, 0x0a, 0x19, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x73, 0x65, 0x67, 0x5f, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x1e, 0x12, 0x0f,
-	0x0a, 0x0b, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x10, 0x1f, 0x12,
-	0x41, 0x0a, 0x3d, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x63, 0x6c, 0x75, 0x73,
-	0x74, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x79, 0x5f,
-	0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x69, 0x72,
-	0x72, 0x6f, 0x72, 0x73, 0x5f, 0x61, 0x6e, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x62, 0x79,
-	0x10, 0x20, 0x12, 0x37, 0x0a, 0x33, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x63,
+	0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x22, 0xfc, 0x01, 0x0a, 0x0e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x56, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x70, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x56, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65,
+	0x12, 0x30, 0x0a, 0x13, 0x73, 0x6b, 0x69, 0x70, 0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x73,
+	0x6b, 0x69, 0x70, 0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x63, 0x6b,
+	0x75, 0x70, 0x44, 0x69, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72,
+	0x12, 0x2e, 0x0a, 0x0b, 0x70, 0x61, 0x75, 0x73, 0x65, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x73,
+	0x74, 0x65, 0x70, 0x52, 0x0b, 0x70, 0x61, 0x75, 0x73, 0x65, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65,
+	0x22, 0xaf, 0x01, 0x0a, 0x0f, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72,
+	0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x74, 0x6d, 0x6c, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x68, 0x74, 0x6d, 0x6c,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x2e, 0x0a, 0x0b, 0x70, 0x61, 0x75, 0x73, 0x65, 0x42,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x69, 0x64,
+	0x6c, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x52, 0x0b, 0x70, 0x61, 0x75, 0x73, 0x65,
+	0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x22, 0x61, 0x0a, 0x0d, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x72,
+	0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x74, 0x6d, 0x6c, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x68, 0x74, 0x6d, 0x6c, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x34, 0x0a,
+	0x12, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x48, 0x6f, 0x73, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x48, 0x6f,
+	0x73, 0x74, 0x73, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x74, 0x6f, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x13, 0x0a, 0x11, 0x53, 0x74,
+	0x6f, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x0f, 0x0a, 0x0d, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x2c, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x1d, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e,
+	0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x65, 0x70, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x22, 0x0f,
+	0x0a, 0x0d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0xb3, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x25, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x65, 0x70,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x29, 0x0a,
+	0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x74, 0x65, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x09, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x65, 0x70, 0x52, 0x0a, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x53, 0x74, 0x65, 0x70, 0x73, 0x12, 0x25, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74,
+	0x53, 0x74, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x53, 0x74, 0x65, 0x70, 0x52, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x53, 0x74, 0x65, 0x70, 0x12,
+	0x28, 0x0a, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x0a, 0x53, 0x74,
+	0x65, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x65,
+	0x70, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x23, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2f, 0x0a, 0x08,
+	0x73, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x52, 0x08, 0x73, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x73, 0x22, 0xaf, 0x02,
+	0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x12, 0x26, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x52,
+	0x07, 0x73, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x12, 0x23, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x38, 0x0a,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e,
+	0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2f,
+	0x0a, 0x0a, 0x72, 0x73, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x0a, 0x72, 0x73, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22,
+	0xa3, 0x01, 0x0a, 0x0b, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68,
+	0x6f, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x28, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x66, 0x0a, 0x0d, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x23, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x56, 0x0a,
+	0x0d, 0x53, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20,
+	0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x69,
+	0x64, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70,
+	0x12, 0x23, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0b, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xd4, 0x01, 0x0a, 0x0b, 0x53, 0x75, 0x62, 0x73, 0x74, 0x65,
+	0x70, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x26, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x75, 0x62,
+	0x73, 0x74, 0x65, 0x70, 0x52, 0x07, 0x73, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x12, 0x2f, 0x0a,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x69, 0x64, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x50, 0x6c, 0x61, 0x6e, 0x2e,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2f,
+	0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x43, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x22,
+	0x3b, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x0e, 0x75, 0x6e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x00, 0x12, 0x07, 0x0a,
+	0x03, 0x72, 0x75, 0x6e, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x10, 0x02,
+	0x12, 0x0a, 0x0a, 0x06, 0x62, 0x79, 0x70, 0x61, 0x73, 0x73, 0x10, 0x03, 0x22, 0x3e, 0x0a, 0x0e,
+	0x50, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0x1b, 0x0a, 0x19,
+	0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x19, 0x0a, 0x17, 0x50, 0x72, 0x65,
+	0x70, 0x61, 0x72, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0xf0, 0x01, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x22, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0a, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x05, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x74,
+	0x65, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x26, 0x0a, 0x04, 0x70, 0x6c, 0x61, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x69, 0x64, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x65, 0x70, 0x50, 0x6c, 0x61, 0x6e, 0x48,
+	0x00, 0x52, 0x04, 0x70, 0x6c, 0x61, 0x6e, 0x12, 0x32, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
+	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x48,
+	0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xa7, 0x02, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x12, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69,
+	0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x12, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x40, 0x0a, 0x0f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00,
+	0x52, 0x0f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x43, 0x0a, 0x10, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69, 0x64,
+	0x6c, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x48, 0x00, 0x52, 0x10, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0e, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x73, 0x22, 0x4e, 0x0a, 0x12, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x17, 0x48, 0x61, 0x73, 0x41, 0x6c,
+	0x6c, 0x4d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x41, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x6e, 0x64,
+	0x62, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x48, 0x61, 0x73, 0x41, 0x6c, 0x6c,
+	0x4d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x41, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x62,
+	0x79, 0x22, 0x35, 0x0a, 0x0f, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x65, 0x22, 0xea, 0x01, 0x0a, 0x10, 0x46, 0x69, 0x6e,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x30, 0x0a, 0x13, 0x4c, 0x6f, 0x67, 0x41, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x13, 0x4c, 0x6f, 0x67, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x44, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x56, 0x0a, 0x26, 0x41, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x61, 0x74, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x26, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
+	0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12,
+	0x1c, 0x0a, 0x09, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x49, 0x44, 0x12, 0x16, 0x0a,
+	0x06, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x72, 0x0a, 0x0e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x30, 0x0a, 0x13, 0x4c, 0x6f, 0x67, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x4c, 0x6f,
+	0x67, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x26, 0x0a, 0x10, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0x26, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x2f, 0x0a, 0x0b, 0x4e, 0x65, 0x78,
+	0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e,
+	0x65, 0x78, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2a, 0x5a, 0x0a, 0x04, 0x53, 0x74,
+	0x65, 0x70, 0x12, 0x10, 0x0a, 0x0c, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x73, 0x74,
+	0x65, 0x70, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69,
+	0x7a, 0x65, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x10,
+	0x02, 0x12, 0x0c, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x10, 0x03, 0x12,
+	0x0a, 0x0a, 0x06, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x10, 0x05, 0x2a, 0xee, 0x0c, 0x0a, 0x07, 0x53, 0x75, 0x62, 0x73, 0x74,
+	0x65, 0x70, 0x12, 0x13, 0x0a, 0x0f, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x65, 0x70, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x73, 0x61, 0x76, 0x69, 0x6e,
+	0x67, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x68, 0x75, 0x62, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x5f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x10,
+	0x04, 0x12, 0x14, 0x0a, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x64, 0x69, 0x73, 0x6b, 0x5f,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x10, 0x05, 0x12, 0x1a, 0x0a, 0x16, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x10, 0x06, 0x12, 0x17, 0x0a, 0x13, 0x69, 0x6e, 0x69, 0x74, 0x5f, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x07, 0x12, 0x1b, 0x0a, 0x17,
+	0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
+	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x08, 0x12, 0x18, 0x0a, 0x14, 0x62, 0x61, 0x63,
+	0x6b, 0x75, 0x70, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x74, 0x65,
+	0x72, 0x10, 0x09, 0x12, 0x11, 0x0a, 0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x75, 0x70, 0x67,
+	0x72, 0x61, 0x64, 0x65, 0x10, 0x0a, 0x12, 0x1b, 0x0a, 0x17, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f,
+	0x77, 0x6e, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x10, 0x0b, 0x12, 0x12, 0x0a, 0x0e, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x5f, 0x6d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x10, 0x0c, 0x12, 0x0f, 0x0a, 0x0b, 0x63, 0x6f, 0x70, 0x79, 0x5f,
+	0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x10, 0x0d, 0x12, 0x15, 0x0a, 0x11, 0x75, 0x70, 0x67, 0x72,
+	0x61, 0x64, 0x65, 0x5f, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x10, 0x0e, 0x12,
+	0x18, 0x0a, 0x14, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
+	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x0f, 0x12, 0x19, 0x0a, 0x15, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x61, 0x74, 0x61, 0x6c,
+	0x6f, 0x67, 0x10, 0x10, 0x12, 0x1b, 0x0a, 0x17, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x10,
+	0x11, 0x12, 0x1c, 0x0a, 0x18, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x10, 0x12, 0x12,
+	0x13, 0x0a, 0x0f, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6e, 0x64,
+	0x62, 0x79, 0x10, 0x13, 0x12, 0x13, 0x0a, 0x0f, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x5f,
+	0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x10, 0x14, 0x12, 0x16, 0x0a, 0x12, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x10,
+	0x15, 0x12, 0x22, 0x0a, 0x1e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x64,
+	0x69, 0x72, 0x73, 0x10, 0x16, 0x12, 0x1c, 0x0a, 0x18, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f,
+	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x64, 0x69, 0x72,
+	0x73, 0x10, 0x17, 0x12, 0x17, 0x0a, 0x13, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x68, 0x75, 0x62, 0x5f,
+	0x61, 0x6e, 0x64, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x10, 0x18, 0x12, 0x1a, 0x0a, 0x16,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x64, 0x69, 0x72, 0x10, 0x19, 0x12, 0x1b, 0x0a, 0x17, 0x61, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x10, 0x1a, 0x12, 0x1a, 0x0a, 0x16, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10,
+	0x1b, 0x12, 0x18, 0x0a, 0x14, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x1c, 0x12, 0x15, 0x0a, 0x11, 0x72,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x70, 0x67, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x10, 0x1d, 0x12, 0x1d, 0x0a, 0x19, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x73, 0x65, 0x67,
+	0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10,
+	0x1e, 0x12, 0x0f, 0x0a, 0x0b, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x10, 0x1f, 0x12, 0x41, 0x0a, 0x3d, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x63,
 	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x65, 0x5f, 0x72, 0x65, 0x61,
-	0x64, 0x79, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x69, 0x6e,
-	0x67, 0x5f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x10, 0x21, 0x12, 0x32, 0x0a, 0x2e, 0x73,
-	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x5f, 0x6c,
-	0x69, 0x62, 0x72, 0x61, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x6f, 0x6e, 0x5f, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x22, 0x12,
-	0x2e, 0x0a, 0x2a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6f, 0x6e, 0x5f, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x23, 0x12,
-	0x2e, 0x0a, 0x2a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6f, 0x6e, 0x5f, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x24, 0x12,
-	0x23, 0x0a, 0x1f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61,
-	0x5f, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x73, 0x10, 0x25, 0x12, 0x28, 0x0a, 0x24, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x5f,
-	0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x69, 0x67, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x10, 0x26, 0x12, 0x2d,
-	0x0a, 0x29, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61,
+	0x64, 0x79, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x5f,
+	0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x5f, 0x61, 0x6e, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x6e,
+	0x64, 0x62, 0x79, 0x10, 0x20, 0x12, 0x37, 0x0a, 0x33, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x6f,
+	0x72, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x65, 0x5f,
+	0x72, 0x65, 0x61, 0x64, 0x79, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x10, 0x21, 0x12, 0x32,
+	0x0a, 0x2e, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x79, 0x6e, 0x61, 0x6d, 0x69,
+	0x63, 0x5f, 0x6c, 0x69, 0x62, 0x72, 0x61, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x6f,
+	0x6e, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x10, 0x22, 0x12, 0x2e, 0x0a, 0x2a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6f,
+	0x6e, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x10, 0x23, 0x12, 0x2e, 0x0a, 0x2a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6f,
+	0x6e, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x10, 0x24, 0x12, 0x23, 0x0a, 0x1f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x73, 0x10, 0x25, 0x12, 0x28, 0x0a, 0x24, 0x65, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x69,
+	0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x10,
+	0x26, 0x12, 0x2d, 0x0a, 0x29, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x5f, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x69, 0x67,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x10, 0x27,
+	0x12, 0x2b, 0x0a, 0x27, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x5f, 0x66, 0x69, 0x6e, 0x61,
 	0x6c, 0x69, 0x7a, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x10, 0x27, 0x12, 0x2b, 0x0a,
-	0x27, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x5f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a,
-	0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x10, 0x28, 0x12, 0x29, 0x0a, 0x25, 0x65, 0x78,
-	0x65, 0x63, 0x75, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74,
-	0x61, 0x5f, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x73, 0x10, 0x29, 0x12, 0x15, 0x0a, 0x11, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f,
-	0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x64, 0x69, 0x72, 0x73, 0x10, 0x2a, 0x12, 0x14, 0x0a, 0x10,
-	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x64, 0x69, 0x72,
-	0x10, 0x2b, 0x12, 0x1a, 0x0a, 0x16, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65, 0x5f, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x2c, 0x12, 0x27,
-	0x0a, 0x23, 0x65, 0x6e, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x67, 0x70, 0x75, 0x70, 0x67, 0x72, 0x61,
-	0x64, 0x65, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x61, 0x72, 0x65, 0x5f, 0x72, 0x75,
-	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x2d, 0x12, 0x18, 0x0a, 0x14, 0x76, 0x65, 0x72, 0x69, 0x66,
-	0x79, 0x5f, 0x67, 0x70, 0x64, 0x62, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x10,
-	0x2e, 0x12, 0x32, 0x0a, 0x2e, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x67, 0x70, 0x75, 0x70,
-	0x67, 0x72, 0x61, 0x64, 0x65, 0x5f, 0x69, 0x73, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
-	0x65, 0x64, 0x5f, 0x61, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x68, 0x6f,
-	0x73, 0x74, 0x73, 0x10, 0x2f, 0x12, 0x2b, 0x0a, 0x27, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c,
-	0x69, 0x7a, 0x65, 0x5f, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x63, 0x6c, 0x75,
-	0x73, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x79,
-	0x10, 0x30, 0x12, 0x36, 0x0a, 0x32, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x63,
-	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x65, 0x5f, 0x72, 0x65, 0x61,
-	0x64, 0x79, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x5f, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64,
-	0x65, 0x5f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x10, 0x31, 0x2a, 0x5a, 0x0a, 0x06, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x0e, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e,
-	0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
-	0x65, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x03, 0x12,
-	0x0b, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x10, 0x04, 0x12, 0x08, 0x0a, 0x04,
-	0x71, 0x75, 0x69, 0x74, 0x10, 0x05, 0x32, 0xf0, 0x03, 0x0a, 0x08, 0x43, 0x6c, 0x69, 0x54, 0x6f,
-	0x48, 0x75, 0x62, 0x12, 0x36, 0x0a, 0x0a, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a,
-	0x65, 0x12, 0x16, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69,
-	0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x17, 0x49,
-	0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43,
-	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x23, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x49, 0x6e, 0x69,
-	0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6c, 0x75,
-	0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x69, 0x64,
-	0x6c, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x30, 0x0a,
-	0x07, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x12, 0x13, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x45,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e,
-	0x69, 0x64, 0x6c, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12,
-	0x32, 0x0a, 0x08, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x2e, 0x69, 0x64,
-	0x6c, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x0c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x00, 0x30, 0x01, 0x12, 0x2e, 0x0a, 0x06, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x12, 0x12, 0x2e,
-	0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x0c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x00, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x12, 0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x47, 0x65,
-	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x45,
-	0x0a, 0x0d, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12,
-	0x19, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65,
-	0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x69, 0x64, 0x6c,
-	0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x70,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x16, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x72, 0x65, 0x65, 0x6e, 0x70, 0x6c, 0x75,
-	0x6d, 0x2d, 0x64, 0x62, 0x2f, 0x67, 0x70, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x2f, 0x69,
-	0x64, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x10, 0x28, 0x12, 0x29, 0x0a,
+	0x25, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x5f,
+	0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x10, 0x29, 0x12, 0x15, 0x0a, 0x11, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x64, 0x69, 0x72, 0x73, 0x10, 0x2a, 0x12,
+	0x14, 0x0a, 0x10, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70,
+	0x64, 0x69, 0x72, 0x10, 0x2b, 0x12, 0x1a, 0x0a, 0x16, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65,
+	0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10,
+	0x2c, 0x12, 0x27, 0x0a, 0x23, 0x65, 0x6e, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x67, 0x70, 0x75, 0x70,
+	0x67, 0x72, 0x61, 0x64, 0x65, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x61, 0x72, 0x65,
+	0x5f, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x2d, 0x12, 0x18, 0x0a, 0x14, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x5f, 0x67, 0x70, 0x64, 0x62, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x10, 0x2e, 0x12, 0x32, 0x0a, 0x2e, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x67,
+	0x70, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x5f, 0x69, 0x73, 0x5f, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x61, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x5f, 0x61, 0x6c, 0x6c,
+	0x5f, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x10, 0x2f, 0x12, 0x2b, 0x0a, 0x27, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x5f, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x5f,
+	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x65, 0x5f, 0x72, 0x65,
+	0x61, 0x64, 0x79, 0x10, 0x30, 0x12, 0x36, 0x0a, 0x32, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x6f,
+	0x72, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x65, 0x5f,
+	0x72, 0x65, 0x61, 0x64, 0x79, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x5f, 0x75, 0x70, 0x67,
+	0x72, 0x61, 0x64, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x10, 0x31, 0x12, 0x1b, 0x0a,
+	0x17, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x32, 0x12, 0x1b, 0x0a, 0x17, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x10, 0x33, 0x2a, 0x66, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x12, 0x0a, 0x0e, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x02,
+	0x12, 0x0a, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07,
+	0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x10, 0x04, 0x12, 0x08, 0x0a, 0x04, 0x71, 0x75, 0x69,
+	0x74, 0x10, 0x05, 0x12, 0x0a, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x10, 0x06, 0x32,
+	0x8d, 0x05, 0x0a, 0x08, 0x43, 0x6c, 0x69, 0x54, 0x6f, 0x48, 0x75, 0x62, 0x12, 0x36, 0x0a, 0x0a,
+	0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x00, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x17, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69,
+	0x7a, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12,
+	0x23, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x30, 0x0a, 0x07, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x65, 0x12, 0x13, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x08, 0x46, 0x69, 0x6e, 0x61,
+	0x6c, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x2e, 0x0a, 0x06,
+	0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x12, 0x12, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x76,
+	0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x09,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x13, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x19, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42,
+	0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x18,
+	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53,
+	0x74, 0x6f, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x39, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x30, 0x0a,
+	0x06, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x12, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x69, 0x64,
+	0x6c, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x2e, 0x0a, 0x06, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x12, 0x12, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
+	0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e,
+	0x69, 0x64, 0x6c, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42,
+	0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x72,
+	0x65, 0x65, 0x6e, 0x70, 0x6c, 0x75, 0x6d, 0x2d, 0x64, 0x62, 0x2f, 0x67, 0x70, 0x75, 0x70, 0x67,
+	0x72, 0x61, 0x64, 0x65, 0x2f, 0x69, 0x64, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1831,12 +2735,12 @@ func file_cli_to_hub_proto_rawDescGZIP() []byte {
 }
 
 var file_cli_to_hub_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_cli_to_hub_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_cli_to_hub_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_cli_to_hub_proto_goTypes = []interface{}{
 	(Step)(0),                              // 0: idl.Step
 	(Substep)(0),                           // 1: idl.Substep
 	(Status)(0),                            // 2: idl.Status
-	(Chunk_Type)(0),                        // 3: idl.Chunk.Type
+	(SubstepPlan_Action)(0),                // 3: idl.SubstepPlan.Action
 	(*InitializeRequest)(nil),              // 4: idl.InitializeRequest
 	(*InitializeCreateClusterRequest)(nil), // 5: idl.InitializeCreateClusterRequest
 	(*ExecuteRequest)(nil),                 // 6: idl.ExecuteRequest
@@ -1846,52 +2750,97 @@ var file_cli_to_hub_proto_goTypes = []interface{}{
 	(*RestartAgentsReply)(nil),             // 10: idl.RestartAgentsReply
 	(*StopServicesRequest)(nil),            // 11: idl.StopServicesRequest
 	(*StopServicesReply)(nil),              // 12: idl.StopServicesReply
-	(*SubstepStatus)(nil),                  // 13: idl.SubstepStatus
-	(*PrepareInitClusterRequest)(nil),      // 14: idl.PrepareInitClusterRequest
-	(*PrepareInitClusterReply)(nil),        // 15: idl.PrepareInitClusterReply
-	(*Chunk)(nil),                          // 16: idl.Chunk
-	(*Message)(nil),                        // 17: idl.Message
-	(*Response)(nil),                       // 18: idl.Response
-	(*InitializeResponse)(nil),             // 19: idl.InitializeResponse
-	(*ExecuteResponse)(nil),                // 20: idl.ExecuteResponse
-	(*FinalizeResponse)(nil),               // 21: idl.FinalizeResponse
-	(*RevertResponse)(nil),                 // 22: idl.RevertResponse
-	(*GetConfigRequest)(nil),               // 23: idl.GetConfigRequest
-	(*GetConfigReply)(nil),                 // 24: idl.GetConfigReply
-	(*NextActions)(nil),                    // 25: idl.NextActions
+	(*CancelRequest)(nil),                  // 13: idl.CancelRequest
+	(*CancelReply)(nil),                    // 14: idl.CancelReply
+	(*AttachRequest)(nil),                  // 15: idl.AttachRequest
+	(*GetStatusRequest)(nil),               // 16: idl.GetStatusRequest
+	(*GetStatusReply)(nil),                 // 17: idl.GetStatusReply
+	(*StepStatus)(nil),                     // 18: idl.StepStatus
+	(*SubstepDetails)(nil),                 // 19: idl.SubstepDetails
+	(*AgentStatus)(nil),                    // 20: idl.AgentStatus
+	(*SegmentStatus)(nil),                  // 21: idl.SegmentStatus
+	(*SubstepStatus)(nil),                  // 22: idl.SubstepStatus
+	(*SubstepPlan)(nil),                    // 23: idl.SubstepPlan
+	(*PlannedCommand)(nil),                 // 24: idl.PlannedCommand
+	(*PrepareInitClusterRequest)(nil),      // 25: idl.PrepareInitClusterRequest
+	(*PrepareInitClusterReply)(nil),        // 26: idl.PrepareInitClusterReply
+	(*Message)(nil),                        // 27: idl.Message
+	(*Response)(nil),                       // 28: idl.Response
+	(*InitializeResponse)(nil),             // 29: idl.InitializeResponse
+	(*ExecuteResponse)(nil),                // 30: idl.ExecuteResponse
+	(*FinalizeResponse)(nil),               // 31: idl.FinalizeResponse
+	(*RevertResponse)(nil),                 // 32: idl.RevertResponse
+	(*GetConfigRequest)(nil),               // 33: idl.GetConfigRequest
+	(*GetConfigReply)(nil),                 // 34: idl.GetConfigReply
+	(*NextActions)(nil),                    // 35: idl.NextActions
+	(*timestamppb.Timestamp)(nil),          // 36: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),            // 37: google.protobuf.Duration
+	(*RsyncStats)(nil),                     // 38: idl.RsyncStats
+	(*AgentHealth)(nil),                    // 39: idl.AgentHealth
+	(*Chunk)(nil),                          // 40: idl.Chunk
+	(*SegmentProgress)(nil),                // 41: idl.SegmentProgress
 }
 var file_cli_to_hub_proto_depIdxs = []int32{
-	1,  // 0: idl.SubstepStatus.step:type_name -> idl.Substep
-	2,  // 1: idl.SubstepStatus.status:type_name -> idl.Status
-	3,  // 2: idl.Chunk.type:type_name -> idl.Chunk.Type
-	16, // 3: idl.Message.chunk:type_name -> idl.Chunk
-	13, // 4: idl.Message.status:type_name -> idl.SubstepStatus
-	18, // 5: idl.Message.response:type_name -> idl.Response
-	19, // 6: idl.Response.initializeResponse:type_name -> idl.InitializeResponse
-	20, // 7: idl.Response.executeResponse:type_name -> idl.ExecuteResponse
-	21, // 8: idl.Response.finalizeResponse:type_name -> idl.FinalizeResponse
-	22, // 9: idl.Response.revertResponse:type_name -> idl.RevertResponse
-	4,  // 10: idl.CliToHub.Initialize:input_type -> idl.InitializeRequest
-	5,  // 11: idl.CliToHub.InitializeCreateCluster:input_type -> idl.InitializeCreateClusterRequest
-	6,  // 12: idl.CliToHub.Execute:input_type -> idl.ExecuteRequest
-	7,  // 13: idl.CliToHub.Finalize:input_type -> idl.FinalizeRequest
-	8,  // 14: idl.CliToHub.Revert:input_type -> idl.RevertRequest
-	23, // 15: idl.CliToHub.GetConfig:input_type -> idl.GetConfigRequest
-	9,  // 16: idl.CliToHub.RestartAgents:input_type -> idl.RestartAgentsRequest
-	11, // 17: idl.CliToHub.StopServices:input_type -> idl.StopServicesRequest
-	17, // 18: idl.CliToHub.Initialize:output_type -> idl.Message
-	17, // 19: idl.CliToHub.InitializeCreateCluster:output_type -> idl.Message
-	17, // 20: idl.CliToHub.Execute:output_type -> idl.Message
-	17, // 21: idl.CliToHub.Finalize:output_type -> idl.Message
-	17, // 22: idl.CliToHub.Revert:output_type -> idl.Message
-	24, // 23: idl.CliToHub.GetConfig:output_type -> idl.GetConfigReply
-	10, // 24: idl.CliToHub.RestartAgents:output_type -> idl.RestartAgentsReply
-	12, // 25: idl.CliToHub.StopServices:output_type -> idl.StopServicesReply
-	18, // [18:26] is the sub-list for method output_type
-	10, // [10:18] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	1,  // 0: idl.ExecuteRequest.pauseBefore:type_name -> idl.Substep
+	1,  // 1: idl.FinalizeRequest.pauseBefore:type_name -> idl.Substep
+	0,  // 2: idl.CancelReply.step:type_name -> idl.Step
+	18, // 3: idl.GetStatusReply.steps:type_name -> idl.StepStatus
+	0,  // 4: idl.GetStatusReply.validSteps:type_name -> idl.Step
+	0,  // 5: idl.GetStatusReply.nextStep:type_name -> idl.Step
+	20, // 6: idl.GetStatusReply.agents:type_name -> idl.AgentStatus
+	0,  // 7: idl.StepStatus.step:type_name -> idl.Step
+	2,  // 8: idl.StepStatus.status:type_name -> idl.Status
+	19, // 9: idl.StepStatus.substeps:type_name -> idl.SubstepDetails
+	1,  // 10: idl.SubstepDetails.substep:type_name -> idl.Substep
+	2,  // 11: idl.SubstepDetails.status:type_name -> idl.Status
+	36, // 12: idl.SubstepDetails.startTime:type_name -> google.protobuf.Timestamp
+	37, // 13: idl.SubstepDetails.duration:type_name -> google.protobuf.Duration
+	21, // 14: idl.SubstepDetails.segments:type_name -> idl.SegmentStatus
+	38, // 15: idl.SubstepDetails.rsyncStats:type_name -> idl.RsyncStats
+	36, // 16: idl.AgentStatus.lastHeartbeat:type_name -> google.protobuf.Timestamp
+	39, // 17: idl.AgentStatus.health:type_name -> idl.AgentHealth
+	2,  // 18: idl.SegmentStatus.status:type_name -> idl.Status
+	1,  // 19: idl.SubstepStatus.step:type_name -> idl.Substep
+	2,  // 20: idl.SubstepStatus.status:type_name -> idl.Status
+	1,  // 21: idl.SubstepPlan.substep:type_name -> idl.Substep
+	3,  // 22: idl.SubstepPlan.action:type_name -> idl.SubstepPlan.Action
+	24, // 23: idl.SubstepPlan.commands:type_name -> idl.PlannedCommand
+	40, // 24: idl.Message.chunk:type_name -> idl.Chunk
+	22, // 25: idl.Message.status:type_name -> idl.SubstepStatus
+	28, // 26: idl.Message.response:type_name -> idl.Response
+	23, // 27: idl.Message.plan:type_name -> idl.SubstepPlan
+	41, // 28: idl.Message.progress:type_name -> idl.SegmentProgress
+	29, // 29: idl.Response.initializeResponse:type_name -> idl.InitializeResponse
+	30, // 30: idl.Response.executeResponse:type_name -> idl.ExecuteResponse
+	31, // 31: idl.Response.finalizeResponse:type_name -> idl.FinalizeResponse
+	32, // 32: idl.Response.revertResponse:type_name -> idl.RevertResponse
+	4,  // 33: idl.CliToHub.Initialize:input_type -> idl.InitializeRequest
+	5,  // 34: idl.CliToHub.InitializeCreateCluster:input_type -> idl.InitializeCreateClusterRequest
+	6,  // 35: idl.CliToHub.Execute:input_type -> idl.ExecuteRequest
+	7,  // 36: idl.CliToHub.Finalize:input_type -> idl.FinalizeRequest
+	8,  // 37: idl.CliToHub.Revert:input_type -> idl.RevertRequest
+	33, // 38: idl.CliToHub.GetConfig:input_type -> idl.GetConfigRequest
+	9,  // 39: idl.CliToHub.RestartAgents:input_type -> idl.RestartAgentsRequest
+	11, // 40: idl.CliToHub.StopServices:input_type -> idl.StopServicesRequest
+	16, // 41: idl.CliToHub.GetStatus:input_type -> idl.GetStatusRequest
+	13, // 42: idl.CliToHub.Cancel:input_type -> idl.CancelRequest
+	15, // 43: idl.CliToHub.Attach:input_type -> idl.AttachRequest
+	27, // 44: idl.CliToHub.Initialize:output_type -> idl.Message
+	27, // 45: idl.CliToHub.InitializeCreateCluster:output_type -> idl.Message
+	27, // 46: idl.CliToHub.Execute:output_type -> idl.Message
+	27, // 47: idl.CliToHub.Finalize:output_type -> idl.Message
+	27, // 48: idl.CliToHub.Revert:output_type -> idl.Message
+	34, // 49: idl.CliToHub.GetConfig:output_type -> idl.GetConfigReply
+	10, // 50: idl.CliToHub.RestartAgents:output_type -> idl.RestartAgentsReply
+	12, // 51: idl.CliToHub.StopServices:output_type -> idl.StopServicesReply
+	17, // 52: idl.CliToHub.GetStatus:output_type -> idl.GetStatusReply
+	14, // 53: idl.CliToHub.Cancel:output_type -> idl.CancelReply
+	27, // 54: idl.CliToHub.Attach:output_type -> idl.Message
+	44, // [44:55] is the sub-list for method output_type
+	33, // [33:44] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_cli_to_hub_proto_init() }
@@ -1899,6 +2848,7 @@ func file_cli_to_hub_proto_init() {
 	if File_cli_to_hub_proto != nil {
 		return
 	}
+	file_common_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_cli_to_hub_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*InitializeRequest); i {
@@ -2009,7 +2959,7 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubstepStatus); i {
+			switch v := v.(*CancelRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2021,7 +2971,7 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrepareInitClusterRequest); i {
+			switch v := v.(*CancelReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2033,7 +2983,7 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrepareInitClusterReply); i {
+			switch v := v.(*AttachRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2045,7 +2995,7 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Chunk); i {
+			switch v := v.(*GetStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2057,7 +3007,7 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Message); i {
+			switch v := v.(*GetStatusReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2069,7 +3019,7 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Response); i {
+			switch v := v.(*StepStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2081,7 +3031,7 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InitializeResponse); i {
+			switch v := v.(*SubstepDetails); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2093,7 +3043,7 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExecuteResponse); i {
+			switch v := v.(*AgentStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2105,7 +3055,7 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FinalizeResponse); i {
+			switch v := v.(*SegmentStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2117,7 +3067,7 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RevertResponse); i {
+			switch v := v.(*SubstepStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2129,7 +3079,7 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetConfigRequest); i {
+			switch v := v.(*SubstepPlan); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2141,7 +3091,7 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetConfigReply); i {
+			switch v := v.(*PlannedCommand); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2153,6 +3103,126 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 		file_cli_to_hub_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrepareInitClusterRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cli_to_hub_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrepareInitClusterReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cli_to_hub_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cli_to_hub_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cli_to_hub_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InitializeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cli_to_hub_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecuteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cli_to_hub_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FinalizeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cli_to_hub_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevertResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cli_to_hub_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cli_to_hub_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConfigReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cli_to_hub_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NextActions); i {
 			case 0:
 				return &v.state
@@ -2165,12 +3235,14 @@ func file_cli_to_hub_proto_init() {
 			}
 		}
 	}
-	file_cli_to_hub_proto_msgTypes[13].OneofWrappers = []interface{}{
+	file_cli_to_hub_proto_msgTypes[23].OneofWrappers = []interface{}{
 		(*Message_Chunk)(nil),
 		(*Message_Status)(nil),
 		(*Message_Response)(nil),
+		(*Message_Plan)(nil),
+		(*Message_Progress)(nil),
 	}
-	file_cli_to_hub_proto_msgTypes[14].OneofWrappers = []interface{}{
+	file_cli_to_hub_proto_msgTypes[24].OneofWrappers = []interface{}{
 		(*Response_InitializeResponse)(nil),
 		(*Response_ExecuteResponse)(nil),
 		(*Response_FinalizeResponse)(nil),
@@ -2182,7 +3254,7 @@ func file_cli_to_hub_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cli_to_hub_proto_rawDesc,
 			NumEnums:      4,
-			NumMessages:   22,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
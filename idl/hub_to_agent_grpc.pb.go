@@ -39,6 +39,9 @@ const (
 	Agent_RenameTablespaces_FullMethodName           = "/idl.Agent/RenameTablespaces"
 	Agent_CreateRecoveryConf_FullMethodName          = "/idl.Agent/CreateRecoveryConf"
 	Agent_AddReplicationEntries_FullMethodName       = "/idl.Agent/AddReplicationEntries"
+	Agent_ExecCommand_FullMethodName                 = "/idl.Agent/ExecCommand"
+	Agent_Heartbeat_FullMethodName                   = "/idl.Agent/Heartbeat"
+	Agent_RemoteShell_FullMethodName                 = "/idl.Agent/RemoteShell"
 )
 
 // AgentClient is the client API for Agent service.
@@ -47,7 +50,7 @@ const (
 type AgentClient interface {
 	CreateBackupDirectory(ctx context.Context, in *CreateBackupDirectoryRequest, opts ...grpc.CallOption) (*CreateBackupDirectoryReply, error)
 	CheckDiskSpace(ctx context.Context, in *CheckSegmentDiskSpaceRequest, opts ...grpc.CallOption) (*CheckDiskSpaceReply, error)
-	UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (*UpgradePrimariesReply, error)
+	UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (Agent_UpgradePrimariesClient, error)
 	RenameDirectories(ctx context.Context, in *RenameDirectoriesRequest, opts ...grpc.CallOption) (*RenameDirectoriesReply, error)
 	StopAgent(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error)
 	DeleteDataDirectories(ctx context.Context, in *DeleteDataDirectoriesRequest, opts ...grpc.CallOption) (*DeleteDataDirectoriesReply, error)
@@ -62,6 +65,9 @@ type AgentClient interface {
 	RenameTablespaces(ctx context.Context, in *RenameTablespacesRequest, opts ...grpc.CallOption) (*RenameTablespacesReply, error)
 	CreateRecoveryConf(ctx context.Context, in *CreateRecoveryConfRequest, opts ...grpc.CallOption) (*CreateRecoveryConfReply, error)
 	AddReplicationEntries(ctx context.Context, in *AddReplicationEntriesRequest, opts ...grpc.CallOption) (*AddReplicationEntriesReply, error)
+	ExecCommand(ctx context.Context, in *ExecCommandRequest, opts ...grpc.CallOption) (*ExecCommandReply, error)
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatReply, error)
+	RemoteShell(ctx context.Context, opts ...grpc.CallOption) (Agent_RemoteShellClient, error)
 }
 
 type agentClient struct {
@@ -90,13 +96,36 @@ func (c *agentClient) CheckDiskSpace(ctx context.Context, in *CheckSegmentDiskSp
 	return out, nil
 }
 
-func (c *agentClient) UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (*UpgradePrimariesReply, error) {
-	out := new(UpgradePrimariesReply)
-	err := c.cc.Invoke(ctx, Agent_UpgradePrimaries_FullMethodName, in, out, opts...)
+func (c *agentClient) UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (Agent_UpgradePrimariesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[0], Agent_UpgradePrimaries_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &agentUpgradePrimariesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_UpgradePrimariesClient interface {
+	Recv() (*UpgradePrimariesReply, error)
+	grpc.ClientStream
+}
+
+type agentUpgradePrimariesClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentUpgradePrimariesClient) Recv() (*UpgradePrimariesReply, error) {
+	m := new(UpgradePrimariesReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
 func (c *agentClient) RenameDirectories(ctx context.Context, in *RenameDirectoriesRequest, opts ...grpc.CallOption) (*RenameDirectoriesReply, error) {
@@ -225,13 +254,62 @@ func (c *agentClient) AddReplicationEntries(ctx context.Context, in *AddReplicat
 	return out, nil
 }
 
+func (c *agentClient) ExecCommand(ctx context.Context, in *ExecCommandRequest, opts ...grpc.CallOption) (*ExecCommandReply, error) {
+	out := new(ExecCommandReply)
+	err := c.cc.Invoke(ctx, Agent_ExecCommand_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatReply, error) {
+	out := new(HeartbeatReply)
+	err := c.cc.Invoke(ctx, Agent_Heartbeat_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) RemoteShell(ctx context.Context, opts ...grpc.CallOption) (Agent_RemoteShellClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[1], Agent_RemoteShell_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentRemoteShellClient{stream}
+	return x, nil
+}
+
+type Agent_RemoteShellClient interface {
+	Send(*RemoteShellRequest) error
+	Recv() (*RemoteShellReply, error)
+	grpc.ClientStream
+}
+
+type agentRemoteShellClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentRemoteShellClient) Send(m *RemoteShellRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentRemoteShellClient) Recv() (*RemoteShellReply, error) {
+	m := new(RemoteShellReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // AgentServer is the server API for Agent service.
 // All implementations should embed UnimplementedAgentServer
 // for forward compatibility
 type AgentServer interface {
 	CreateBackupDirectory(context.Context, *CreateBackupDirectoryRequest) (*CreateBackupDirectoryReply, error)
 	CheckDiskSpace(context.Context, *CheckSegmentDiskSpaceRequest) (*CheckDiskSpaceReply, error)
-	UpgradePrimaries(context.Context, *UpgradePrimariesRequest) (*UpgradePrimariesReply, error)
+	UpgradePrimaries(*UpgradePrimariesRequest, Agent_UpgradePrimariesServer) error
 	RenameDirectories(context.Context, *RenameDirectoriesRequest) (*RenameDirectoriesReply, error)
 	StopAgent(context.Context, *StopAgentRequest) (*StopAgentReply, error)
 	DeleteDataDirectories(context.Context, *DeleteDataDirectoriesRequest) (*DeleteDataDirectoriesReply, error)
@@ -246,6 +324,9 @@ type AgentServer interface {
 	RenameTablespaces(context.Context, *RenameTablespacesRequest) (*RenameTablespacesReply, error)
 	CreateRecoveryConf(context.Context, *CreateRecoveryConfRequest) (*CreateRecoveryConfReply, error)
 	AddReplicationEntries(context.Context, *AddReplicationEntriesRequest) (*AddReplicationEntriesReply, error)
+	ExecCommand(context.Context, *ExecCommandRequest) (*ExecCommandReply, error)
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatReply, error)
+	RemoteShell(Agent_RemoteShellServer) error
 }
 
 // UnimplementedAgentServer should be embedded to have forward compatible implementations.
@@ -258,8 +339,8 @@ func (UnimplementedAgentServer) CreateBackupDirectory(context.Context, *CreateBa
 func (UnimplementedAgentServer) CheckDiskSpace(context.Context, *CheckSegmentDiskSpaceRequest) (*CheckDiskSpaceReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckDiskSpace not implemented")
 }
-func (UnimplementedAgentServer) UpgradePrimaries(context.Context, *UpgradePrimariesRequest) (*UpgradePrimariesReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpgradePrimaries not implemented")
+func (UnimplementedAgentServer) UpgradePrimaries(*UpgradePrimariesRequest, Agent_UpgradePrimariesServer) error {
+	return status.Errorf(codes.Unimplemented, "method UpgradePrimaries not implemented")
 }
 func (UnimplementedAgentServer) RenameDirectories(context.Context, *RenameDirectoriesRequest) (*RenameDirectoriesReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RenameDirectories not implemented")
@@ -303,6 +384,15 @@ func (UnimplementedAgentServer) CreateRecoveryConf(context.Context, *CreateRecov
 func (UnimplementedAgentServer) AddReplicationEntries(context.Context, *AddReplicationEntriesRequest) (*AddReplicationEntriesReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddReplicationEntries not implemented")
 }
+func (UnimplementedAgentServer) ExecCommand(context.Context, *ExecCommandRequest) (*ExecCommandReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecCommand not implemented")
+}
+func (UnimplementedAgentServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedAgentServer) RemoteShell(Agent_RemoteShellServer) error {
+	return status.Errorf(codes.Unimplemented, "method RemoteShell not implemented")
+}
 
 // UnsafeAgentServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to AgentServer will
@@ -351,22 +441,25 @@ func _Agent_CheckDiskSpace_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_UpgradePrimaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpgradePrimariesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func _Agent_UpgradePrimaries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpgradePrimariesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	if interceptor == nil {
-		return srv.(AgentServer).UpgradePrimaries(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: Agent_UpgradePrimaries_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).UpgradePrimaries(ctx, req.(*UpgradePrimariesRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(AgentServer).UpgradePrimaries(m, &agentUpgradePrimariesServer{stream})
+}
+
+type Agent_UpgradePrimariesServer interface {
+	Send(*UpgradePrimariesReply) error
+	grpc.ServerStream
+}
+
+type agentUpgradePrimariesServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentUpgradePrimariesServer) Send(m *UpgradePrimariesReply) error {
+	return x.ServerStream.SendMsg(m)
 }
 
 func _Agent_RenameDirectories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
@@ -621,6 +714,68 @@ func _Agent_AddReplicationEntries_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_ExecCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ExecCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_ExecCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ExecCommand(ctx, req.(*ExecCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_RemoteShell_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).RemoteShell(&agentRemoteShellServer{stream})
+}
+
+type Agent_RemoteShellServer interface {
+	Send(*RemoteShellReply) error
+	Recv() (*RemoteShellRequest, error)
+	grpc.ServerStream
+}
+
+type agentRemoteShellServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentRemoteShellServer) Send(m *RemoteShellReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentRemoteShellServer) Recv() (*RemoteShellRequest, error) {
+	m := new(RemoteShellRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Agent_ServiceDesc is the grpc.ServiceDesc for Agent service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -636,10 +791,6 @@ var Agent_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckDiskSpace",
 			Handler:    _Agent_CheckDiskSpace_Handler,
 		},
-		{
-			MethodName: "UpgradePrimaries",
-			Handler:    _Agent_UpgradePrimaries_Handler,
-		},
 		{
 			MethodName: "RenameDirectories",
 			Handler:    _Agent_RenameDirectories_Handler,
@@ -696,7 +847,27 @@ var Agent_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddReplicationEntries",
 			Handler:    _Agent_AddReplicationEntries_Handler,
 		},
+		{
+			MethodName: "ExecCommand",
+			Handler:    _Agent_ExecCommand_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _Agent_Heartbeat_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UpgradePrimaries",
+			Handler:       _Agent_UpgradePrimaries_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RemoteShell",
+			Handler:       _Agent_RemoteShell_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "hub_to_agent.proto",
 }
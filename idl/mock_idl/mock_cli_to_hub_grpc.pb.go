@@ -37,6 +37,46 @@ func (m *MockCliToHubClient) EXPECT() *MockCliToHubClientMockRecorder {
 	return m.recorder
 }
 
+// Attach mocks base method.
+func (m *MockCliToHubClient) Attach(ctx context.Context, in *idl.AttachRequest, opts ...grpc.CallOption) (idl.CliToHub_AttachClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Attach", varargs...)
+	ret0, _ := ret[0].(idl.CliToHub_AttachClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockCliToHubClientMockRecorder) Attach(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockCliToHubClient)(nil).Attach), varargs...)
+}
+
+// Cancel mocks base method.
+func (m *MockCliToHubClient) Cancel(ctx context.Context, in *idl.CancelRequest, opts ...grpc.CallOption) (*idl.CancelReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Cancel", varargs...)
+	ret0, _ := ret[0].(*idl.CancelReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockCliToHubClientMockRecorder) Cancel(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockCliToHubClient)(nil).Cancel), varargs...)
+}
+
 // Execute mocks base method.
 func (m *MockCliToHubClient) Execute(ctx context.Context, in *idl.ExecuteRequest, opts ...grpc.CallOption) (idl.CliToHub_ExecuteClient, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +137,26 @@ func (mr *MockCliToHubClientMockRecorder) GetConfig(ctx, in interface{}, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockCliToHubClient)(nil).GetConfig), varargs...)
 }
 
+// GetStatus mocks base method.
+func (m *MockCliToHubClient) GetStatus(ctx context.Context, in *idl.GetStatusRequest, opts ...grpc.CallOption) (*idl.GetStatusReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetStatus", varargs...)
+	ret0, _ := ret[0].(*idl.GetStatusReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockCliToHubClientMockRecorder) GetStatus(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockCliToHubClient)(nil).GetStatus), varargs...)
+}
+
 // Initialize mocks base method.
 func (m *MockCliToHubClient) Initialize(ctx context.Context, in *idl.InitializeRequest, opts ...grpc.CallOption) (idl.CliToHub_InitializeClient, error) {
 	m.ctrl.T.Helper()
@@ -812,6 +872,129 @@ func (mr *MockCliToHub_RevertClientMockRecorder) Trailer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockCliToHub_RevertClient)(nil).Trailer))
 }
 
+// MockCliToHub_AttachClient is a mock of CliToHub_AttachClient interface.
+type MockCliToHub_AttachClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCliToHub_AttachClientMockRecorder
+}
+
+// MockCliToHub_AttachClientMockRecorder is the mock recorder for MockCliToHub_AttachClient.
+type MockCliToHub_AttachClientMockRecorder struct {
+	mock *MockCliToHub_AttachClient
+}
+
+// NewMockCliToHub_AttachClient creates a new mock instance.
+func NewMockCliToHub_AttachClient(ctrl *gomock.Controller) *MockCliToHub_AttachClient {
+	mock := &MockCliToHub_AttachClient{ctrl: ctrl}
+	mock.recorder = &MockCliToHub_AttachClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCliToHub_AttachClient) EXPECT() *MockCliToHub_AttachClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockCliToHub_AttachClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockCliToHub_AttachClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockCliToHub_AttachClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockCliToHub_AttachClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockCliToHub_AttachClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockCliToHub_AttachClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockCliToHub_AttachClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockCliToHub_AttachClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockCliToHub_AttachClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockCliToHub_AttachClient) Recv() (*idl.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockCliToHub_AttachClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockCliToHub_AttachClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockCliToHub_AttachClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockCliToHub_AttachClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockCliToHub_AttachClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockCliToHub_AttachClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockCliToHub_AttachClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockCliToHub_AttachClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockCliToHub_AttachClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockCliToHub_AttachClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockCliToHub_AttachClient)(nil).Trailer))
+}
+
 // MockCliToHubServer is a mock of CliToHubServer interface.
 type MockCliToHubServer struct {
 	ctrl     *gomock.Controller
@@ -835,6 +1018,35 @@ func (m *MockCliToHubServer) EXPECT() *MockCliToHubServerMockRecorder {
 	return m.recorder
 }
 
+// Attach mocks base method.
+func (m *MockCliToHubServer) Attach(arg0 *idl.AttachRequest, arg1 idl.CliToHub_AttachServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockCliToHubServerMockRecorder) Attach(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockCliToHubServer)(nil).Attach), arg0, arg1)
+}
+
+// Cancel mocks base method.
+func (m *MockCliToHubServer) Cancel(arg0 context.Context, arg1 *idl.CancelRequest) (*idl.CancelReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CancelReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockCliToHubServerMockRecorder) Cancel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockCliToHubServer)(nil).Cancel), arg0, arg1)
+}
+
 // Execute mocks base method.
 func (m *MockCliToHubServer) Execute(arg0 *idl.ExecuteRequest, arg1 idl.CliToHub_ExecuteServer) error {
 	m.ctrl.T.Helper()
@@ -878,6 +1090,21 @@ func (mr *MockCliToHubServerMockRecorder) GetConfig(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockCliToHubServer)(nil).GetConfig), arg0, arg1)
 }
 
+// GetStatus mocks base method.
+func (m *MockCliToHubServer) GetStatus(arg0 context.Context, arg1 *idl.GetStatusRequest) (*idl.GetStatusReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatus", arg0, arg1)
+	ret0, _ := ret[0].(*idl.GetStatusReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockCliToHubServerMockRecorder) GetStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockCliToHubServer)(nil).GetStatus), arg0, arg1)
+}
+
 // Initialize mocks base method.
 func (m *MockCliToHubServer) Initialize(arg0 *idl.InitializeRequest, arg1 idl.CliToHub_InitializeServer) error {
 	m.ctrl.T.Helper()
@@ -1579,3 +1806,122 @@ func (mr *MockCliToHub_RevertServerMockRecorder) SetTrailer(arg0 interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockCliToHub_RevertServer)(nil).SetTrailer), arg0)
 }
+
+// MockCliToHub_AttachServer is a mock of CliToHub_AttachServer interface.
+type MockCliToHub_AttachServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCliToHub_AttachServerMockRecorder
+}
+
+// MockCliToHub_AttachServerMockRecorder is the mock recorder for MockCliToHub_AttachServer.
+type MockCliToHub_AttachServerMockRecorder struct {
+	mock *MockCliToHub_AttachServer
+}
+
+// NewMockCliToHub_AttachServer creates a new mock instance.
+func NewMockCliToHub_AttachServer(ctrl *gomock.Controller) *MockCliToHub_AttachServer {
+	mock := &MockCliToHub_AttachServer{ctrl: ctrl}
+	mock.recorder = &MockCliToHub_AttachServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCliToHub_AttachServer) EXPECT() *MockCliToHub_AttachServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockCliToHub_AttachServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockCliToHub_AttachServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockCliToHub_AttachServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockCliToHub_AttachServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockCliToHub_AttachServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockCliToHub_AttachServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockCliToHub_AttachServer) Send(arg0 *idl.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockCliToHub_AttachServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockCliToHub_AttachServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockCliToHub_AttachServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockCliToHub_AttachServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockCliToHub_AttachServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockCliToHub_AttachServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockCliToHub_AttachServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockCliToHub_AttachServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockCliToHub_AttachServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockCliToHub_AttachServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockCliToHub_AttachServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockCliToHub_AttachServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockCliToHub_AttachServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockCliToHub_AttachServer)(nil).SetTrailer), arg0)
+}
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	idl "github.com/greenplum-db/gpupgrade/idl"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 )
 
 // MockAgentClient is a mock of AgentClient interface.
@@ -216,6 +217,66 @@ func (mr *MockAgentClientMockRecorder) DeleteTablespaceDirectories(ctx, in inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTablespaceDirectories", reflect.TypeOf((*MockAgentClient)(nil).DeleteTablespaceDirectories), varargs...)
 }
 
+// ExecCommand mocks base method.
+func (m *MockAgentClient) ExecCommand(ctx context.Context, in *idl.ExecCommandRequest, opts ...grpc.CallOption) (*idl.ExecCommandReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecCommand", varargs...)
+	ret0, _ := ret[0].(*idl.ExecCommandReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecCommand indicates an expected call of ExecCommand.
+func (mr *MockAgentClientMockRecorder) ExecCommand(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecCommand", reflect.TypeOf((*MockAgentClient)(nil).ExecCommand), varargs...)
+}
+
+// Heartbeat mocks base method.
+func (m *MockAgentClient) Heartbeat(ctx context.Context, in *idl.HeartbeatRequest, opts ...grpc.CallOption) (*idl.HeartbeatReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Heartbeat", varargs...)
+	ret0, _ := ret[0].(*idl.HeartbeatReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Heartbeat indicates an expected call of Heartbeat.
+func (mr *MockAgentClientMockRecorder) Heartbeat(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockAgentClient)(nil).Heartbeat), varargs...)
+}
+
+// RemoteShell mocks base method.
+func (m *MockAgentClient) RemoteShell(ctx context.Context, opts ...grpc.CallOption) (idl.Agent_RemoteShellClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoteShell", varargs...)
+	ret0, _ := ret[0].(idl.Agent_RemoteShellClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoteShell indicates an expected call of RemoteShell.
+func (mr *MockAgentClientMockRecorder) RemoteShell(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteShell", reflect.TypeOf((*MockAgentClient)(nil).RemoteShell), varargs...)
+}
+
 // RenameDirectories mocks base method.
 func (m *MockAgentClient) RenameDirectories(ctx context.Context, in *idl.RenameDirectoriesRequest, opts ...grpc.CallOption) (*idl.RenameDirectoriesReply, error) {
 	m.ctrl.T.Helper()
@@ -357,14 +418,14 @@ func (mr *MockAgentClientMockRecorder) UpdateConfiguration(ctx, in interface{},
 }
 
 // UpgradePrimaries mocks base method.
-func (m *MockAgentClient) UpgradePrimaries(ctx context.Context, in *idl.UpgradePrimariesRequest, opts ...grpc.CallOption) (*idl.UpgradePrimariesReply, error) {
+func (m *MockAgentClient) UpgradePrimaries(ctx context.Context, in *idl.UpgradePrimariesRequest, opts ...grpc.CallOption) (idl.Agent_UpgradePrimariesClient, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "UpgradePrimaries", varargs...)
-	ret0, _ := ret[0].(*idl.UpgradePrimariesReply)
+	ret0, _ := ret[0].(idl.Agent_UpgradePrimariesClient)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -376,6 +437,266 @@ func (mr *MockAgentClientMockRecorder) UpgradePrimaries(ctx, in interface{}, opt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradePrimaries", reflect.TypeOf((*MockAgentClient)(nil).UpgradePrimaries), varargs...)
 }
 
+// MockAgent_UpgradePrimariesClient is a mock of Agent_UpgradePrimariesClient interface.
+type MockAgent_UpgradePrimariesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_UpgradePrimariesClientMockRecorder
+}
+
+// MockAgent_UpgradePrimariesClientMockRecorder is the mock recorder for MockAgent_UpgradePrimariesClient.
+type MockAgent_UpgradePrimariesClientMockRecorder struct {
+	mock *MockAgent_UpgradePrimariesClient
+}
+
+// NewMockAgent_UpgradePrimariesClient creates a new mock instance.
+func NewMockAgent_UpgradePrimariesClient(ctrl *gomock.Controller) *MockAgent_UpgradePrimariesClient {
+	mock := &MockAgent_UpgradePrimariesClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_UpgradePrimariesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_UpgradePrimariesClient) EXPECT() *MockAgent_UpgradePrimariesClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockAgent_UpgradePrimariesClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAgent_UpgradePrimariesClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_UpgradePrimariesClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAgent_UpgradePrimariesClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_UpgradePrimariesClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_UpgradePrimariesClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAgent_UpgradePrimariesClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAgent_UpgradePrimariesClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_UpgradePrimariesClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockAgent_UpgradePrimariesClient) Recv() (*idl.UpgradePrimariesReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.UpgradePrimariesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_UpgradePrimariesClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_UpgradePrimariesClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_UpgradePrimariesClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_UpgradePrimariesClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_UpgradePrimariesClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_UpgradePrimariesClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_UpgradePrimariesClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_UpgradePrimariesClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAgent_UpgradePrimariesClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAgent_UpgradePrimariesClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_UpgradePrimariesClient)(nil).Trailer))
+}
+
+// MockAgent_RemoteShellClient is a mock of Agent_RemoteShellClient interface.
+type MockAgent_RemoteShellClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_RemoteShellClientMockRecorder
+}
+
+// MockAgent_RemoteShellClientMockRecorder is the mock recorder for MockAgent_RemoteShellClient.
+type MockAgent_RemoteShellClientMockRecorder struct {
+	mock *MockAgent_RemoteShellClient
+}
+
+// NewMockAgent_RemoteShellClient creates a new mock instance.
+func NewMockAgent_RemoteShellClient(ctrl *gomock.Controller) *MockAgent_RemoteShellClient {
+	mock := &MockAgent_RemoteShellClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_RemoteShellClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_RemoteShellClient) EXPECT() *MockAgent_RemoteShellClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockAgent_RemoteShellClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAgent_RemoteShellClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_RemoteShellClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAgent_RemoteShellClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_RemoteShellClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_RemoteShellClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAgent_RemoteShellClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAgent_RemoteShellClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_RemoteShellClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockAgent_RemoteShellClient) Recv() (*idl.RemoteShellReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.RemoteShellReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_RemoteShellClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_RemoteShellClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_RemoteShellClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_RemoteShellClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_RemoteShellClient)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_RemoteShellClient) Send(arg0 *idl.RemoteShellRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_RemoteShellClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_RemoteShellClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_RemoteShellClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_RemoteShellClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_RemoteShellClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAgent_RemoteShellClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAgent_RemoteShellClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_RemoteShellClient)(nil).Trailer))
+}
+
 // MockAgentServer is a mock of AgentServer interface.
 type MockAgentServer struct {
 	ctrl     *gomock.Controller
@@ -534,6 +855,50 @@ func (mr *MockAgentServerMockRecorder) DeleteTablespaceDirectories(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTablespaceDirectories", reflect.TypeOf((*MockAgentServer)(nil).DeleteTablespaceDirectories), arg0, arg1)
 }
 
+// ExecCommand mocks base method.
+func (m *MockAgentServer) ExecCommand(arg0 context.Context, arg1 *idl.ExecCommandRequest) (*idl.ExecCommandReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecCommand", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ExecCommandReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecCommand indicates an expected call of ExecCommand.
+func (mr *MockAgentServerMockRecorder) ExecCommand(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecCommand", reflect.TypeOf((*MockAgentServer)(nil).ExecCommand), arg0, arg1)
+}
+
+// Heartbeat mocks base method.
+func (m *MockAgentServer) Heartbeat(arg0 context.Context, arg1 *idl.HeartbeatRequest) (*idl.HeartbeatReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Heartbeat", arg0, arg1)
+	ret0, _ := ret[0].(*idl.HeartbeatReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Heartbeat indicates an expected call of Heartbeat.
+func (mr *MockAgentServerMockRecorder) Heartbeat(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockAgentServer)(nil).Heartbeat), arg0, arg1)
+}
+
+// RemoteShell mocks base method.
+func (m *MockAgentServer) RemoteShell(arg0 idl.Agent_RemoteShellServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteShell", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoteShell indicates an expected call of RemoteShell.
+func (mr *MockAgentServerMockRecorder) RemoteShell(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteShell", reflect.TypeOf((*MockAgentServer)(nil).RemoteShell), arg0)
+}
+
 // RenameDirectories mocks base method.
 func (m *MockAgentServer) RenameDirectories(arg0 context.Context, arg1 *idl.RenameDirectoriesRequest) (*idl.RenameDirectoriesReply, error) {
 	m.ctrl.T.Helper()
@@ -640,12 +1005,11 @@ func (mr *MockAgentServerMockRecorder) UpdateConfiguration(arg0, arg1 interface{
 }
 
 // UpgradePrimaries mocks base method.
-func (m *MockAgentServer) UpgradePrimaries(arg0 context.Context, arg1 *idl.UpgradePrimariesRequest) (*idl.UpgradePrimariesReply, error) {
+func (m *MockAgentServer) UpgradePrimaries(arg0 *idl.UpgradePrimariesRequest, arg1 idl.Agent_UpgradePrimariesServer) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpgradePrimaries", arg0, arg1)
-	ret0, _ := ret[0].(*idl.UpgradePrimariesReply)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // UpgradePrimaries indicates an expected call of UpgradePrimaries.
@@ -688,3 +1052,256 @@ func (mr *MockUnsafeAgentServerMockRecorder) mustEmbedUnimplementedAgentServer()
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAgentServer", reflect.TypeOf((*MockUnsafeAgentServer)(nil).mustEmbedUnimplementedAgentServer))
 }
+
+// MockAgent_UpgradePrimariesServer is a mock of Agent_UpgradePrimariesServer interface.
+type MockAgent_UpgradePrimariesServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_UpgradePrimariesServerMockRecorder
+}
+
+// MockAgent_UpgradePrimariesServerMockRecorder is the mock recorder for MockAgent_UpgradePrimariesServer.
+type MockAgent_UpgradePrimariesServerMockRecorder struct {
+	mock *MockAgent_UpgradePrimariesServer
+}
+
+// NewMockAgent_UpgradePrimariesServer creates a new mock instance.
+func NewMockAgent_UpgradePrimariesServer(ctrl *gomock.Controller) *MockAgent_UpgradePrimariesServer {
+	mock := &MockAgent_UpgradePrimariesServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_UpgradePrimariesServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_UpgradePrimariesServer) EXPECT() *MockAgent_UpgradePrimariesServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAgent_UpgradePrimariesServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_UpgradePrimariesServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_UpgradePrimariesServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_UpgradePrimariesServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_UpgradePrimariesServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_UpgradePrimariesServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_UpgradePrimariesServer) Send(arg0 *idl.UpgradePrimariesReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_UpgradePrimariesServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_UpgradePrimariesServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAgent_UpgradePrimariesServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAgent_UpgradePrimariesServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_UpgradePrimariesServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_UpgradePrimariesServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_UpgradePrimariesServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_UpgradePrimariesServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAgent_UpgradePrimariesServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAgent_UpgradePrimariesServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_UpgradePrimariesServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAgent_UpgradePrimariesServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAgent_UpgradePrimariesServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_UpgradePrimariesServer)(nil).SetTrailer), arg0)
+}
+
+// MockAgent_RemoteShellServer is a mock of Agent_RemoteShellServer interface.
+type MockAgent_RemoteShellServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_RemoteShellServerMockRecorder
+}
+
+// MockAgent_RemoteShellServerMockRecorder is the mock recorder for MockAgent_RemoteShellServer.
+type MockAgent_RemoteShellServerMockRecorder struct {
+	mock *MockAgent_RemoteShellServer
+}
+
+// NewMockAgent_RemoteShellServer creates a new mock instance.
+func NewMockAgent_RemoteShellServer(ctrl *gomock.Controller) *MockAgent_RemoteShellServer {
+	mock := &MockAgent_RemoteShellServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_RemoteShellServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_RemoteShellServer) EXPECT() *MockAgent_RemoteShellServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAgent_RemoteShellServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_RemoteShellServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_RemoteShellServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockAgent_RemoteShellServer) Recv() (*idl.RemoteShellRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.RemoteShellRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_RemoteShellServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_RemoteShellServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_RemoteShellServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_RemoteShellServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_RemoteShellServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_RemoteShellServer) Send(arg0 *idl.RemoteShellReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_RemoteShellServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_RemoteShellServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAgent_RemoteShellServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAgent_RemoteShellServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_RemoteShellServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_RemoteShellServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_RemoteShellServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_RemoteShellServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAgent_RemoteShellServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAgent_RemoteShellServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_RemoteShellServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAgent_RemoteShellServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAgent_RemoteShellServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_RemoteShellServer)(nil).SetTrailer), arg0)
+}
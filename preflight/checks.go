@@ -0,0 +1,176 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package preflight runs the validations performed by initialize without
+// creating the state directory, backup directories, or intermediate cluster
+// such that users can check their environment ahead of an upgrade.
+package preflight
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/config/backupdir"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/greenplum/connection"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
+)
+
+const (
+	CheckVersions         = "source and target Greenplum versions are compatible"
+	CheckSourceReachable  = "source cluster is reachable"
+	CheckSegmentsUp       = "all source segments are up and in their preferred role"
+	CheckTempPortRange    = "temp_port_range does not overlap with the source cluster ports"
+	CheckBackupDirs       = "parent_backup_dirs is valid"
+	CheckGpupgradeVersion = "gpupgrade is installed with the same version on all hosts"
+	CheckEnvironment      = "environment does not set Greenplum variables"
+	CheckDiskSpace        = "disk space is sufficient"
+	CheckPgUpgrade        = "pg_upgrade --check"
+)
+
+type Options struct {
+	SourceGPHome     string
+	TargetGPHome     string
+	SourcePort       int
+	Ports            []int
+	Mode             idl.Mode
+	DiskFreeRatio    float64
+	ParentBackupDirs string
+	AgentPort        int
+	RemoteExecutor   string
+	TLS              mtls.Config
+}
+
+// Run performs each check and reports the results. Checks that depend on the
+// source cluster are reported as unchecked when it cannot be reached. Only
+// agents that are already running are used, since starting them would require
+// a state directory. pg_upgrade --check is always unchecked since it requires
+// the intermediate cluster.
+func Run(streams step.OutStreams, opts Options) Report {
+	var report Report
+	add := func(check string, err error) {
+		if err != nil {
+			report = append(report, Result{Check: check, Status: Fail, Message: err.Error()})
+			return
+		}
+
+		report = append(report, Result{Check: check, Status: Pass})
+	}
+
+	unchecked := func(check string, message string) {
+		report = append(report, Result{Check: check, Status: Unchecked, Message: message})
+	}
+
+	add(CheckVersions, greenplum.VerifyCompatibleGPDBVersions(opts.SourceGPHome, opts.TargetGPHome))
+
+	db, source, err := sourceCluster(opts)
+	add(CheckSourceReachable, err)
+	if err != nil {
+		for _, check := range []string{CheckSegmentsUp, CheckTempPortRange, CheckBackupDirs, CheckGpupgradeVersion, CheckEnvironment, CheckDiskSpace} {
+			unchecked(check, "not run since the source cluster is unreachable")
+		}
+		unchecked(CheckPgUpgrade, pgUpgradeMessage)
+		return report
+	}
+	defer db.Close()
+
+	add(CheckSegmentsUp, greenplum.WaitForSegments(db, 0, &source))
+	add(CheckTempPortRange, checkTempPortRange(&source, opts))
+
+	_, err = backupdir.ParseParentBackupDirs(opts.ParentBackupDirs, source)
+	add(CheckBackupDirs, err)
+
+	server := hub.New(&config.Config{
+		Source:         &source,
+		AgentPort:      opts.AgentPort,
+		RemoteExecutor: opts.RemoteExecutor,
+		TLS:            opts.TLS,
+	})
+	defer server.Stop(true)
+
+	executor := remote.New(opts.RemoteExecutor, server.AgentConns, opts.AgentPort, opts.TLS)
+	add(CheckGpupgradeVersion, upgrade.EnsureGpupgradeVersionsMatch(executor, hub.AgentHosts(&source)))
+	add(CheckEnvironment, hub.CheckEnvironment(executor, append(hub.AgentHosts(&source), source.CoordinatorHostname()), opts.SourceGPHome, opts.TargetGPHome))
+
+	tablespaces, err := sourceTablespaces(db, source)
+	if err != nil {
+		add(CheckDiskSpace, err)
+	} else {
+		conns, err := server.AgentConns()
+		if err != nil {
+			// Only the coordinator can be checked without the agents. The
+			// remaining hosts are reported as unchecked even when the
+			// coordinator fails such that they are not assumed to pass.
+			hosts := hub.AgentHosts(&source)
+			sort.Strings(hosts)
+			message := fmt.Sprintf("only checked the coordinator since the agents are not running. Unchecked hosts: %s", strings.Join(hosts, ", "))
+
+			err = hub.CheckDiskSpace(context.Background(), streams, nil, opts.DiskFreeRatio, &source, tablespaces)
+			if err != nil {
+				report = append(report, Result{Check: CheckDiskSpace, Status: Fail, Message: err.Error() + "\n" + message})
+			} else {
+				unchecked(CheckDiskSpace, message)
+			}
+		} else {
+			add(CheckDiskSpace, hub.CheckDiskSpace(context.Background(), streams, conns, opts.DiskFreeRatio, &source, tablespaces))
+		}
+	}
+
+	unchecked(CheckPgUpgrade, pgUpgradeMessage)
+	return report
+}
+
+const pgUpgradeMessage = `not run since it requires the intermediate cluster created by "gpupgrade initialize" which runs it on the coordinator and primaries`
+
+func sourceCluster(opts Options) (*sql.DB, greenplum.Cluster, error) {
+	db, err := connection.Bootstrap(idl.ClusterDestination_source, opts.SourceGPHome, opts.SourcePort)
+	if err != nil {
+		return nil, greenplum.Cluster{}, err
+	}
+
+	source, err := greenplum.ClusterFromDB(db, opts.SourceGPHome, idl.ClusterDestination_source)
+	if err != nil {
+		db.Close()
+		return nil, greenplum.Cluster{}, err
+	}
+
+	return db, source, nil
+}
+
+func checkTempPortRange(source *greenplum.Cluster, opts Options) error {
+	version, err := greenplum.Version(opts.TargetGPHome)
+	if err != nil {
+		return err
+	}
+
+	intermediate, err := config.GenerateIntermediateCluster(source, opts.Ports, upgrade.NewID(), version, opts.TargetGPHome)
+	if err != nil {
+		return err
+	}
+
+	return config.EnsureTempPortRangeDoesNotOverlapWithSourceClusterPorts(source, intermediate)
+}
+
+// sourceTablespaces retrieves the tablespaces without writing the tablespace
+// mapping file to the state directory.
+func sourceTablespaces(db *sql.DB, source greenplum.Cluster) (greenplum.Tablespaces, error) {
+	if source.Version.Major != 5 {
+		return nil, nil
+	}
+
+	tuples, err := greenplum.GetTablespaceTuples(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return greenplum.NewTablespaces(tuples), nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package preflight_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/preflight"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("skips the checks needing the source cluster when it is unreachable and creates no state", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		stateDir := filepath.Join(dir, ".gpupgrade")
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+		defer resetEnv()
+
+		report := preflight.Run(step.DevNullStream, preflight.Options{
+			SourceGPHome: filepath.Join(dir, "source"),
+			TargetGPHome: filepath.Join(dir, "target"),
+			SourcePort:   15432,
+		})
+
+		if !report.Failed() {
+			t.Errorf("expected report to fail")
+		}
+
+		expected := map[string]preflight.Status{
+			preflight.CheckVersions:         preflight.Fail,
+			preflight.CheckSourceReachable:  preflight.Fail,
+			preflight.CheckSegmentsUp:       preflight.Unchecked,
+			preflight.CheckTempPortRange:    preflight.Unchecked,
+			preflight.CheckBackupDirs:       preflight.Unchecked,
+			preflight.CheckGpupgradeVersion: preflight.Unchecked,
+			preflight.CheckEnvironment:      preflight.Unchecked,
+			preflight.CheckDiskSpace:        preflight.Unchecked,
+			preflight.CheckPgUpgrade:        preflight.Unchecked,
+		}
+
+		if len(report) != len(expected) {
+			t.Errorf("got %d results want %d", len(report), len(expected))
+		}
+
+		for _, result := range report {
+			if result.Status != expected[result.Check] {
+				t.Errorf("got status %q for %q want %q", result.Status, result.Check, expected[result.Check])
+			}
+		}
+
+		_, err := os.Stat(utils.GetStateDir())
+		if !os.IsNotExist(err) {
+			t.Errorf("expected state directory %q to not exist: %v", utils.GetStateDir(), err)
+		}
+	})
+}
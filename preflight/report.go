@@ -0,0 +1,70 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package preflight
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Status string
+
+const (
+	Pass Status = "pass"
+	Warn Status = "warn"
+	Fail Status = "fail"
+
+	// Unchecked reports a check that was not performed, or only partially,
+	// such that it must still be verified by initialize.
+	Unchecked Status = "unchecked"
+)
+
+// Result is the outcome of a single check. The message explains warnings and
+// failures.
+type Result struct {
+	Check   string
+	Status  Status
+	Message string
+}
+
+type Report []Result
+
+func (r Report) Failed() bool {
+	for _, result := range r {
+		if result.Status == Fail {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r Report) count(status Status) int {
+	var count int
+	for _, result := range r {
+		if result.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// String lists each check along with the message of any warnings and
+// failures followed by a summary.
+func (r Report) String() string {
+	var b strings.Builder
+	for _, result := range r {
+		fmt.Fprintf(&b, "[%s] %s\n", result.Status, result.Check)
+
+		if result.Message != "" {
+			for _, line := range strings.Split(strings.TrimSpace(result.Message), "\n") {
+				fmt.Fprintf(&b, "       %s\n", line)
+			}
+		}
+	}
+
+	fmt.Fprintf(&b, "\n%d passed, %d warned, %d failed, %d unchecked\n", r.count(Pass), r.count(Warn), r.count(Fail), r.count(Unchecked))
+	return b.String()
+}
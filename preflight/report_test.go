@@ -0,0 +1,52 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package preflight_test
+
+import (
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/preflight"
+)
+
+func TestReport(t *testing.T) {
+	t.Run("fails when any check fails", func(t *testing.T) {
+		report := preflight.Report{
+			{Check: "one", Status: preflight.Pass},
+			{Check: "two", Status: preflight.Warn, Message: "warning"},
+		}
+
+		if report.Failed() {
+			t.Errorf("expected report to not fail")
+		}
+
+		report = append(report, preflight.Result{Check: "three", Status: preflight.Fail, Message: "failure"})
+		if !report.Failed() {
+			t.Errorf("expected report to fail")
+		}
+	})
+
+	t.Run("formats each check and a summary", func(t *testing.T) {
+		report := preflight.Report{
+			{Check: "one", Status: preflight.Pass},
+			{Check: "two", Status: preflight.Warn, Message: "warning"},
+			{Check: "three", Status: preflight.Fail, Message: "first line\nsecond line\n"},
+			{Check: "four", Status: preflight.Unchecked, Message: "not run"},
+		}
+
+		expected := `[pass] one
+[warn] two
+       warning
+[fail] three
+       first line
+       second line
+[unchecked] four
+       not run
+
+1 passed, 1 warned, 1 failed, 1 unchecked
+`
+		if report.String() != expected {
+			t.Errorf("got %q want %q", report.String(), expected)
+		}
+	})
+}
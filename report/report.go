@@ -0,0 +1,320 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package report generates the upgrade report written to the log archive
+// directory at the end of finalize and revert. It records what was upgraded
+// and how for auditing after the state directory has been removed.
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/config/backupdir"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+const (
+	JSONFileName = "upgrade_report.json"
+	HTMLFileName = "upgrade_report.html"
+
+	// DataMigrationScriptsFileName records the data migration scripts applied
+	// in each phase. It is written to the log directory which is archived
+	// along with the report.
+	DataMigrationScriptsFileName = "data_migration_scripts_applied.json"
+)
+
+type Report struct {
+	UpgradeID   string
+	Step        string
+	Mode        string
+	GeneratedAt time.Time
+
+	SourceVersion string
+	TargetVersion string
+
+	Source       *greenplum.Cluster
+	Intermediate *greenplum.Cluster `json:",omitempty"`
+	Target       *greenplum.Cluster `json:",omitempty"`
+
+	// Substeps contains the status and timing of each substep keyed by step
+	// and then substep.
+	Substeps map[string]map[string]step.SubstepEntry
+
+	// RsyncStats contains the data transferred by substeps that use rsync
+	// keyed by step and then substep.
+	RsyncStats map[string]map[string]rsync.Stats `json:",omitempty"`
+
+	BackupDirs backupdir.BackupDirs
+
+	// DataMigrationScripts contains the data migration scripts applied keyed
+	// by phase.
+	DataMigrationScripts map[string][]string
+
+	ArchivedSourceDataDirectories []ArchivedDataDirectory `json:",omitempty"`
+	LogArchiveDirectory           string
+}
+
+type ArchivedDataDirectory struct {
+	Host      string
+	ContentID int
+	DataDir   string
+}
+
+// New creates the report for the given step. The archived source data
+// directories are only included when finalizing since revert does not archive
+// them.
+func New(st idl.Step, conf *config.Config, substeps map[string]map[string]step.SubstepEntry, rsyncStats map[string]map[string]rsync.Stats, logArchiveDir string) (*Report, error) {
+	scripts, err := ReadDataMigrationScripts(logArchiveDir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		UpgradeID:            conf.UpgradeID,
+		Step:                 st.String(),
+		Mode:                 conf.Mode.String(),
+		GeneratedAt:          utils.System.Now(),
+		Source:               conf.Source,
+		Intermediate:         conf.Intermediate,
+		Target:               conf.Target,
+		Substeps:             substeps,
+		RsyncStats:           rsyncStats,
+		BackupDirs:           conf.BackupDirs,
+		DataMigrationScripts: scripts,
+		LogArchiveDirectory:  logArchiveDir,
+	}
+
+	if conf.Source != nil {
+		report.SourceVersion = conf.Source.Version.String()
+	}
+
+	if conf.Target != nil {
+		report.TargetVersion = conf.Target.Version.String()
+	}
+
+	if st == idl.Step_finalize {
+		report.ArchivedSourceDataDirectories = archivedDataDirectories(conf.Source, conf.Intermediate)
+	}
+
+	return report, nil
+}
+
+// archivedDataDirectories returns the locations the source data directories
+// were archived to when renaming the intermediate data directories.
+func archivedDataDirectories(source *greenplum.Cluster, intermediate *greenplum.Cluster) []ArchivedDataDirectory {
+	if source == nil || intermediate == nil {
+		return nil
+	}
+
+	var archived []ArchivedDataDirectory
+	for _, segs := range []struct{ source, intermediate greenplum.ContentToSegConfig }{
+		{source.Primaries, intermediate.Primaries},
+		{source.Mirrors, intermediate.Mirrors},
+	} {
+		for contentID, seg := range segs.source {
+			intermediateSeg, ok := segs.intermediate[contentID]
+			if !ok {
+				continue
+			}
+
+			archived = append(archived, ArchivedDataDirectory{
+				Host:      seg.Hostname,
+				ContentID: contentID,
+				DataDir:   intermediateSeg.DataDir + upgrade.OldSuffix,
+			})
+		}
+	}
+
+	sort.Slice(archived, func(i, j int) bool {
+		if archived[i].ContentID != archived[j].ContentID {
+			return archived[i].ContentID < archived[j].ContentID
+		}
+
+		return archived[i].Host < archived[j].Host
+	})
+
+	return archived
+}
+
+// Write writes the JSON report and optionally the HTML report to dir
+// returning the JSON.
+func (r *Report) Write(dir string, html bool) ([]byte, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, xerrors.Errorf("marshal upgrade report: %w", err)
+	}
+
+	err = utils.System.WriteFile(filepath.Join(dir, JSONFileName), data, 0644)
+	if err != nil {
+		return nil, xerrors.Errorf("write upgrade report: %w", err)
+	}
+
+	if !html {
+		return data, nil
+	}
+
+	var b strings.Builder
+	err = htmlTemplate.Execute(&b, r)
+	if err != nil {
+		return nil, xerrors.Errorf("render upgrade report: %w", err)
+	}
+
+	err = utils.System.WriteFile(filepath.Join(dir, HTMLFileName), []byte(b.String()), 0644)
+	if err != nil {
+		return nil, xerrors.Errorf("write upgrade report: %w", err)
+	}
+
+	return data, nil
+}
+
+// UpdateDataMigrationScripts refreshes the data migration scripts of the
+// report in dir. This picks up the finalize and revert scripts which are
+// applied after the report is created. The HTML report is only rewritten if it
+// exists. It is a no-op if there is no report.
+func UpdateDataMigrationScripts(dir string) error {
+	data, err := utils.System.ReadFile(filepath.Join(dir, JSONFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	var report Report
+	err = json.Unmarshal(data, &report)
+	if err != nil {
+		return xerrors.Errorf("unmarshal upgrade report: %w", err)
+	}
+
+	report.DataMigrationScripts, err = ReadDataMigrationScripts(dir)
+	if err != nil {
+		return err
+	}
+
+	_, err = utils.System.Stat(filepath.Join(dir, HTMLFileName))
+	html := err == nil
+
+	_, err = report.Write(dir, html)
+	return err
+}
+
+// RecordDataMigrationScripts adds the scripts applied for the phase to the
+// record in the log directory.
+func RecordDataMigrationScripts(logDir string, phase idl.Step, scripts []string) error {
+	applied, err := ReadDataMigrationScripts(logDir)
+	if err != nil {
+		return err
+	}
+
+	applied[phase.String()] = utils.RemoveDuplicates(append(applied[phase.String()], scripts...))
+
+	data, err := json.MarshalIndent(applied, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return utils.AtomicallyWrite(filepath.Join(logDir, DataMigrationScriptsFileName), data)
+}
+
+// ReadDataMigrationScripts returns the data migration scripts applied keyed by
+// phase. It is empty when none have been applied.
+func ReadDataMigrationScripts(logDir string) (map[string][]string, error) {
+	applied := make(map[string][]string)
+
+	data, err := utils.System.ReadFile(filepath.Join(logDir, DataMigrationScriptsFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return applied, nil
+		}
+
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &applied)
+	if err != nil {
+		return nil, xerrors.Errorf("unmarshal %q: %w", DataMigrationScriptsFileName, err)
+	}
+
+	return applied, nil
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>gpupgrade {{.Step}} report {{.UpgradeID}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>gpupgrade {{.Step}} report</h1>
+<table>
+<tr><th>Upgrade ID</th><td>{{.UpgradeID}}</td></tr>
+<tr><th>Generated</th><td>{{.GeneratedAt}}</td></tr>
+<tr><th>Mode</th><td>{{.Mode}}</td></tr>
+<tr><th>Source version</th><td>{{.SourceVersion}}</td></tr>
+<tr><th>Target version</th><td>{{.TargetVersion}}</td></tr>
+<tr><th>Log archive directory</th><td>{{.LogArchiveDirectory}}</td></tr>
+</table>
+{{define "cluster"}}{{if .}}
+<p>GPHOME {{.GPHome}}</p>
+<table>
+<tr><th>Content</th><th>Role</th><th>Host</th><th>Port</th><th>Data directory</th></tr>
+{{range .Primaries}}<tr><td>{{.ContentID}}</td><td>primary</td><td>{{.Hostname}}</td><td>{{.Port}}</td><td>{{.DataDir}}</td></tr>
+{{end}}{{range .Mirrors}}<tr><td>{{.ContentID}}</td><td>mirror</td><td>{{.Hostname}}</td><td>{{.Port}}</td><td>{{.DataDir}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+<h2>Source cluster</h2>
+{{template "cluster" .Source}}
+{{if .Intermediate}}<h2>Intermediate cluster</h2>
+{{template "cluster" .Intermediate}}{{end}}
+{{if .Target}}<h2>Target cluster</h2>
+{{template "cluster" .Target}}{{end}}
+<h2>Substeps</h2>
+<table>
+<tr><th>Step</th><th>Substep</th><th>Status</th><th>Duration</th><th>Attempts</th></tr>
+{{range $step, $substeps := .Substeps}}{{range $substep, $entry := $substeps}}<tr><td>{{$step}}</td><td>{{$substep}}</td><td>{{$entry.Status}}</td><td>{{$entry.Duration}}</td><td>{{$entry.Attempts}}</td></tr>
+{{end}}{{end}}</table>
+{{if .RsyncStats}}<h2>Rsync transfers</h2>
+<table>
+<tr><th>Step</th><th>Substep</th><th>Files transferred</th><th>Total file size</th><th>Bytes sent</th><th>Bytes received</th><th>Speedup</th><th>Elapsed</th></tr>
+{{range $step, $substeps := .RsyncStats}}{{range $substep, $stats := $substeps}}<tr><td>{{$step}}</td><td>{{$substep}}</td><td>{{$stats.FilesTransferred}}</td><td>{{$stats.TotalFileSize}}</td><td>{{$stats.BytesSent}}</td><td>{{$stats.BytesReceived}}</td><td>{{printf "%.2f" $stats.Speedup}}</td><td>{{$stats.Elapsed}}</td></tr>
+{{end}}{{end}}</table>{{end}}
+<h2>Backup directories</h2>
+<table>
+<tr><th>Host</th><th>Directory</th></tr>
+<tr><td>coordinator</td><td>{{.BackupDirs.CoordinatorBackupDir}}</td></tr>
+{{range $host, $dir := .BackupDirs.AgentHostsToBackupDir}}<tr><td>{{$host}}</td><td>{{$dir}}</td></tr>
+{{end}}</table>
+<h2>Data migration scripts applied</h2>
+<table>
+<tr><th>Phase</th><th>Scripts</th></tr>
+{{range $phase, $scripts := .DataMigrationScripts}}<tr><td>{{$phase}}</td><td>{{range $scripts}}{{.}}<br>{{end}}</td></tr>
+{{end}}</table>
+{{if .ArchivedSourceDataDirectories}}<h2>Archived source data directories</h2>
+<table>
+<tr><th>Content</th><th>Host</th><th>Data directory</th></tr>
+{{range .ArchivedSourceDataDirectories}}<tr><td>{{.ContentID}}</td><td>{{.Host}}</td><td>{{.DataDir}}</td></tr>
+{{end}}</table>{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,276 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package report_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/config/backupdir"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/report"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+func TestReport(t *testing.T) {
+	source := greenplum.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg0", Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg0", Role: greenplum.MirrorRole},
+	})
+	source.Version = semver.MustParse("6.20.0")
+
+	intermediate := greenplum.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/seg.AAAAAAAAAAA.-1", Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.AAAAAAAAAAA.0", Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg.AAAAAAAAAAA.0", Role: greenplum.MirrorRole},
+	})
+
+	target := greenplum.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+	})
+	target.Version = semver.MustParse("7.1.0")
+
+	conf := &config.Config{
+		Source:       source,
+		Intermediate: intermediate,
+		Target:       target,
+		Mode:         idl.Mode_link,
+		UpgradeID:    "AAAAAAAAAAA",
+		BackupDirs: backupdir.BackupDirs{
+			CoordinatorBackupDir:  "/data/.gpupgrade",
+			AgentHostsToBackupDir: backupdir.AgentHostsToBackupDir{"sdw1": "/data/.gpupgrade"},
+		},
+	}
+
+	substeps := map[string]map[string]step.SubstepEntry{
+		idl.Step_finalize.String(): {
+			idl.Substep_upgrade_mirrors.String(): {
+				Status:   step.PrettyStatus{Status: idl.Status_complete},
+				Duration: step.PrettyDuration{Duration: 90 * time.Second},
+				Attempts: 1,
+			},
+		},
+	}
+
+	rsyncStats := map[string]map[string]rsync.Stats{
+		idl.Step_finalize.String(): {
+			idl.Substep_upgrade_mirrors.String(): {FilesTransferred: 1100, BytesSent: 10485760, Speedup: 4.99, Elapsed: 2 * time.Minute},
+		},
+	}
+
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	utils.System.Now = func() time.Time {
+		return now
+	}
+	defer utils.ResetSystemFunctions()
+
+	t.Run("creates a report for finalize including the archived source data directories", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		err := report.RecordDataMigrationScripts(dir, idl.Step_initialize, []string{"unique_primary_foreign_key_constraint"})
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		r, err := report.New(idl.Step_finalize, conf, substeps, rsyncStats, dir)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := &report.Report{
+			UpgradeID:            "AAAAAAAAAAA",
+			Step:                 "finalize",
+			Mode:                 "link",
+			GeneratedAt:          now,
+			SourceVersion:        "6.20.0",
+			TargetVersion:        "7.1.0",
+			Source:               source,
+			Intermediate:         intermediate,
+			Target:               target,
+			Substeps:             substeps,
+			RsyncStats:           rsyncStats,
+			BackupDirs:           conf.BackupDirs,
+			DataMigrationScripts: map[string][]string{"initialize": {"unique_primary_foreign_key_constraint"}},
+			ArchivedSourceDataDirectories: []report.ArchivedDataDirectory{
+				{Host: "cdw", ContentID: -1, DataDir: "/data/qddir/seg.AAAAAAAAAAA.-1.old"},
+				{Host: "sdw1", ContentID: 0, DataDir: "/data/dbfast1/seg.AAAAAAAAAAA.0.old"},
+				{Host: "sdw2", ContentID: 0, DataDir: "/data/dbfast_mirror1/seg.AAAAAAAAAAA.0.old"},
+			},
+			LogArchiveDirectory: dir,
+		}
+
+		if !reflect.DeepEqual(r, expected) {
+			t.Errorf("got %+v want %+v", r, expected)
+		}
+	})
+
+	t.Run("does not include archived source data directories for revert", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		r, err := report.New(idl.Step_revert, conf, substeps, nil, dir)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if r.ArchivedSourceDataDirectories != nil {
+			t.Errorf("got archived source data directories %v want none", r.ArchivedSourceDataDirectories)
+		}
+
+		if len(r.DataMigrationScripts) != 0 {
+			t.Errorf("got data migration scripts %v want none", r.DataMigrationScripts)
+		}
+	})
+
+	t.Run("writes the JSON report and optionally the HTML report", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		r, err := report.New(idl.Step_finalize, conf, substeps, rsyncStats, dir)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		data, err := r.Write(dir, false)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		contents := testutils.MustReadFile(t, filepath.Join(dir, report.JSONFileName))
+		if contents != string(data) {
+			t.Errorf("got %q want %q", contents, data)
+		}
+
+		var decoded report.Report
+		err = json.Unmarshal(data, &decoded)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if decoded.UpgradeID != conf.UpgradeID || decoded.Source.Version.String() != "6.20.0" {
+			t.Errorf("got %+v want upgrade ID %q and source version 6.20.0", decoded, conf.UpgradeID)
+		}
+
+		_, err = os.Stat(filepath.Join(dir, report.HTMLFileName))
+		if !os.IsNotExist(err) {
+			t.Errorf("expected HTML report to not exist: %v", err)
+		}
+
+		_, err = r.Write(dir, true)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		html := testutils.MustReadFile(t, filepath.Join(dir, report.HTMLFileName))
+		for _, expected := range []string{"AAAAAAAAAAA", "upgrade_mirrors", "1m30s", "/data/dbfast1/seg.AAAAAAAAAAA.0.old", "Rsync transfers", "10485760", "4.99", "2m0s"} {
+			if !strings.Contains(html, expected) {
+				t.Errorf("expected HTML report to contain %q", expected)
+			}
+		}
+	})
+
+	t.Run("updates the data migration scripts of an existing report", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		r, err := report.New(idl.Step_finalize, conf, substeps, rsyncStats, dir)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		_, err = r.Write(dir, true)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		err = report.RecordDataMigrationScripts(dir, idl.Step_finalize, []string{"gphdfs_user_roles"})
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		err = report.UpdateDataMigrationScripts(dir)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		var decoded report.Report
+		err = json.Unmarshal([]byte(testutils.MustReadFile(t, filepath.Join(dir, report.JSONFileName))), &decoded)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := map[string][]string{"finalize": {"gphdfs_user_roles"}}
+		if !reflect.DeepEqual(decoded.DataMigrationScripts, expected) {
+			t.Errorf("got %v want %v", decoded.DataMigrationScripts, expected)
+		}
+
+		html := testutils.MustReadFile(t, filepath.Join(dir, report.HTMLFileName))
+		if !strings.Contains(html, "gphdfs_user_roles") {
+			t.Errorf("expected HTML report to contain the updated data migration scripts")
+		}
+	})
+
+	t.Run("updating is a no-op when there is no report", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		err := report.UpdateDataMigrationScripts(dir)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+}
+
+func TestDataMigrationScripts(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	scripts, err := report.ReadDataMigrationScripts(dir)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	if len(scripts) != 0 {
+		t.Errorf("got %v want none", scripts)
+	}
+
+	err = report.RecordDataMigrationScripts(dir, idl.Step_initialize, []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	err = report.RecordDataMigrationScripts(dir, idl.Step_initialize, []string{"b", "c"})
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	err = report.RecordDataMigrationScripts(dir, idl.Step_stats, []string{"d"})
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	scripts, err = report.ReadDataMigrationScripts(dir)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	expected := map[string][]string{"initialize": {"a", "b", "c"}, "stats": {"d"}}
+	if !reflect.DeepEqual(scripts, expected) {
+		t.Errorf("got %v want %v", scripts, expected)
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// ValidationFileName is where the differences found when validating the
+// target cluster during finalize are written in the log directory.
+const ValidationFileName = "validation.json"
+
+// Missing is the value of a difference for an object that does not exist.
+const Missing = "missing"
+
+type Kind string
+
+const (
+	Databases   Kind = "database"
+	Relations   Kind = "relations"
+	Rows        Kind = "rows"
+	Roles       Kind = "role"
+	Tablespaces Kind = "tablespace"
+	Extensions  Kind = "extension"
+	Segments    Kind = "segment"
+)
+
+// Difference is an object whose value differs between the source and target.
+// The database is only set for objects within a database.
+type Difference struct {
+	Kind     Kind
+	Database string `json:",omitempty"`
+	Object   string
+	Source   string
+	Target   string
+}
+
+type Differences []Difference
+
+// Compare returns the differences of the target from the source. Extension
+// versions are not compared since they are expected to change when upgrading.
+// Only the rows of the tables counted in the source are compared.
+func Compare(source, target Snapshot) Differences {
+	var diffs Differences
+
+	diffs = append(diffs, compareNames(Databases, "", databaseNames(source), databaseNames(target))...)
+	for _, sourceDatabase := range source.Databases {
+		targetDatabase, ok := target.Database(sourceDatabase.Name)
+		if !ok {
+			continue
+		}
+
+		diffs = append(diffs, compareDatabase(sourceDatabase, targetDatabase)...)
+	}
+
+	diffs = append(diffs, compareNames(Roles, "", source.Roles, target.Roles)...)
+	diffs = append(diffs, compareNames(Tablespaces, "", source.Tablespaces, target.Tablespaces)...)
+	diffs = append(diffs, compareValues(Segments, "", segmentLocations(source.Segments), segmentLocations(target.Segments))...)
+
+	return diffs
+}
+
+func compareDatabase(source, target Database) Differences {
+	var diffs Differences
+
+	// A kind of relation missing from either database has none.
+	sourceCounts := comparableRelations(source.Relations)
+	targetCounts := comparableRelations(target.Relations)
+	sourceRelations := make(map[string]string)
+	targetRelations := make(map[string]string)
+	for kind := range sourceCounts {
+		sourceRelations[kind] = strconv.FormatInt(sourceCounts[kind], 10)
+		targetRelations[kind] = strconv.FormatInt(targetCounts[kind], 10)
+	}
+	for kind := range targetCounts {
+		sourceRelations[kind] = strconv.FormatInt(sourceCounts[kind], 10)
+		targetRelations[kind] = strconv.FormatInt(targetCounts[kind], 10)
+	}
+	diffs = append(diffs, compareValues(Relations, source.Name, sourceRelations, targetRelations)...)
+
+	// Only the tables counted in the source are compared.
+	sourceRows := make(map[string]string)
+	targetRows := make(map[string]string)
+	for table, count := range source.RowCounts {
+		sourceRows[table] = strconv.FormatInt(count, 10)
+		if targetCount, ok := target.RowCounts[table]; ok {
+			targetRows[table] = strconv.FormatInt(targetCount, 10)
+		}
+	}
+	diffs = append(diffs, compareValues(Rows, source.Name, sourceRows, targetRows)...)
+
+	diffs = append(diffs, compareNames(Extensions, source.Name, sortedKeys(source.Extensions), sortedKeys(target.Extensions))...)
+
+	return diffs
+}
+
+// comparableKinds are the kinds of relations counted as another kind when
+// comparing. The roots of partitioned tables and their indexes are tables and
+// indexes on GPDB 6 but partitioned tables and partitioned indexes on GPDB 7.
+var comparableKinds = map[string]string{
+	"partitioned table": "table",
+	"partitioned index": "index",
+}
+
+func comparableRelations(relations map[string]int64) map[string]int64 {
+	counts := make(map[string]int64)
+	for kind, count := range relations {
+		if as, ok := comparableKinds[kind]; ok {
+			kind = as
+		}
+
+		counts[kind] += count
+	}
+
+	return counts
+}
+
+// compareNames returns the names missing from either the source or target.
+func compareNames(kind Kind, database string, source []string, target []string) Differences {
+	sourceValues := make(map[string]string)
+	for _, name := range source {
+		sourceValues[name] = "present"
+	}
+
+	targetValues := make(map[string]string)
+	for _, name := range target {
+		targetValues[name] = "present"
+	}
+
+	return compareValues(kind, database, sourceValues, targetValues)
+}
+
+// compareValues returns the objects whose values differ ordered by object.
+// Objects missing from either the source or target have the value missing.
+func compareValues(kind Kind, database string, source map[string]string, target map[string]string) Differences {
+	objects := make(map[string]string)
+	for object := range source {
+		objects[object] = ""
+	}
+	for object := range target {
+		objects[object] = ""
+	}
+
+	var diffs Differences
+	for _, object := range sortedKeys(objects) {
+		sourceValue, ok := source[object]
+		if !ok {
+			sourceValue = Missing
+		}
+
+		targetValue, ok := target[object]
+		if !ok {
+			targetValue = Missing
+		}
+
+		if sourceValue == targetValue {
+			continue
+		}
+
+		diffs = append(diffs, Difference{Kind: kind, Database: database, Object: object, Source: sourceValue, Target: targetValue})
+	}
+
+	return diffs
+}
+
+func databaseNames(s Snapshot) []string {
+	var names []string
+	for _, database := range s.Databases {
+		names = append(names, database.Name)
+	}
+
+	return names
+}
+
+// segmentLocations returns the host and port of each segment keyed by its
+// content and preferred role such that a failed over segment is reported.
+func segmentLocations(segments []Segment) map[string]string {
+	locations := make(map[string]string)
+	for _, segment := range segments {
+		key := fmt.Sprintf("content %d preferred role %s", segment.ContentID, segment.PreferredRole)
+		locations[key] = fmt.Sprintf("%s:%d role %s", segment.Hostname, segment.Port, segment.Role)
+	}
+
+	return locations
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// String lists each difference followed by a summary.
+func (d Differences) String() string {
+	if len(d) == 0 {
+		return "No differences found between the source and target clusters.\n"
+	}
+
+	var b strings.Builder
+	for _, diff := range d {
+		object := diff.Object
+		if diff.Database != "" {
+			object = fmt.Sprintf("%s in database %s", diff.Object, diff.Database)
+		}
+
+		fmt.Fprintf(&b, "[%s] %s: source %s, target %s\n", diff.Kind, object, diff.Source, diff.Target)
+	}
+
+	fmt.Fprintf(&b, "\n%d differences found between the source and target clusters.\n", len(d))
+	return b.String()
+}
+
+// Write writes the differences as JSON to the validation file in dir.
+func (d Differences) Write(dir string) error {
+	data, err := d.JSON()
+	if err != nil {
+		return err
+	}
+
+	return utils.AtomicallyWrite(filepath.Join(dir, ValidationFileName), data)
+}
+
+func (d Differences) JSON() ([]byte, error) {
+	if d == nil {
+		d = Differences{}
+	}
+
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, xerrors.Errorf("marshal differences: %w", err)
+	}
+
+	return data, nil
+}
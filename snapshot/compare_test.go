@@ -0,0 +1,187 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/snapshot"
+)
+
+func source() snapshot.Snapshot {
+	return snapshot.Snapshot{
+		Version: snapshot.Version,
+		Databases: []snapshot.Database{
+			{
+				Name:       "postgres",
+				Relations:  map[string]int64{"table": 2, "index": 1},
+				RowCounts:  map[string]int64{"public.a": 10, "public.b": 5},
+				Extensions: map[string]string{"plpgsql": "1.0"},
+			},
+			{Name: "template1"},
+		},
+		Roles:       []string{"gpadmin", "reader"},
+		Tablespaces: []string{"pg_default", "pg_global"},
+		Segments: []snapshot.Segment{
+			{ContentID: -1, Role: "p", PreferredRole: "p", Hostname: "cdw", Port: 5432},
+			{ContentID: 0, Role: "p", PreferredRole: "p", Hostname: "sdw1", Port: 6000},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Run("finds no differences for identical snapshots", func(t *testing.T) {
+		diffs := snapshot.Compare(source(), source())
+		if len(diffs) != 0 {
+			t.Errorf("got %v want no differences", diffs)
+		}
+	})
+
+	t.Run("ignores extension versions", func(t *testing.T) {
+		target := source()
+		target.Databases[0].Extensions = map[string]string{"plpgsql": "2.0"}
+
+		diffs := snapshot.Compare(source(), target)
+		if len(diffs) != 0 {
+			t.Errorf("got %v want no differences", diffs)
+		}
+	})
+
+	t.Run("finds missing objects", func(t *testing.T) {
+		target := source()
+		target.Databases = target.Databases[:1]
+		target.Databases[0].Extensions = map[string]string{"plpgsql": "1.0", "postgis": "3.0"}
+		target.Roles = []string{"gpadmin"}
+		target.Tablespaces = []string{"pg_default", "pg_global", "fast"}
+
+		expected := snapshot.Differences{
+			{Kind: snapshot.Databases, Object: "template1", Source: "present", Target: snapshot.Missing},
+			{Kind: snapshot.Extensions, Database: "postgres", Object: "postgis", Source: snapshot.Missing, Target: "present"},
+			{Kind: snapshot.Roles, Object: "reader", Source: "present", Target: snapshot.Missing},
+			{Kind: snapshot.Tablespaces, Object: "fast", Source: snapshot.Missing, Target: "present"},
+		}
+
+		diffs := snapshot.Compare(source(), target)
+		if !reflect.DeepEqual(diffs, expected) {
+			t.Errorf("got %+v want %+v", diffs, expected)
+		}
+	})
+
+	t.Run("finds differing relation and row counts", func(t *testing.T) {
+		target := source()
+		target.Databases[0].Relations = map[string]int64{"table": 2, "view": 1}
+		target.Databases[0].RowCounts = map[string]int64{"public.a": 9}
+
+		expected := snapshot.Differences{
+			{Kind: snapshot.Relations, Database: "postgres", Object: "index", Source: "1", Target: "0"},
+			{Kind: snapshot.Relations, Database: "postgres", Object: "view", Source: "0", Target: "1"},
+			{Kind: snapshot.Rows, Database: "postgres", Object: "public.a", Source: "10", Target: "9"},
+			{Kind: snapshot.Rows, Database: "postgres", Object: "public.b", Source: "5", Target: snapshot.Missing},
+		}
+
+		diffs := snapshot.Compare(source(), target)
+		if !reflect.DeepEqual(diffs, expected) {
+			t.Errorf("got %+v want %+v", diffs, expected)
+		}
+	})
+
+	t.Run("counts partitioned tables and indexes as tables and indexes", func(t *testing.T) {
+		gpdb6 := source()
+		gpdb6.Databases[0].Relations = map[string]int64{"table": 5, "index": 2}
+
+		target := source()
+		target.Databases[0].Relations = map[string]int64{"table": 3, "partitioned table": 2, "index": 1, "partitioned index": 1}
+
+		diffs := snapshot.Compare(gpdb6, target)
+		if len(diffs) != 0 {
+			t.Errorf("got %v want no differences", diffs)
+		}
+
+		target.Databases[0].Relations = map[string]int64{"table": 3, "partitioned table": 1, "index": 1, "partitioned index": 1}
+
+		expected := snapshot.Differences{
+			{Kind: snapshot.Relations, Database: "postgres", Object: "table", Source: "5", Target: "4"},
+		}
+
+		diffs = snapshot.Compare(gpdb6, target)
+		if !reflect.DeepEqual(diffs, expected) {
+			t.Errorf("got %+v want %+v", diffs, expected)
+		}
+	})
+
+	t.Run("finds changed segments", func(t *testing.T) {
+		target := source()
+		target.Segments = []snapshot.Segment{
+			{ContentID: -1, Role: "p", PreferredRole: "p", Hostname: "cdw", Port: 5432},
+			{ContentID: 0, Role: "p", PreferredRole: "p", Hostname: "sdw1", Port: 6001},
+		}
+
+		expected := snapshot.Differences{
+			{Kind: snapshot.Segments, Object: "content 0 preferred role p", Source: "sdw1:6000 role p", Target: "sdw1:6001 role p"},
+		}
+
+		diffs := snapshot.Compare(source(), target)
+		if !reflect.DeepEqual(diffs, expected) {
+			t.Errorf("got %+v want %+v", diffs, expected)
+		}
+	})
+}
+
+func TestDifferences(t *testing.T) {
+	diffs := snapshot.Differences{
+		{Kind: snapshot.Roles, Object: "reader", Source: "present", Target: snapshot.Missing},
+		{Kind: snapshot.Rows, Database: "postgres", Object: "public.a", Source: "10", Target: "9"},
+	}
+
+	t.Run("String lists the differences", func(t *testing.T) {
+		expected := `[role] reader: source present, target missing
+[rows] public.a in database postgres: source 10, target 9
+
+2 differences found between the source and target clusters.
+`
+		if diffs.String() != expected {
+			t.Errorf("got %q want %q", diffs.String(), expected)
+		}
+
+		var none snapshot.Differences
+		if !strings.HasPrefix(none.String(), "No differences found") {
+			t.Errorf("got %q want no differences found", none.String())
+		}
+	})
+
+	t.Run("JSON omits the database of cluster wide objects", func(t *testing.T) {
+		data, err := diffs.JSON()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var result []map[string]string
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, ok := result[0]["Database"]; ok {
+			t.Errorf("expected no database for %v", result[0])
+		}
+
+		if result[1]["Database"] != "postgres" {
+			t.Errorf("got database %q want %q", result[1]["Database"], "postgres")
+		}
+	})
+
+	t.Run("JSON is an empty list when there are no differences", func(t *testing.T) {
+		var none snapshot.Differences
+		data, err := none.JSON()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(data) != "[]" {
+			t.Errorf("got %q want %q", data, "[]")
+		}
+	})
+}
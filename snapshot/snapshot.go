@@ -0,0 +1,529 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package snapshot captures the user visible contents of a cluster such as its
+// databases, schemas, relations, row counts, sizes, roles, tablespaces,
+// extensions, settings, and segment topology. A snapshot of the source cluster
+// is taken during initialize and compared against the upgraded target cluster
+// to confirm nothing was lost. It is archived with the logs such that the
+// source cluster can be reasoned about after it has been modified or shut down.
+package snapshot
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// Version is incremented when the format of the snapshot changes.
+const Version = 1
+
+// SourceFileName is the snapshot of the source cluster in the state directory.
+const SourceFileName = "source_snapshot.json"
+
+func SourcePath() string {
+	return filepath.Join(utils.GetStateDir(), SourceFileName)
+}
+
+type Snapshot struct {
+	Version     int
+	TakenAt     time.Time
+	Databases   []Database
+	Roles       []string
+	Tablespaces []string
+	Segments    []Segment
+
+	// TablespaceLocations is the location of each tablespace on each segment.
+	TablespaceLocations []Tablespace
+
+	// Settings is the value of each GUC from pg_settings on the coordinator
+	// keyed by name.
+	Settings map[string]string
+}
+
+type Database struct {
+	Name string
+
+	// Schemas is the names of the schemas excluding the system catalogs.
+	Schemas []string
+
+	// Sizes is the size in bytes of the database on each segment keyed by
+	// content ID.
+	Sizes map[int]int64
+
+	// Relations is the number of relations of each kind such as table, index,
+	// or view excluding the system catalogs.
+	Relations map[string]int64
+
+	// RowCounts is the number of rows of each table counted keyed by its
+	// qualified name.
+	RowCounts map[string]int64
+
+	// Extensions is the version of each extension keyed by name.
+	Extensions map[string]string
+}
+
+type Segment struct {
+	ContentID     int
+	Role          string
+	PreferredRole string
+	Hostname      string
+	Port          int
+}
+
+type Tablespace struct {
+	DbID        int
+	Oid         int
+	Name        string
+	Location    string
+	UserDefined bool
+}
+
+// Size returns the total size in bytes of the database across the segments.
+func (d Database) Size() int64 {
+	var size int64
+	for _, s := range d.Sizes {
+		size += s
+	}
+
+	return size
+}
+
+func (s Snapshot) Database(name string) (Database, bool) {
+	for _, database := range s.Databases {
+		if database.Name == name {
+			return database, true
+		}
+	}
+
+	return Database{}, false
+}
+
+// Take snapshots the cluster. The rows of up to sample tables in each database
+// are counted, or all tables when sample is zero. When source is set the rows
+// of the tables counted in the source are counted instead such that the
+// snapshots can be compared.
+func Take(cluster *greenplum.Cluster, sample uint, source *Snapshot) (Snapshot, error) {
+	db, err := sql.Open("pgx", cluster.Connection())
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot, err := TakeCluster(db, cluster.Version)
+	if cErr := db.Close(); cErr != nil {
+		err = errorlist.Append(err, cErr)
+	}
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	for i, database := range snapshot.Databases {
+		snapshot.Databases[i], err = takeDatabase(cluster, database.Name, sample, source)
+		if err != nil {
+			return Snapshot{}, xerrors.Errorf("database %q: %w", database.Name, err)
+		}
+
+		snapshot.Databases[i].Sizes = database.Sizes
+	}
+
+	return snapshot, nil
+}
+
+func takeDatabase(cluster *greenplum.Cluster, name string, sample uint, source *Snapshot) (_ Database, err error) {
+	db, err := sql.Open("pgx", cluster.Connection(greenplum.Database(name)))
+	if err != nil {
+		return Database{}, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	if source == nil {
+		tables, err := Tables(db, cluster.Version, sample)
+		if err != nil {
+			return Database{}, err
+		}
+
+		return TakeDatabase(db, name, tables)
+	}
+
+	sourceDatabase, ok := source.Database(name)
+	if !ok {
+		return TakeDatabase(db, name, nil)
+	}
+
+	// Only count the tables that exist. Those missing are reported when
+	// comparing.
+	existing, err := Tables(db, cluster.Version, 0)
+	if err != nil {
+		return Database{}, err
+	}
+
+	var tables []string
+	for _, table := range existing {
+		if _, ok := sourceDatabase.RowCounts[table]; ok {
+			tables = append(tables, table)
+		}
+	}
+
+	return TakeDatabase(db, name, tables)
+}
+
+// TakeCluster snapshots the objects shared by the databases of the cluster.
+// Only the names and sizes of the databases are set.
+func TakeCluster(db *sql.DB, version semver.Version) (Snapshot, error) {
+	snapshot := Snapshot{Version: Version, TakenAt: utils.System.Now()}
+
+	names, err := queryStrings(db, "SELECT datname FROM pg_database WHERE datname <> 'template0' ORDER BY datname")
+	if err != nil {
+		return Snapshot{}, xerrors.Errorf("query databases: %w", err)
+	}
+
+	sizes, err := databaseSizes(db)
+	if err != nil {
+		return Snapshot{}, xerrors.Errorf("query database sizes: %w", err)
+	}
+
+	for _, name := range names {
+		snapshot.Databases = append(snapshot.Databases, Database{Name: name, Sizes: sizes[name]})
+	}
+
+	snapshot.Roles, err = queryStrings(db, "SELECT rolname FROM pg_roles ORDER BY rolname")
+	if err != nil {
+		return Snapshot{}, xerrors.Errorf("query roles: %w", err)
+	}
+
+	snapshot.Tablespaces, err = queryStrings(db, "SELECT spcname FROM pg_tablespace ORDER BY spcname")
+	if err != nil {
+		return Snapshot{}, xerrors.Errorf("query tablespaces: %w", err)
+	}
+
+	snapshot.Segments, err = segments(db)
+	if err != nil {
+		return Snapshot{}, xerrors.Errorf("query segments: %w", err)
+	}
+
+	snapshot.TablespaceLocations, err = TablespaceLocations(db, version)
+	if err != nil {
+		return Snapshot{}, xerrors.Errorf("query tablespace locations: %w", err)
+	}
+
+	snapshot.Settings, err = settings(db)
+	if err != nil {
+		return Snapshot{}, xerrors.Errorf("query settings: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// databaseSizes returns the size of each database on each segment keyed by
+// database name and content ID.
+func databaseSizes(db *sql.DB) (map[string]map[int]int64, error) {
+	sizes := make(map[string]map[int]int64)
+	err := queryRows(db, `
+	SELECT datname, -1, pg_database_size(datname) FROM pg_database WHERE datname <> 'template0'
+	UNION ALL
+	SELECT datname, gp_segment_id, pg_database_size(datname) FROM gp_dist_random('pg_database') WHERE datname <> 'template0'`, func(rows *sql.Rows) error {
+		var name string
+		var contentID int
+		var size int64
+		if err := rows.Scan(&name, &contentID, &size); err != nil {
+			return err
+		}
+
+		if _, ok := sizes[name]; !ok {
+			sizes[name] = make(map[int]int64)
+		}
+
+		sizes[name][contentID] = size
+		return nil
+	})
+
+	return sizes, err
+}
+
+// TablespaceLocations returns the location of each tablespace on each
+// segment. GPDB 5 stores the locations in filespaces which are queried the same
+// way as when creating the configuration.
+func TablespaceLocations(db *sql.DB, version semver.Version) ([]Tablespace, error) {
+	var tablespaces []Tablespace
+	if version.Major == 5 {
+		tuples, err := greenplum.GetTablespaceTuples(db)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tuple := range tuples {
+			tablespaces = append(tablespaces, Tablespace{
+				DbID:        int(tuple.DbId),
+				Oid:         int(tuple.Oid),
+				Name:        tuple.Name,
+				Location:    tuple.Info.GetLocation(),
+				UserDefined: tuple.Info.GetUserDefined(),
+			})
+		}
+
+		return tablespaces, nil
+	}
+
+	err := queryRows(db, `
+	SELECT c.dbid, t.oid, t.spcname, coalesce(l.tblspc_loc, ''), t.spcname NOT IN ('pg_default', 'pg_global')
+	FROM pg_tablespace t
+	CROSS JOIN LATERAL gp_tablespace_location(t.oid) l
+	JOIN gp_segment_configuration c ON c.content = l.gp_segment_id AND c.role = 'p'
+	ORDER BY c.dbid, t.oid`, func(rows *sql.Rows) error {
+		var tablespace Tablespace
+		if err := rows.Scan(&tablespace.DbID, &tablespace.Oid, &tablespace.Name, &tablespace.Location, &tablespace.UserDefined); err != nil {
+			return err
+		}
+
+		tablespaces = append(tablespaces, tablespace)
+		return nil
+	})
+
+	return tablespaces, err
+}
+
+func settings(db *sql.DB) (map[string]string, error) {
+	settings := make(map[string]string)
+	err := queryRows(db, "SELECT name, setting FROM pg_settings ORDER BY name", func(rows *sql.Rows) error {
+		var name, setting string
+		if err := rows.Scan(&name, &setting); err != nil {
+			return err
+		}
+
+		settings[name] = setting
+		return nil
+	})
+
+	return settings, err
+}
+
+func segments(db *sql.DB) ([]Segment, error) {
+	var segments []Segment
+	err := queryRows(db, "SELECT content, role::text, preferred_role::text, hostname, port FROM gp_segment_configuration ORDER BY content, role", func(rows *sql.Rows) error {
+		var segment Segment
+		if err := rows.Scan(&segment.ContentID, &segment.Role, &segment.PreferredRole, &segment.Hostname, &segment.Port); err != nil {
+			return err
+		}
+
+		segments = append(segments, segment)
+		return nil
+	})
+
+	return segments, err
+}
+
+// userRelations excludes the system catalogs and the auxiliary relations
+// Greenplum creates for append-optimized tables and bitmap indexes.
+const userRelations = `
+	n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast', 'pg_aoseg', 'pg_bitmapindex', 'gp_toolkit')
+	AND n.nspname NOT LIKE 'pg_temp_%'
+	AND n.nspname NOT LIKE 'pg_toast_temp_%'`
+
+// Tables returns the qualified names of the user tables ordered by name. Up to
+// limit tables are returned, or all tables when limit is zero. External tables
+// are excluded since counting their rows reads the external data.
+func Tables(db *sql.DB, version semver.Version, limit uint) ([]string, error) {
+	query := `
+	SELECT quote_ident(n.nspname) || '.' || quote_ident(c.relname)
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE c.relkind IN ('r', 'p') AND` + userRelations
+
+	if version.Major < 7 {
+		query += `
+	AND c.relstorage <> 'x'`
+	}
+
+	query += `
+	ORDER BY 1`
+
+	if limit > 0 {
+		query += fmt.Sprintf(`
+	LIMIT %d`, limit)
+	}
+
+	tables, err := queryStrings(db, query)
+	if err != nil {
+		return nil, xerrors.Errorf("query tables: %w", err)
+	}
+
+	return tables, nil
+}
+
+// relkinds names the kinds of relations counted.
+var relkinds = map[string]string{
+	"r": "table",
+	"i": "index",
+	"S": "sequence",
+	"v": "view",
+	"m": "materialized view",
+	"c": "composite type",
+	"f": "foreign table",
+	"p": "partitioned table",
+	"I": "partitioned index",
+	"t": "toast table",
+}
+
+// TakeDatabase snapshots the schemas, relations, and extensions of the
+// database along with the number of rows of the given tables.
+func TakeDatabase(db *sql.DB, name string, tables []string) (Database, error) {
+	database := Database{
+		Name:       name,
+		Relations:  make(map[string]int64),
+		RowCounts:  make(map[string]int64),
+		Extensions: make(map[string]string),
+	}
+
+	var err error
+	database.Schemas, err = queryStrings(db, `
+	SELECT n.nspname
+	FROM pg_namespace n
+	WHERE`+userRelations+`
+	ORDER BY n.nspname`)
+	if err != nil {
+		return Database{}, xerrors.Errorf("query schemas: %w", err)
+	}
+
+	err = queryRows(db, `
+	SELECT c.relkind::text, count(*)
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE`+userRelations+`
+	GROUP BY c.relkind`, func(rows *sql.Rows) error {
+		var relkind string
+		var count int64
+		if err := rows.Scan(&relkind, &count); err != nil {
+			return err
+		}
+
+		kind, ok := relkinds[relkind]
+		if !ok {
+			kind = relkind
+		}
+
+		database.Relations[kind] = count
+		return nil
+	})
+	if err != nil {
+		return Database{}, xerrors.Errorf("query relations: %w", err)
+	}
+
+	err = queryRows(db, "SELECT extname, extversion FROM pg_extension ORDER BY extname", func(rows *sql.Rows) error {
+		var extension, version string
+		if err := rows.Scan(&extension, &version); err != nil {
+			return err
+		}
+
+		database.Extensions[extension] = version
+		return nil
+	})
+	if err != nil {
+		return Database{}, xerrors.Errorf("query extensions: %w", err)
+	}
+
+	for _, table := range tables {
+		var count int64
+		// The table is already quoted by Tables.
+		err := db.QueryRow("SELECT count(*) FROM " + table).Scan(&count)
+		if err != nil {
+			return Database{}, xerrors.Errorf("count rows of %s: %w", table, err)
+		}
+
+		database.RowCounts[table] = count
+	}
+
+	return database, nil
+}
+
+func queryStrings(db *sql.DB, query string) ([]string, error) {
+	var values []string
+	err := queryRows(db, query, func(rows *sql.Rows) error {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return err
+		}
+
+		values = append(values, value)
+		return nil
+	})
+
+	return values, err
+}
+
+// queryRows calls scan for each row of the query.
+func queryRows(db *sql.DB, query string, scan func(*sql.Rows) error) (err error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := rows.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (s Snapshot) Write(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("marshal snapshot: %w", err)
+	}
+
+	return utils.AtomicallyWrite(path, data)
+}
+
+// ArchiveSource copies the snapshot of the source cluster to the log directory
+// such that it is archived with the logs. Nothing is copied when there is no
+// snapshot such as when initialize did not get far enough to take it.
+func ArchiveSource(logDir string) error {
+	data, err := utils.System.ReadFile(SourcePath())
+	if err != nil {
+		if utils.System.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return utils.AtomicallyWrite(filepath.Join(logDir, SourceFileName), data)
+}
+
+func Read(path string) (Snapshot, error) {
+	data, err := utils.System.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	var snapshot Snapshot
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return Snapshot{}, xerrors.Errorf("unmarshal snapshot %q: %w", path, err)
+	}
+
+	if snapshot.Version != Version {
+		return Snapshot{}, fmt.Errorf("snapshot %q has version %d want %d", path, snapshot.Version, Version)
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,379 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/snapshot"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func TestTakeCluster(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	utils.System.Now = func() time.Time {
+		return now
+	}
+	defer func() {
+		utils.System.Now = time.Now
+	}()
+
+	t.Run("snapshots the databases, roles, tablespaces, segments, and settings", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery("SELECT datname FROM pg_database").
+			WillReturnRows(sqlmock.NewRows([]string{"datname"}).AddRow("postgres").AddRow("template1"))
+		mock.ExpectQuery(`SELECT datname, -1, pg_database_size\(datname\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"datname", "gp_segment_id", "size"}).
+				AddRow("postgres", -1, 100).
+				AddRow("postgres", 0, 200).
+				AddRow("template1", -1, 50))
+		mock.ExpectQuery("SELECT rolname FROM pg_roles").
+			WillReturnRows(sqlmock.NewRows([]string{"rolname"}).AddRow("gpadmin"))
+		mock.ExpectQuery("SELECT spcname FROM pg_tablespace").
+			WillReturnRows(sqlmock.NewRows([]string{"spcname"}).AddRow("pg_default").AddRow("pg_global"))
+		mock.ExpectQuery("SELECT content, role::text, preferred_role::text, hostname, port FROM gp_segment_configuration").
+			WillReturnRows(sqlmock.NewRows([]string{"content", "role", "preferred_role", "hostname", "port"}).
+				AddRow(-1, "p", "p", "cdw", 5432).
+				AddRow(0, "p", "p", "sdw1", 6000).
+				AddRow(0, "m", "m", "sdw2", 7000))
+		mock.ExpectQuery("SELECT c.dbid, t.oid, t.spcname").
+			WillReturnRows(sqlmock.NewRows([]string{"dbid", "oid", "spcname", "location", "userdefined"}).
+				AddRow(1, 1663, "pg_default", "", false).
+				AddRow(1, 16385, "fast", "/data/fast", true))
+		mock.ExpectQuery("SELECT name, setting FROM pg_settings").
+			WillReturnRows(sqlmock.NewRows([]string{"name", "setting"}).AddRow("max_connections", "250"))
+
+		result, err := snapshot.TakeCluster(db, semver.MustParse("6.20.0"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := snapshot.Snapshot{
+			Version: snapshot.Version,
+			TakenAt: now,
+			Databases: []snapshot.Database{
+				{Name: "postgres", Sizes: map[int]int64{-1: 100, 0: 200}},
+				{Name: "template1", Sizes: map[int]int64{-1: 50}},
+			},
+			Roles:       []string{"gpadmin"},
+			Tablespaces: []string{"pg_default", "pg_global"},
+			Segments: []snapshot.Segment{
+				{ContentID: -1, Role: "p", PreferredRole: "p", Hostname: "cdw", Port: 5432},
+				{ContentID: 0, Role: "p", PreferredRole: "p", Hostname: "sdw1", Port: 6000},
+				{ContentID: 0, Role: "m", PreferredRole: "m", Hostname: "sdw2", Port: 7000},
+			},
+			TablespaceLocations: []snapshot.Tablespace{
+				{DbID: 1, Oid: 1663, Name: "pg_default", Location: "", UserDefined: false},
+				{DbID: 1, Oid: 16385, Name: "fast", Location: "/data/fast", UserDefined: true},
+			},
+			Settings: map[string]string{"max_connections": "250"},
+		}
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("got %+v want %+v", result, expected)
+		}
+
+		if result.Databases[0].Size() != 300 {
+			t.Errorf("got size %d want %d", result.Databases[0].Size(), 300)
+		}
+	})
+
+	t.Run("queries filespaces for the tablespace locations on GPDB 5", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery("SELECT datname FROM pg_database").
+			WillReturnRows(sqlmock.NewRows([]string{"datname"}))
+		mock.ExpectQuery(`SELECT datname, -1, pg_database_size\(datname\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"datname", "gp_segment_id", "size"}))
+		mock.ExpectQuery("SELECT rolname FROM pg_roles").
+			WillReturnRows(sqlmock.NewRows([]string{"rolname"}))
+		mock.ExpectQuery("SELECT spcname FROM pg_tablespace").
+			WillReturnRows(sqlmock.NewRows([]string{"spcname"}))
+		mock.ExpectQuery("SELECT content, role::text").
+			WillReturnRows(sqlmock.NewRows([]string{"content", "role", "preferred_role", "hostname", "port"}))
+		mock.ExpectQuery("pg_filespace_entry").
+			WillReturnRows(sqlmock.NewRows([]string{"dbid", "oid", "name", "location", "userdefined"}).
+				AddRow(2, 16385, "fast", "/data/fast/16385", 1))
+		mock.ExpectQuery("SELECT name, setting FROM pg_settings").
+			WillReturnRows(sqlmock.NewRows([]string{"name", "setting"}))
+
+		result, err := snapshot.TakeCluster(db, semver.MustParse("5.29.0"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []snapshot.Tablespace{{DbID: 2, Oid: 16385, Name: "fast", Location: "/data/fast/16385", UserDefined: true}}
+		if !reflect.DeepEqual(result.TablespaceLocations, expected) {
+			t.Errorf("got %+v want %+v", result.TablespaceLocations, expected)
+		}
+	})
+
+	t.Run("errors when querying fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		expected := errors.New("permission denied")
+		mock.ExpectQuery("SELECT datname FROM pg_database").
+			WillReturnRows(sqlmock.NewRows([]string{"datname"}).AddRow("postgres"))
+		mock.ExpectQuery(`SELECT datname, -1, pg_database_size\(datname\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"datname", "gp_segment_id", "size"}))
+		mock.ExpectQuery("SELECT rolname FROM pg_roles").WillReturnError(expected)
+
+		_, err = snapshot.TakeCluster(db, semver.MustParse("6.20.0"))
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestTables(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	cases := []struct {
+		name     string
+		version  string
+		limit    uint
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "excludes external tables on GPDB 6",
+			version:  "6.20.0",
+			contains: []string{"relstorage <> 'x'"},
+			excludes: []string{"LIMIT"},
+		},
+		{
+			name:     "does not reference relstorage on GPDB 7",
+			version:  "7.0.0",
+			excludes: []string{"relstorage", "LIMIT"},
+		},
+		{
+			name:     "limits the tables",
+			version:  "7.0.0",
+			limit:    25,
+			contains: []string{"LIMIT 25"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherFunc(func(_, actual string) error {
+				for _, s := range c.contains {
+					if !strings.Contains(actual, s) {
+						return errors.New("expected query to contain " + s)
+					}
+				}
+
+				for _, s := range c.excludes {
+					if strings.Contains(actual, s) {
+						return errors.New("expected query to not contain " + s)
+					}
+				}
+
+				return nil
+			})))
+			if err != nil {
+				t.Fatalf("couldn't create sqlmock: %v", err)
+			}
+			defer testutils.FinishMock(mock, t)
+
+			mock.ExpectQuery("").WillReturnRows(sqlmock.NewRows([]string{"table"}).AddRow("public.a").AddRow(`public."B"`))
+
+			tables, err := snapshot.Tables(db, semver.MustParse(c.version), c.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := []string{"public.a", `public."B"`}
+			if !reflect.DeepEqual(tables, expected) {
+				t.Errorf("got %q want %q", tables, expected)
+			}
+		})
+	}
+}
+
+func TestTakeDatabase(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("snapshots the schemas, relations, extensions, and row counts", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery("SELECT n.nspname").
+			WillReturnRows(sqlmock.NewRows([]string{"nspname"}).AddRow("public").AddRow("sales"))
+		mock.ExpectQuery("SELECT c.relkind::text, count").
+			WillReturnRows(sqlmock.NewRows([]string{"relkind", "count"}).AddRow("r", 2).AddRow("i", 3).AddRow("z", 1))
+		mock.ExpectQuery("SELECT extname, extversion FROM pg_extension").
+			WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion"}).AddRow("plpgsql", "1.0"))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM public.a`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM public."B"`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		database, err := snapshot.TakeDatabase(db, "postgres", []string{"public.a", `public."B"`})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := snapshot.Database{
+			Name:       "postgres",
+			Schemas:    []string{"public", "sales"},
+			Relations:  map[string]int64{"table": 2, "index": 3, "z": 1},
+			RowCounts:  map[string]int64{"public.a": 10, `public."B"`: 0},
+			Extensions: map[string]string{"plpgsql": "1.0"},
+		}
+
+		if !reflect.DeepEqual(database, expected) {
+			t.Errorf("got %+v want %+v", database, expected)
+		}
+	})
+
+	t.Run("errors when counting rows fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		expected := errors.New("relation does not exist")
+		mock.ExpectQuery("SELECT n.nspname").
+			WillReturnRows(sqlmock.NewRows([]string{"nspname"}))
+		mock.ExpectQuery("SELECT c.relkind::text, count").
+			WillReturnRows(sqlmock.NewRows([]string{"relkind", "count"}))
+		mock.ExpectQuery("SELECT extname, extversion FROM pg_extension").
+			WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion"}))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM public.a`).WillReturnError(expected)
+
+		_, err = snapshot.TakeDatabase(db, "postgres", []string{"public.a"})
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestWriteAndRead(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	path := filepath.Join(dir, snapshot.SourceFileName)
+
+	t.Run("reads the written snapshot", func(t *testing.T) {
+		expected := snapshot.Snapshot{
+			Version:   snapshot.Version,
+			TakenAt:   time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+			Databases: []snapshot.Database{{Name: "postgres", Relations: map[string]int64{"table": 1}, RowCounts: map[string]int64{"public.a": 1}, Extensions: map[string]string{"plpgsql": "1.0"}}},
+			Roles:     []string{"gpadmin"},
+			Segments:  []snapshot.Segment{{ContentID: -1, Role: "p", PreferredRole: "p", Hostname: "cdw", Port: 5432}},
+		}
+
+		err := expected.Write(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result, err := snapshot.Read(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("got %+v want %+v", result, expected)
+		}
+	})
+
+	t.Run("errors when the version differs", func(t *testing.T) {
+		err := snapshot.Snapshot{Version: snapshot.Version + 1}.Write(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = snapshot.Read(path)
+		if err == nil || !strings.Contains(err.Error(), "has version") {
+			t.Errorf("expected version error got %v", err)
+		}
+	})
+
+	t.Run("errors when the snapshot does not exist", func(t *testing.T) {
+		_, err := snapshot.Read(filepath.Join(dir, "does-not-exist"))
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("got error %#v want %#v", err, os.ErrNotExist)
+		}
+	})
+}
+
+func TestArchiveSource(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	logDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, logDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	t.Run("does nothing when there is no snapshot", func(t *testing.T) {
+		err := snapshot.ArchiveSource(logDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		testutils.PathMustNotExist(t, filepath.Join(logDir, snapshot.SourceFileName))
+	})
+
+	t.Run("copies the snapshot to the log directory", func(t *testing.T) {
+		expected := snapshot.Snapshot{Version: snapshot.Version, Roles: []string{"gpadmin"}}
+		err := expected.Write(snapshot.SourcePath())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = snapshot.ArchiveSource(logDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result, err := snapshot.Read(filepath.Join(logDir, snapshot.SourceFileName))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("got %+v want %+v", result, expected)
+		}
+
+		testutils.PathMustExist(t, snapshot.SourcePath())
+	})
+}
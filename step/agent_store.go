@@ -0,0 +1,129 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package step
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+const AgentsFileName = "agents.json"
+
+// AgentFileStore persists the result of the latest heartbeat sent to each
+// agent such that the health of the agents can be shown in the status even
+// when the hub is not running.
+type AgentFileStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// AgentEntry is the persisted health of an agent keyed by host. The health is
+// from the last successful heartbeat while Error is set when the latest
+// heartbeat failed.
+type AgentEntry struct {
+	LastHeartbeat  *time.Time `json:",omitempty"`
+	Version        string
+	Pid            int32
+	Uptime         PrettyDuration
+	StateDirectory string
+	LoadAverage1   float64
+	LoadAverage5   float64
+	LoadAverage15  float64
+	DiskAvailable  uint64
+	DiskTotal      uint64
+	Error          string `json:",omitempty"`
+}
+
+func NewAgentFileStore() (*AgentFileStore, error) {
+	path, err := utils.GetJSONFile(utils.GetStateDir(), AgentsFileName)
+	if err != nil {
+		return &AgentFileStore{}, xerrors.Errorf("read %q: %w", AgentsFileName, err)
+	}
+
+	return &AgentFileStore{path: path}, nil
+}
+
+func NewAgentStoreUsingFile(path string) *AgentFileStore {
+	return &AgentFileStore{path: path}
+}
+
+func (f *AgentFileStore) load() (map[string]AgentEntry, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var agents map[string]AgentEntry
+	err = json.Unmarshal(data, &agents)
+	if err != nil {
+		return nil, err
+	}
+
+	if agents == nil {
+		agents = make(map[string]AgentEntry)
+	}
+
+	return agents, nil
+}
+
+func (f *AgentFileStore) ReadAll() (map[string]AgentEntry, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.load()
+}
+
+// WriteHealth records a successful heartbeat clearing any previous error.
+func (f *AgentFileStore) WriteHealth(host string, health *idl.AgentHealth, heartbeat time.Time) error {
+	return f.update(host, func(entry *AgentEntry) {
+		*entry = AgentEntry{
+			LastHeartbeat:  &heartbeat,
+			Version:        health.GetVersion(),
+			Pid:            health.GetPid(),
+			Uptime:         PrettyDuration{health.GetUptime().AsDuration()},
+			StateDirectory: health.GetStateDirectory(),
+			LoadAverage1:   health.GetLoadAverage1(),
+			LoadAverage5:   health.GetLoadAverage5(),
+			LoadAverage15:  health.GetLoadAverage15(),
+			DiskAvailable:  health.GetDiskAvailable(),
+			DiskTotal:      health.GetDiskTotal(),
+		}
+	})
+}
+
+// WriteError records a failed heartbeat keeping the health from the last
+// successful one.
+func (f *AgentFileStore) WriteError(host string, heartbeatErr error) error {
+	return f.update(host, func(entry *AgentEntry) {
+		entry.Error = heartbeatErr.Error()
+	})
+}
+
+func (f *AgentFileStore) update(host string, update func(entry *AgentEntry)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	agents, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	entry := agents[host]
+	update(&entry)
+	agents[host] = entry
+
+	data, err := json.MarshalIndent(agents, "", "  ") // pretty print JSON
+	if err != nil {
+		return err
+	}
+
+	return utils.AtomicallyWrite(f.path, data)
+}
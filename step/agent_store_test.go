@@ -0,0 +1,109 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package step_test
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestAgentFileStore(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	path := filepath.Join(dir, step.AgentsFileName)
+	testutils.MustWriteToFile(t, path, "{}")
+	store := step.NewAgentStoreUsingFile(path)
+
+	heartbeat := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	health := &idl.AgentHealth{
+		Version:        "1.2.3",
+		Pid:            42,
+		Uptime:         durationpb.New(time.Hour),
+		StateDirectory: "/home/gpadmin/.gpupgrade",
+		LoadAverage1:   0.5,
+		DiskAvailable:  1024,
+		DiskTotal:      4096,
+	}
+
+	healthy := step.AgentEntry{
+		LastHeartbeat:  &heartbeat,
+		Version:        "1.2.3",
+		Pid:            42,
+		Uptime:         step.PrettyDuration{Duration: time.Hour},
+		StateDirectory: "/home/gpadmin/.gpupgrade",
+		LoadAverage1:   0.5,
+		DiskAvailable:  1024,
+		DiskTotal:      4096,
+	}
+
+	t.Run("records the health of each agent", func(t *testing.T) {
+		err := store.WriteHealth("sdw1", health, heartbeat)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		agents, err := store.ReadAll()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := map[string]step.AgentEntry{"sdw1": healthy}
+		if !reflect.DeepEqual(agents, expected) {
+			t.Errorf("got %+v want %+v", agents, expected)
+		}
+	})
+
+	t.Run("keeps the last health when recording an error", func(t *testing.T) {
+		err := store.WriteError("sdw1", errors.New("connection refused"))
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		err = store.WriteError("sdw2", errors.New("connection refused"))
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		agents, err := store.ReadAll()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		failed := healthy
+		failed.Error = "connection refused"
+		expected := map[string]step.AgentEntry{
+			"sdw1": failed,
+			"sdw2": {Error: "connection refused"},
+		}
+		if !reflect.DeepEqual(agents, expected) {
+			t.Errorf("got %+v want %+v", agents, expected)
+		}
+	})
+
+	t.Run("clears the error on the next successful heartbeat", func(t *testing.T) {
+		err := store.WriteHealth("sdw1", health, heartbeat)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		agents, err := store.ReadAll()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if agents["sdw1"].Error != "" {
+			t.Errorf("got error %q want none", agents["sdw1"].Error)
+		}
+	})
+}
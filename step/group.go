@@ -0,0 +1,137 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package step
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/substeps"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/logger"
+)
+
+// Group is a set of independent substeps such as those that touch different
+// hosts and directories. They are declared like the substeps of a step and
+// are run concurrently by RunConcurrently. Since they share the step each is
+// given its own context which holds its span for the requests it makes.
+type Group struct {
+	substeps []groupSubstep
+}
+
+type groupSubstep struct {
+	substep   idl.Substep
+	f         func(context.Context, OutStreams) error
+	alwaysRun bool
+	bypass    bool // the run condition is not met
+}
+
+func (g *Group) Run(substep idl.Substep, f func(context.Context, OutStreams) error) {
+	g.substeps = append(g.substeps, groupSubstep{substep: substep, f: f})
+}
+
+func (g *Group) AlwaysRun(substep idl.Substep, f func(context.Context, OutStreams) error) {
+	g.substeps = append(g.substeps, groupSubstep{substep: substep, f: f, alwaysRun: true})
+}
+
+func (g *Group) RunConditionally(substep idl.Substep, shouldRun bool, f func(context.Context, OutStreams) error) {
+	g.substeps = append(g.substeps, groupSubstep{substep: substep, f: f, bypass: !shouldRun})
+}
+
+// RunConcurrently runs the substeps declared in the group at once and waits
+// for them to finish. Each substep persists its status independently and
+// writes its output a line at a time. The errors of the failed substeps are
+// combined failing the step once all have finished. Pausing before any of
+// them pauses before the group. A dry run reports the plan of each substep in
+// the order they are declared.
+func (s *Step) RunConcurrently(declare func(group *Group)) {
+	group := &Group{}
+	declare(group)
+
+	var run []groupSubstep
+	for _, substep := range group.substeps {
+		if substep.bypass {
+			log.Printf("%s skipped. Run condition not met.", substeps.SubstepDescriptions[substep.substep].HelpText)
+			if s.dryRun && s.err == nil {
+				s.sendPlan(substep.substep, idl.SubstepPlan_bypass)
+			}
+
+			continue
+		}
+
+		if s.dryRun {
+			s.planSubstep(substep.substep, substep.alwaysRun)
+			continue
+		}
+
+		run = append(run, substep)
+	}
+
+	if len(run) == 0 {
+		return
+	}
+
+	// Pausing before a substep of the group pauses before all of them such
+	// that the cluster can be inspected before any has run.
+	for _, substep := range run {
+		if s.err != nil || substep.substep != s.pauseBefore {
+			continue
+		}
+
+		status, err := s.substepStore.Read(s.name, substep.substep)
+		if err != nil {
+			s.err = xerrors.Errorf("substep %q: %w", substep.substep, err)
+			return
+		}
+
+		if status == idl.Status_unknown_status {
+			s.err = s.runSubstep(substep.substep, substep.f, substep.alwaysRun, s.streams, false)
+			return
+		}
+	}
+
+	// Records logged by the hub without a context while the substeps run at
+	// once are correlated with the group. Requests to the agents are correlated
+	// with their own substep by the context given to each.
+	var names []string
+	for _, substep := range run {
+		names = append(names, substep.substep.String())
+	}
+
+	logger.SetSubstep(strings.Join(names, ","))
+	defer logger.SetSubstep("")
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, len(run))
+
+	for i, substep := range run {
+		i, substep := i, substep
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer logger.WritePanics()
+
+			streams := newConcurrentStreams(s.streams, &mutex)
+			errs[i] = s.runSubstep(substep.substep, substep.f, substep.alwaysRun, streams, true)
+			if err := streams.flush(); err != nil {
+				errs[i] = errorlist.Append(errs[i], err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			s.err = errorlist.Append(s.err, err)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package step_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/trace"
+)
+
+func TestRunConcurrently(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := mock_idl.NewMockCliToHub_FinalizeServer(ctrl)
+	server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+	newStep := func(t *testing.T, streams step.OutStreams) (*step.Step, *step.SubstepFileStore) {
+		t.Helper()
+
+		testutils.MustWriteToFile(t, stateDir+"/"+step.SubstepsFileName, "{}")
+		store, err := step.NewSubstepFileStore()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		return step.New(idl.Step_finalize, server, store, streams), store
+	}
+
+	expectStatus := func(t *testing.T, store *step.SubstepFileStore, substep idl.Substep, expected idl.Status) {
+		t.Helper()
+
+		status, err := store.Read(idl.Step_finalize, substep)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if status != expected {
+			t.Errorf("got %s status %s want %s", substep, status, expected)
+		}
+	}
+
+	t.Run("runs the substeps at once persisting their statuses and interleaving their lines", func(t *testing.T) {
+		streams := &step.BufferedStreams{}
+		s, store := newStep(t, streams)
+
+		// Each substep waits for the other to start such that the test hangs
+		// if they are not run concurrently.
+		mirrorsStarted := make(chan struct{})
+		standbyStarted := make(chan struct{})
+
+		s.RunConcurrently(func(group *step.Group) {
+			group.Run(idl.Substep_upgrade_mirrors, func(_ context.Context, streams step.OutStreams) error {
+				close(mirrorsStarted)
+				<-standbyStarted
+
+				for i := 0; i < 100; i++ {
+					fmt.Fprintf(streams.Stdout(), "mirrors ")
+					fmt.Fprintf(streams.Stdout(), "%d\n", i)
+				}
+				return nil
+			})
+
+			group.Run(idl.Substep_upgrade_standby, func(_ context.Context, streams step.OutStreams) error {
+				close(standbyStarted)
+				<-mirrorsStarted
+
+				for i := 0; i < 100; i++ {
+					fmt.Fprintf(streams.Stdout(), "standby ")
+					fmt.Fprintf(streams.Stdout(), "%d\n", i)
+				}
+				return nil
+			})
+		})
+
+		if s.Err() != nil {
+			t.Fatalf("unexpected error %#v", s.Err())
+		}
+
+		expectStatus(t, store, idl.Substep_upgrade_mirrors, idl.Status_complete)
+		expectStatus(t, store, idl.Substep_upgrade_standby, idl.Status_complete)
+
+		// Partial writes that were interleaved would not match.
+		lines := regexp.MustCompile(`(?m)^(mirrors|standby) \d+$`).FindAllString(streams.StdoutBuf.String(), -1)
+		if len(lines) != 200 {
+			t.Errorf("got %d whole lines want 200 in stdout %q", len(lines), streams.StdoutBuf.String())
+		}
+	})
+
+	t.Run("combines the errors of the failed substeps after all have finished", func(t *testing.T) {
+		s, store := newStep(t, step.DevNullStream)
+
+		var called bool
+		s.RunConcurrently(func(group *step.Group) {
+			group.Run(idl.Substep_delete_target_cluster_datadirs, func(_ context.Context, streams step.OutStreams) error {
+				return errors.New("permission denied")
+			})
+
+			group.Run(idl.Substep_delete_tablespaces, func(_ context.Context, streams step.OutStreams) error {
+				return errors.New("no such host")
+			})
+
+			group.Run(idl.Substep_upgrade_standby, func(_ context.Context, streams step.OutStreams) error {
+				called = true
+				return nil
+			})
+		})
+
+		if !called {
+			t.Errorf("expected the substep to be called")
+		}
+
+		var errs errorlist.Errors
+		if !errors.As(s.Err(), &errs) || len(errs) != 2 {
+			t.Fatalf("got error %#v want two errors", s.Err())
+		}
+
+		if !strings.Contains(errs[0].Error(), "permission denied") || !strings.Contains(errs[1].Error(), "no such host") {
+			t.Errorf("got errors %q", errs)
+		}
+
+		expectStatus(t, store, idl.Substep_delete_target_cluster_datadirs, idl.Status_failed)
+		expectStatus(t, store, idl.Substep_delete_tablespaces, idl.Status_failed)
+		expectStatus(t, store, idl.Substep_upgrade_standby, idl.Status_complete)
+
+		s.Run(idl.Substep_restore_pgcontrol, func(streams step.OutStreams) error {
+			t.Errorf("expected the substep to not be called")
+			return nil
+		})
+	})
+
+	t.Run("skips substeps whose run condition is not met and those already completed", func(t *testing.T) {
+		s, store := newStep(t, step.DevNullStream)
+		err := store.Write(idl.Step_finalize, idl.Substep_upgrade_standby, idl.Status_complete)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		s.RunConcurrently(func(group *step.Group) {
+			group.RunConditionally(idl.Substep_upgrade_mirrors, false, func(_ context.Context, streams step.OutStreams) error {
+				t.Errorf("expected the substep to not be called")
+				return nil
+			})
+
+			group.Run(idl.Substep_upgrade_standby, func(_ context.Context, streams step.OutStreams) error {
+				t.Errorf("expected the substep to not be called")
+				return nil
+			})
+		})
+
+		if s.Err() != nil {
+			t.Errorf("unexpected error %#v", s.Err())
+		}
+
+		expectStatus(t, store, idl.Substep_upgrade_mirrors, idl.Status_unknown_status)
+	})
+
+	t.Run("gives each substep the context of its own span", func(t *testing.T) {
+		s, _ := newStep(t, step.DevNullStream)
+
+		spans := make(chan *trace.Span, 2)
+		s.RunConcurrently(func(group *step.Group) {
+			group.Run(idl.Substep_upgrade_mirrors, func(ctx context.Context, streams step.OutStreams) error {
+				spans <- trace.FromContext(ctx)
+				return nil
+			})
+
+			group.Run(idl.Substep_upgrade_standby, func(ctx context.Context, streams step.OutStreams) error {
+				spans <- trace.FromContext(ctx)
+				return nil
+			})
+		})
+		close(spans)
+
+		if s.Err() != nil {
+			t.Fatalf("unexpected error %#v", s.Err())
+		}
+
+		names := make(map[string]bool)
+		for span := range spans {
+			if span == nil {
+				t.Fatalf("expected the substep context to have a span")
+			}
+
+			names[span.Name] = true
+		}
+
+		if !names[idl.Substep_upgrade_mirrors.String()] || !names[idl.Substep_upgrade_standby.String()] {
+			t.Errorf("got spans %v want one for each substep", names)
+		}
+
+		if trace.Current() != nil {
+			t.Errorf("expected concurrent substeps to not set the current span")
+		}
+	})
+
+	t.Run("pausing before a substep pauses before the group", func(t *testing.T) {
+		s, store := newStep(t, step.DevNullStream)
+		s.PauseBefore(idl.Substep_upgrade_standby)
+
+		s.RunConcurrently(func(group *step.Group) {
+			group.Run(idl.Substep_upgrade_mirrors, func(_ context.Context, streams step.OutStreams) error {
+				t.Errorf("expected the substep to not be called")
+				return nil
+			})
+
+			group.Run(idl.Substep_upgrade_standby, func(_ context.Context, streams step.OutStreams) error {
+				t.Errorf("expected the substep to not be called")
+				return nil
+			})
+		})
+
+		if s.Err() == nil {
+			t.Errorf("expected the step to pause")
+		}
+
+		expectStatus(t, store, idl.Substep_upgrade_mirrors, idl.Status_unknown_status)
+		expectStatus(t, store, idl.Substep_upgrade_standby, idl.Status_paused)
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package step
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+const (
+	PreHook  = "pre"
+	PostHook = "post"
+)
+
+// Hooks runs the user defined executables in a hooks directory before and
+// after steps and substeps. Each hook is named after when it runs and the step
+// or substep such as pre-shutdown_source_cluster, post-start_target_cluster,
+// or pre-execute. Missing hooks are skipped. The output of a hook is written
+// to the substep output.
+type Hooks struct {
+	// Dir is the hooks directory. When empty no hooks are run.
+	Dir string
+
+	// Env describes the upgrade to the hooks. Unknown values such as the
+	// target cluster before it is created are left empty.
+	Env HookEnv
+}
+
+type HookEnv struct {
+	UpgradeID    string
+	SourcePort   int
+	SourceGPHome string
+	TargetPort   int
+	TargetGPHome string
+}
+
+// HookName returns the file name of the hook that runs before or after the
+// step or substep.
+func HookName(when string, name string) string {
+	return when + "-" + name
+}
+
+// Run runs the hook of the step, or of the substep when set, if it exists.
+// The status is the status of the step or substep which is running for pre
+// hooks.
+func (h Hooks) Run(ctx context.Context, streams OutStreams, when string, step idl.Step, substep idl.Substep, status idl.Status) error {
+	if h.Dir == "" {
+		return nil
+	}
+
+	name := HookName(when, step.String())
+	if substep != idl.Substep_unknown_substep {
+		name = HookName(when, substep.String())
+	}
+
+	path := filepath.Join(h.Dir, name)
+	_, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return xerrors.Errorf("hook %s: %w", name, err)
+	}
+
+	log.Printf("running hook %q", path)
+
+	cmd := exec.Command(path)
+	cmd.Dir = h.Dir
+	cmd.Stdout = streams.Stdout()
+	cmd.Stderr = streams.Stderr()
+	cmd.Env = append(os.Environ(), h.environ(when, step, substep, status)...)
+
+	if err := utils.RunCommand(ctx, cmd); err != nil {
+		return xerrors.Errorf("hook %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (h Hooks) environ(when string, step idl.Step, substep idl.Substep, status idl.Status) []string {
+	port := func(port int) string {
+		if port == 0 {
+			return ""
+		}
+
+		return strconv.Itoa(port)
+	}
+
+	name := ""
+	if substep != idl.Substep_unknown_substep {
+		name = substep.String()
+	}
+
+	return []string{
+		"GPUPGRADE_HOOK=" + when,
+		"GPUPGRADE_UPGRADE_ID=" + h.Env.UpgradeID,
+		"GPUPGRADE_STEP=" + step.String(),
+		"GPUPGRADE_SUBSTEP=" + name,
+		"GPUPGRADE_STATUS=" + status.String(),
+		"GPUPGRADE_SOURCE_PORT=" + port(h.Env.SourcePort),
+		"GPUPGRADE_SOURCE_GPHOME=" + h.Env.SourceGPHome,
+		"GPUPGRADE_TARGET_PORT=" + port(h.Env.TargetPort),
+		"GPUPGRADE_TARGET_GPHOME=" + h.Env.TargetGPHome,
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package step_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func writeHook(t *testing.T, dir string, name string, script string) {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	testutils.MustWriteToFile(t, path, "#!/bin/sh\n"+script)
+	if err := os.Chmod(path, 0700); err != nil {
+		t.Fatalf("chmod %q: %v", path, err)
+	}
+}
+
+func TestHooksRun(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	hooks := step.Hooks{Dir: dir, Env: step.HookEnv{
+		UpgradeID:    "ABC123",
+		SourcePort:   5432,
+		SourceGPHome: "/usr/local/greenplum-db-source",
+		TargetGPHome: "/usr/local/greenplum-db-target",
+	}}
+
+	t.Run("runs the hook of the substep with the environment of the upgrade", func(t *testing.T) {
+		writeHook(t, dir, "pre-upgrade_master", `echo "$GPUPGRADE_HOOK $GPUPGRADE_UPGRADE_ID $GPUPGRADE_STEP $GPUPGRADE_SUBSTEP $GPUPGRADE_STATUS"
+echo "$GPUPGRADE_SOURCE_PORT $GPUPGRADE_SOURCE_GPHOME [$GPUPGRADE_TARGET_PORT] $GPUPGRADE_TARGET_GPHOME" >&2
+`)
+
+		streams := &step.BufferedStreams{}
+		err := hooks.Run(context.Background(), streams, step.PreHook, idl.Step_execute, idl.Substep_upgrade_master, idl.Status_running)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := "pre ABC123 execute upgrade_master running\n"
+		if streams.StdoutBuf.String() != expected {
+			t.Errorf("got stdout %q want %q", streams.StdoutBuf.String(), expected)
+		}
+
+		expected = "5432 /usr/local/greenplum-db-source [] /usr/local/greenplum-db-target\n"
+		if streams.StderrBuf.String() != expected {
+			t.Errorf("got stderr %q want %q", streams.StderrBuf.String(), expected)
+		}
+	})
+
+	t.Run("runs the hook of the step when there is no substep", func(t *testing.T) {
+		writeHook(t, dir, "post-finalize", `echo "$GPUPGRADE_HOOK $GPUPGRADE_STEP [$GPUPGRADE_SUBSTEP] $GPUPGRADE_STATUS"`)
+
+		streams := &step.BufferedStreams{}
+		err := hooks.Run(context.Background(), streams, step.PostHook, idl.Step_finalize, idl.Substep_unknown_substep, idl.Status_failed)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := "post finalize [] failed\n"
+		if streams.StdoutBuf.String() != expected {
+			t.Errorf("got stdout %q want %q", streams.StdoutBuf.String(), expected)
+		}
+	})
+
+	t.Run("skips missing hooks", func(t *testing.T) {
+		err := hooks.Run(context.Background(), step.DevNullStream, step.PreHook, idl.Step_execute, idl.Substep_start_target_cluster, idl.Status_running)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("does nothing without a hooks directory", func(t *testing.T) {
+		err := step.Hooks{}.Run(context.Background(), step.DevNullStream, step.PreHook, idl.Step_execute, idl.Substep_upgrade_master, idl.Status_running)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("errors when the hook fails", func(t *testing.T) {
+		writeHook(t, dir, "pre-shutdown_source_cluster", "exit 1")
+
+		err := hooks.Run(context.Background(), step.DevNullStream, step.PreHook, idl.Step_execute, idl.Substep_shutdown_source_cluster, idl.Status_running)
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Errorf("got error %#v want %T", err, exitErr)
+		}
+	})
+}
+
+func TestStepHooks(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+	server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+	t.Run("runs the hooks before and after the substep", func(t *testing.T) {
+		writeHook(t, dir, "pre-upgrade_master", `echo "before $GPUPGRADE_STATUS"`)
+		writeHook(t, dir, "post-upgrade_master", `echo "after $GPUPGRADE_STATUS"`)
+		defer testutils.MustRemoveAll(t, filepath.Join(dir, "pre-upgrade_master"))
+		defer testutils.MustRemoveAll(t, filepath.Join(dir, "post-upgrade_master"))
+
+		streams := &step.BufferedStreams{}
+		s := step.New(idl.Step_execute, server, &TestSubstepStore{}, streams)
+		s.RunHooks(step.Hooks{Dir: dir})
+
+		s.Run(idl.Substep_upgrade_master, func(streams step.OutStreams) error {
+			_, err := streams.Stdout().Write([]byte("substep\n"))
+			return err
+		})
+
+		if s.Err() != nil {
+			t.Fatalf("unexpected error %#v", s.Err())
+		}
+
+		if !strings.HasPrefix(streams.StdoutBuf.String(), "before running\nsubstep\nafter complete\n") {
+			t.Errorf("got stdout %q", streams.StdoutBuf.String())
+		}
+	})
+
+	t.Run("a failing pre hook fails the substep without running it", func(t *testing.T) {
+		writeHook(t, dir, "pre-shutdown_source_cluster", "echo 'monitoring unavailable' >&2; exit 1")
+		writeHook(t, dir, "post-shutdown_source_cluster", `echo "after $GPUPGRADE_STATUS"`)
+
+		streams := &step.BufferedStreams{}
+		substepStore := &TestSubstepStore{}
+		s := step.New(idl.Step_execute, server, substepStore, streams)
+		s.RunHooks(step.Hooks{Dir: dir})
+
+		var called bool
+		s.Run(idl.Substep_shutdown_source_cluster, func(streams step.OutStreams) error {
+			called = true
+			return nil
+		})
+
+		if called {
+			t.Errorf("expected substep to not be called")
+		}
+
+		if s.Err() == nil || !strings.Contains(s.Err().Error(), "pre-shutdown_source_cluster") {
+			t.Errorf("got error %#v want the pre hook to fail the substep", s.Err())
+		}
+
+		if substepStore.Status != idl.Status_failed {
+			t.Errorf("got status %s want %s", substepStore.Status, idl.Status_failed)
+		}
+
+		if streams.StderrBuf.String() != "monitoring unavailable\n" {
+			t.Errorf("got stderr %q want %q", streams.StderrBuf.String(), "monitoring unavailable\n")
+		}
+
+		if !strings.HasPrefix(streams.StdoutBuf.String(), "after failed\n") {
+			t.Errorf("got stdout %q", streams.StdoutBuf.String())
+		}
+	})
+
+	t.Run("a failing post hook does not fail the substep", func(t *testing.T) {
+		writeHook(t, dir, "post-start_target_cluster", "exit 1")
+
+		streams := &step.BufferedStreams{}
+		substepStore := &TestSubstepStore{}
+		s := step.New(idl.Step_execute, server, substepStore, streams)
+		s.RunHooks(step.Hooks{Dir: dir})
+
+		s.Run(idl.Substep_start_target_cluster, func(streams step.OutStreams) error {
+			return nil
+		})
+
+		if s.Err() != nil {
+			t.Errorf("unexpected error %#v", s.Err())
+		}
+
+		if substepStore.Status != idl.Status_complete {
+			t.Errorf("got status %s want %s", substepStore.Status, idl.Status_complete)
+		}
+
+		if !strings.Contains(streams.StderrBuf.String(), "post-start_target_cluster") {
+			t.Errorf("expected stderr %q to report the failing hook", streams.StderrBuf.String())
+		}
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package step
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/substeps"
+)
+
+// PausedErr is returned when a step pauses before a substep as requested with
+// --pause-before or pause_before in the configuration file. The substep is
+// marked paused such that re-running the step continues by running it.
+type PausedErr struct {
+	Substep idl.Substep
+}
+
+func (e PausedErr) Error() string {
+	return fmt.Sprintf("paused before substep %s", e.Substep)
+}
+
+// cliSubsteps are the finalize substeps run by the CLI after the hub has
+// stopped which cannot be paused before.
+var cliSubsteps = map[idl.Substep]bool{
+	idl.Substep_stop_hub_and_agents:                     true,
+	idl.Substep_execute_finalize_data_migration_scripts: true,
+	idl.Substep_analyze_target_cluster:                  true,
+	idl.Substep_delete_master_statedir:                  true,
+}
+
+// PausableSubsteps returns the substeps of the steps that can be paused
+// before in the order they are run.
+func PausableSubsteps(steps ...idl.Step) []idl.Substep {
+	var pausable []idl.Substep
+	seen := make(map[idl.Substep]bool)
+	for _, st := range steps {
+		for _, substep := range substeps.StepSubsteps[st] {
+			if cliSubsteps[substep] || seen[substep] {
+				continue
+			}
+
+			seen[substep] = true
+			pausable = append(pausable, substep)
+		}
+	}
+
+	return pausable
+}
+
+// ParsePauseBefore parses the name of the substep of the steps to pause
+// before. An empty name returns unknown_substep which does not pause.
+func ParsePauseBefore(name string, steps ...idl.Step) (idl.Substep, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return idl.Substep_unknown_substep, nil
+	}
+
+	var names []string
+	for _, substep := range PausableSubsteps(steps...) {
+		if substep.String() == name {
+			return substep, nil
+		}
+
+		names = append(names, substep.String())
+	}
+
+	var stepNames []string
+	for _, st := range steps {
+		stepNames = append(stepNames, st.String())
+	}
+
+	return idl.Substep_unknown_substep, fmt.Errorf("invalid pause before substep %q. Expected one of the %s substeps run by the hub: %s.",
+		name, strings.Join(stepNames, " or "), strings.Join(names, ", "))
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package step_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+)
+
+func TestParsePauseBefore(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		steps    []idl.Step
+		expected idl.Substep
+	}{
+		{
+			name:     "returns unknown_substep when empty",
+			input:    "",
+			steps:    []idl.Step{idl.Step_execute},
+			expected: idl.Substep_unknown_substep,
+		},
+		{
+			name:     "parses an execute substep",
+			input:    " upgrade_primaries ",
+			steps:    []idl.Step{idl.Step_execute},
+			expected: idl.Substep_upgrade_primaries,
+		},
+		{
+			name:     "parses a substep of any of the steps",
+			input:    "upgrade_mirrors",
+			steps:    []idl.Step{idl.Step_execute, idl.Step_finalize},
+			expected: idl.Substep_upgrade_mirrors,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			substep, err := step.ParsePauseBefore(c.input, c.steps...)
+			if err != nil {
+				t.Fatalf("unexpected error %#v", err)
+			}
+
+			if substep != c.expected {
+				t.Errorf("got %s want %s", substep, c.expected)
+			}
+		})
+	}
+
+	errCases := []struct {
+		name  string
+		input string
+		steps []idl.Step
+	}{
+		{
+			name:  "errors on an unknown substep",
+			input: "upgrade_everything",
+			steps: []idl.Step{idl.Step_execute},
+		},
+		{
+			name:  "errors on a substep of another step",
+			input: "upgrade_mirrors",
+			steps: []idl.Step{idl.Step_execute},
+		},
+		{
+			name:  "errors on a substep run by the cli",
+			input: "analyze_target_cluster",
+			steps: []idl.Step{idl.Step_finalize},
+		},
+	}
+
+	for _, c := range errCases {
+		t.Run(c.name, func(t *testing.T) {
+			substep, err := step.ParsePauseBefore(c.input, c.steps...)
+			if err == nil || !strings.Contains(err.Error(), c.input) {
+				t.Errorf("got error %v want it to name %q", err, c.input)
+			}
+
+			if substep != idl.Substep_unknown_substep {
+				t.Errorf("got %s want %s", substep, idl.Substep_unknown_substep)
+			}
+		})
+	}
+}
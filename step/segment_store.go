@@ -0,0 +1,134 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package step
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+const SegmentsFileName = "segments.json"
+
+// SegmentFileStore persists the status of each segment for substeps that act
+// on the segments individually such as upgrading the primaries. Re-running
+// such a substep only needs to process the segments that did not complete.
+type SegmentFileStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// SegmentEntry is the persisted status of a segment keyed by content ID.
+type SegmentEntry struct {
+	Host   string
+	Status PrettyStatus
+}
+
+type segmentMap = map[string]map[string]map[int32]SegmentEntry
+
+func NewSegmentFileStore() (*SegmentFileStore, error) {
+	path, err := utils.GetJSONFile(utils.GetStateDir(), SegmentsFileName)
+	if err != nil {
+		return &SegmentFileStore{}, xerrors.Errorf("read %q: %w", SegmentsFileName, err)
+	}
+
+	return &SegmentFileStore{path: path}, nil
+}
+
+func NewSegmentStoreUsingFile(path string) *SegmentFileStore {
+	return &SegmentFileStore{path: path}
+}
+
+func (f *SegmentFileStore) load() (segmentMap, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments segmentMap
+	err = json.Unmarshal(data, &segments)
+	if err != nil {
+		return nil, err
+	}
+
+	return segments, nil
+}
+
+// ReadAll returns the status of each segment keyed by step, substep, and then
+// content ID.
+func (f *SegmentFileStore) ReadAll() (map[string]map[string]map[int32]SegmentEntry, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.load()
+}
+
+// Read returns the status of each segment for the substep keyed by content ID.
+func (f *SegmentFileStore) Read(step idl.Step, substep idl.Substep) (map[int32]SegmentEntry, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	segments, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return segments[step.String()][substep.String()], nil
+}
+
+// Completed returns the content IDs of the segments that completed the
+// substep.
+func (f *SegmentFileStore) Completed(step idl.Step, substep idl.Substep) (map[int32]bool, error) {
+	entries, err := f.Read(step, substep)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := make(map[int32]bool)
+	for contentID, entry := range entries {
+		if entry.Status.Status == idl.Status_complete {
+			completed[contentID] = true
+		}
+	}
+
+	return completed, nil
+}
+
+// Write atomically updates the status of a segment. It is safe to call
+// concurrently such as when segments across hosts finish at the same time.
+func (f *SegmentFileStore) Write(step idl.Step, substep idl.Substep, host string, contentID int32, status idl.Status) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	segments, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	if segments == nil {
+		segments = make(segmentMap)
+	}
+
+	if _, ok := segments[step.String()]; !ok {
+		segments[step.String()] = make(map[string]map[int32]SegmentEntry)
+	}
+
+	if _, ok := segments[step.String()][substep.String()]; !ok {
+		segments[step.String()][substep.String()] = make(map[int32]SegmentEntry)
+	}
+
+	segments[step.String()][substep.String()][contentID] = SegmentEntry{Host: host, Status: PrettyStatus{status}}
+
+	data, err := json.MarshalIndent(segments, "", "  ") // pretty print JSON
+	if err != nil {
+		return err
+	}
+
+	return utils.AtomicallyWrite(f.path, data)
+}
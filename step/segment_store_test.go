@@ -0,0 +1,101 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package step_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestSegmentFileStore(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	path := filepath.Join(dir, step.SegmentsFileName)
+	store := step.NewSegmentStoreUsingFile(path)
+
+	t.Run("Read returns errors when failing to read", func(t *testing.T) {
+		_, err := store.Read(idl.Step_execute, idl.Substep_upgrade_primaries)
+		if !os.IsNotExist(err) {
+			t.Errorf("returned error %#v, want ErrNotExist", err)
+		}
+	})
+
+	t.Run("returns the completed segments written concurrently", func(t *testing.T) {
+		testutils.MustWriteToFile(t, path, "{}")
+
+		var wg sync.WaitGroup
+		for content := int32(0); content < 8; content++ {
+			wg.Add(1)
+			go func(content int32) {
+				defer wg.Done()
+
+				status := idl.Status_complete
+				if content%2 == 1 {
+					status = idl.Status_failed
+				}
+
+				if err := store.Write(idl.Step_execute, idl.Substep_upgrade_primaries, "sdw1", content, status); err != nil {
+					t.Errorf("Write() returned error %#v", err)
+				}
+			}(content)
+		}
+		wg.Wait()
+
+		completed, err := store.Completed(idl.Step_execute, idl.Substep_upgrade_primaries)
+		if err != nil {
+			t.Fatalf("Completed() returned error %#v", err)
+		}
+
+		expected := map[int32]bool{0: true, 2: true, 4: true, 6: true}
+		if !reflect.DeepEqual(completed, expected) {
+			t.Errorf("got %v want %v", completed, expected)
+		}
+	})
+
+	t.Run("overwrites the status of a segment", func(t *testing.T) {
+		testutils.MustWriteToFile(t, path, "{}")
+
+		for _, status := range []idl.Status{idl.Status_running, idl.Status_complete} {
+			if err := store.Write(idl.Step_execute, idl.Substep_upgrade_primaries, "sdw1", 0, status); err != nil {
+				t.Fatalf("Write() returned error %#v", err)
+			}
+		}
+
+		entries, err := store.Read(idl.Step_execute, idl.Substep_upgrade_primaries)
+		if err != nil {
+			t.Fatalf("Read() returned error %#v", err)
+		}
+
+		expected := map[int32]step.SegmentEntry{0: {Host: "sdw1", Status: step.PrettyStatus{Status: idl.Status_complete}}}
+		if !reflect.DeepEqual(entries, expected) {
+			t.Errorf("got %v want %v", entries, expected)
+		}
+	})
+
+	t.Run("writes to a file containing null", func(t *testing.T) {
+		testutils.MustWriteToFile(t, path, "null")
+
+		if err := store.Write(idl.Step_execute, idl.Substep_upgrade_primaries, "sdw1", 0, idl.Status_complete); err != nil {
+			t.Fatalf("Write() returned error %#v", err)
+		}
+
+		completed, err := store.Completed(idl.Step_execute, idl.Substep_upgrade_primaries)
+		if err != nil {
+			t.Fatalf("Completed() returned error %#v", err)
+		}
+
+		expected := map[int32]bool{0: true}
+		if !reflect.DeepEqual(completed, expected) {
+			t.Errorf("got %v want %v", completed, expected)
+		}
+	})
+}
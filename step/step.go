@@ -4,10 +4,14 @@
 package step
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log"
+	"log/slog"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"golang.org/x/text/cases"
@@ -20,21 +24,43 @@ import (
 	"github.com/greenplum-db/gpupgrade/substeps"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/logger"
 	"github.com/greenplum-db/gpupgrade/utils/stopwatch"
+	"github.com/greenplum-db/gpupgrade/utils/trace"
 )
 
 const SubstepsFileName = "substeps.json"
 
 type Step struct {
+	ctx          context.Context // cancelled when the step is cancelled
 	name         idl.Step
 	sender       idl.MessageSender // sends substep status messages
 	substepStore SubstepStore      // persistent substep status storage
 	streams      OutStreams        // writes substep stdout/err
+	dryRun       bool              // report the substeps rather than run them
+	plan         Plan              // commands reported during a dry run
+	recover      bool              // recover substeps left running
+	recoveries   Recoveries        // cleans up substeps left running
+	pauseBefore  idl.Substep       // substep to pause before running
+	hooks        Hooks             // run before and after each substep
 	err          error
 }
 
+// Plan maps each substep to the commands it issues. It is used to describe
+// the substeps during a dry run.
+type Plan map[idl.Substep][]*idl.PlannedCommand
+
+// Recoveries maps a substep to the function that cleans up or verifies its
+// partial work when it was left running, such as when the hub crashed. Only
+// substeps listed in substeps.RecoveryDescriptions are recovered. Those
+// without a recovery function are safe to simply run again.
+type Recoveries map[idl.Substep]func(context.Context, OutStreams) error
+
 func New(name idl.Step, sender idl.MessageSender, substepStore SubstepStore, streams OutStreams) *Step {
+	logger.SetStep(name.String())
+
 	return &Step{
+		ctx:          context.Background(),
 		name:         name,
 		sender:       sender,
 		substepStore: substepStore,
@@ -42,7 +68,9 @@ func New(name idl.Step, sender idl.MessageSender, substepStore SubstepStore, str
 	}
 }
 
-func Begin(step idl.Step, sender idl.MessageSender) (*Step, error) {
+// Begin begins the step. When the context is cancelled the running substep is
+// interrupted and marked failed, and the remaining substeps are not run.
+func Begin(ctx context.Context, step idl.Step, sender idl.MessageSender) (*Step, error) {
 	substepStore, err := NewSubstepFileStore()
 	if err != nil {
 		return nil, err
@@ -55,7 +83,52 @@ func Begin(step idl.Step, sender idl.MessageSender) (*Step, error) {
 		return nil, err
 	}
 
-	return New(step, sender, substepStore, streams), nil
+	s := New(step, sender, substepStore, streams)
+	s.ctx = ctx
+	return s, nil
+}
+
+// BeginDryRun begins a step where rather than running each substep its plan
+// is sent. That is whether it would run, be skipped since it already
+// completed, or be bypassed since its run condition is not met along with the
+// commands it would issue. Nothing is written to the substep store.
+func BeginDryRun(step idl.Step, sender idl.MessageSender, plan Plan) (*Step, error) {
+	// Do not create the substep store since a dry run should not change
+	// anything. A missing store is treated as no substeps having run.
+	substepStore := NewSubstepStoreUsingFile(filepath.Join(utils.GetStateDir(), SubstepsFileName))
+
+	streams := newLogMessageSender(sender)
+
+	_, err := fmt.Fprintf(streams.Stdout(), "\n%s dry run.\n", cases.Title(language.English).String(step.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	s := New(step, sender, substepStore, streams)
+	s.dryRun = true
+	s.plan = plan
+	return s, nil
+}
+
+// Recover enables recovering substeps that were left running by a previous
+// attempt rather than failing.
+func (s *Step) Recover(recoveries Recoveries) {
+	s.recover = true
+	s.recoveries = recoveries
+}
+
+// PauseBefore pauses the step before running the substep if it has not yet
+// run. Pausing marks the substep paused and stops the step returning a
+// PausedErr such that re-running the step continues by running it.
+func (s *Step) PauseBefore(substep idl.Substep) {
+	s.pauseBefore = substep
+}
+
+// RunHooks runs the hooks before and after each substep that runs. A failing
+// pre hook fails the substep without running it, while a failing post hook is
+// only reported since the substep has already run.
+func (s *Step) RunHooks(hooks Hooks) {
+	s.hooks = hooks
 }
 
 func HasStarted(step idl.Step) (bool, error) {
@@ -78,7 +151,7 @@ func HasStarted(step idl.Step) (bool, error) {
 
 func HasRun(step idl.Step, substep idl.Substep) (bool, error) {
 	return hasStatus(step, substep, func(status idl.Status) bool {
-		return status != idl.Status_unknown_status
+		return status != idl.Status_unknown_status && status != idl.Status_paused
 	})
 }
 
@@ -102,6 +175,13 @@ func hasStatus(step idl.Step, substep idl.Substep, check func(status idl.Status)
 	return check(status), nil
 }
 
+// Context returns the context of the step which is cancelled when the step is
+// cancelled. Substeps pass it to long running commands such as pg_upgrade and
+// rsync such that they are terminated.
+func (s *Step) Context() context.Context {
+	return s.ctx
+}
+
 func (s *Step) Streams() OutStreams {
 	return s.streams
 }
@@ -154,6 +234,10 @@ func (s *Step) AlwaysRun(substep idl.Substep, f func(OutStreams) error) {
 func (s *Step) RunConditionally(substep idl.Substep, shouldRun bool, f func(OutStreams) error) {
 	if !shouldRun {
 		log.Printf("%s skipped. Run condition not met.", substeps.SubstepDescriptions[substep].HelpText)
+		if s.dryRun && s.err == nil {
+			s.sendPlan(substep, idl.SubstepPlan_bypass)
+		}
+
 		return
 	}
 
@@ -165,14 +249,33 @@ func (s *Step) Run(substep idl.Substep, f func(OutStreams) error) {
 }
 
 func (s *Step) run(substep idl.Substep, f func(OutStreams) error, alwaysRun bool) {
+	if s.dryRun {
+		s.planSubstep(substep, alwaysRun)
+		return
+	}
+
+	run := func(_ context.Context, streams OutStreams) error {
+		return f(streams)
+	}
+
+	if err := s.runSubstep(substep, run, alwaysRun, s.streams, false); err != nil {
+		s.err = err
+	}
+}
+
+// runSubstep runs the substep writing its output to the streams. The substep
+// is given its context which holds the span of the substep. Substeps run
+// concurrently with others share the step and so neither change its context
+// nor the current substep of the logs and traces.
+func (s *Step) runSubstep(substep idl.Substep, f func(context.Context, OutStreams) error, alwaysRun bool, streams OutStreams, concurrent bool) (result error) {
 	var err error
 	defer func() {
-		if _, pErr := fmt.Fprintf(s.Streams().Stdout(), "\n\n%s\n\n", substeps.Divider); pErr != nil {
+		if _, pErr := fmt.Fprintf(streams.Stdout(), "\n\n%s\n\n", substeps.Divider); pErr != nil {
 			err = errorlist.Append(err, pErr)
 		}
 
 		if err != nil {
-			s.err = xerrors.Errorf("substep %q: %w", substep, err)
+			result = xerrors.Errorf("substep %q: %w", substep, err)
 		}
 	}()
 
@@ -180,16 +283,22 @@ func (s *Step) run(substep idl.Substep, f func(OutStreams) error, alwaysRun bool
 		return
 	}
 
+	if s.ctx.Err() != nil {
+		err = CancelledErr(s.name, nil)
+		return
+	}
+
 	status, err := s.substepStore.Read(s.name, substep)
 	if err != nil {
 		return
 	}
 
 	if status == idl.Status_running {
-		// TODO: Finalize error wording and recommended action
-		err = fmt.Errorf("Found previous substep %s was running. Manual intervention needed to cleanup. Please contact support.", substep)
-		s.sendStatus(substep, idl.Status_failed)
-		return
+		err = s.recoverSubstep(substep, streams)
+		if err != nil {
+			s.sendStatus(substep, idl.Status_failed)
+			return
+		}
 	}
 
 	// Only re-run substeps that are failed or pending. Do not skip substeps that must always be run.
@@ -199,9 +308,20 @@ func (s *Step) run(substep idl.Substep, f func(OutStreams) error, alwaysRun bool
 		return
 	}
 
+	if substep == s.pauseBefore && status == idl.Status_unknown_status {
+		err = s.write(substep, idl.Status_paused)
+		if err != nil {
+			return
+		}
+
+		log.Printf("Pausing before substep %s.", substep)
+		nextAction := fmt.Sprintf("To continue run \"gpupgrade %s\".", s.name)
+		return utils.NewNextActionErr(PausedErr{Substep: substep}, nextAction)
+	}
+
 	timer := stopwatch.Start()
 	defer func() {
-		if pErr := s.printDuration(substep, timer.Stop().String()); pErr != nil {
+		if pErr := s.printDuration(streams, substep, timer.Stop().String()); pErr != nil {
 			err = errorlist.Append(err, pErr)
 		}
 	}()
@@ -211,31 +331,111 @@ func (s *Step) run(substep idl.Substep, f func(OutStreams) error, alwaysRun bool
 		return
 	}
 
-	err = f(s.streams)
+	// Trace the substep as the parent of the commands it runs and the
+	// requests it makes to the agents.
+	ctx, span := trace.Start(s.ctx, substep.String(), "step", s.name.String())
+	defer func() {
+		span.End(err)
+	}()
+
+	// Correlate the requests made to the agents with the substep making them.
+	ctx = logger.WithSubstep(ctx, s.name.String(), substep.String())
+
+	if !concurrent {
+		logger.SetSubstep(substep.String())
+		defer logger.SetSubstep("")
+
+		stepCtx := s.ctx
+		s.ctx = ctx
+		restoreSpan := trace.SetCurrent(span)
+		defer func() {
+			restoreSpan()
+			s.ctx = stepCtx
+		}()
+	}
+
+	substepStatus := idl.Status_failed
+	defer func() {
+		s.runPostHook(streams, substep, substepStatus)
+	}()
+
+	err = s.hooks.Run(ctx, streams, PreHook, s.name, substep, idl.Status_running)
+	if err == nil {
+		err = f(ctx, streams)
+	}
 
 	switch {
 	case errors.Is(err, Skip):
 		// The substep has requested a manual skip; this isn't really an error.
+		substepStatus = idl.Status_skipped
 		err = s.write(substep, idl.Status_skipped)
 		return
 
 	case err != nil:
+		slog.Error("substep failed", "error", err.Error())
+
 		if werr := s.write(substep, idl.Status_failed); werr != nil {
 			err = errorlist.Append(err, werr)
 		}
 
+		if werr := s.substepStore.WriteError(s.name, substep, err); werr != nil {
+			err = errorlist.Append(err, werr)
+		}
+
 		var exitErr *exec.ExitError
 		if errors.As(err, &exitErr) {
-			_, wErr := s.Streams().Stderr().Write(exitErr.Stderr)
+			_, wErr := streams.Stderr().Write(exitErr.Stderr)
 			if wErr != nil {
 				err = errorlist.Append(err, wErr)
 			}
 		}
 
+		if s.ctx.Err() != nil {
+			err = CancelledErr(s.name, err)
+		}
+
 		return
 	}
 
+	substepStatus = idl.Status_complete
 	err = s.write(substep, idl.Status_complete)
+	return
+}
+
+// runPostHook reports rather than returns the error of the post hook since the
+// substep has already run.
+func (s *Step) runPostHook(streams OutStreams, substep idl.Substep, status idl.Status) {
+	err := s.hooks.Run(context.Background(), streams, PostHook, s.name, substep, status)
+	if err != nil {
+		slog.Error("post hook failed", "error", err.Error())
+		_, _ = fmt.Fprintf(streams.Stderr(), "%v\n", err)
+	}
+}
+
+// planSubstep sends whether the substep would run or be skipped without
+// running it.
+func (s *Step) planSubstep(substep idl.Substep, alwaysRun bool) {
+	if s.err != nil {
+		return
+	}
+
+	status, err := s.substepStore.Read(s.name, substep)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		s.err = xerrors.Errorf("substep %q: %w", substep, err)
+		return
+	}
+
+	if status == idl.Status_running && !(s.recover && Recoverable(substep)) {
+		s.err = xerrors.Errorf("substep %q: %w", substep, RunningErr(s.name, substep))
+		return
+	}
+
+	action := idl.SubstepPlan_run
+	if status == idl.Status_complete && !alwaysRun {
+		action = idl.SubstepPlan_skip
+	}
+
+	s.sendPlan(substep, action)
 }
 
 func (s *Step) write(substep idl.Substep, status idl.Status) error {
@@ -266,11 +466,65 @@ func (s *Step) sendStatus(substep idl.Substep, status idl.Status) {
 	})
 }
 
-func (s *Step) printDuration(substep idl.Substep, duration string) error {
-	_, err := fmt.Fprintf(s.streams.Stdout(), "%-67s[%s]", substeps.SubstepDescriptions[substep].OutputText, duration)
+func (s *Step) sendPlan(substep idl.Substep, action idl.SubstepPlan_Action) {
+	plan := &idl.SubstepPlan{Substep: substep, Action: action}
+	if action == idl.SubstepPlan_run {
+		plan.Commands = s.plan[substep]
+	}
+
+	// A stream is not guaranteed to remain connected during execution, so
+	// errors are explicitly ignored.
+	_ = s.sender.Send(&idl.Message{Contents: &idl.Message_Plan{Plan: plan}})
+}
+
+func (s *Step) printDuration(streams OutStreams, substep idl.Substep, duration string) error {
+	_, err := fmt.Fprintf(streams.Stdout(), "%-67s[%s]", substeps.SubstepDescriptions[substep].OutputText, duration)
 	return err
 }
 
+// recoverSubstep cleans up a substep that was left running such that it can
+// be run again.
+func (s *Step) recoverSubstep(substep idl.Substep, streams OutStreams) error {
+	if !s.recover || !Recoverable(substep) {
+		return RunningErr(s.name, substep)
+	}
+
+	text := fmt.Sprintf("Found previous substep %s was running. Recovering: %s.", substep, substeps.RecoveryDescriptions[substep])
+	log.Print(text)
+	if _, err := fmt.Fprintln(streams.Stdout(), text); err != nil {
+		return err
+	}
+
+	recovery, ok := s.recoveries[substep]
+	if !ok {
+		return nil
+	}
+
+	if err := recovery(s.ctx, streams); err != nil {
+		return xerrors.Errorf("recover: %w", err)
+	}
+
+	return nil
+}
+
+// Recoverable returns whether the substep can be recovered when it was left
+// running.
+func Recoverable(substep idl.Substep) bool {
+	_, ok := substeps.RecoveryDescriptions[substep]
+	return ok
+}
+
+// RunningErr returns the error for a substep that was left running such as
+// when the hub crashed.
+func RunningErr(step idl.Step, substep idl.Substep) error {
+	if !Recoverable(substep) {
+		return fmt.Errorf("Found previous substep %s was running. Manual intervention needed to cleanup. Please contact support.", substep)
+	}
+
+	nextAction := fmt.Sprintf("To recover the substep run \"gpupgrade %s --recover\" which will: %s.", step, substeps.RecoveryDescriptions[substep])
+	return utils.NewNextActionErr(fmt.Errorf("Found previous substep %s was running.", substep), nextAction)
+}
+
 // Skip can be returned from a Run or AlwaysRun callback to immediately mark the
 // substep complete on disk and report "skipped" to the UI.
 var Skip = skipErr{}
@@ -279,6 +533,27 @@ type skipErr struct{}
 
 func (s skipErr) Error() string { return "skipped" }
 
+// Cancelled indicates that the step was cancelled such as with
+// "gpupgrade cancel".
+var Cancelled = cancelledErr{}
+
+type cancelledErr struct{}
+
+func (c cancelledErr) Error() string { return "cancelled" }
+
+// CancelledErr returns the error for a cancelled step wrapping Cancelled along
+// with the error of the interrupted substep if any. Since the interrupted
+// substep is marked failed re-running the step resumes from it.
+func CancelledErr(step idl.Step, err error) error {
+	cancelled := error(Cancelled)
+	if err != nil {
+		cancelled = fmt.Errorf("%w: %v", Cancelled, err)
+	}
+
+	nextAction := fmt.Sprintf("To resume the step re-run \"gpupgrade %s\".", step)
+	return utils.NewNextActionErr(cancelled, nextAction)
+}
+
 // Quit indicates that the user has canceled and does not want to proceed.
 var Quit = userQuitErr{}
 
@@ -1,7 +1,7 @@
 // Copyright (c) 2017-2023 VMware, Inc. or its affiliates
 // SPDX-License-Identifier: Apache-2.0
 
-package clistep
+package step
 
 import (
 	"errors"
@@ -9,7 +9,6 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/idl"
-	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/utils"
 )
 
@@ -18,6 +17,11 @@ import (
 // required and for convenience StepStore uses the same data structure and
 // file store as substeps.json. An internal substep enum STEP_STATUS is used to
 // track the overall step status and should not be used as a normal substep.
+const StepsFileName = "steps.json"
+
+// Steps are the steps in the order they are reported.
+var Steps = []idl.Step{idl.Step_initialize, idl.Step_execute, idl.Step_finalize, idl.Step_revert}
+
 type StepStore interface {
 	Read(idl.Step) (idl.Status, error)
 	Write(idl.Step, idl.Status) error
@@ -28,7 +32,7 @@ type StepStore interface {
 }
 
 type StepStoreFileStore struct {
-	store *step.SubstepFileStore
+	store *SubstepFileStore
 }
 
 func NewStepFileStore() (*StepStoreFileStore, error) {
@@ -37,7 +41,7 @@ func NewStepFileStore() (*StepStoreFileStore, error) {
 		return &StepStoreFileStore{}, xerrors.Errorf("getting %q file: %w", StepsFileName, err)
 	}
 
-	return &StepStoreFileStore{store: step.NewSubstepStoreUsingFile(path)}, nil
+	return &StepStoreFileStore{store: NewSubstepStoreUsingFile(path)}, nil
 }
 
 func (s *StepStoreFileStore) Write(stepName idl.Step, status idl.Status) error {
@@ -149,3 +153,46 @@ func (s *StepStoreFileStore) ValidateStep(currentStep idl.Step) (err error) {
 
 	return nil
 }
+
+// ValidSteps returns the steps whose conditions are currently met and thus can
+// be run.
+func (s *StepStoreFileStore) ValidSteps() ([]idl.Step, error) {
+	var valid []idl.Step
+	for _, currentStep := range Steps {
+		err := s.ValidateStep(currentStep)
+		var nextActionErr utils.NextActionErr
+		if errors.As(err, &nextActionErr) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		valid = append(valid, currentStep)
+	}
+
+	return valid, nil
+}
+
+// NextStep returns the first valid step that has not yet completed. If no such
+// step exists such as after finalize has completed unknown_step is returned.
+func (s *StepStoreFileStore) NextStep() (idl.Step, error) {
+	valid, err := s.ValidSteps()
+	if err != nil {
+		return idl.Step_unknown_step, err
+	}
+
+	for _, currentStep := range valid {
+		completed, err := s.HasStepCompleted(currentStep)
+		if err != nil {
+			return idl.Step_unknown_step, err
+		}
+
+		if !completed {
+			return currentStep, nil
+		}
+	}
+
+	return idl.Step_unknown_step, nil
+}
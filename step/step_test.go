@@ -4,10 +4,12 @@
 package step_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -20,6 +22,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/substeps"
 	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
@@ -242,6 +245,30 @@ func TestStepRun(t *testing.T) {
 		}
 	})
 
+	t.Run("records the error of a failed substep", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		substepStore := &TestSubstepStore{}
+		s := step.New(idl.Step_initialize, server, substepStore, step.DevNullStream)
+
+		expected := errors.New("oops")
+		s.Run(idl.Substep_saving_source_cluster_config, func(streams step.OutStreams) error {
+			return expected
+		})
+
+		if substepStore.Status != idl.Status_failed {
+			t.Errorf("got status %s want %s", substepStore.Status, idl.Status_failed)
+		}
+
+		if !errors.Is(substepStore.LastError, expected) {
+			t.Errorf("got error %#v want %#v", substepStore.LastError, expected)
+		}
+	})
+
 	t.Run("returns an error when MarkInProgress fails", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -344,6 +371,343 @@ func TestStepRun(t *testing.T) {
 			t.Error("got nil want err")
 		}
 	})
+
+	t.Run("for a recoverable substep that was running returns a next action to recover", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		substepStore := &TestSubstepStore{Status: idl.Status_running}
+		s := step.New(idl.Step_finalize, server, substepStore, step.DevNullStream)
+
+		s.Run(idl.Substep_update_data_directories, func(streams step.OutStreams) error {
+			t.Error("expected substep to not be called")
+			return nil
+		})
+
+		st, ok := status.FromError(s.Err())
+		if !ok {
+			t.Fatalf("got %#v want a gRPC status error", s.Err())
+		}
+
+		var nextActions []string
+		for _, detail := range st.Details() {
+			if msg, ok := detail.(*idl.NextActions); ok {
+				nextActions = append(nextActions, msg.GetNextActions())
+			}
+		}
+
+		expected := `To recover the substep run "gpupgrade finalize --recover"`
+		if len(nextActions) != 1 || !strings.Contains(nextActions[0], expected) {
+			t.Errorf("got next actions %q want it to contain %q", nextActions, expected)
+		}
+	})
+
+	t.Run("when recovering a substep that was running calls its recovery before running it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().
+			Send(&idl.Message{Contents: &idl.Message_Status{Status: &idl.SubstepStatus{
+				Step:   idl.Substep_init_target_cluster,
+				Status: idl.Status_running,
+			}}})
+		server.EXPECT().
+			Send(&idl.Message{Contents: &idl.Message_Status{Status: &idl.SubstepStatus{
+				Step:   idl.Substep_init_target_cluster,
+				Status: idl.Status_complete,
+			}}})
+
+		substepStore := &TestSubstepStore{Status: idl.Status_running}
+		s := step.New(idl.Step_initialize, server, substepStore, step.DevNullStream)
+
+		var calls []string
+		s.Recover(step.Recoveries{
+			idl.Substep_init_target_cluster: func(_ context.Context, streams step.OutStreams) error {
+				calls = append(calls, "recovery")
+				return nil
+			},
+		})
+
+		s.Run(idl.Substep_init_target_cluster, func(streams step.OutStreams) error {
+			calls = append(calls, "substep")
+			return nil
+		})
+
+		if s.Err() != nil {
+			t.Errorf("unexpected error %#v", s.Err())
+		}
+
+		expected := []string{"recovery", "substep"}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("got calls %q want %q", calls, expected)
+		}
+
+		if substepStore.Status != idl.Status_complete {
+			t.Errorf("got status %q want %q", substepStore.Status, idl.Status_complete)
+		}
+	})
+
+	t.Run("when recovering fails the substep is not run and is marked as failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().
+			Send(&idl.Message{Contents: &idl.Message_Status{Status: &idl.SubstepStatus{
+				Step:   idl.Substep_init_target_cluster,
+				Status: idl.Status_failed,
+			}}})
+
+		substepStore := &TestSubstepStore{Status: idl.Status_running}
+		s := step.New(idl.Step_initialize, server, substepStore, step.DevNullStream)
+
+		expected := errors.New("permission denied")
+		s.Recover(step.Recoveries{
+			idl.Substep_init_target_cluster: func(_ context.Context, streams step.OutStreams) error {
+				return expected
+			},
+		})
+
+		s.Run(idl.Substep_init_target_cluster, func(streams step.OutStreams) error {
+			t.Error("expected substep to not be called")
+			return nil
+		})
+
+		if !errors.Is(s.Err(), expected) {
+			t.Errorf("got error %#v want %#v", s.Err(), expected)
+		}
+	})
+
+	t.Run("when recovering still fails for a substep that cannot be recovered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		substepStore := &TestSubstepStore{Status: idl.Status_running}
+		s := step.New(idl.Step_execute, server, substepStore, step.DevNullStream)
+		s.Recover(step.Recoveries{})
+
+		s.Run(idl.Substep_upgrade_primaries, func(streams step.OutStreams) error {
+			t.Error("expected substep to not be called")
+			return nil
+		})
+
+		expected := "Manual intervention needed to cleanup."
+		if s.Err() == nil || !strings.Contains(s.Err().Error(), expected) {
+			t.Errorf("got error %v want it to contain %q", s.Err(), expected)
+		}
+	})
+}
+
+func TestRecoverable(t *testing.T) {
+	// Re-running these substeps is not safe and no recovery cleans them up.
+	for _, substep := range []idl.Substep{idl.Substep_upgrade_primaries, idl.Substep_shutdown_source_cluster, idl.Substep_restore_pgcontrol} {
+		if step.Recoverable(substep) {
+			t.Errorf("expected substep %s to not be recoverable", substep)
+		}
+	}
+
+	if !step.Recoverable(idl.Substep_update_data_directories) {
+		t.Errorf("expected substep %s to be recoverable", idl.Substep_update_data_directories)
+	}
+}
+
+func TestStepCancel(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	t.Run("marks the interrupted substep failed and does not run subsequent substeps", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		s, err := step.Begin(ctx, idl.Step_execute, server)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		s.Run(idl.Substep_upgrade_primaries, func(streams step.OutStreams) error {
+			cancel()
+			<-s.Context().Done()
+			return errors.New("pg_upgrade terminated")
+		})
+
+		var called bool
+		s.Run(idl.Substep_start_target_cluster, func(streams step.OutStreams) error {
+			called = true
+			return nil
+		})
+
+		if called {
+			t.Error("expected substep to not be called")
+		}
+
+		store, err := step.NewSubstepFileStore()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		substepStatus, err := store.Read(idl.Step_execute, idl.Substep_upgrade_primaries)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if substepStatus != idl.Status_failed {
+			t.Errorf("got status %s want %s", substepStatus, idl.Status_failed)
+		}
+
+		statusErr := status.Convert(s.Err())
+		expected := fmt.Sprintf("substep %q: cancelled: pg_upgrade terminated", idl.Substep_upgrade_primaries)
+		if statusErr.Message() != expected {
+			t.Errorf("got error %q want %q", statusErr.Message(), expected)
+		}
+
+		details := statusErr.Details()
+		if len(details) != 1 || details[0].(*idl.NextActions).GetNextActions() != `To resume the step re-run "gpupgrade execute".` {
+			t.Errorf("got details %v want the next action to re-run the step", details)
+		}
+	})
+
+	t.Run("does not start substeps once cancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		s, err := step.Begin(ctx, idl.Step_execute, server)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		var called bool
+		s.Run(idl.Substep_shutdown_source_cluster, func(streams step.OutStreams) error {
+			called = true
+			return nil
+		})
+
+		if called {
+			t.Error("expected substep to not be called")
+		}
+
+		if s.Err() == nil || !strings.Contains(s.Err().Error(), step.Cancelled.Error()) {
+			t.Errorf("got error %v want it to contain %q", s.Err(), step.Cancelled)
+		}
+	})
+}
+
+func TestStepPauseBefore(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+	server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+	store, err := step.NewSubstepFileStore()
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	runStep := func() (*step.Step, []idl.Substep) {
+		s, err := step.Begin(context.Background(), idl.Step_execute, server)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		s.PauseBefore(idl.Substep_upgrade_primaries)
+
+		var called []idl.Substep
+		for _, substep := range []idl.Substep{idl.Substep_upgrade_master, idl.Substep_upgrade_primaries, idl.Substep_start_target_cluster} {
+			substep := substep
+			s.Run(substep, func(streams step.OutStreams) error {
+				called = append(called, substep)
+				return nil
+			})
+		}
+
+		return s, called
+	}
+
+	t.Run("pauses before the substep marking it paused", func(t *testing.T) {
+		s, called := runStep()
+
+		expected := []idl.Substep{idl.Substep_upgrade_master}
+		if !reflect.DeepEqual(called, expected) {
+			t.Errorf("ran substeps %v want %v", called, expected)
+		}
+
+		substepStatus, err := store.Read(idl.Step_execute, idl.Substep_upgrade_primaries)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if substepStatus != idl.Status_paused {
+			t.Errorf("got status %s want %s", substepStatus, idl.Status_paused)
+		}
+
+		hasRun, err := step.HasRun(idl.Step_execute, idl.Substep_upgrade_primaries)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if hasRun {
+			t.Errorf("expected paused substep to not have run")
+		}
+
+		statusErr := status.Convert(s.Err())
+		expectedErr := step.PausedErr{Substep: idl.Substep_upgrade_primaries}.Error()
+		if statusErr.Message() != expectedErr {
+			t.Errorf("got error %q want %q", statusErr.Message(), expectedErr)
+		}
+	})
+
+	t.Run("re-running continues from the paused substep", func(t *testing.T) {
+		s, called := runStep()
+		if s.Err() != nil {
+			t.Fatalf("unexpected error %#v", s.Err())
+		}
+
+		expected := []idl.Substep{idl.Substep_upgrade_primaries, idl.Substep_start_target_cluster}
+		if !reflect.DeepEqual(called, expected) {
+			t.Errorf("ran substeps %v want %v", called, expected)
+		}
+
+		substepStatus, err := store.Read(idl.Step_execute, idl.Substep_upgrade_primaries)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if substepStatus != idl.Status_complete {
+			t.Errorf("got status %s want %s", substepStatus, idl.Status_complete)
+		}
+	})
 }
 
 func TestHasStarted(t *testing.T) {
@@ -661,6 +1025,153 @@ func TestHasCompleted(t *testing.T) {
 	})
 }
 
+func TestBeginDryRun(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("sends the plan of each substep without running them", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", dir)
+		defer resetEnv()
+
+		path := filepath.Join(dir, step.SubstepsFileName)
+		jsonContent := fmt.Sprintf("{\"%s\":{\"%s\":\"%s\",\"%s\":\"%s\"}}",
+			idl.Step_execute, idl.Substep_shutdown_source_cluster, idl.Status_complete, idl.Substep_start_target_cluster, idl.Status_complete)
+		testutils.MustWriteToFile(t, path, jsonContent)
+
+		commands := []*idl.PlannedCommand{{Host: "cdw", Command: "/usr/local/target/bin/pg_upgrade --check"}}
+		plan := step.Plan{idl.Substep_upgrade_master: commands}
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		gomock.InOrder(
+			server.EXPECT().Send(headerMessage("Execute")),
+			server.EXPECT().Send(planMessage(idl.Substep_shutdown_source_cluster, idl.SubstepPlan_skip, nil)),
+			server.EXPECT().Send(planMessage(idl.Substep_upgrade_master, idl.SubstepPlan_run, commands)),
+			server.EXPECT().Send(planMessage(idl.Substep_copy_master, idl.SubstepPlan_bypass, nil)),
+			server.EXPECT().Send(planMessage(idl.Substep_start_target_cluster, idl.SubstepPlan_run, nil)),
+		)
+
+		s, err := step.BeginDryRun(idl.Step_execute, server, plan)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		var called bool
+		f := func(streams step.OutStreams) error {
+			called = true
+			return nil
+		}
+
+		s.Run(idl.Substep_shutdown_source_cluster, f)
+		s.Run(idl.Substep_upgrade_master, f)
+		s.RunConditionally(idl.Substep_copy_master, false, f)
+		s.AlwaysRun(idl.Substep_start_target_cluster, f)
+
+		if called {
+			t.Error("expected substeps to not be called")
+		}
+
+		if s.Err() != nil {
+			t.Errorf("unexpected error %#v", s.Err())
+		}
+
+		contents := testutils.MustReadFile(t, path)
+		if contents != jsonContent {
+			t.Errorf("substeps file changed to %q want %q", contents, jsonContent)
+		}
+	})
+
+	t.Run("reports substeps would run when the substep store does not exist", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", dir)
+		defer resetEnv()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_InitializeServer(ctrl)
+		server.EXPECT().Send(headerMessage("Initialize"))
+		server.EXPECT().Send(planMessage(idl.Substep_check_environment, idl.SubstepPlan_run, nil))
+
+		s, err := step.BeginDryRun(idl.Step_initialize, server, step.Plan{})
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		s.Run(idl.Substep_check_environment, func(streams step.OutStreams) error {
+			t.Error("expected substep to not be called")
+			return nil
+		})
+
+		if s.Err() != nil {
+			t.Errorf("unexpected error %#v", s.Err())
+		}
+
+		path := filepath.Join(dir, step.SubstepsFileName)
+		exist, err := upgrade.PathExist(path)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if exist {
+			t.Errorf("expected %q to not be created", path)
+		}
+	})
+
+	t.Run("errors when a substep was left running", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", dir)
+		defer resetEnv()
+
+		path := filepath.Join(dir, step.SubstepsFileName)
+		testutils.MustWriteToFile(t, path, fmt.Sprintf("{\"%s\":{\"%s\":\"%s\"}}",
+			idl.Step_execute, idl.Substep_upgrade_master, idl.Status_running))
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(headerMessage("Execute"))
+
+		s, err := step.BeginDryRun(idl.Step_execute, server, step.Plan{})
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		s.Run(idl.Substep_upgrade_master, func(streams step.OutStreams) error {
+			return nil
+		})
+
+		expected := "Found previous substep upgrade_master was running."
+		if s.Err() == nil || !strings.Contains(s.Err().Error(), expected) {
+			t.Errorf("got error %v want it to contain %q", s.Err(), expected)
+		}
+	})
+}
+
+func headerMessage(step string) *idl.Message {
+	return &idl.Message{Contents: &idl.Message_Chunk{Chunk: &idl.Chunk{
+		Buffer: []byte(fmt.Sprintf("\n%s dry run.\n", step)),
+		Type:   idl.Chunk_stdout,
+	}}}
+}
+
+func planMessage(substep idl.Substep, action idl.SubstepPlan_Action, commands []*idl.PlannedCommand) *idl.Message {
+	return &idl.Message{Contents: &idl.Message_Plan{Plan: &idl.SubstepPlan{
+		Substep:  substep,
+		Action:   action,
+		Commands: commands,
+	}}}
+}
+
 func TestStepErr(t *testing.T) {
 	t.Run("returns nil when substep did not fail", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -813,8 +1324,9 @@ func TestStepErr(t *testing.T) {
 }
 
 type TestSubstepStore struct {
-	Status   idl.Status
-	WriteErr error
+	Status    idl.Status
+	LastError error
+	WriteErr  error
 }
 
 func (t *TestSubstepStore) Read(_ idl.Step, substep idl.Substep) (idl.Status, error) {
@@ -825,3 +1337,8 @@ func (t *TestSubstepStore) Write(_ idl.Step, substep idl.Substep, status idl.Sta
 	t.Status = status
 	return t.WriteErr
 }
+
+func (t *TestSubstepStore) WriteError(_ idl.Step, substep idl.Substep, err error) error {
+	t.LastError = err
+	return t.WriteErr
+}
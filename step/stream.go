@@ -22,6 +22,35 @@ type OutStreams interface {
 	Stderr() io.Writer
 }
 
+// ProgressSender is implemented by OutStreams that can report the progress
+// of the segments being upgraded in addition to their output.
+type ProgressSender interface {
+	SendProgress(progress *idl.SegmentProgress)
+}
+
+// SendProgress reports the segment progress when the streams support it.
+// Otherwise, the progress is only logged.
+func SendProgress(streams OutStreams, progress *idl.SegmentProgress) {
+	if sender, ok := streams.(ProgressSender); ok {
+		sender.SendProgress(progress)
+		return
+	}
+
+	logProgress(progress)
+}
+
+func logProgress(progress *idl.SegmentProgress) {
+	status := progress.GetStage()
+	switch {
+	case progress.GetFailed():
+		status = "failed"
+	case progress.GetDone():
+		status = "done"
+	}
+
+	log.Printf("%s content %d: %s (%d stages completed)", progress.GetHost(), progress.GetContentID(), status, progress.GetCompletedStages())
+}
+
 // DevNullStream provides an implementation of OutStreams that drops
 // all writes to it.
 var DevNullStream = devNullStream{}
@@ -152,6 +181,26 @@ func (l *logMessageSender) Stderr() io.Writer {
 	return l.stderr
 }
 
+func (l *logMessageSender) SendProgress(progress *idl.SegmentProgress) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	logProgress(progress)
+
+	if l.sender == nil {
+		return
+	}
+
+	// Like chunks, send errors are logged and otherwise ignored.
+	err := l.sender.Send(&idl.Message{
+		Contents: &idl.Message_Progress{Progress: progress},
+	})
+	if err != nil {
+		log.Printf("halting client sender: %v", err)
+		l.sender = nil
+	}
+}
+
 // logMessageSenderWriter is an internal type used by logMessageSender to send stdout and
 // stderr to both a gRPC MessageSender and log file.
 type logMessageSenderWriter struct {
@@ -195,3 +244,88 @@ func (l *logMessageSenderWriter) Write(p []byte) (int, error) {
 
 	return len(p), nil
 }
+
+// concurrentStreams is the OutStreams of a substep run concurrently with
+// others. Output is written a line at a time under a mutex shared by the
+// substeps such that their lines are interleaved rather than partial writes.
+type concurrentStreams struct {
+	streams OutStreams
+	mutex   *sync.Mutex
+	stdout  *lineWriter
+	stderr  *lineWriter
+}
+
+func newConcurrentStreams(streams OutStreams, mutex *sync.Mutex) *concurrentStreams {
+	return &concurrentStreams{
+		streams: streams,
+		mutex:   mutex,
+		stdout:  &lineWriter{mutex: mutex, w: streams.Stdout()},
+		stderr:  &lineWriter{mutex: mutex, w: streams.Stderr()},
+	}
+}
+
+func (c *concurrentStreams) Stdout() io.Writer {
+	return c.stdout
+}
+
+func (c *concurrentStreams) Stderr() io.Writer {
+	return c.stderr
+}
+
+func (c *concurrentStreams) SendProgress(progress *idl.SegmentProgress) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	SendProgress(c.streams, progress)
+}
+
+// flush writes any remaining partial lines.
+func (c *concurrentStreams) flush() error {
+	stdoutErr := c.stdout.flush()
+	stderrErr := c.stderr.flush()
+	if stdoutErr != nil {
+		return stdoutErr
+	}
+
+	return stderrErr
+}
+
+type lineWriter struct {
+	mutex *sync.Mutex
+	w     io.Writer
+	buf   []byte
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.buf = append(l.buf, p...)
+
+	i := bytes.LastIndexByte(l.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+
+	lines := l.buf[:i+1]
+	l.buf = append([]byte(nil), l.buf[i+1:]...)
+
+	if _, err := l.w.Write(lines); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (l *lineWriter) flush() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if len(l.buf) == 0 {
+		return nil
+	}
+
+	_, err := l.w.Write(l.buf)
+	l.buf = nil
+	return err
+}
@@ -4,9 +4,12 @@
 package step
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"sync"
+	"time"
 
 	"golang.org/x/xerrors"
 
@@ -17,11 +20,13 @@ import (
 type SubstepStore interface {
 	Read(idl.Step, idl.Substep) (idl.Status, error)
 	Write(idl.Step, idl.Substep, idl.Status) error
+	WriteError(idl.Step, idl.Substep, error) error
 }
 
 // SubstepFileStore implements SubstepStore by providing persistent storage on disk.
 type SubstepFileStore struct {
-	path string
+	path  string
+	mutex sync.Mutex // serializes updates of substeps run concurrently
 }
 
 func NewSubstepFileStore() (*SubstepFileStore, error) {
@@ -30,14 +35,84 @@ func NewSubstepFileStore() (*SubstepFileStore, error) {
 		return &SubstepFileStore{}, xerrors.Errorf("read %q: %w", SubstepsFileName, err)
 	}
 
-	return &SubstepFileStore{path}, nil
+	return &SubstepFileStore{path: path}, nil
 }
 
 func NewSubstepStoreUsingFile(path string) *SubstepFileStore {
-	return &SubstepFileStore{path}
+	return &SubstepFileStore{path: path}
 }
 
-type prettyMap = map[string]map[string]PrettyStatus
+type prettyMap = map[string]map[string]SubstepEntry
+
+// SubstepEntry is the persisted record of a substep. Besides the latest status
+// it tracks the timing of the current attempt, the number of attempts, and the
+// history of previous attempts such that it survives re-running a step.
+type SubstepEntry struct {
+	Status    PrettyStatus
+	StartTime *time.Time `json:",omitempty"`
+	EndTime   *time.Time `json:",omitempty"`
+	Duration  PrettyDuration
+	Attempts  int
+	LastError string           `json:",omitempty"`
+	History   []SubstepAttempt `json:",omitempty"`
+}
+
+// SubstepAttempt records a previous attempt of a substep.
+type SubstepAttempt struct {
+	Status    PrettyStatus
+	StartTime *time.Time `json:",omitempty"`
+	EndTime   *time.Time `json:",omitempty"`
+	Duration  PrettyDuration
+	Error     string `json:",omitempty"`
+}
+
+// UnmarshalJSON supports the previous flat format where each substep mapped
+// directly to its status such as "check_upgrade": "complete". Such entries are
+// migrated to the current format on the next write.
+func (e *SubstepEntry) UnmarshalJSON(buf []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(buf), []byte(`"`)) {
+		*e = SubstepEntry{}
+		return json.Unmarshal(buf, &e.Status)
+	}
+
+	type entry SubstepEntry // avoid recursing into UnmarshalJSON
+	return json.Unmarshal(buf, (*entry)(e))
+}
+
+// start begins a new attempt saving any previous attempt to the history.
+func (e *SubstepEntry) start(now time.Time) {
+	if e.StartTime != nil {
+		e.History = append(e.History, SubstepAttempt{
+			Status:    e.Status,
+			StartTime: e.StartTime,
+			EndTime:   e.EndTime,
+			Duration:  e.Duration,
+			Error:     e.currentError(),
+		})
+	}
+
+	e.StartTime = &now
+	e.EndTime = nil
+	e.Duration = PrettyDuration{}
+	e.Attempts++
+}
+
+// end finishes the current attempt.
+func (e *SubstepEntry) end(now time.Time) {
+	e.EndTime = &now
+	if e.StartTime != nil {
+		e.Duration = PrettyDuration{now.Sub(*e.StartTime)}
+	}
+}
+
+// currentError returns the error of the current attempt if it failed.
+func (e *SubstepEntry) currentError() string {
+	if e.Status.Status != idl.Status_failed && e.Status.Status != idl.Status_quit {
+		return ""
+	}
+
+	return e.LastError
+}
 
 // PrettyStatus exists only to write a string description of idl.Status to
 // the JSON representation, instead of an integer.
@@ -61,6 +136,26 @@ func (p *PrettyStatus) UnmarshalText(buf []byte) error {
 	return nil
 }
 
+// PrettyDuration exists only to write a string description of a duration
+// such as "1m30s" to the JSON representation, instead of nanoseconds.
+type PrettyDuration struct {
+	time.Duration
+}
+
+func (p PrettyDuration) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *PrettyDuration) UnmarshalText(buf []byte) error {
+	duration, err := time.ParseDuration(string(buf))
+	if err != nil {
+		return err
+	}
+
+	p.Duration = duration
+	return nil
+}
+
 func (f *SubstepFileStore) load() (prettyMap, error) {
 	data, err := os.ReadFile(f.path)
 	if err != nil {
@@ -76,7 +171,12 @@ func (f *SubstepFileStore) load() (prettyMap, error) {
 	return substeps, nil
 }
 
-func (f *SubstepFileStore) ReadStep(step idl.Step) (map[string]PrettyStatus, error) {
+// ReadAll returns the entries of every step keyed by step and then substep.
+func (f *SubstepFileStore) ReadAll() (map[string]map[string]SubstepEntry, error) {
+	return f.load()
+}
+
+func (f *SubstepFileStore) ReadStep(step idl.Step) (map[string]SubstepEntry, error) {
 	steps, err := f.load()
 	if err != nil {
 		return nil, err
@@ -101,22 +201,62 @@ func (f *SubstepFileStore) Read(step idl.Step, substep idl.Substep) (idl.Status,
 		return idl.Status_unknown_status, nil
 	}
 
-	return status.Status, nil
+	return status.Status.Status, nil
 }
 
 // Write atomically updates the status file.
 // Load the latest values from the filesystem, rather than storing
 // in-memory on a struct to avoid having two sources of truth.
+// Writing a running status starts a new attempt while writing any other status
+// after running ends the attempt recording its duration. Writing a running or
+// complete status clears the error of the previous attempt which is kept in the
+// history.
 func (f *SubstepFileStore) Write(step idl.Step, substep idl.Substep, status idl.Status) (err error) {
+	return f.update(step, substep, func(entry *SubstepEntry) {
+		now := time.Now()
+
+		switch {
+		case status == idl.Status_running:
+			entry.start(now)
+		case entry.Status.Status == idl.Status_running:
+			entry.end(now)
+		}
+
+		if status == idl.Status_running || status == idl.Status_complete {
+			entry.LastError = ""
+		}
+
+		entry.Status = PrettyStatus{status}
+	})
+}
+
+// WriteError records the error of the current attempt.
+func (f *SubstepFileStore) WriteError(step idl.Step, substep idl.Substep, substepErr error) error {
+	if substepErr == nil {
+		return nil
+	}
+
+	return f.update(step, substep, func(entry *SubstepEntry) {
+		entry.LastError = substepErr.Error()
+	})
+}
+
+func (f *SubstepFileStore) update(step idl.Step, substep idl.Substep, modify func(entry *SubstepEntry)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
 	steps, err := f.load()
 	if err != nil {
 		return err
 	}
 
 	if _, ok := steps[step.String()]; !ok {
-		steps[step.String()] = make(map[string]PrettyStatus)
+		steps[step.String()] = make(map[string]SubstepEntry)
 	}
-	steps[step.String()][substep.String()] = PrettyStatus{status}
+
+	entry := steps[step.String()][substep.String()]
+	modify(&entry)
+	steps[step.String()][substep.String()] = entry
 
 	data, err := json.MarshalIndent(steps, "", "  ") // pretty print JSON
 	if err != nil {
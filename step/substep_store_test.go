@@ -5,10 +5,12 @@ package step_test
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
@@ -45,6 +47,34 @@ func TestFileStore(t *testing.T) {
 		}
 	})
 
+	t.Run("ReadAll reads the substeps of every step", func(t *testing.T) {
+		clear(t, path)
+
+		err := fs.Write(initialize, idl.Substep_check_upgrade, idl.Status_complete)
+		if err != nil {
+			t.Fatalf("Write() returned error %#v", err)
+		}
+
+		err = fs.Write(idl.Step_execute, idl.Substep_upgrade_primaries, idl.Status_failed)
+		if err != nil {
+			t.Fatalf("Write() returned error %#v", err)
+		}
+
+		steps, err := fs.ReadAll()
+		if err != nil {
+			t.Errorf("ReadAll() returned error %#v", err)
+		}
+
+		if len(steps) != 2 {
+			t.Errorf("got %d steps want 2", len(steps))
+		}
+
+		status := steps[idl.Step_execute.String()][idl.Substep_upgrade_primaries.String()].Status
+		if status.Status != idl.Status_failed {
+			t.Errorf("got status %s want %s", status, idl.Status_failed)
+		}
+	})
+
 	t.Run("ReadStep reads the same status that was written", func(t *testing.T) {
 		clear(t, path)
 
@@ -62,7 +92,7 @@ func TestFileStore(t *testing.T) {
 		}
 
 		expected := step.PrettyStatus{Status: status}
-		if !reflect.DeepEqual(statusMap[substep.String()], expected) {
+		if !reflect.DeepEqual(statusMap[substep.String()].Status, expected) {
 			t.Errorf("read %v, want %v", statusMap, expected)
 		}
 	})
@@ -199,16 +229,152 @@ func TestFileStore(t *testing.T) {
 		defer f.Close()
 
 		dec := json.NewDecoder(f)
-		raw := make(map[string]map[string]string)
+		raw := make(map[string]map[string]map[string]interface{})
 		if err := dec.Decode(&raw); err != nil {
 			t.Fatalf("decoding statuses: %+v", err)
 		}
 
 		key := substep.String()
-		if raw[initialize.String()][key] != status.String() {
-			t.Errorf("status[%q][%q] = %q, want %q", initialize, key, raw[initialize.String()][key], status.String())
+		if raw[initialize.String()][key]["Status"] != status.String() {
+			t.Errorf("status[%q][%q] = %q, want %q", initialize, key, raw[initialize.String()][key]["Status"], status.String())
+		}
+	})
+
+	t.Run("records the timing of each attempt", func(t *testing.T) {
+		clear(t, path)
+
+		substep := idl.Substep_upgrade_primaries
+		before := time.Now()
+
+		err := fs.Write(initialize, substep, idl.Status_running)
+		if err != nil {
+			t.Fatalf("Write() returned error %+v", err)
+		}
+
+		entry := mustReadEntry(t, fs, initialize, substep)
+		if entry.StartTime == nil || entry.StartTime.Before(before) {
+			t.Errorf("got start time %v want after %v", entry.StartTime, before)
+		}
+
+		if entry.EndTime != nil {
+			t.Errorf("got end time %v want nil", entry.EndTime)
+		}
+
+		if entry.Attempts != 1 {
+			t.Errorf("got %d attempts want 1", entry.Attempts)
+		}
+
+		err = fs.Write(initialize, substep, idl.Status_complete)
+		if err != nil {
+			t.Fatalf("Write() returned error %+v", err)
+		}
+
+		entry = mustReadEntry(t, fs, initialize, substep)
+		if entry.EndTime == nil || entry.EndTime.Before(*entry.StartTime) {
+			t.Errorf("got end time %v want after start time %v", entry.EndTime, entry.StartTime)
+		}
+
+		if entry.Duration.Duration != entry.EndTime.Sub(*entry.StartTime) {
+			t.Errorf("got duration %v want %v", entry.Duration, entry.EndTime.Sub(*entry.StartTime))
 		}
 	})
+
+	t.Run("keeps the history of previous attempts", func(t *testing.T) {
+		clear(t, path)
+
+		substep := idl.Substep_upgrade_primaries
+		expectedErr := errors.New("oops")
+
+		for _, status := range []idl.Status{idl.Status_running, idl.Status_failed} {
+			if err := fs.Write(initialize, substep, status); err != nil {
+				t.Fatalf("Write() returned error %+v", err)
+			}
+		}
+
+		if err := fs.WriteError(initialize, substep, expectedErr); err != nil {
+			t.Fatalf("WriteError() returned error %+v", err)
+		}
+
+		failed := mustReadEntry(t, fs, initialize, substep)
+
+		for _, status := range []idl.Status{idl.Status_running, idl.Status_complete} {
+			if err := fs.Write(initialize, substep, status); err != nil {
+				t.Fatalf("Write() returned error %+v", err)
+			}
+		}
+
+		entry := mustReadEntry(t, fs, initialize, substep)
+		if entry.Status.Status != idl.Status_complete {
+			t.Errorf("got status %s want %s", entry.Status, idl.Status_complete)
+		}
+
+		if entry.Attempts != 2 {
+			t.Errorf("got %d attempts want 2", entry.Attempts)
+		}
+
+		if entry.LastError != "" {
+			t.Errorf("got last error %q want it cleared after the successful retry", entry.LastError)
+		}
+
+		expected := []step.SubstepAttempt{{
+			Status:    step.PrettyStatus{Status: idl.Status_failed},
+			StartTime: failed.StartTime,
+			EndTime:   failed.EndTime,
+			Duration:  failed.Duration,
+			Error:     expectedErr.Error(),
+		}}
+		if !reflect.DeepEqual(entry.History, expected) {
+			t.Errorf("got history %+v want %+v", entry.History, expected)
+		}
+	})
+
+	t.Run("migrates the previous format", func(t *testing.T) {
+		testutils.MustWriteToFile(t, path, `{
+  "initialize": {
+    "check_upgrade": "complete",
+    "init_target_cluster": "failed"
+  }
+}`)
+
+		status, err := fs.Read(initialize, idl.Substep_init_target_cluster)
+		if err != nil {
+			t.Fatalf("Read() returned error %+v", err)
+		}
+
+		if status != idl.Status_failed {
+			t.Errorf("got status %s want %s", status, idl.Status_failed)
+		}
+
+		err = fs.Write(initialize, idl.Substep_init_target_cluster, idl.Status_running)
+		if err != nil {
+			t.Fatalf("Write() returned error %+v", err)
+		}
+
+		raw := make(map[string]map[string]map[string]interface{})
+		if err := json.Unmarshal([]byte(testutils.MustReadFile(t, path)), &raw); err != nil {
+			t.Fatalf("decoding statuses: %+v", err)
+		}
+
+		if raw[initialize.String()][idl.Substep_check_upgrade.String()]["Status"] != idl.Status_complete.String() {
+			t.Errorf("got %v want status %s", raw[initialize.String()][idl.Substep_check_upgrade.String()], idl.Status_complete)
+		}
+
+		entry := mustReadEntry(t, fs, initialize, idl.Substep_init_target_cluster)
+		if entry.Attempts != 1 || entry.Status.Status != idl.Status_running {
+			t.Errorf("got %+v want a single running attempt", entry)
+		}
+	})
+}
+
+func mustReadEntry(t *testing.T, fs *step.SubstepFileStore, stepName idl.Step, substep idl.Substep) step.SubstepEntry {
+	t.Helper()
+
+	entries, err := fs.ReadStep(stepName)
+	if err != nil {
+		t.Fatalf("ReadStep() returned error %+v", err)
+	}
+
+	return entries[substep.String()]
 }
 
 // clear writes an empty JSON map to the given SubstepFileStore backing path.
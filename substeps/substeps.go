@@ -76,4 +76,119 @@ var SubstepDescriptions = map[idl.Substep]substepText{
 	idl.Substep_verify_gpupgrade_is_installed_across_all_hosts:                substepText{"Verifying gpupgrade is installed across all hosts...", "Verify gpupgrade is installed across all hosts"},
 	idl.Substep_initialize_wait_for_cluster_to_be_ready:                       substepText{"Waiting for cluster to be ready...", "Wait for cluster to be ready"},
 	idl.Substep_wait_for_cluster_to_be_ready_before_upgrade_master:            substepText{"Waiting for cluster to be ready...", "Wait for cluster to be ready"},
+	idl.Substep_snapshot_source_cluster:                                       substepText{"Taking snapshot of source cluster...", "Take snapshot of source cluster"},
+	idl.Substep_validate_target_cluster:                                       substepText{"Validating target cluster against source snapshot...", "Validate target cluster against source snapshot"},
+}
+
+// RecoveryDescriptions describes how each substep left running, such as when
+// the hub crashed, is recovered when its step is re-run with --recover. The
+// recovery cleans up or verifies the partial work before the substep is run
+// again. Substeps not listed need manual intervention.
+var RecoveryDescriptions = map[idl.Substep]string{
+	idl.Substep_saving_source_cluster_config:                                  "Re-save the source cluster configuration",
+	idl.Substep_start_hub:                                                     "Restart the gpupgrade hub process",
+	idl.Substep_start_agents:                                                  "Restart the gpupgrade agent processes",
+	idl.Substep_ensure_gpupgrade_agents_are_running:                           "Restart the gpupgrade agent processes",
+	idl.Substep_verify_gpdb_versions:                                          "Re-verify the source and target cluster versions",
+	idl.Substep_verify_gpupgrade_is_installed_across_all_hosts:                "Re-verify gpupgrade is installed across all hosts",
+	idl.Substep_check_environment:                                             "Re-check the environment",
+	idl.Substep_check_disk_space:                                              "Re-check disk space",
+	idl.Substep_snapshot_source_cluster:                                       "Re-take the source cluster snapshot",
+	idl.Substep_check_active_connections_on_source_cluster:                    "Re-check active connections on the source cluster",
+	idl.Substep_check_active_connections_on_target_cluster:                    "Re-check active connections on the target cluster",
+	idl.Substep_create_backupdirs:                                             "Re-create the internal backup directories",
+	idl.Substep_generate_target_config:                                        "Re-generate the target cluster configuration",
+	idl.Substep_init_target_cluster:                                           "Delete the partially created target cluster and re-create it",
+	idl.Substep_shutdown_target_cluster:                                       "Stop the target cluster",
+	idl.Substep_backup_target_master:                                          "Re-copy the target master backup",
+	idl.Substep_initialize_wait_for_cluster_to_be_ready:                       "Wait for the cluster to be ready",
+	idl.Substep_wait_for_cluster_to_be_ready_before_upgrade_master:            "Wait for the cluster to be ready",
+	idl.Substep_wait_for_cluster_to_be_ready_after_adding_mirrors_and_standby: "Wait for the cluster to be ready",
+	idl.Substep_wait_for_cluster_to_be_ready_after_updating_catalog:           "Wait for the cluster to be ready",
+	idl.Substep_check_upgrade:                                                 "Re-run pg_upgrade checks",
+	idl.Substep_upgrade_master:                                                "Restore the target master from its backup and re-run pg_upgrade",
+	idl.Substep_copy_master:                                                   "Re-copy the master catalog to the primary segments",
+	idl.Substep_start_target_cluster:                                          "Start the target cluster",
+	idl.Substep_update_data_directories:                                       "Verify the data directory renames and finish those not yet renamed",
+	idl.Substep_start_source_cluster:                                          "Start the source cluster",
+	idl.Substep_delete_target_cluster_datadirs:                                "Finish deleting the target cluster data directories",
+	idl.Substep_delete_tablespaces:                                            "Finish deleting the target tablespace directories",
+	idl.Substep_delete_backupdir:                                              "Finish deleting the internal backup directories",
+	idl.Substep_delete_segment_statedirs:                                      "Finish deleting the state directories on the segments",
+	idl.Substep_stop_hub_and_agents:                                           "Stop the hub and agents",
+	idl.Substep_delete_master_statedir:                                        "Finish deleting the master state directory",
+	idl.Substep_validate_target_cluster:                                       "Re-validate the target cluster",
+}
+
+// StepSubsteps lists the substeps of each step in the order they are run.
+var StepSubsteps = map[idl.Step]Substeps{
+	idl.Step_initialize: {
+		idl.Substep_verify_gpdb_versions,
+		idl.Substep_saving_source_cluster_config,
+		idl.Substep_start_hub,
+		idl.Substep_generate_data_migration_scripts,
+		idl.Substep_execute_stats_data_migration_scripts,
+		idl.Substep_execute_initialize_data_migration_scripts,
+		idl.Substep_verify_gpupgrade_is_installed_across_all_hosts,
+		idl.Substep_start_agents,
+		idl.Substep_check_environment,
+		idl.Substep_create_backupdirs,
+		idl.Substep_check_disk_space,
+		idl.Substep_snapshot_source_cluster,
+		idl.Substep_generate_target_config,
+		idl.Substep_init_target_cluster,
+		idl.Substep_setting_dynamic_library_path_on_target_cluster,
+		idl.Substep_shutdown_target_cluster,
+		idl.Substep_backup_target_master,
+		idl.Substep_initialize_wait_for_cluster_to_be_ready,
+		idl.Substep_check_upgrade,
+	},
+	idl.Step_execute: {
+		idl.Substep_ensure_gpupgrade_agents_are_running,
+		idl.Substep_check_active_connections_on_source_cluster,
+		idl.Substep_wait_for_cluster_to_be_ready_before_upgrade_master,
+		idl.Substep_shutdown_source_cluster,
+		idl.Substep_upgrade_master,
+		idl.Substep_copy_master,
+		idl.Substep_upgrade_primaries,
+		idl.Substep_start_target_cluster,
+	},
+	idl.Step_finalize: {
+		idl.Substep_ensure_gpupgrade_agents_are_running,
+		idl.Substep_check_active_connections_on_target_cluster,
+		idl.Substep_upgrade_mirrors,
+		idl.Substep_upgrade_standby,
+		idl.Substep_wait_for_cluster_to_be_ready_after_adding_mirrors_and_standby,
+		idl.Substep_shutdown_target_cluster,
+		idl.Substep_update_target_catalog,
+		idl.Substep_update_data_directories,
+		idl.Substep_update_target_conf_files,
+		idl.Substep_start_target_cluster,
+		idl.Substep_wait_for_cluster_to_be_ready_after_updating_catalog,
+		idl.Substep_validate_target_cluster,
+		idl.Substep_archive_log_directories,
+		idl.Substep_delete_backupdir,
+		idl.Substep_delete_segment_statedirs,
+		idl.Substep_stop_hub_and_agents,
+		idl.Substep_execute_finalize_data_migration_scripts,
+		idl.Substep_analyze_target_cluster,
+		idl.Substep_delete_master_statedir,
+	},
+	idl.Step_revert: {
+		idl.Substep_ensure_gpupgrade_agents_are_running,
+		idl.Substep_check_active_connections_on_target_cluster,
+		idl.Substep_shutdown_target_cluster,
+		idl.Substep_delete_target_cluster_datadirs,
+		idl.Substep_delete_tablespaces,
+		idl.Substep_restore_pgcontrol,
+		idl.Substep_restore_source_cluster,
+		idl.Substep_start_source_cluster,
+		idl.Substep_recoverseg_source_cluster,
+		idl.Substep_archive_log_directories,
+		idl.Substep_delete_backupdir,
+		idl.Substep_delete_segment_statedirs,
+		idl.Substep_stop_hub_and_agents,
+		idl.Substep_execute_revert_data_migration_scripts,
+		idl.Substep_delete_master_statedir,
+	},
 }
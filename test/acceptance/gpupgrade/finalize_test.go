@@ -20,6 +20,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/config"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/report"
 	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/acceptance"
 	"github.com/greenplum-db/gpupgrade/upgrade"
@@ -139,6 +140,7 @@ func verifyFinalize(t *testing.T, source greenplum.Cluster, conf *config.Config,
 		fmt.Sprintf("%s.<contentID>%s", conf.UpgradeID, upgrade.OldSuffix),
 		conf.Intermediate.CoordinatorDataDir()+upgrade.OldSuffix,
 		logArchiveDir+`\d{5}`,
+		filepath.Join(logArchiveDir+`\d{5}`, report.JSONFileName),
 		filepath.Join(conf.Target.GPHome, "greenplum_path.sh"),
 		filepath.Join(filepath.Dir(conf.Target.GPHome), "greenplum-db"), conf.Target.GPHome,
 		filepath.Join(conf.Target.GPHome, "greenplum_path.sh"),
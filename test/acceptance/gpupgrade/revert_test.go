@@ -20,6 +20,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/config"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/report"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/substeps"
 	"github.com/greenplum-db/gpupgrade/testutils"
@@ -252,6 +253,7 @@ func verifyRevert(t *testing.T, source greenplum.Cluster, intermediate *greenplu
 		source.Version,
 		filepath.Join(source.GPHome, "greenplum_path.sh"), source.CoordinatorDataDir(), source.CoordinatorPort(),
 		logArchiveDir+`\d{5}`,
+		filepath.Join(logArchiveDir+`\d{5}`, report.JSONFileName),
 		idl.Step_revert,
 		source.GPHome, source.CoordinatorPort(), filepath.Join(logArchiveDir+`\d{5}`, "data-migration-scripts"), idl.Step_revert)
 	expected := regexp.MustCompile(match)
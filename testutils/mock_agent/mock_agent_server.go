@@ -72,7 +72,7 @@ func (m *MockAgentServer) CheckDiskSpace(context.Context, *idl.CheckSegmentDiskS
 	return &idl.CheckDiskSpaceReply{}, nil
 }
 
-func (m *MockAgentServer) UpgradePrimaries(ctx context.Context, in *idl.UpgradePrimariesRequest) (*idl.UpgradePrimariesReply, error) {
+func (m *MockAgentServer) UpgradePrimaries(in *idl.UpgradePrimariesRequest, stream idl.Agent_UpgradePrimariesServer) error {
 	m.increaseCalls()
 
 	m.mu.Lock()
@@ -84,7 +84,7 @@ func (m *MockAgentServer) UpgradePrimaries(ctx context.Context, in *idl.UpgradeP
 		err = <-m.Err
 	}
 
-	return &idl.UpgradePrimariesReply{}, err
+	return err
 }
 
 func (m *MockAgentServer) RenameDirectories(context.Context, *idl.RenameDirectoriesRequest) (*idl.RenameDirectoriesReply, error) {
@@ -131,6 +131,10 @@ func (m *MockAgentServer) StopAgent(ctx context.Context, in *idl.StopAgentReques
 	return &idl.StopAgentReply{}, nil
 }
 
+func (m *MockAgentServer) Heartbeat(ctx context.Context, in *idl.HeartbeatRequest) (*idl.HeartbeatReply, error) {
+	return &idl.HeartbeatReply{Health: &idl.AgentHealth{}}, nil
+}
+
 func (m *MockAgentServer) Stop() {
 	m.grpcServer.Stop()
 }
@@ -169,3 +173,13 @@ func (m *MockAgentServer) CreateRecoveryConf(context context.Context, in *idl.Cr
 func (m *MockAgentServer) AddReplicationEntries(context context.Context, in *idl.AddReplicationEntriesRequest) (*idl.AddReplicationEntriesReply, error) {
 	return &idl.AddReplicationEntriesReply{}, nil
 }
+
+func (m *MockAgentServer) ExecCommand(context context.Context, in *idl.ExecCommandRequest) (*idl.ExecCommandReply, error) {
+	m.increaseCalls()
+	return &idl.ExecCommandReply{}, nil
+}
+
+func (m *MockAgentServer) RemoteShell(stream idl.Agent_RemoteShellServer) error {
+	m.increaseCalls()
+	return stream.Send(&idl.RemoteShellReply{Exited: true})
+}
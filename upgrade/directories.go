@@ -93,6 +93,22 @@ func RenameDirectories(source, target string) error {
 	return nil
 }
 
+// VerifyRenameDirectories returns whether the target was already renamed to
+// the source by RenameDirectories. Otherwise the target must still be a data
+// directory such that it can be.
+func VerifyRenameDirectories(target string) (bool, error) {
+	renamed, err := AlreadyRenamed(target, target+OldSuffix)
+	if err != nil {
+		return false, err
+	}
+
+	if renamed {
+		return true, nil
+	}
+
+	return false, VerifyDataDirectory(target)
+}
+
 func renameDataDirectory(src, dst string) error {
 	if err := VerifyDataDirectory(src); err != nil {
 		return err
@@ -0,0 +1,94 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// Progress is the progress of pg_upgrade parsed from its output.
+type Progress struct {
+	Stage           string
+	CompletedStages int
+}
+
+// ProgressWriter parses the status lines pg_upgrade prints to stdout and
+// calls report whenever its progress changes. pg_upgrade prints the stage it
+// is on such as "Copying user relation files" and once finished "ok" either on
+// the same line or on its own line. Indented lines such as the files being
+// copied and section underlines are ignored.
+type ProgressWriter struct {
+	report   func(Progress)
+	progress Progress
+	line     []byte
+	mutex    sync.Mutex
+}
+
+func NewProgressWriter(report func(Progress)) *ProgressWriter {
+	return &ProgressWriter{report: report}
+}
+
+func (w *ProgressWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, b := range p {
+		// pg_upgrade rewrites the current line using a carriage return when
+		// reporting the file being processed, so treat it as a line end.
+		if b != '\n' && b != '\r' {
+			w.line = append(w.line, b)
+			continue
+		}
+
+		w.parseLine(string(w.line))
+		w.line = w.line[:0]
+	}
+
+	return len(p), nil
+}
+
+func (w *ProgressWriter) parseLine(line string) {
+	if strings.TrimSpace(line) == "" || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+		if strings.TrimSpace(line) == "ok" {
+			w.complete(w.progress.Stage)
+		}
+
+		return
+	}
+
+	trimmed := strings.TrimRight(line, " \t")
+	if strings.Trim(trimmed, "-") == "" {
+		return
+	}
+
+	fields := strings.Fields(trimmed)
+	if len(fields) > 1 && fields[len(fields)-1] == "ok" {
+		w.complete(strings.TrimSpace(strings.TrimSuffix(trimmed, "ok")))
+		return
+	}
+
+	w.progress.Stage = trimmed
+	w.report(w.progress)
+}
+
+func (w *ProgressWriter) complete(stage string) {
+	w.progress.Stage = stage
+	w.progress.CompletedStages++
+	w.report(w.progress)
+}
+
+// Flush parses any remaining output not ending in a newline.
+func (w *ProgressWriter) Flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(bytes.TrimSpace(w.line)) == 0 {
+		return
+	}
+
+	w.parseLine(string(w.line))
+	w.line = w.line[:0]
+}
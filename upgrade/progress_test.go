@@ -0,0 +1,87 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/upgrade"
+)
+
+func TestProgressWriter(t *testing.T) {
+	cases := []struct {
+		name     string
+		writes   []string
+		expected []upgrade.Progress
+	}{
+		{
+			name: "reports stages completed on the same line",
+			writes: []string{
+				"Performing Consistency Checks\n",
+				"-----------------------------\n",
+				"Checking cluster versions                                   ok\n",
+				"Checking database user is the install user                  ok\n",
+			},
+			expected: []upgrade.Progress{
+				{Stage: "Performing Consistency Checks", CompletedStages: 0},
+				{Stage: "Checking cluster versions", CompletedStages: 1},
+				{Stage: "Checking database user is the install user", CompletedStages: 2},
+			},
+		},
+		{
+			name: "reports stages completed on their own line and ignores the files being processed",
+			writes: []string{
+				"Copying user relation files\n",
+				"  /data/base/16384/16385\r",
+				"  /data/base/16384/16386\r",
+				"                                                            ok\n",
+				"Setting next OID for new cluster                            ok\n",
+			},
+			expected: []upgrade.Progress{
+				{Stage: "Copying user relation files", CompletedStages: 0},
+				{Stage: "Copying user relation files", CompletedStages: 1},
+				{Stage: "Setting next OID for new cluster", CompletedStages: 2},
+			},
+		},
+		{
+			name: "handles lines split across writes",
+			writes: []string{
+				"Analyzing all rows",
+				" in the new cluster                       ok\nFreezing",
+				" all rows in the new cluster",
+			},
+			expected: []upgrade.Progress{
+				{Stage: "Analyzing all rows in the new cluster", CompletedStages: 1},
+				{Stage: "Freezing all rows in the new cluster", CompletedStages: 1},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var progress []upgrade.Progress
+			writer := upgrade.NewProgressWriter(func(p upgrade.Progress) {
+				progress = append(progress, p)
+			})
+
+			for _, w := range c.writes {
+				n, err := writer.Write([]byte(w))
+				if err != nil {
+					t.Fatalf("unexpected error %+v", err)
+				}
+
+				if n != len(w) {
+					t.Errorf("wrote %d bytes want %d", n, len(w))
+				}
+			}
+
+			writer.Flush()
+
+			if !reflect.DeepEqual(progress, c.expected) {
+				t.Errorf("got %+v want %+v", progress, c.expected)
+			}
+		})
+	}
+}
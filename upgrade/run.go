@@ -4,6 +4,7 @@
 package upgrade
 
 import (
+	"context"
 	"io"
 	"log"
 	"os/exec"
@@ -22,7 +23,9 @@ const DefaultDynamicLibraryPath = "$libdir"
 
 var pgupgradeCmd = exec.Command
 
-func Run(stdout, stderr io.Writer, opts *idl.PgOptions) error {
+// Run runs pg_upgrade with the options. It is terminated when the context is
+// cancelled.
+func Run(ctx context.Context, stdout, stderr io.Writer, opts *idl.PgOptions) error {
 	upgradeDir, err := utils.GetPgUpgradeDir(
 		opts.GetRole(),
 		opts.GetContentID(),
@@ -38,6 +41,39 @@ func Run(stdout, stderr io.Writer, opts *idl.PgOptions) error {
 		return err
 	}
 
+	utility, args, err := Command(opts)
+	if err != nil {
+		return err
+	}
+
+	cmd := pgupgradeCmd(utility, args...)
+
+	cmd.Dir = upgradeDir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	// Explicitly clear the child environment. pg_upgrade shouldn't need things
+	// like PATH and PGPORT which are explicitly forbidden to be set.
+	cmd.Env = []string{}
+
+	log.Printf("Executing: %q", cmd.String())
+
+	return utils.RunCommand(ctx, cmd)
+}
+
+// Command returns the pg_upgrade utility and arguments for the given options
+// without executing it.
+func Command(opts *idl.PgOptions) (string, []string, error) {
+	upgradeDir, err := utils.GetPgUpgradeDir(
+		opts.GetRole(),
+		opts.GetContentID(),
+		opts.GetPgUpgradeTimeStamp(),
+		opts.GetTargetVersion(),
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
 	args := []string{
 		"--retain",
 		"--progress",
@@ -87,20 +123,7 @@ func Run(stdout, stderr io.Writer, opts *idl.PgOptions) error {
 		args = append(args, "--new-gp-dbid", opts.GetNewDBID())
 	}
 
-	utility := filepath.Join(opts.GetNewBinDir(), "pg_upgrade")
-	cmd := pgupgradeCmd(utility, args...)
-
-	cmd.Dir = upgradeDir
-	cmd.Stdout = stdout
-	cmd.Stderr = stderr
-
-	// Explicitly clear the child environment. pg_upgrade shouldn't need things
-	// like PATH and PGPORT which are explicitly forbidden to be set.
-	cmd.Env = []string{}
-
-	log.Printf("Executing: %q", cmd.String())
-
-	return cmd.Run()
+	return filepath.Join(opts.GetNewBinDir(), "pg_upgrade"), args, nil
 }
 
 func SetPgUpgradeCommand(cmdFunc exectest.Command) {
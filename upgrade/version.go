@@ -16,52 +16,16 @@ import (
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
 )
 
 func LocalVersion() (string, error) {
-	return version("")
-}
-
-func RemoteVersion(host string) (string, error) {
-	return version(host)
-}
-
-var versionCommand = exec.Command
-var remoteVersionCommand = exec.Command
-
-// XXX: for internal testing only
-func SetLocalVersionCommand(command exectest.Command) {
-	versionCommand = command
-}
-
-func SetRemoteVersionCommand(command exectest.Command) {
-	remoteVersionCommand = command
-}
-
-// XXX: for internal testing only
-func ResetLocalVersionCommand() {
-	versionCommand = exec.Command
-}
-
-func ResetRemoteVersionCommand() {
-	remoteVersionCommand = exec.Command
-}
-
-func version(host string) (string, error) {
 	gpupgradePath, err := utils.GetGpupgradePath()
 	if err != nil {
 		return "", xerrors.Errorf("getting gpupgrade binary path: %w", err)
 	}
 
-	name := gpupgradePath
-	args := []string{"version", "--format", "oneline"}
-	if host != "" {
-		versionCommand = remoteVersionCommand
-		name = "ssh"
-		args = []string{"-q", host, fmt.Sprintf(`bash -c "%s version --format oneline"`, gpupgradePath)}
-	}
-
-	cmd := versionCommand(name, args...)
+	cmd := versionCommand(gpupgradePath, "version", "--format", "oneline")
 	log.Printf("Executing: %q", cmd.String())
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -73,7 +37,33 @@ func version(host string) (string, error) {
 	return string(output), nil
 }
 
-func EnsureGpupgradeVersionsMatch(agentHosts []string) error {
+func RemoteVersion(executor remote.Executor, host string) (string, error) {
+	gpupgradePath, err := utils.GetGpupgradePath()
+	if err != nil {
+		return "", xerrors.Errorf("getting gpupgrade binary path: %w", err)
+	}
+
+	output, err := executor.Output(host, gpupgradePath, "version", "--format", "oneline")
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
+
+var versionCommand = exec.Command
+
+// XXX: for internal testing only
+func SetLocalVersionCommand(command exectest.Command) {
+	versionCommand = command
+}
+
+// XXX: for internal testing only
+func ResetLocalVersionCommand() {
+	versionCommand = exec.Command
+}
+
+func EnsureGpupgradeVersionsMatch(executor remote.Executor, agentHosts []string) error {
 	type HostVersion struct {
 		host    string
 		version string
@@ -95,7 +85,7 @@ func EnsureGpupgradeVersionsMatch(agentHosts []string) error {
 		go func() {
 			defer wg.Done()
 
-			version, err := RemoteVersion(host)
+			version, err := RemoteVersion(executor, host)
 			hostVersions <- HostVersion{host: host, version: version, err: err}
 		}()
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
 )
 
 const localVersion = `Version: 1.0.0 Commit: 83aaa4 Release: Enterprise`
@@ -91,12 +92,10 @@ func TestGpupgradeVersionOnHost(t *testing.T) {
 	testlog.SetupTestLogger()
 	host := "sdw1"
 
-	t.Run("returns remote version using -q to suppress motd banner messages from polluting the version output", func(t *testing.T) {
+	t.Run("returns remote version", func(t *testing.T) {
+		executor := remote.SSH{Command: exectest.NewCommand(gpupgrade_remote_version)}
 
-		upgrade.SetRemoteVersionCommand(exectest.NewCommand(gpupgrade_remote_version))
-		defer upgrade.ResetRemoteVersionCommand()
-
-		version, err := upgrade.RemoteVersion(host)
+		version, err := upgrade.RemoteVersion(executor, host)
 		if err != nil {
 			t.Errorf("unexpected errr %#v", err)
 		}
@@ -107,10 +106,9 @@ func TestGpupgradeVersionOnHost(t *testing.T) {
 	})
 
 	t.Run("returns error when command fails", func(t *testing.T) {
-		upgrade.SetRemoteVersionCommand(exectest.NewCommand(gpupgrade_version_fails))
-		defer upgrade.ResetRemoteVersionCommand()
+		executor := remote.SSH{Command: exectest.NewCommand(gpupgrade_version_fails)}
 
-		version, err := upgrade.RemoteVersion(host)
+		version, err := upgrade.RemoteVersion(executor, host)
 		var actual *exec.ExitError
 		if !errors.As(err, &actual) {
 			t.Fatalf("got %#v want ExitError", err)
@@ -137,7 +135,9 @@ func TestEnsureVersionsMatch(t *testing.T) {
 		upgrade.SetLocalVersionCommand(exectest.NewCommand(gpupgrade_local_version))
 		defer upgrade.ResetLocalVersionCommand()
 
-		err := upgrade.EnsureGpupgradeVersionsMatch([]string{""})
+		executor := remote.SSH{Command: exectest.NewCommand(gpupgrade_local_version)}
+
+		err := upgrade.EnsureGpupgradeVersionsMatch(executor, []string{"sdw1"})
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -147,7 +147,9 @@ func TestEnsureVersionsMatch(t *testing.T) {
 		upgrade.SetLocalVersionCommand(exectest.NewCommand(gpupgrade_version_fails))
 		defer upgrade.ResetLocalVersionCommand()
 
-		err := upgrade.EnsureGpupgradeVersionsMatch([]string{""})
+		executor := remote.SSH{Command: exectest.NewCommand(gpupgrade_local_version)}
+
+		err := upgrade.EnsureGpupgradeVersionsMatch(executor, []string{"sdw1"})
 		expected := `failed with "oops": exit status 1`
 		if !strings.HasSuffix(err.Error(), expected) {
 			t.Errorf("got %v want %v", err, expected)
@@ -158,11 +160,10 @@ func TestEnsureVersionsMatch(t *testing.T) {
 		upgrade.SetLocalVersionCommand(exectest.NewCommand(gpupgrade_local_version))
 		defer upgrade.ResetLocalVersionCommand()
 
-		upgrade.SetRemoteVersionCommand(exectest.NewCommand(gpupgrade_version_fails))
-		defer upgrade.ResetRemoteVersionCommand()
+		executor := remote.SSH{Command: exectest.NewCommand(gpupgrade_version_fails)}
 
 		hosts := []string{"sdw1", "sdw2"}
-		err := upgrade.EnsureGpupgradeVersionsMatch(hosts)
+		err := upgrade.EnsureGpupgradeVersionsMatch(executor, hosts)
 		var expected errorlist.Errors
 		if !errors.As(err, &expected) {
 			t.Fatalf("got type %T, want type %T", err, expected)
@@ -177,11 +178,10 @@ func TestEnsureVersionsMatch(t *testing.T) {
 		upgrade.SetLocalVersionCommand(exectest.NewCommand(gpupgrade_local_version))
 		defer upgrade.ResetLocalVersionCommand()
 
-		upgrade.SetRemoteVersionCommand(exectest.NewCommand(gpupgrade_remote_version))
-		defer upgrade.ResetRemoteVersionCommand()
+		executor := remote.SSH{Command: exectest.NewCommand(gpupgrade_remote_version)}
 
 		hosts := []string{"sdw1"}
-		err := upgrade.EnsureGpupgradeVersionsMatch(hosts)
+		err := upgrade.EnsureGpupgradeVersionsMatch(executor, hosts)
 		expected := upgrade.MismatchedVersions{remoteVersion: hosts}
 		if !strings.HasSuffix(err.Error(), expected.String()) {
 			t.Error("expected error to contain mismatched agents")
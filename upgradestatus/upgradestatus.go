@@ -0,0 +1,230 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package upgradestatus reads the status of the steps, substeps, segments, and agents
+// from the state directory. It is shared by the hub and the CLI such that the
+// status can be shown when the hub is not running.
+package upgradestatus
+
+import (
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/substeps"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+// Read reads the step and substep status files from the state directory and
+// returns the status of every step and substep that has run along with the
+// commands that are valid to run next.
+func Read() (*idl.GetStatusReply, error) {
+	stepStore, err := step.NewStepFileStore()
+	if err != nil {
+		return nil, err
+	}
+
+	substepStore, err := step.NewSubstepFileStore()
+	if err != nil {
+		return nil, err
+	}
+
+	segmentStore, err := step.NewSegmentFileStore()
+	if err != nil {
+		return nil, err
+	}
+
+	statsStore, err := rsync.NewStatsFileStore()
+	if err != nil {
+		return nil, err
+	}
+
+	agentStore, err := step.NewAgentFileStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(stepStore, substepStore, segmentStore, statsStore, agentStore)
+}
+
+func New(stepStore *step.StepStoreFileStore, substepStore *step.SubstepFileStore, segmentStore *step.SegmentFileStore, statsStore *rsync.StatsFileStore, agentStore *step.AgentFileStore) (*idl.GetStatusReply, error) {
+	reply := &idl.GetStatusReply{}
+
+	for _, currentStep := range step.Steps {
+		stepStatus, err := stepStore.Read(currentStep)
+		if err != nil {
+			return nil, err
+		}
+
+		substepStatuses, err := substepStore.ReadStep(currentStep)
+		if err != nil {
+			return nil, err
+		}
+
+		if stepStatus == idl.Status_unknown_status && len(substepStatuses) == 0 {
+			continue
+		}
+
+		details := orderSubsteps(currentStep, substepStatuses)
+		for _, detail := range details {
+			detail.Segments, err = segmentStatuses(segmentStore, currentStep, detail.GetSubstep())
+			if err != nil {
+				return nil, err
+			}
+
+			stats, ok, err := statsStore.Read(currentStep, detail.GetSubstep())
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				detail.RsyncStats = stats.Proto()
+			}
+		}
+
+		reply.Steps = append(reply.Steps, &idl.StepStatus{
+			Step:     currentStep,
+			Status:   stepStatus,
+			Substeps: details,
+		})
+	}
+
+	validSteps, err := stepStore.ValidSteps()
+	if err != nil {
+		return nil, err
+	}
+	reply.ValidSteps = validSteps
+
+	reply.NextStep, err = stepStore.NextStep()
+	if err != nil {
+		return nil, err
+	}
+
+	reply.Agents, err = agentStatuses(agentStore)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// orderSubsteps returns the substeps in the order they are run. Any substeps
+// not known to be part of the step are appended in enum order.
+func orderSubsteps(currentStep idl.Step, entries map[string]step.SubstepEntry) []*idl.SubstepDetails {
+	var details []*idl.SubstepDetails
+	seen := make(map[idl.Substep]bool)
+
+	for _, substep := range substeps.StepSubsteps[currentStep] {
+		entry, ok := entries[substep.String()]
+		if !ok || seen[substep] {
+			continue
+		}
+
+		seen[substep] = true
+		details = append(details, substepDetails(substep, entry))
+	}
+
+	var remaining []*idl.SubstepDetails
+	for name, entry := range entries {
+		substep := idl.Substep(idl.Substep_value[name])
+		if seen[substep] || substep == idl.Substep_step_status {
+			continue
+		}
+
+		remaining = append(remaining, substepDetails(substep, entry))
+	}
+
+	sort.Slice(remaining, func(i, j int) bool {
+		return remaining[i].GetSubstep() < remaining[j].GetSubstep()
+	})
+
+	return append(details, remaining...)
+}
+
+// substepDetails converts the persisted entry. The duration of a running
+// substep is the time elapsed since it started.
+func substepDetails(substep idl.Substep, entry step.SubstepEntry) *idl.SubstepDetails {
+	details := &idl.SubstepDetails{Substep: substep, Status: entry.Status.Status}
+
+	if entry.StartTime == nil {
+		return details
+	}
+
+	details.StartTime = timestamppb.New(*entry.StartTime)
+
+	duration := entry.Duration.Duration
+	if entry.Status.Status == idl.Status_running {
+		duration = time.Since(*entry.StartTime)
+	}
+	details.Duration = durationpb.New(duration)
+
+	return details
+}
+
+// segmentStatuses returns the status of each segment ordered by content ID for
+// substeps that track the segments individually.
+func segmentStatuses(store *step.SegmentFileStore, currentStep idl.Step, substep idl.Substep) ([]*idl.SegmentStatus, error) {
+	entries, err := store.Read(currentStep, substep)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []*idl.SegmentStatus
+	for contentID, entry := range entries {
+		statuses = append(statuses, &idl.SegmentStatus{
+			Host:      entry.Host,
+			ContentID: contentID,
+			Status:    entry.Status.Status,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].GetContentID() < statuses[j].GetContentID()
+	})
+
+	return statuses, nil
+}
+
+// agentStatuses returns the result of the latest heartbeat sent to each agent
+// ordered by host.
+func agentStatuses(store *step.AgentFileStore) ([]*idl.AgentStatus, error) {
+	entries, err := store.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []*idl.AgentStatus
+	for host, entry := range entries {
+		status := &idl.AgentStatus{
+			Host: host,
+			Health: &idl.AgentHealth{
+				Version:        entry.Version,
+				Pid:            entry.Pid,
+				Uptime:         durationpb.New(entry.Uptime.Duration),
+				StateDirectory: entry.StateDirectory,
+				LoadAverage1:   entry.LoadAverage1,
+				LoadAverage5:   entry.LoadAverage5,
+				LoadAverage15:  entry.LoadAverage15,
+				DiskAvailable:  entry.DiskAvailable,
+				DiskTotal:      entry.DiskTotal,
+			},
+			Error: entry.Error,
+		}
+
+		if entry.LastHeartbeat != nil {
+			status.LastHeartbeat = timestamppb.New(*entry.LastHeartbeat)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].GetHost() < statuses[j].GetHost()
+	})
+
+	return statuses, nil
+}
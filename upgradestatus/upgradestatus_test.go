@@ -0,0 +1,231 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgradestatus_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/upgradestatus"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+func TestStatus(t *testing.T) {
+	setup := func(t *testing.T) (*step.StepStoreFileStore, *step.SubstepFileStore, *step.SegmentFileStore, *rsync.StatsFileStore, *step.AgentFileStore) {
+		stateDir := testutils.GetTempDir(t, "")
+		t.Cleanup(func() { testutils.MustRemoveAll(t, stateDir) })
+
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+		t.Cleanup(resetEnv)
+
+		stepStore, err := step.NewStepFileStore()
+		if err != nil {
+			t.Fatalf("NewStepFileStore: %v", err)
+		}
+
+		substepStore, err := step.NewSubstepFileStore()
+		if err != nil {
+			t.Fatalf("NewSubstepFileStore: %v", err)
+		}
+
+		segmentStore, err := step.NewSegmentFileStore()
+		if err != nil {
+			t.Fatalf("NewSegmentFileStore: %v", err)
+		}
+
+		statsStore, err := rsync.NewStatsFileStore()
+		if err != nil {
+			t.Fatalf("NewStatsFileStore: %v", err)
+		}
+
+		agentStore, err := step.NewAgentFileStore()
+		if err != nil {
+			t.Fatalf("NewAgentFileStore: %v", err)
+		}
+
+		return stepStore, substepStore, segmentStore, statsStore, agentStore
+	}
+
+	t.Run("returns initialize as the next step when nothing has run", func(t *testing.T) {
+		stepStore, substepStore, segmentStore, statsStore, agentStore := setup(t)
+
+		reply, err := upgradestatus.New(stepStore, substepStore, segmentStore, statsStore, agentStore)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := &idl.GetStatusReply{
+			ValidSteps: []idl.Step{idl.Step_initialize},
+			NextStep:   idl.Step_initialize,
+		}
+		if !reflect.DeepEqual(reply, expected) {
+			t.Errorf("got %v want %v", reply, expected)
+		}
+	})
+
+	t.Run("returns the substeps in the order they are run", func(t *testing.T) {
+		stepStore, substepStore, segmentStore, statsStore, agentStore := setup(t)
+
+		mustWriteStep(t, stepStore, idl.Step_initialize, idl.Status_complete)
+		mustWriteSubstep(t, substepStore, idl.Step_initialize, idl.Substep_check_upgrade, idl.Status_complete)
+		mustWriteSubstep(t, substepStore, idl.Step_initialize, idl.Substep_saving_source_cluster_config, idl.Status_complete)
+		mustWriteSubstep(t, substepStore, idl.Step_initialize, idl.Substep_start_agents, idl.Status_complete)
+
+		mustWriteStep(t, stepStore, idl.Step_execute, idl.Status_failed)
+		mustWriteSubstep(t, substepStore, idl.Step_execute, idl.Substep_upgrade_primaries, idl.Status_failed)
+		mustWriteSubstep(t, substepStore, idl.Step_execute, idl.Substep_upgrade_master, idl.Status_complete)
+		mustWriteSegment(t, segmentStore, "sdw2", 1, idl.Status_failed)
+		mustWriteSegment(t, segmentStore, "sdw1", 0, idl.Status_complete)
+		mustWriteSubstep(t, substepStore, idl.Step_execute, idl.Substep_copy_master, idl.Status_complete)
+		mustWriteStats(t, statsStore, idl.Step_execute, idl.Substep_copy_master, rsync.Stats{FilesTransferred: 12, BytesSent: 4096, Elapsed: time.Minute})
+
+		reply, err := upgradestatus.New(stepStore, substepStore, segmentStore, statsStore, agentStore)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := &idl.GetStatusReply{
+			Steps: []*idl.StepStatus{
+				{
+					Step:   idl.Step_initialize,
+					Status: idl.Status_complete,
+					Substeps: []*idl.SubstepDetails{
+						{Substep: idl.Substep_saving_source_cluster_config, Status: idl.Status_complete},
+						{Substep: idl.Substep_start_agents, Status: idl.Status_complete},
+						{Substep: idl.Substep_check_upgrade, Status: idl.Status_complete},
+					},
+				},
+				{
+					Step:   idl.Step_execute,
+					Status: idl.Status_failed,
+					Substeps: []*idl.SubstepDetails{
+						{Substep: idl.Substep_upgrade_master, Status: idl.Status_complete},
+						{Substep: idl.Substep_copy_master, Status: idl.Status_complete, RsyncStats: &idl.RsyncStats{
+							FilesTransferred: 12,
+							BytesSent:        4096,
+							Elapsed:          durationpb.New(time.Minute),
+						}},
+						{Substep: idl.Substep_upgrade_primaries, Status: idl.Status_failed, Segments: []*idl.SegmentStatus{
+							{Host: "sdw1", ContentID: 0, Status: idl.Status_complete},
+							{Host: "sdw2", ContentID: 1, Status: idl.Status_failed},
+						}},
+					},
+				},
+			},
+			ValidSteps: []idl.Step{idl.Step_execute, idl.Step_revert},
+			NextStep:   idl.Step_execute,
+		}
+		if !reflect.DeepEqual(reply, expected) {
+			t.Errorf("got %v want %v", reply, expected)
+		}
+	})
+
+	t.Run("returns the latest heartbeat of each agent ordered by host", func(t *testing.T) {
+		stepStore, substepStore, segmentStore, statsStore, agentStore := setup(t)
+
+		heartbeat := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+		health := &idl.AgentHealth{Version: "1.2.3", Pid: 42, Uptime: durationpb.New(time.Hour), StateDirectory: "/home/gpadmin/.gpupgrade"}
+
+		if err := agentStore.WriteHealth("sdw2", health, heartbeat); err != nil {
+			t.Fatalf("writing agent health: %v", err)
+		}
+
+		if err := agentStore.WriteError("sdw1", errors.New("connection refused")); err != nil {
+			t.Fatalf("writing agent error: %v", err)
+		}
+
+		reply, err := upgradestatus.New(stepStore, substepStore, segmentStore, statsStore, agentStore)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := []*idl.AgentStatus{
+			{Host: "sdw1", Health: &idl.AgentHealth{Uptime: durationpb.New(0)}, Error: "connection refused"},
+			{Host: "sdw2", LastHeartbeat: timestamppb.New(heartbeat), Health: health},
+		}
+		if !reflect.DeepEqual(reply.GetAgents(), expected) {
+			t.Errorf("got %v want %v", reply.GetAgents(), expected)
+		}
+	})
+}
+
+func TestNextStep(t *testing.T) {
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	stepStore, err := step.NewStepFileStore()
+	if err != nil {
+		t.Fatalf("NewStepFileStore: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		step     idl.Step
+		status   idl.Status
+		expected idl.Step
+	}{
+		{"initialize failed", idl.Step_initialize, idl.Status_failed, idl.Step_initialize},
+		{"initialize completed", idl.Step_initialize, idl.Status_complete, idl.Step_execute},
+		{"execute completed", idl.Step_execute, idl.Status_complete, idl.Step_finalize},
+		{"finalize completed", idl.Step_finalize, idl.Status_complete, idl.Step_unknown_step},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mustWriteStep(t, stepStore, c.step, c.status)
+
+			next, err := stepStore.NextStep()
+			if err != nil {
+				t.Fatalf("unexpected error %#v", err)
+			}
+
+			if next != c.expected {
+				t.Errorf("got %s want %s", next, c.expected)
+			}
+		})
+	}
+}
+
+func mustWriteStep(t *testing.T, store *step.StepStoreFileStore, stepName idl.Step, status idl.Status) {
+	t.Helper()
+
+	if err := store.Write(stepName, status); err != nil {
+		t.Fatalf("writing step status: %v", err)
+	}
+}
+
+func mustWriteSubstep(t *testing.T, store *step.SubstepFileStore, stepName idl.Step, substep idl.Substep, status idl.Status) {
+	t.Helper()
+
+	if err := store.Write(stepName, substep, status); err != nil {
+		t.Fatalf("writing substep status: %v", err)
+	}
+}
+
+func mustWriteSegment(t *testing.T, store *step.SegmentFileStore, host string, contentID int32, status idl.Status) {
+	t.Helper()
+
+	if err := store.Write(idl.Step_execute, idl.Substep_upgrade_primaries, host, contentID, status); err != nil {
+		t.Fatalf("writing segment status: %v", err)
+	}
+}
+
+func mustWriteStats(t *testing.T, store *rsync.StatsFileStore, stepName idl.Step, substep idl.Substep, stats rsync.Stats) {
+	t.Helper()
+
+	if err := store.Write(stepName, substep, stats); err != nil {
+		t.Fatalf("writing rsync stats: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils/trace"
+)
+
+// RunCommand runs the command terminating it along with any processes it
+// started when the context is cancelled. In that case the returned error wraps
+// the context error. Commands are created with exec.Command rather than
+// exec.CommandContext such that they can still be mocked with exectest.
+func RunCommand(ctx context.Context, cmd *exec.Cmd) (err error) {
+	_, span := trace.Start(ctx, filepath.Base(cmd.Path), "command", cmd.String())
+	defer func() { span.End(err) }()
+
+	if err := ctx.Err(); err != nil {
+		return xerrors.Errorf("%s not started: %w", filepath.Base(cmd.Path), err)
+	}
+
+	// Run the command in its own process group such that processes it starts,
+	// such as the servers started by pg_upgrade or the remote shell started by
+	// rsync, are terminated along with it.
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		log.Printf("Terminating: %q", cmd.String())
+		if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM); err != nil {
+			log.Printf("terminate %q: %v", cmd.String(), err)
+		}
+	})
+
+	err = cmd.Wait()
+	if !stop() && ctx.Err() != nil {
+		return xerrors.Errorf("%s terminated: %w", filepath.Base(cmd.Path), ctx.Err())
+	}
+
+	return err
+}
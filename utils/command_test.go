@@ -0,0 +1,70 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func TestRunCommand(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("runs the command", func(t *testing.T) {
+		err := utils.RunCommand(context.Background(), exec.Command("true"))
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("returns errors from the command", func(t *testing.T) {
+		err := utils.RunCommand(context.Background(), exec.Command("false"))
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Errorf("got error %#v want type %T", err, exitErr)
+		}
+	})
+
+	t.Run("terminates the command and the processes it started when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+
+		// The output is captured such that waiting for the command also
+		// waits for the backgrounded sleep which holds the pipe open.
+		cmd := exec.Command("sh", "-c", "sleep 30 & wait")
+		cmd.Stdout = &bytes.Buffer{}
+
+		start := time.Now()
+		err := utils.RunCommand(ctx, cmd)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got error %#v want %#v", err, context.Canceled)
+		}
+
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Errorf("took %s want the command terminated right away", elapsed)
+		}
+	})
+
+	t.Run("does not start the command when already cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		cmd := exec.Command("true")
+		err := utils.RunCommand(ctx, cmd)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got error %#v want %#v", err, context.Canceled)
+		}
+
+		if cmd.Process != nil {
+			t.Errorf("expected the command not to be started")
+		}
+	})
+}
@@ -47,6 +47,8 @@ func (f FileSystemDiskUsage) Swap(i, j int) {
 	f[i], f[j] = f[j], f[i]
 }
 
+type CheckRequiredType func(streams step.OutStreams, d Disk, required map[string]uint64) (FileSystemDiskUsage, error)
+
 type CheckUsageType func(streams step.OutStreams, d Disk, diskFreeRatio float64, paths ...string) (FileSystemDiskUsage, error)
 
 // CheckUsage uses the given Disk to look up filesystem usage for each path, and
@@ -67,26 +69,9 @@ func CheckUsage(streams step.OutStreams, d Disk, diskFreeRatio float64, paths ..
 
 	failures := make(map[string]*idl.CheckDiskSpaceReply_DiskUsage)
 
-	// Find the device ID for every filesystem. We'll use these to map data
-	// directories to filesystems later.
-	fs, err := d.Filesystems()
+	fsByID, err := filesystemsByID(d, hostname)
 	if err != nil {
-		return nil, xerrors.Errorf("enumerating filesystems: %w", err)
-	}
-
-	fsByID := make(map[uint64]string)
-	for _, f := range fs.List {
-		stat, err := d.Stat(f.DirName)
-		if os.IsPermission(err) {
-			log.Printf("Ignoring filesystem %s on host %s when checking disk space. Unable to stat filesystem due to %v.", f.DirName, hostname, err)
-			continue
-		}
-
-		if err != nil {
-			return nil, xerrors.Errorf("stat'ing %s: %w", f.DirName, err)
-		}
-
-		fsByID[uint64(stat.Dev)] = f.DirName
+		return nil, err
 	}
 
 	for _, path := range paths {
@@ -125,15 +110,97 @@ func CheckUsage(streams step.OutStreams, d Disk, diskFreeRatio float64, paths ..
 		}
 	}
 
-	// NOTE: Transform the failures map used to prevent duplicate filesystems
-	// into a list since protobuf can't handle maps with complex keys such as a
-	// struct of filesystem and host.
+	return toUsage(failures), nil
+}
+
+// CheckRequired uses the given Disk to compare the available space of each
+// filesystem against the space required in kilobytes by each path on it.
+// Unlike CheckUsage the requirements of the paths on the same filesystem are
+// summed since they are the actual sizes to be written. Any filesystems that
+// don't have enough space are returned.
+func CheckRequired(streams step.OutStreams, d Disk, required map[string]uint64) (FileSystemDiskUsage, error) {
+	hostname, err := utils.System.Hostname()
+	if err != nil {
+		return nil, xerrors.Errorf("determining hostname: %w", err)
+	}
+
+	fsByID, err := filesystemsByID(d, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make(map[string]*idl.CheckDiskSpaceReply_DiskUsage)
+	for path, kb := range required {
+		usage, err := d.Usage(path)
+		if err != nil {
+			return nil, xerrors.Errorf("getting fs usage for %s: %w", path, err)
+		}
+
+		stat, err := d.Stat(path)
+		if err != nil {
+			return nil, xerrors.Errorf("stat'ing %s: %w", path, err)
+		}
+
+		fs, ok := fsByID[uint64(stat.Dev)]
+		if !ok {
+			fs = path
+		}
+
+		if _, ok := usages[fs]; !ok {
+			usages[fs] = &idl.CheckDiskSpaceReply_DiskUsage{Fs: fs, Host: hostname, Available: usage.Avail}
+		}
+
+		usages[fs].Required += kb
+		log.Printf("%s: %d required on %s", path, kb, fs)
+	}
+
+	failures := make(map[string]*idl.CheckDiskSpaceReply_DiskUsage)
+	for fs, usage := range usages {
+		log.Printf("%s: %d avail of %d required", fs, usage.Available, usage.Required)
+		if usage.Available < usage.Required {
+			failures[fs] = usage
+		}
+	}
+
+	return toUsage(failures), nil
+}
+
+// filesystemsByID finds the device ID for every filesystem which are used to
+// map data directories to filesystems.
+func filesystemsByID(d Disk, hostname string) (map[uint64]string, error) {
+	fs, err := d.Filesystems()
+	if err != nil {
+		return nil, xerrors.Errorf("enumerating filesystems: %w", err)
+	}
+
+	fsByID := make(map[uint64]string)
+	for _, f := range fs.List {
+		stat, err := d.Stat(f.DirName)
+		if os.IsPermission(err) {
+			log.Printf("Ignoring filesystem %s on host %s when checking disk space. Unable to stat filesystem due to %v.", f.DirName, hostname, err)
+			continue
+		}
+
+		if err != nil {
+			return nil, xerrors.Errorf("stat'ing %s: %w", f.DirName, err)
+		}
+
+		fsByID[uint64(stat.Dev)] = f.DirName
+	}
+
+	return fsByID, nil
+}
+
+// NOTE: Transform the failures map used to prevent duplicate filesystems
+// into a list since protobuf can't handle maps with complex keys such as a
+// struct of filesystem and host.
+func toUsage(failures map[string]*idl.CheckDiskSpaceReply_DiskUsage) FileSystemDiskUsage {
 	var usage FileSystemDiskUsage
 	for _, failure := range failures {
 		usage = append(usage, failure)
 	}
 
-	return usage, nil
+	return usage
 }
 
 // Local is a standard implementation of the Disk interface that uses gosigar
@@ -347,6 +347,118 @@ func TestCheckUsage(t *testing.T) {
 	})
 }
 
+func TestCheckRequired(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	host := "localhost"
+	utils.System.Hostname = func() (string, error) {
+		return host, nil
+	}
+	defer func() {
+		utils.System.Hostname = os.Hostname
+	}()
+
+	// This test disk has two mount points with 1000 and 100 kilobytes
+	// available on / and /tmp respectively.
+	d := testDisk{
+		err: errors.New("should never happen"),
+
+		filesystems: func() (sigar.FileSystemList, error) {
+			return sigar.FileSystemList{List: []sigar.FileSystem{
+				{DirName: "/"},
+				{DirName: "/tmp"},
+			}}, nil
+		},
+
+		usage: func(path string) (sigar.FileSystemUsage, error) {
+			if strings.HasPrefix(path, "/tmp") {
+				return sigar.FileSystemUsage{Total: 2000, Avail: 100}, nil
+			}
+
+			return sigar.FileSystemUsage{Total: 2000, Avail: 1000}, nil
+		},
+
+		stat: func(path string) (*unix.Stat_t, error) {
+			stat := new(unix.Stat_t)
+			stat.Dev = 1
+			if strings.HasPrefix(path, "/tmp") {
+				stat.Dev = 2
+			}
+
+			return stat, nil
+		},
+	}
+
+	cases := []struct {
+		name     string
+		required map[string]uint64
+		expected disk.FileSystemDiskUsage
+	}{
+		{
+			name:     "returns no failures with adequate space",
+			required: map[string]uint64{"/data/seg1": 400, "/data/seg2": 600, "/tmp/ts": 100},
+			expected: nil,
+		},
+		{
+			name:     "sums the required space of the paths on each filesystem",
+			required: map[string]uint64{"/data/seg1": 600, "/data/seg2": 600, "/tmp/ts": 50},
+			expected: disk.FileSystemDiskUsage{
+				&idl.CheckDiskSpaceReply_DiskUsage{
+					Fs:        "/",
+					Host:      host,
+					Required:  1200,
+					Available: 1000,
+				},
+			},
+		},
+		{
+			name:     "returns a failure for each filesystem",
+			required: map[string]uint64{"/data/seg1": 1001, "/tmp/ts": 101},
+			expected: disk.FileSystemDiskUsage{
+				&idl.CheckDiskSpaceReply_DiskUsage{
+					Fs:        "/",
+					Host:      host,
+					Required:  1001,
+					Available: 1000,
+				},
+				&idl.CheckDiskSpaceReply_DiskUsage{
+					Fs:        "/tmp",
+					Host:      host,
+					Required:  101,
+					Available: 100,
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := disk.CheckRequired(step.DevNullStream, d, c.required)
+			if err != nil {
+				t.Fatalf("returned error %#v", err)
+			}
+
+			sort.Sort(actual)
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("returned %v want %v", actual, c.expected)
+			}
+		})
+	}
+
+	t.Run("bubbles up any errors", func(t *testing.T) {
+		expected := errors.New("permission denied")
+		d := d
+		d.usage = func(string) (sigar.FileSystemUsage, error) {
+			return sigar.FileSystemUsage{}, expected
+		}
+
+		_, err := disk.CheckRequired(step.DevNullStream, d, map[string]uint64{"/data/seg1": 1})
+		if !errors.Is(err, expected) {
+			t.Errorf("returned error %#v want %#v", err, expected)
+		}
+	})
+}
+
 func TestLocal(t *testing.T) {
 	// disk.Local is a passthrough to more complicated implementations. Rather
 	// than duplicate the tests for those implementations, just verify simple
@@ -0,0 +1,88 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package logger
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys used to pass the upgrade ID, step, and substep from the hub to
+// the agents such that the agent logs can be correlated with the hub log.
+const (
+	upgradeIDMetadataKey = "gpupgrade-upgrade-id"
+	stepMetadataKey      = "gpupgrade-step"
+	substepMetadataKey   = "gpupgrade-substep"
+)
+
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+}
+
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+}
+
+// UnaryServerInterceptor adds the upgrade ID, step, and substep of the hub to
+// the context of the request. Handlers log with the context such that
+// concurrent requests are each correlated with their own substep.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(incomingContext(ctx), req)
+}
+
+func StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &serverStream{ServerStream: stream, ctx: incomingContext(stream.Context())})
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// outgoingContext passes the correlation of the substep in the context.
+// Requests made outside of a substep, such as heartbeats, pass only the upgrade
+// ID and step of the process.
+func outgoingContext(ctx context.Context) context.Context {
+	values, ok := ctx.Value(correlationKey{}).(correlationValues)
+	if !ok {
+		correlation.mutex.Lock()
+		values = correlationValues{upgradeID: correlation.upgradeID, step: correlation.step}
+		correlation.mutex.Unlock()
+	}
+
+	return metadata.AppendToOutgoingContext(ctx,
+		upgradeIDMetadataKey, values.upgradeID,
+		stepMetadataKey, values.step,
+		substepMetadataKey, values.substep)
+}
+
+// incomingContext returns the context holding the upgrade ID, step, and
+// substep of the hub making the request. Requests without them such as from an
+// older hub are logged with those of the agent process.
+func incomingContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(upgradeIDMetadataKey)) == 0 {
+		return ctx
+	}
+
+	return withCorrelation(ctx, correlationValues{
+		upgradeID: first(md.Get(upgradeIDMetadataKey)),
+		step:      first(md.Get(stepMetadataKey)),
+		substep:   first(md.Get(substepMetadataKey)),
+	})
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
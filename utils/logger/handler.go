@@ -0,0 +1,226 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Attribute keys used to correlate records across the hub, agent, and CLI
+// logs of an upgrade.
+const (
+	ProcessKey   = "process"
+	UserKey      = "user"
+	HostKey      = "host"
+	PIDKey       = "pid"
+	UpgradeIDKey = "upgrade_id"
+	StepKey      = "step"
+	SubstepKey   = "substep"
+	ContentIDKey = "content_id"
+)
+
+// correlation holds the upgrade ID and the step and substep currently being
+// run by the process. They are added to every record logged without those of
+// a request in its context.
+var correlation struct {
+	mutex sync.Mutex
+	correlationValues
+}
+
+type correlationValues struct {
+	upgradeID string
+	step      string
+	substep   string
+}
+
+type correlationKey struct{}
+
+// withCorrelation returns a context holding the upgrade ID, step, and substep
+// of a request. Records logged with the context, such as by slog.InfoContext,
+// are correlated with the request rather than the process such that
+// concurrent requests of different substeps are not confused.
+func withCorrelation(ctx context.Context, values correlationValues) context.Context {
+	return context.WithValue(ctx, correlationKey{}, values)
+}
+
+// WithSubstep returns a context holding the step and substep being run. Agent
+// requests made with it are correlated with the substep, even when several
+// substeps run at once.
+func WithSubstep(ctx context.Context, step string, substep string) context.Context {
+	correlation.mutex.Lock()
+	upgradeID := correlation.upgradeID
+	correlation.mutex.Unlock()
+
+	return withCorrelation(ctx, correlationValues{
+		upgradeID: upgradeID,
+		step:      step,
+		substep:   substep,
+	})
+}
+
+func SetUpgradeID(upgradeID string) {
+	correlation.mutex.Lock()
+	defer correlation.mutex.Unlock()
+
+	correlation.upgradeID = upgradeID
+}
+
+// SetStep sets the step being run and clears the substep.
+func SetStep(step string) {
+	correlation.mutex.Lock()
+	defer correlation.mutex.Unlock()
+
+	correlation.step = step
+	correlation.substep = ""
+}
+
+func SetSubstep(substep string) {
+	correlation.mutex.Lock()
+	defer correlation.mutex.Unlock()
+
+	correlation.substep = substep
+}
+
+func correlationAttrs(ctx context.Context) []slog.Attr {
+	var values correlationValues
+	var ok bool
+	if ctx != nil {
+		values, ok = ctx.Value(correlationKey{}).(correlationValues)
+	}
+
+	if !ok {
+		correlation.mutex.Lock()
+		values = correlation.correlationValues
+		correlation.mutex.Unlock()
+	}
+
+	var attrs []slog.Attr
+	if values.upgradeID != "" {
+		attrs = append(attrs, slog.String(UpgradeIDKey, values.upgradeID))
+	}
+
+	if values.step != "" {
+		attrs = append(attrs, slog.String(StepKey, values.step))
+	}
+
+	if values.substep != "" {
+		attrs = append(attrs, slog.String(SubstepKey, values.substep))
+	}
+
+	return attrs
+}
+
+// correlationHandler adds the upgrade ID, step, and substep to each record.
+// Those of the request in the context of the record take precedence over the
+// process.
+type correlationHandler struct {
+	handler slog.Handler
+}
+
+func (c *correlationHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return c.handler.Enabled(ctx, level)
+}
+
+func (c *correlationHandler) Handle(ctx context.Context, record slog.Record) error {
+	record = record.Clone()
+	record.AddAttrs(correlationAttrs(ctx)...)
+	return c.handler.Handle(ctx, record)
+}
+
+func (c *correlationHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &correlationHandler{handler: c.handler.WithAttrs(attrs)}
+}
+
+func (c *correlationHandler) WithGroup(name string) slog.Handler {
+	return &correlationHandler{handler: c.handler.WithGroup(name)}
+}
+
+// textHandler writes records as:
+//
+//	DATE TIME PROGRAMNAME:USERNAME:HOSTNAME:PID [LEVEL]: MESSAGE KEY=VALUE...
+type textHandler struct {
+	mutex  *sync.Mutex
+	out    io.Writer
+	prefix string
+	group  string
+	attrs  []slog.Attr
+}
+
+func newTextHandler(out io.Writer, prefix string) *textHandler {
+	return &textHandler{mutex: &sync.Mutex{}, out: out, prefix: prefix}
+}
+
+func (t *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= slog.LevelInfo
+}
+
+func (t *textHandler) Handle(_ context.Context, record slog.Record) error {
+	var buf bytes.Buffer
+	buf.WriteString(record.Time.Format("2006/01/02 15:04:05 "))
+	fmt.Fprintf(&buf, "%s [%s]: %s", t.prefix, record.Level, strings.TrimSuffix(record.Message, "\n"))
+
+	for _, attr := range t.attrs {
+		writeAttr(&buf, "", attr)
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		writeAttr(&buf, t.group, attr)
+		return true
+	})
+
+	buf.WriteString("\n")
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	_, err := t.out.Write(buf.Bytes())
+	return err
+}
+
+func (t *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *t
+	handler.attrs = nil
+	handler.attrs = append(handler.attrs, t.attrs...)
+	for _, attr := range attrs {
+		attr.Key = t.group + attr.Key
+		handler.attrs = append(handler.attrs, attr)
+	}
+
+	return &handler
+}
+
+func (t *textHandler) WithGroup(name string) slog.Handler {
+	handler := *t
+	handler.group = t.group + name + "."
+	return &handler
+}
+
+func writeAttr(buf *bytes.Buffer, group string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, a := range attr.Value.Group() {
+			writeAttr(buf, group+attr.Key+".", a)
+		}
+
+		return
+	}
+
+	value := attr.Value.String()
+	if value == "" || strings.ContainsAny(value, " =\"\n") {
+		value = strconv.Quote(value)
+	}
+
+	fmt.Fprintf(buf, " %s%s=%s", group, attr.Key, value)
+}
@@ -5,29 +5,90 @@ package logger
 
 import (
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"os"
 	"os/user"
 	"path/filepath"
 	"time"
 
+	"golang.org/x/xerrors"
+
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/trace"
+)
+
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
 )
 
-func Initialize(process string) {
+// Initialize directs both the log and slog packages to the process's log
+// file. Records written with the log package are logged at the info level.
+func Initialize(process string, format string) {
 	f, err := OpenFile(process)
 	if err != nil {
 		fmt.Printf("%+v\n", err)
 		os.Exit(1)
 	}
 
-	// If more robust logging is needed consider using logutils, zap, zerolog, etc.
-	log.SetOutput(f)
-	log.SetPrefix(prefix())
-	log.SetFlags(log.Ldate | log.Ltime | log.Lmsgprefix)
+	slog.SetDefault(New(f, process, format))
+}
+
+// New returns a logger writing records in the given format. The text format
+// matches the historical gpupgrade log format followed by any attributes
+// while the json format writes a JSON object per line. Every record includes
+// the attributes used to correlate the hub and agent logs of an upgrade.
+func New(w io.Writer, process string, format string) *slog.Logger {
+	var handler slog.Handler
+	switch format {
+	case JSONFormat:
+		currentUser, _ := user.Current()
+		host, _ := os.Hostname()
+
+		handler = slog.NewJSONHandler(w, nil).WithAttrs([]slog.Attr{
+			slog.String(ProcessKey, process),
+			slog.String(UserKey, currentUser.Username),
+			slog.String(HostKey, host),
+			slog.Int(PIDKey, os.Getpid()),
+		})
+	default:
+		handler = newTextHandler(w, prefix())
+	}
+
+	return slog.New(&correlationHandler{handler: handler})
+}
+
+func ValidateFormat(format string) error {
+	switch format {
+	case "", TextFormat, JSONFormat:
+		return nil
+	default:
+		return xerrors.Errorf("invalid log_format %q. Expected either %s or %s.", format, TextFormat, JSONFormat)
+	}
+}
+
+// InitializeTracing exports the spans of the process to its trace file in the
+// log directory.
+func InitializeTracing(process string) {
+	f, err := openInLogDir(func(logDir string) string {
+		return TracePath(logDir, process)
+	})
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(1)
+	}
+
+	trace.Initialize(f, process)
 }
 
 func OpenFile(process string) (*os.File, error) {
+	return openInLogDir(func(logDir string) string {
+		return LogPath(logDir, process)
+	})
+}
+
+func openInLogDir(path func(logDir string) string) (*os.File, error) {
 	logDir, err := utils.GetLogDir()
 	if err != nil {
 		fmt.Printf("\n%+v\n", err)
@@ -40,18 +101,21 @@ func OpenFile(process string) (*os.File, error) {
 		os.Exit(1)
 	}
 
-	return os.OpenFile(LogPath(logDir, process), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	return os.OpenFile(path(logDir), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 }
 
 func LogPath(logDir, process string) string {
 	return filepath.Join(logDir, fmt.Sprintf("%s_%s.log", process, time.Now().Format("20060102")))
 }
 
-// prefix has the form PROGRAMNAME:USERNAME:HOSTNAME:PID [LOGLEVEL]:
+func TracePath(logDir, process string) string {
+	return filepath.Join(logDir, fmt.Sprintf("%s_trace_%s.json", process, time.Now().Format("20060102")))
+}
+
+// prefix has the form PROGRAMNAME:USERNAME:HOSTNAME:PID
 func prefix() string {
 	currentUser, _ := user.Current()
 	host, _ := os.Hostname()
 
-	return fmt.Sprintf("gpupgrade:%s:%s:%06d [INFO]: ",
-		currentUser.Username, host, os.Getpid())
+	return fmt.Sprintf("gpupgrade:%s:%s:%06d", currentUser.Username, host, os.Getpid())
 }
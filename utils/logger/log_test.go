@@ -0,0 +1,261 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+	"regexp"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/greenplum-db/gpupgrade/utils/logger"
+)
+
+func TestNew(t *testing.T) {
+	logger.SetUpgradeID("ABC123")
+	logger.SetStep("initialize")
+	logger.SetSubstep("check_environment")
+	defer resetCorrelation()
+
+	t.Run("writes text records in the historical format followed by the attributes", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.New(&buf, "hub", logger.TextFormat)
+
+		log.Warn("checking segment", logger.ContentIDKey, 1)
+
+		expected := regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2} gpupgrade:.*:.*:\d{6} \[WARN\]: checking segment content_id=1 upgrade_id=ABC123 step=initialize substep=check_environment\n$`)
+		if !expected.Match(buf.Bytes()) {
+			t.Errorf("got %q want match %q", buf.String(), expected)
+		}
+	})
+
+	t.Run("quotes text values containing spaces", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.New(&buf, "hub", logger.TextFormat)
+
+		log.Error("substep failed", "error", "permission denied")
+
+		expected := regexp.MustCompile(`\[ERROR\]: substep failed error="permission denied" upgrade_id=`)
+		if !expected.Match(buf.Bytes()) {
+			t.Errorf("got %q want match %q", buf.String(), expected)
+		}
+	})
+
+	t.Run("writes json records with the correlation attributes", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.New(&buf, "agent", logger.JSONFormat)
+
+		log.With(logger.ContentIDKey, 2).Info("upgrading primary")
+
+		var record map[string]interface{}
+		err := json.Unmarshal(buf.Bytes(), &record)
+		if err != nil {
+			t.Fatalf("unexpected error %#v for %q", err, buf.String())
+		}
+
+		host, _ := os.Hostname()
+		expected := map[string]interface{}{
+			"level":             "INFO",
+			"msg":               "upgrading primary",
+			logger.ProcessKey:   "agent",
+			logger.HostKey:      host,
+			logger.PIDKey:       float64(os.Getpid()),
+			logger.UpgradeIDKey: "ABC123",
+			logger.StepKey:      "initialize",
+			logger.SubstepKey:   "check_environment",
+			logger.ContentIDKey: float64(2),
+		}
+
+		for key, value := range expected {
+			if record[key] != value {
+				t.Errorf("got %s %v want %v", key, record[key], value)
+			}
+		}
+	})
+
+	t.Run("omits empty correlation attributes", func(t *testing.T) {
+		logger.SetStep("")
+		logger.SetUpgradeID("")
+
+		var buf bytes.Buffer
+		log := logger.New(&buf, "cli", logger.TextFormat)
+
+		log.Info("starting")
+
+		expected := regexp.MustCompile(`\[INFO\]: starting\n$`)
+		if !expected.Match(buf.Bytes()) {
+			t.Errorf("got %q want match %q", buf.String(), expected)
+		}
+	})
+}
+
+func TestValidateFormat(t *testing.T) {
+	for _, format := range []string{"", logger.TextFormat, logger.JSONFormat} {
+		err := logger.ValidateFormat(format)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %#v", format, err)
+		}
+	}
+
+	err := logger.ValidateFormat("xml")
+	expected := `invalid log_format "xml". Expected either text or json.`
+	if err == nil || err.Error() != expected {
+		t.Errorf("got error %v want %q", err, expected)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	defer resetCorrelation()
+
+	t.Run("passes the hub's upgrade ID, step, and substep to the agent", func(t *testing.T) {
+		logger.SetUpgradeID("ABC123")
+		logger.SetStep("execute")
+
+		var md metadata.MD
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		}
+
+		ctx := logger.WithSubstep(context.Background(), "execute", "upgrade_primaries")
+		err := logger.UnaryClientInterceptor(ctx, "/idl.Agent/UpgradePrimaries", nil, nil, nil, invoker)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		// simulate the agent which starts without correlation attributes
+		resetCorrelation()
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			var buf bytes.Buffer
+			logger.New(&buf, "agent", logger.TextFormat).InfoContext(ctx, "upgrading")
+
+			expected := regexp.MustCompile(`upgrading upgrade_id=ABC123 step=execute substep=upgrade_primaries\n$`)
+			if !expected.Match(buf.Bytes()) {
+				t.Errorf("got %q want match %q", buf.String(), expected)
+			}
+
+			return nil, nil
+		}
+
+		_, err = logger.UnaryServerInterceptor(metadata.NewIncomingContext(context.Background(), md), nil, &grpc.UnaryServerInfo{}, handler)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("passes the substep of the request rather than of the process", func(t *testing.T) {
+		resetCorrelation()
+		logger.SetUpgradeID("ABC123")
+		logger.SetStep("finalize")
+		logger.SetSubstep("upgrade_mirrors,upgrade_standby")
+
+		substeps := make(chan []string, 3)
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			substeps <- md.Get("gpupgrade-substep")
+			return nil
+		}
+
+		for _, ctx := range []context.Context{
+			logger.WithSubstep(context.Background(), "finalize", "upgrade_mirrors"),
+			logger.WithSubstep(context.Background(), "finalize", "upgrade_standby"),
+			context.Background(), // such as a heartbeat
+		} {
+			err := logger.UnaryClientInterceptor(ctx, "/idl.Agent/Heartbeat", nil, nil, nil, invoker)
+			if err != nil {
+				t.Fatalf("unexpected error %#v", err)
+			}
+		}
+		close(substeps)
+
+		var actual []string
+		for substep := range substeps {
+			actual = append(actual, substep...)
+		}
+
+		expected := []string{"upgrade_mirrors", "upgrade_standby", ""}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("got substeps %q want %q", actual, expected)
+		}
+	})
+
+	t.Run("leaves the agent's correlation attributes unchanged without metadata", func(t *testing.T) {
+		resetCorrelation()
+		logger.SetUpgradeID("ABC123")
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			var buf bytes.Buffer
+			logger.New(&buf, "agent", logger.TextFormat).InfoContext(ctx, "stopping")
+
+			expected := regexp.MustCompile(`stopping upgrade_id=ABC123\n$`)
+			if !expected.Match(buf.Bytes()) {
+				t.Errorf("got %q want match %q", buf.String(), expected)
+			}
+
+			return nil, nil
+		}
+
+		_, err := logger.UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("correlates concurrent requests with their own step and substep", func(t *testing.T) {
+		resetCorrelation()
+
+		// Both requests are handled before either logs such that each would
+		// log the substep of the other if they shared the process's values.
+		var handling sync.WaitGroup
+		handling.Add(2)
+
+		request := func(substep string) <-chan string {
+			done := make(chan string, 1)
+
+			go func() {
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					handling.Done()
+					handling.Wait()
+
+					var buf bytes.Buffer
+					logger.New(&buf, "agent", logger.TextFormat).InfoContext(ctx, "starting")
+					done <- buf.String()
+					return nil, nil
+				}
+
+				md := metadata.Pairs("gpupgrade-upgrade-id", "ABC123", "gpupgrade-step", "finalize", "gpupgrade-substep", substep)
+				_, err := logger.UnaryServerInterceptor(metadata.NewIncomingContext(context.Background(), md), nil, &grpc.UnaryServerInfo{}, handler)
+				if err != nil {
+					t.Errorf("unexpected error %#v", err)
+				}
+			}()
+
+			return done
+		}
+
+		mirrors := request("upgrade_mirrors")
+		standby := request("upgrade_standby")
+
+		for substep, done := range map[string]<-chan string{"upgrade_mirrors": mirrors, "upgrade_standby": standby} {
+			line := <-done
+			expected := regexp.MustCompile(`starting upgrade_id=ABC123 step=finalize substep=` + substep + `\n$`)
+			if !expected.MatchString(line) {
+				t.Errorf("got %q want match %q", line, expected)
+			}
+		}
+	})
+}
+
+func resetCorrelation() {
+	logger.SetUpgradeID("")
+	logger.SetStep("")
+}
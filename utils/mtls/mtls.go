@@ -0,0 +1,149 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mtls configures mutual TLS for the gRPC connections between the
+// CLI, hub, and agents.
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Config holds the paths to the certificate, private key, and certificate
+// authority used by the hub and agents. The certificate is presented both when
+// serving and when connecting, and the peer's certificate must be signed by the
+// certificate authority. When no paths are set connections are insecure.
+type Config struct {
+	CertFile string
+	KeyFile  string
+	CAFile   string
+}
+
+func (c Config) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.CAFile != ""
+}
+
+// Validate ensures either none or all of the paths are set, and that the
+// certificate, key, and certificate authority can be loaded.
+func (c Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	if c.CertFile == "" || c.KeyFile == "" || c.CAFile == "" {
+		return xerrors.New("tls_cert_file, tls_key_file, and tls_ca_file must all be set to enable TLS")
+	}
+
+	_, _, err := c.load()
+	return err
+}
+
+// ServerCredentials returns the credentials to serve with requiring clients
+// to present a certificate signed by the certificate authority.
+func (c Config) ServerCredentials() (credentials.TransportCredentials, error) {
+	config, err := c.ServerTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		return insecure.NewCredentials(), nil
+	}
+
+	return credentials.NewTLS(config), nil
+}
+
+// ServerTLSConfig returns the configuration of ServerCredentials for servers
+// other than gRPC such as the hub's metrics. It is nil when TLS is not enabled.
+func (c Config) ServerTLSConfig() (*tls.Config, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+
+	cert, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ClientCredentials returns the credentials to connect with requiring the
+// server to present a certificate signed by the certificate authority for the
+// host being connected to.
+func (c Config) ClientCredentials() (credentials.TransportCredentials, error) {
+	config, err := c.ClientTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		return insecure.NewCredentials(), nil
+	}
+
+	return credentials.NewTLS(config), nil
+}
+
+// ClientTLSConfig returns the configuration of ClientCredentials for clients
+// other than gRPC such as those scraping the hub's metrics. It is nil when TLS
+// is not enabled.
+func (c Config) ClientTLSConfig() (*tls.Config, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+
+	cert, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// Args returns the command line arguments used to pass the configuration to
+// the agent.
+func (c Config) Args() []string {
+	if !c.Enabled() {
+		return nil
+	}
+
+	return []string{
+		"--tls-cert-file", c.CertFile,
+		"--tls-key-file", c.KeyFile,
+		"--tls-ca-file", c.CAFile,
+	}
+}
+
+func (c Config) load() (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, xerrors.Errorf("load certificate %q and key %q: %w", c.CertFile, c.KeyFile, err)
+	}
+
+	ca, err := os.ReadFile(c.CAFile)
+	if err != nil {
+		return tls.Certificate{}, nil, xerrors.Errorf("read certificate authority: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return tls.Certificate{}, nil, xerrors.Errorf("no certificates found in certificate authority %q", c.CAFile)
+	}
+
+	return cert, pool, nil
+}
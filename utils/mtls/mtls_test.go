@@ -0,0 +1,156 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package mtls_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+)
+
+func TestConfig(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	conf := testutils.MustCreateCertificates(t, dir)
+
+	t.Run("validates a complete configuration", func(t *testing.T) {
+		err := conf.Validate()
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("an empty configuration is valid and not enabled", func(t *testing.T) {
+		err := mtls.Config{}.Validate()
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		if (mtls.Config{}).Enabled() {
+			t.Errorf("expected empty configuration to not be enabled")
+		}
+
+		if args := (mtls.Config{}).Args(); args != nil {
+			t.Errorf("got args %q want none", args)
+		}
+	})
+
+	t.Run("errors when the configuration is incomplete", func(t *testing.T) {
+		err := mtls.Config{CertFile: conf.CertFile, KeyFile: conf.KeyFile}.Validate()
+		expected := "tls_cert_file, tls_key_file, and tls_ca_file must all be set to enable TLS"
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+
+	t.Run("errors when the certificate authority does not exist", func(t *testing.T) {
+		invalid := conf
+		invalid.CAFile = "/does/not/exist"
+
+		err := invalid.Validate()
+		var pathErr *os.PathError
+		if !errors.As(err, &pathErr) {
+			t.Errorf("got error %#v want type %T", err, pathErr)
+		}
+	})
+
+	t.Run("errors when the certificate authority contains no certificates", func(t *testing.T) {
+		invalid := conf
+		invalid.CAFile = invalid.KeyFile
+
+		err := invalid.Validate()
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+
+	t.Run("clients with a certificate signed by the certificate authority can connect", func(t *testing.T) {
+		address := mustServe(t, conf)
+
+		creds, err := conf.ClientCredentials()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		err = check(address, creds)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("clients without a certificate cannot connect", func(t *testing.T) {
+		address := mustServe(t, conf)
+
+		err := check(address, insecure.NewCredentials())
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+
+	t.Run("clients with a certificate signed by another certificate authority cannot connect", func(t *testing.T) {
+		address := mustServe(t, conf)
+
+		otherDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, otherDir)
+
+		other := testutils.MustCreateCertificates(t, otherDir)
+		creds, err := other.ClientCredentials()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		err = check(address, creds)
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
+
+func mustServe(t *testing.T, conf mtls.Config) string {
+	t.Helper()
+
+	creds, err := conf.ServerCredentials()
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	server := grpc.NewServer(grpc.Creds(creds))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(listener) //nolint
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func check(address string, creds credentials.TransportCredentials) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
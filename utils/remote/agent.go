@@ -0,0 +1,79 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+)
+
+// Agent runs commands through the ExecCommand RPC of the gpupgrade agent
+// already running on the host. Agents only run an allowlisted set of
+// commands. Since an agent cannot be used to start itself the agents must
+// already be running such as when started by a service manager. Commands for
+// the hub's host, which has no agent when it has no segments, are run
+// locally.
+type Agent struct {
+	conns     func() ([]*idl.Connection, error)
+	local     Executor
+	port      int
+	tlsConfig mtls.Config
+}
+
+func NewAgent(conns func() ([]*idl.Connection, error), port int, tlsConfig mtls.Config) Agent {
+	return Agent{conns: conns, local: Local{}, port: port, tlsConfig: tlsConfig}
+}
+
+func (a Agent) Output(host string, name string, args ...string) ([]byte, error) {
+	conns, err := a.conns()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, conn := range conns {
+		if conn.Hostname != host {
+			continue
+		}
+
+		log.Printf("Executing on agent %s: %q", host, commandLine(name, args))
+		reply, err := conn.AgentClient.ExecCommand(context.Background(), &idl.ExecCommandRequest{Name: name, Args: args})
+		if err != nil {
+			return nil, xerrors.Errorf("%q on host %s: %w", commandLine(name, args), host, err)
+		}
+
+		log.Printf("Output: %q", reply.GetOutput())
+		return reply.GetOutput(), nil
+	}
+
+	hostname, err := utils.System.Hostname()
+	if err != nil {
+		return nil, err
+	}
+
+	if host == hostname {
+		return a.local.Output(host, name, args...)
+	}
+
+	return nil, xerrors.Errorf("no agent connection to host %s", host)
+}
+
+// Shell runs the rsync server on the destination host through the agent's
+// RemoteShell such that rsync does not need ssh. It is used both by the hub
+// and by agents copying to other hosts.
+func (a Agent) Shell() ([]string, error) {
+	path, err := utils.GetGpupgradePath()
+	if err != nil {
+		return nil, err
+	}
+
+	shell := []string{path, "remote-shell", "--port", strconv.Itoa(a.port)}
+	return append(shell, a.tlsConfig.Args()...), nil
+}
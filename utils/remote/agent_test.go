@@ -0,0 +1,141 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package remote_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
+)
+
+func TestAgent(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	utils.System.Hostname = func() (string, error) {
+		return "coordinator", nil
+	}
+	defer utils.ResetSystemFunctions()
+
+	t.Run("runs the command using the agent on the host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().ExecCommand(
+			gomock.Any(),
+			&idl.ExecCommandRequest{Name: "echo", Args: []string{"$PATH"}},
+		).Return(&idl.ExecCommandReply{Output: []byte(output)}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		// NOTE: we expect no call to sdw2
+
+		executor := remote.NewAgent(func() ([]*idl.Connection, error) {
+			return []*idl.Connection{
+				{AgentClient: sdw1, Hostname: "sdw1"},
+				{AgentClient: sdw2, Hostname: "sdw2"},
+			}, nil
+		}, 0, mtls.Config{})
+
+		actual, err := executor.Output("sdw1", "echo", "$PATH")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if string(actual) != output {
+			t.Errorf("got %q want %q", actual, output)
+		}
+	})
+
+	t.Run("runs the command locally for the hub's host without an agent", func(t *testing.T) {
+		resetEnv := testutils.SetEnv(t, "LD_LIBRARY_PATH", "/usr/local/lib")
+		defer resetEnv()
+
+		executor := remote.NewAgent(func() ([]*idl.Connection, error) {
+			return nil, nil
+		}, 0, mtls.Config{})
+
+		actual, err := executor.Output("coordinator", "echo", "$LD_LIBRARY_PATH")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := "/usr/local/lib\n"
+		if string(actual) != expected {
+			t.Errorf("got %q want %q", actual, expected)
+		}
+	})
+
+	t.Run("errors when there is no agent connection to the host", func(t *testing.T) {
+		executor := remote.NewAgent(func() ([]*idl.Connection, error) {
+			return nil, nil
+		}, 0, mtls.Config{})
+
+		_, err := executor.Output("sdw1", "echo", "$PATH")
+		expected := "no agent connection to host sdw1"
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+
+	t.Run("errors when the agent connections cannot be retrieved", func(t *testing.T) {
+		expected := errors.New("permission denied")
+		executor := remote.NewAgent(func() ([]*idl.Connection, error) {
+			return nil, expected
+		}, 0, mtls.Config{})
+
+		_, err := executor.Output("sdw1", "echo", "$PATH")
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
+	t.Run("errors when the agent fails to run the command", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().ExecCommand(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(nil, expected)
+
+		executor := remote.NewAgent(func() ([]*idl.Connection, error) {
+			return []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}, nil
+		}, 0, mtls.Config{})
+
+		_, err := executor.Output("sdw1", "echo", "$PATH")
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
+	t.Run("uses the remote shell of the agents rather than ssh", func(t *testing.T) {
+		tlsConfig := mtls.Config{CertFile: "cert.pem", KeyFile: "key.pem", CAFile: "ca.pem"}
+		shell, err := remote.NewAgent(nil, 6416, tlsConfig).Shell()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		path, err := utils.GetGpupgradePath()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := []string{path, "remote-shell", "--port", "6416", "--tls-cert-file", "cert.pem", "--tls-key-file", "key.pem", "--tls-ca-file", "ca.pem"}
+		if !reflect.DeepEqual(shell, expected) {
+			t.Errorf("got shell %q want %q", shell, expected)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"log"
+	"os/exec"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// Local runs commands for every host on this machine. It is used to test the
+// multi-host flow on a single machine where all hosts resolve to localhost.
+type Local struct {
+	// Command defaults to exec.Command and is overridden for internal
+	// testing.
+	Command exectest.Command
+}
+
+func (l Local) Output(host string, name string, args ...string) ([]byte, error) {
+	command := l.Command
+	if command == nil {
+		command = exec.Command
+	}
+
+	cmd := command("bash", "-c", commandLine(name, args))
+	log.Printf("Executing for host %s: %q", host, cmd.String())
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return output, xerrors.Errorf("%q failed with %q: %w", cmd.String(), string(output), err)
+	}
+
+	log.Printf("Output: %q", output)
+	return output, nil
+}
+
+// Shell runs the rsync of every host on this machine.
+func (l Local) Shell() ([]string, error) {
+	path, err := utils.GetGpupgradePath()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{path, "remote-shell", "--local"}, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package remote runs commands on the hosts of the cluster and provides the
+// remote shell rsync uses to copy to them. By default both use ssh.
+// Alternatively, they can be run through the already running gpupgrade agents
+// for hosts where only the agent port is reachable, or locally to exercise a
+// multi-host flow on a single machine.
+package remote
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+)
+
+const (
+	SSHExecutor   = "ssh"
+	AgentExecutor = "agent"
+	LocalExecutor = "local"
+)
+
+// Executor runs a command on a host returning its combined stdout and
+// stderr. Environment variables in the arguments such as $PATH are expanded
+// on the host.
+type Executor interface {
+	Output(host string, name string, args ...string) ([]byte, error)
+
+	// Shell returns the remote shell rsync uses to reach the hosts given
+	// with its --rsh option. It is empty for ssh which rsync uses by
+	// default.
+	Shell() ([]string, error)
+}
+
+// ValidateExecutor ensures the name is a known executor. An empty name
+// defaults to ssh.
+func ValidateExecutor(name string) error {
+	switch name {
+	case "", SSHExecutor, AgentExecutor, LocalExecutor:
+		return nil
+	default:
+		return xerrors.Errorf("invalid remote_executor %q. Expected one of %s, %s, or %s.", name, SSHExecutor, AgentExecutor, LocalExecutor)
+	}
+}
+
+// New returns the executor for the given name. The agent executor uses conns
+// to lazily retrieve the agent connections since the agents may not be
+// running when the executor is created. The agent port and TLS configuration
+// are passed to the remote shell to connect to the agents.
+func New(name string, conns func() ([]*idl.Connection, error), agentPort int, tlsConfig mtls.Config) Executor {
+	switch name {
+	case AgentExecutor:
+		return NewAgent(conns, agentPort, tlsConfig)
+	case LocalExecutor:
+		return Local{}
+	default:
+		return SSH{}
+	}
+}
+
+func commandLine(name string, args []string) string {
+	return strings.Join(append([]string{name}, args...), " ")
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package remote_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils/mtls"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
+)
+
+const output = "some output\n"
+
+func Success() {
+	fmt.Print(output)
+}
+
+func Failure() {
+	os.Stderr.WriteString("oops")
+	os.Exit(1)
+}
+
+func init() {
+	exectest.RegisterMains(
+		Success,
+		Failure,
+	)
+}
+
+// Enable exectest.NewCommand mocking.
+func TestMain(m *testing.M) {
+	os.Exit(exectest.Run(m))
+}
+
+func TestSSH(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("runs the command on the host using bash", func(t *testing.T) {
+		command := exectest.NewCommandWithVerifier(Success, func(name string, args ...string) {
+			if name != "ssh" {
+				t.Errorf("got %q want ssh", name)
+			}
+
+			expected := []string{"-q", "sdw1", `bash -c "echo $PATH"`}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got %q want %q", args, expected)
+			}
+		})
+
+		actual, err := remote.SSH{Command: command}.Output("sdw1", "echo", "$PATH")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if string(actual) != output {
+			t.Errorf("got %q want %q", actual, output)
+		}
+	})
+
+	t.Run("returns the output when the command fails", func(t *testing.T) {
+		_, err := remote.SSH{Command: exectest.NewCommand(Failure)}.Output("sdw1", "echo", "$PATH")
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			t.Fatalf("got %#v want ExitError with code 1", err)
+		}
+
+		expected := `failed with "oops": exit status 1`
+		if !strings.HasSuffix(err.Error(), expected) {
+			t.Errorf("got %q want suffix %q", err.Error(), expected)
+		}
+	})
+}
+
+func TestShell(t *testing.T) {
+	shell, err := remote.SSH{}.Shell()
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	if shell != nil {
+		t.Errorf("got shell %q want rsync's default of ssh", shell)
+	}
+}
+
+func TestLocal(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("runs the command on this machine expanding environment variables", func(t *testing.T) {
+		resetEnv := testutils.SetEnv(t, "LD_LIBRARY_PATH", "/usr/local/lib")
+		defer resetEnv()
+
+		actual, err := remote.Local{}.Output("sdw1", "echo", "$LD_LIBRARY_PATH")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := "/usr/local/lib\n"
+		if string(actual) != expected {
+			t.Errorf("got %q want %q", actual, expected)
+		}
+	})
+
+	t.Run("returns an error when the command fails", func(t *testing.T) {
+		_, err := remote.Local{Command: exectest.NewCommand(Failure)}.Output("sdw1", "false")
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			t.Errorf("got %#v want ExitError with code 1", err)
+		}
+	})
+}
+
+func TestValidateExecutor(t *testing.T) {
+	for _, name := range []string{"", remote.SSHExecutor, remote.AgentExecutor, remote.LocalExecutor} {
+		err := remote.ValidateExecutor(name)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %#v", name, err)
+		}
+	}
+
+	err := remote.ValidateExecutor("rsh")
+	expected := `invalid remote_executor "rsh". Expected one of ssh, agent, or local.`
+	if err == nil || err.Error() != expected {
+		t.Errorf("got error %v want %q", err, expected)
+	}
+}
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected remote.Executor
+	}{
+		{name: "", expected: remote.SSH{}},
+		{name: remote.SSHExecutor, expected: remote.SSH{}},
+		{name: remote.LocalExecutor, expected: remote.Local{}},
+	}
+
+	for _, c := range cases {
+		actual := remote.New(c.name, nil, 0, mtls.Config{})
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("got %#v want %#v for %q", actual, c.expected, c.name)
+		}
+	}
+
+	if _, ok := remote.New(remote.AgentExecutor, nil, 0, mtls.Config{}).(remote.Agent); !ok {
+		t.Errorf("expected agent executor")
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+// RunShell runs the command through the RemoteShell of the agent relaying
+// stdin, stdout, and stderr returning its exit code. It is run by the remote
+// shell given to rsync such that the rsync protocol is carried over the agent
+// connection rather than ssh.
+func RunShell(ctx context.Context, client idl.AgentClient, args []string, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.RemoteShell(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := stream.Send(&idl.RemoteShellRequest{Args: args}); err != nil {
+		return 0, err
+	}
+
+	go func() {
+		buffer := make([]byte, 32*1024)
+		for {
+			n, err := stdin.Read(buffer)
+			if n > 0 {
+				// Copy the buffer since it is reused by the next read.
+				chunk := make([]byte, n)
+				copy(chunk, buffer[:n])
+				if sErr := stream.Send(&idl.RemoteShellRequest{Stdin: chunk}); sErr != nil {
+					return
+				}
+			}
+
+			if err != nil {
+				stream.CloseSend() //nolint
+				return
+			}
+		}
+	}()
+
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			return 0, xerrors.Errorf("remote shell %q closed before the command exited", args)
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err := stdout.Write(reply.GetStdout()); err != nil {
+			return 0, err
+		}
+
+		if _, err := stderr.Write(reply.GetStderr()); err != nil {
+			return 0, err
+		}
+
+		if reply.GetExited() {
+			return int(reply.GetExitCode()), nil
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package remote_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/utils/remote"
+)
+
+func TestRunShell(t *testing.T) {
+	args := []string{"rsync", "--server", "-logDtpre.iLsfxC", ".", "/data/primary"}
+
+	t.Run("relays stdin, stdout, and stderr returning the exit code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stdinSent := make(chan struct{})
+
+		stream := mock_idl.NewMockAgent_RemoteShellClient(ctrl)
+		gomock.InOrder(
+			stream.EXPECT().Send(&idl.RemoteShellRequest{Args: args}),
+			stream.EXPECT().Send(&idl.RemoteShellRequest{Stdin: []byte("protocol")}),
+			stream.EXPECT().CloseSend().Do(func() { close(stdinSent) }),
+		)
+
+		gomock.InOrder(
+			stream.EXPECT().Recv().Return(&idl.RemoteShellReply{Stdout: []byte("file list")}, nil),
+			stream.EXPECT().Recv().Return(&idl.RemoteShellReply{Stderr: []byte("warning")}, nil),
+			stream.EXPECT().Recv().DoAndReturn(func() (*idl.RemoteShellReply, error) {
+				<-stdinSent
+				return &idl.RemoteShellReply{Exited: true, ExitCode: 23}, nil
+			}),
+		)
+
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().RemoteShell(gomock.Any()).Return(stream, nil)
+
+		var stdout, stderr bytes.Buffer
+		code, err := remote.RunShell(context.Background(), client, args, strings.NewReader("protocol"), &stdout, &stderr)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if code != 23 {
+			t.Errorf("got exit code %d want %d", code, 23)
+		}
+
+		if stdout.String() != "file list" {
+			t.Errorf("got stdout %q want %q", stdout.String(), "file list")
+		}
+
+		if stderr.String() != "warning" {
+			t.Errorf("got stderr %q want %q", stderr.String(), "warning")
+		}
+	})
+
+	t.Run("errors when the stream closes before the command exits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stream := mock_idl.NewMockAgent_RemoteShellClient(ctrl)
+		stream.EXPECT().Send(gomock.Any()).AnyTimes()
+		stream.EXPECT().CloseSend().AnyTimes()
+		stream.EXPECT().Recv().Return(nil, io.EOF)
+
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().RemoteShell(gomock.Any()).Return(stream, nil)
+
+		_, err := remote.RunShell(context.Background(), client, args, strings.NewReader(""), io.Discard, io.Discard)
+		if err == nil || !strings.Contains(err.Error(), "closed before the command exited") {
+			t.Errorf("got error %v want the stream to have closed", err)
+		}
+	})
+
+	t.Run("errors when the remote shell cannot be started", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("connection refused")
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().RemoteShell(gomock.Any()).Return(nil, expected)
+
+		_, err := remote.RunShell(context.Background(), client, args, strings.NewReader(""), io.Discard, io.Discard)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
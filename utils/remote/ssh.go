@@ -0,0 +1,43 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+)
+
+// SSH runs commands using ssh. The -q flag suppresses motd banner messages
+// from polluting the output.
+type SSH struct {
+	// Command defaults to exec.Command and is overridden for internal
+	// testing.
+	Command exectest.Command
+}
+
+func (s SSH) Output(host string, name string, args ...string) ([]byte, error) {
+	command := s.Command
+	if command == nil {
+		command = exec.Command
+	}
+
+	cmd := command("ssh", "-q", host, fmt.Sprintf(`bash -c "%s"`, commandLine(name, args)))
+	log.Printf("Executing: %q", cmd.String())
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return output, xerrors.Errorf("%q failed with %q: %w", cmd.String(), string(output), err)
+	}
+
+	log.Printf("Output: %q", output)
+	return output, nil
+}
+
+func (s SSH) Shell() ([]string, error) {
+	return nil, nil
+}
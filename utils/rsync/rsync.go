@@ -4,14 +4,25 @@
 package rsync
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
 	"log"
 	"os/exec"
+	"regexp"
 	"runtime"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/utils"
 )
 
 var Options = []string{"--archive", "--compress", "--stats"}
@@ -36,6 +47,65 @@ var ErrInvalidRsyncSourcePath = errors.New("multiple remote source path passed")
 func Rsync(options ...Option) error {
 	opts := newOptionList(options...)
 
+	utility, args, err := Command(options...)
+	if err != nil {
+		return err
+	}
+
+	cmd := rsyncCommand(utility, args...)
+
+	// when no streams are specified, capture stderr for the error message
+	stream := step.BufferedStreams{}
+	cmd.Stderr = stream.Stderr()
+	if opts.useStream {
+		cmd.Stdout = opts.stream.Stdout()
+		cmd.Stderr = opts.stream.Stderr()
+	}
+
+	// capture stdout to parse the stats while still writing to any stream
+	var stdout bytes.Buffer
+	if opts.stats != nil {
+		cmd.Stdout = &stdout
+		if opts.useStream {
+			cmd.Stdout = io.MultiWriter(&stdout, opts.stream.Stdout())
+		}
+	}
+
+	log.Printf("Executing: %q", cmd.String())
+
+	start := time.Now()
+	err = utils.RunCommand(opts.ctx, cmd)
+	if err != nil {
+		errorText := err.Error()
+
+		// bubble up the rsync error with the underlying cause
+		if !opts.useStream && stream.StderrBuf.String() != "" {
+			errorText = stream.StderrBuf.String()
+		}
+
+		return RsyncError{errorText: errorText, err: err}
+	}
+
+	if opts.stats != nil {
+		stats, err := ParseStats(stdout.String())
+		if err != nil {
+			// The stats are informational so do not fail the transfer.
+			log.Printf("parsing rsync stats: %v", err)
+			return nil
+		}
+
+		stats.Elapsed = time.Since(start)
+		opts.stats.Add(stats)
+	}
+
+	return nil
+}
+
+// Command returns the rsync utility and arguments for the given options
+// without executing it.
+func Command(options ...Option) (string, []string, error) {
+	opts := newOptionList(options...)
+
 	dstPath := opts.destination
 	if opts.hasDestinationHost {
 		dstPath = opts.destinationHost + ":" + opts.destination
@@ -46,13 +116,16 @@ func Rsync(options ...Option) error {
 		// can't make an assumption what is required here
 		// i.e host:path1 path2 or host:path1 host:path2
 		if len(opts.sources) != 1 {
-			return ErrInvalidRsyncSourcePath
+			return "", nil, ErrInvalidRsyncSourcePath
 		}
 		srcPath = []string{opts.sourceHost + ":" + opts.sources[0]}
 	}
 
 	var args []string
 	args = append(args, opts.options...)
+	if opts.stats != nil && !contains(opts.options, "--stats") {
+		args = append(args, "--stats")
+	}
 	args = append(args, srcPath...)
 	args = append(args, dstPath)
 	args = append(args, opts.excludedFiles...)
@@ -66,31 +139,186 @@ func Rsync(options ...Option) error {
 		utility = "/usr/local/bin/rsync"
 	}
 
-	cmd := rsyncCommand(utility, args...)
+	return utility, args, nil
+}
 
-	// when no streams are specified, capture stderr for the error message
-	stream := step.BufferedStreams{}
-	cmd.Stderr = stream.Stderr()
-	if opts.useStream {
-		cmd.Stdout = opts.stream.Stdout()
-		cmd.Stderr = opts.stream.Stderr()
+// Verify compares the sources with the destination without transferring any
+// files by running rsync in dry run mode with the given options. Files are
+// compared by size unless WithChecksum is given. It returns a VerifyError
+// listing the files that differ such as those missing or with a different
+// size.
+func Verify(options ...Option) error {
+	verifyOptions := append([]Option{}, options...)
+	verifyOptions = append(verifyOptions, WithOptions("--dry-run", "--itemize-changes"))
+	if !newOptionList(options...).checksum {
+		verifyOptions = append(verifyOptions, WithOptions("--size-only"))
 	}
 
-	log.Printf("Executing: %q", cmd.String())
+	stream := &step.BufferedStreams{}
+	verifyOptions = append(verifyOptions, WithStream(stream), WithStats(nil))
 
-	err := cmd.Run()
+	err := Rsync(verifyOptions...)
 	if err != nil {
-		errorText := err.Error()
+		return err
+	}
 
-		// bubble up the rsync error with the underlying cause
-		if !opts.useStream && stream.StderrBuf.String() != "" {
-			errorText = stream.StderrBuf.String()
+	files := changedFiles(stream.StdoutBuf.String())
+	if len(files) > 0 {
+		opts := newOptionList(options...)
+		return VerifyError{Sources: opts.sources, Destination: opts.destination, Files: files}
+	}
+
+	return nil
+}
+
+// itemizedChange matches the --itemize-changes output of files that would be
+// transferred, created, or deleted. Lines starting with "." only differ in
+// their attributes and are ignored.
+var itemizedChange = regexp.MustCompile(`^(?:[<>ch][fdLDS]\S*|\*deleting)\s+(.+)$`)
+
+func changedFiles(output string) []string {
+	var files []string
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		match := itemizedChange.FindStringSubmatch(scanner.Text())
+		if match != nil {
+			files = append(files, match[1])
 		}
+	}
 
-		return RsyncError{errorText: errorText, err: err}
+	return files
+}
+
+type VerifyError struct {
+	Sources     []string
+	Destination string
+	Files       []string
+}
+
+func (e VerifyError) Error() string {
+	return fmt.Sprintf("verifying %q with destination %q found %d differing files: %s",
+		e.Sources, e.Destination, len(e.Files), strings.Join(e.Files, ", "))
+}
+
+// Settings configures the rsync transfers of a phase.
+type Settings struct {
+	// BandwidthLimit is the maximum transfer rate in KiB per second. Zero is
+	// unlimited.
+	BandwidthLimit uint
+
+	// Checksum compares files by checksum rather than size and modification
+	// time when deciding what to transfer and verify.
+	Checksum bool
+
+	// Partial keeps partially transferred files such that re-running resumes
+	// rather than starting over.
+	Partial bool
+
+	// Verify compares the sources with the destination after transferring
+	// and fails on any difference.
+	Verify bool
+
+	// RemoteShell is the command used to reach the destination host. It is
+	// set from the remote executor rather than configured. Empty is ssh.
+	RemoteShell string
+}
+
+// Options returns the options for the settings. Verify is not an option since
+// it is run after transferring using Verify.
+func (s Settings) Options() []Option {
+	var options []Option
+	if s.BandwidthLimit > 0 {
+		options = append(options, WithBandwidthLimit(s.BandwidthLimit))
 	}
 
-	return nil
+	if s.Checksum {
+		options = append(options, WithChecksum())
+	}
+
+	if s.Partial {
+		options = append(options, WithPartial())
+	}
+
+	if s.RemoteShell != "" {
+		options = append(options, WithRemoteShell(s.RemoteShell))
+	}
+
+	return options
+}
+
+// Rsync runs rsync with the options and settings. When Verify is set the
+// sources are then compared with the destination.
+func (s Settings) Rsync(options ...Option) error {
+	options = append(append([]Option{}, options...), s.Options()...)
+
+	err := Rsync(options...)
+	if err != nil {
+		return err
+	}
+
+	if !s.Verify {
+		return nil
+	}
+
+	return Verify(options...)
+}
+
+// RequestSettings returns the settings of rsync options sent from the hub to
+// an agent.
+func RequestSettings(opt *idl.RsyncRequest_RsyncOptions) Settings {
+	return Settings{
+		BandwidthLimit: uint(opt.GetBandwidthLimit()),
+		Checksum:       opt.GetChecksum(),
+		Partial:        opt.GetPartial(),
+		Verify:         opt.GetVerify(),
+		RemoteShell:    opt.GetRemoteShell(),
+	}
+}
+
+// SetRequestSettings sets the settings on each of the rsync options sent from
+// the hub to an agent.
+func SetRequestSettings(opts []*idl.RsyncRequest_RsyncOptions, settings Settings) []*idl.RsyncRequest_RsyncOptions {
+	for _, opt := range opts {
+		opt.BandwidthLimit = uint32(settings.BandwidthLimit)
+		opt.Checksum = settings.Checksum
+		opt.Partial = settings.Partial
+		opt.Verify = settings.Verify
+		opt.RemoteShell = settings.RemoteShell
+	}
+
+	return opts
+}
+
+// ParseSettings parses a comma separated list of settings such as
+// "bandwidth_limit=10000,checksum,partial,verify". An empty string returns the
+// default settings.
+func ParseSettings(input string) (Settings, error) {
+	var settings Settings
+	if strings.TrimSpace(input) == "" {
+		return settings, nil
+	}
+
+	for _, setting := range strings.Split(input, ",") {
+		name, value, hasValue := strings.Cut(strings.TrimSpace(setting), "=")
+		switch {
+		case name == "bandwidth_limit" && hasValue:
+			limit, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+			if err != nil {
+				return Settings{}, fmt.Errorf("invalid rsync bandwidth_limit %q. Expected KiB per second.", value)
+			}
+			settings.BandwidthLimit = uint(limit)
+		case name == "checksum" && !hasValue:
+			settings.Checksum = true
+		case name == "partial" && !hasValue:
+			settings.Partial = true
+		case name == "verify" && !hasValue:
+			settings.Verify = true
+		default:
+			return Settings{}, fmt.Errorf("invalid rsync setting %q. Expected bandwidth_limit=<KiB per second>, checksum, partial, or verify.", setting)
+		}
+	}
+
+	return settings, nil
 }
 
 // XXX: for internal testing only
@@ -157,6 +385,69 @@ func WithExcludedFiles(files ...string) Option {
 	}
 }
 
+// WithBandwidthLimit limits the transfer rate to the given KiB per second.
+func WithBandwidthLimit(kbps uint) Option {
+	return func(options *optionList) {
+		options.options = append(options.options, fmt.Sprintf("--bwlimit=%d", kbps))
+	}
+}
+
+// WithChecksum compares files by checksum rather than size and modification
+// time.
+func WithChecksum() Option {
+	return func(options *optionList) {
+		options.checksum = true
+		options.options = append(options.options, "--checksum")
+	}
+}
+
+// WithPartial keeps partially transferred files such that a subsequent
+// transfer resumes them.
+func WithPartial() Option {
+	return func(options *optionList) {
+		options.options = append(options.options, "--partial")
+	}
+}
+
+// WithRemoteShell reaches the source or destination host using the command
+// rather than ssh. rsync runs it with the host followed by the rsync server
+// command to run on the host.
+func WithRemoteShell(shell string) Option {
+	return func(options *optionList) {
+		options.options = append(options.options, "--rsh="+shell)
+	}
+}
+
+// RemoteShell returns the remote shell of the arguments quoting those
+// containing spaces since rsync splits the remote shell on spaces.
+func RemoteShell(shell []string) string {
+	var args []string
+	for _, arg := range shell {
+		if strings.ContainsAny(arg, " \t'") {
+			arg = `"` + arg + `"`
+		}
+
+		args = append(args, arg)
+	}
+
+	return strings.Join(args, " ")
+}
+
+// WithStats adds the stats of the transfer to the collector. It passes --stats
+// to rsync if not already given. A nil collector does not collect the stats.
+func WithStats(collector *Collector) Option {
+	return func(options *optionList) {
+		options.stats = collector
+	}
+}
+
+// WithContext terminates rsync when the context is cancelled.
+func WithContext(ctx context.Context) Option {
+	return func(options *optionList) {
+		options.ctx = ctx
+	}
+}
+
 func WithStream(stream step.OutStreams) Option {
 	return func(options *optionList) {
 		options.stream = stream
@@ -172,15 +463,28 @@ type optionList struct {
 	destinationHost    string
 	destination        string
 	options            []string
+	checksum           bool
 	excludedFiles      []string
 	useStream          bool
 	stream             step.OutStreams
+	stats              *Collector
+	ctx                context.Context
 }
 
 func newOptionList(opts ...Option) *optionList {
-	o := new(optionList)
+	o := &optionList{ctx: context.Background()}
 	for _, option := range opts {
 		option(o)
 	}
 	return o
 }
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
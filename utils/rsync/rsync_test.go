@@ -4,10 +4,13 @@
 package rsync_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -20,9 +23,26 @@ import (
 
 func Success() {}
 
+func InSync() {
+	fmt.Println(".d..t...... ./")
+	fmt.Println("")
+	fmt.Println("Number of files: 2 (reg: 1, dir: 1)")
+}
+
+func OutOfSync() {
+	fmt.Println(">f.st...... base/1/1234")
+	fmt.Println("cd+++++++++ pg_tblspc/")
+	fmt.Println("*deleting   global/pg_control.old")
+	fmt.Println(".f..t...... postgresql.conf")
+	fmt.Println("")
+	fmt.Println("Number of files: 4 (reg: 3, dir: 1)")
+}
+
 func init() {
 	exectest.RegisterMains(
 		Success,
+		InSync,
+		OutOfSync,
 	)
 }
 
@@ -394,4 +414,154 @@ func TestRsync(t *testing.T) {
 			t.Errorf("got error '%#v' want '%#v'", err, rsync.ErrInvalidRsyncSourcePath)
 		}
 	})
+
+}
+
+func TestRsyncWithContext(t *testing.T) {
+	t.Run("errors once the context is cancelled", func(t *testing.T) {
+		rsync.SetRsyncCommand(exectest.NewCommand(Success))
+		defer rsync.ResetRsyncCommand()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := rsync.Rsync(rsync.WithSources("/data/seg0/"), rsync.WithDestination("/data/seg1"), rsync.WithContext(ctx))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got error %#v want %#v", err, context.Canceled)
+		}
+	})
+}
+
+func TestVerify(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("runs rsync in dry run mode comparing sizes", func(t *testing.T) {
+		cmd := exectest.NewCommandWithVerifier(InSync, func(name string, args ...string) {
+			expected := []string{"--archive", "--dry-run", "--itemize-changes", "--size-only", "/data/seg0/", "sdw1:/data/seg0"}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		})
+		rsync.SetRsyncCommand(cmd)
+		defer rsync.ResetRsyncCommand()
+
+		err := rsync.Verify(
+			rsync.WithSources("/data/seg0/"),
+			rsync.WithDestinationHost("sdw1"),
+			rsync.WithDestination("/data/seg0"),
+			rsync.WithOptions("--archive"),
+		)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("compares checksums rather than sizes when using checksum", func(t *testing.T) {
+		cmd := exectest.NewCommandWithVerifier(InSync, func(name string, args ...string) {
+			expected := []string{"--archive", "--checksum", "--dry-run", "--itemize-changes", "/data/seg0/", "/data/seg1"}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		})
+		rsync.SetRsyncCommand(cmd)
+		defer rsync.ResetRsyncCommand()
+
+		err := rsync.Verify(
+			rsync.WithSources("/data/seg0/"),
+			rsync.WithDestination("/data/seg1"),
+			rsync.WithOptions("--archive"),
+			rsync.WithChecksum(),
+		)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("errors listing the files that differ", func(t *testing.T) {
+		rsync.SetRsyncCommand(exectest.NewCommand(OutOfSync))
+		defer rsync.ResetRsyncCommand()
+
+		err := rsync.Verify(
+			rsync.WithSources("/data/seg0/"),
+			rsync.WithDestination("/data/seg1"),
+		)
+
+		var verifyErr rsync.VerifyError
+		if !errors.As(err, &verifyErr) {
+			t.Fatalf("got error %#v want type %T", err, verifyErr)
+		}
+
+		expected := []string{"base/1/1234", "pg_tblspc/", "global/pg_control.old"}
+		if !reflect.DeepEqual(verifyErr.Files, expected) {
+			t.Errorf("got files %q want %q", verifyErr.Files, expected)
+		}
+	})
+}
+
+func TestSettings(t *testing.T) {
+	t.Run("parses settings", func(t *testing.T) {
+		cases := []struct {
+			input    string
+			expected rsync.Settings
+		}{
+			{input: "", expected: rsync.Settings{}},
+			{input: "checksum", expected: rsync.Settings{Checksum: true}},
+			{input: "bandwidth_limit=10000, partial,verify", expected: rsync.Settings{BandwidthLimit: 10000, Partial: true, Verify: true}},
+		}
+
+		for _, c := range cases {
+			settings, err := rsync.ParseSettings(c.input)
+			if err != nil {
+				t.Errorf("unexpected error %#v", err)
+			}
+
+			if settings != c.expected {
+				t.Errorf("got %+v want %+v", settings, c.expected)
+			}
+		}
+	})
+
+	t.Run("errors when parsing invalid settings", func(t *testing.T) {
+		for _, input := range []string{"compress", "bandwidth_limit", "bandwidth_limit=fast", "checksum=true"} {
+			_, err := rsync.ParseSettings(input)
+			if err == nil {
+				t.Errorf("expected error when parsing %q", input)
+			}
+		}
+	})
+
+	t.Run("returns the options for the settings", func(t *testing.T) {
+		settings := rsync.Settings{BandwidthLimit: 500, Checksum: true, Partial: true, Verify: true}
+
+		_, args, err := rsync.Command(append(settings.Options(), rsync.WithSources("/src"), rsync.WithDestination("/dst"))...)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := []string{"--bwlimit=500", "--checksum", "--partial", "/src", "/dst"}
+		if !reflect.DeepEqual(args, expected) {
+			t.Errorf("got args %q want %q", args, expected)
+		}
+	})
+
+	t.Run("uses the remote shell of the agents rather than ssh", func(t *testing.T) {
+		shell := []string{"/usr/local/gpupgrade/gpupgrade", "remote-shell", "--port", "6416", "--tls-cert-file", "/certs/my cert.pem"}
+		settings := rsync.Settings{RemoteShell: rsync.RemoteShell(shell)}
+
+		_, args, err := rsync.Command(append(settings.Options(), rsync.WithSources("/src"), rsync.WithDestinationHost("sdw1"), rsync.WithDestination("/dst"))...)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := []string{`--rsh=/usr/local/gpupgrade/gpupgrade remote-shell --port 6416 --tls-cert-file "/certs/my cert.pem"`, "/src", "sdw1:/dst"}
+		if !reflect.DeepEqual(args, expected) {
+			t.Errorf("got args %q want %q", args, expected)
+		}
+
+		for _, arg := range args {
+			if strings.Contains(arg, "ssh") {
+				t.Errorf("got ssh in args %q", args)
+			}
+		}
+	})
 }
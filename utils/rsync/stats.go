@@ -0,0 +1,252 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package rsync
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// Stats are the transfer metrics of one or more rsync runs.
+type Stats struct {
+	FilesTransferred int64
+	TotalFileSize    int64
+	BytesSent        int64
+	BytesReceived    int64
+
+	// Speedup is the total file size divided by the bytes sent and received.
+	// Values well above one indicate most of the data was already in place.
+	Speedup float64
+
+	// Elapsed is the total time spent running rsync. When transfers run in
+	// parallel it may exceed the duration of the substep.
+	Elapsed time.Duration
+}
+
+var ErrNoStats = errors.New("no rsync --stats output found")
+
+var statsLines = []struct {
+	pattern *regexp.Regexp
+	field   func(*Stats) *int64
+}{
+	// rsync 3.1 and later report regular files while earlier versions report
+	// all files.
+	{regexp.MustCompile(`^Number of (?:regular )?files transferred: ([\d,]+)`), func(s *Stats) *int64 { return &s.FilesTransferred }},
+	{regexp.MustCompile(`^Total file size: ([\d,]+)`), func(s *Stats) *int64 { return &s.TotalFileSize }},
+	{regexp.MustCompile(`^Total bytes sent: ([\d,]+)`), func(s *Stats) *int64 { return &s.BytesSent }},
+	{regexp.MustCompile(`^Total bytes received: ([\d,]+)`), func(s *Stats) *int64 { return &s.BytesReceived }},
+}
+
+var speedupLine = regexp.MustCompile(`speedup is ([\d,.]+)`)
+
+// ParseStats parses the output of rsync --stats. The elapsed time is not part
+// of the output and is left for the caller to set.
+func ParseStats(output string) (Stats, error) {
+	var stats Stats
+	found := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		for _, statsLine := range statsLines {
+			match := statsLine.pattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+
+			value, err := strconv.ParseInt(strings.ReplaceAll(match[1], ",", ""), 10, 64)
+			if err != nil {
+				return Stats{}, xerrors.Errorf("parse rsync stats %q: %w", line, err)
+			}
+
+			*statsLine.field(&stats) = value
+			found = true
+		}
+
+		if match := speedupLine.FindStringSubmatch(line); match != nil {
+			speedup, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64)
+			if err != nil {
+				return Stats{}, xerrors.Errorf("parse rsync stats %q: %w", line, err)
+			}
+
+			stats.Speedup = speedup
+			found = true
+		}
+	}
+
+	if !found {
+		return Stats{}, ErrNoStats
+	}
+
+	return stats, nil
+}
+
+// Add aggregates the other stats recomputing the speedup over the combined
+// transfers.
+func (s *Stats) Add(other Stats) {
+	s.FilesTransferred += other.FilesTransferred
+	s.TotalFileSize += other.TotalFileSize
+	s.BytesSent += other.BytesSent
+	s.BytesReceived += other.BytesReceived
+	s.Elapsed += other.Elapsed
+
+	s.Speedup = 0
+	if transferred := s.BytesSent + s.BytesReceived; transferred > 0 {
+		s.Speedup = float64(s.TotalFileSize) / float64(transferred)
+	}
+}
+
+func (s Stats) Proto() *idl.RsyncStats {
+	return &idl.RsyncStats{
+		FilesTransferred: s.FilesTransferred,
+		TotalFileSize:    s.TotalFileSize,
+		BytesSent:        s.BytesSent,
+		BytesReceived:    s.BytesReceived,
+		Speedup:          s.Speedup,
+		Elapsed:          durationpb.New(s.Elapsed),
+	}
+}
+
+func StatsFromProto(stats *idl.RsyncStats) Stats {
+	if stats == nil {
+		return Stats{}
+	}
+
+	return Stats{
+		FilesTransferred: stats.GetFilesTransferred(),
+		TotalFileSize:    stats.GetTotalFileSize(),
+		BytesSent:        stats.GetBytesSent(),
+		BytesReceived:    stats.GetBytesReceived(),
+		Speedup:          stats.GetSpeedup(),
+		Elapsed:          stats.GetElapsed().AsDuration(),
+	}
+}
+
+// Collector aggregates the stats of transfers that may run concurrently. A nil
+// collector ignores the stats.
+type Collector struct {
+	mutex sync.Mutex
+	stats Stats
+}
+
+func (c *Collector) Add(stats Stats) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.stats.Add(stats)
+}
+
+func (c *Collector) Stats() Stats {
+	if c == nil {
+		return Stats{}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.stats
+}
+
+const StatsFileName = "rsync_stats.json"
+
+// StatsFileStore persists the aggregated rsync stats of substeps that transfer
+// data such that they can be shown in the status and upgrade report.
+type StatsFileStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+func NewStatsFileStore() (*StatsFileStore, error) {
+	path, err := utils.GetJSONFile(utils.GetStateDir(), StatsFileName)
+	if err != nil {
+		return &StatsFileStore{}, xerrors.Errorf("read %q: %w", StatsFileName, err)
+	}
+
+	return &StatsFileStore{path: path}, nil
+}
+
+func NewStatsStoreUsingFile(path string) *StatsFileStore {
+	return &StatsFileStore{path: path}
+}
+
+// ReadAll returns the stats keyed by step and then substep.
+func (f *StatsFileStore) ReadAll() (map[string]map[string]Stats, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.load()
+}
+
+func (f *StatsFileStore) load() (map[string]map[string]Stats, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats map[string]map[string]Stats
+	err = json.Unmarshal(data, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// Read returns the stats of the substep and whether any were recorded.
+func (f *StatsFileStore) Read(step idl.Step, substep idl.Substep) (Stats, bool, error) {
+	all, err := f.ReadAll()
+	if err != nil {
+		return Stats{}, false, err
+	}
+
+	stats, ok := all[step.String()][substep.String()]
+	return stats, ok, nil
+}
+
+// Write replaces the stats of the substep such that re-running a substep
+// records the stats of the latest attempt.
+func (f *StatsFileStore) Write(step idl.Step, substep idl.Substep, stats Stats) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	all, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	if all == nil {
+		all = make(map[string]map[string]Stats)
+	}
+
+	if _, ok := all[step.String()]; !ok {
+		all[step.String()] = make(map[string]Stats)
+	}
+
+	all[step.String()][substep.String()] = stats
+
+	data, err := json.MarshalIndent(all, "", "  ") // pretty print JSON
+	if err != nil {
+		return err
+	}
+
+	return utils.AtomicallyWrite(f.path, data)
+}
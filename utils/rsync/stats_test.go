@@ -0,0 +1,199 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package rsync_test
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+const statsOutput = `
+Number of files: 1,205 (reg: 1,100, dir: 105)
+Number of created files: 1,205 (reg: 1,100, dir: 105)
+Number of deleted files: 0
+Number of regular files transferred: 1,100
+Total file size: 52,428,800 bytes
+Total transferred file size: 52,428,800 bytes
+Literal data: 52,428,800 bytes
+Matched data: 0 bytes
+File list size: 32,768
+File list generation time: 0.001 seconds
+File list transfer time: 0.000 seconds
+Total bytes sent: 10,485,760
+Total bytes received: 20,972
+
+sent 10,485,760 bytes  received 20,972 bytes  7,004,488.00 bytes/sec
+total size is 52,428,800  speedup is 4.99
+`
+
+func Stats() {
+	fmt.Print(statsOutput)
+}
+
+func init() {
+	exectest.RegisterMains(
+		Stats,
+	)
+}
+
+func TestParseStats(t *testing.T) {
+	t.Run("parses the stats", func(t *testing.T) {
+		stats, err := rsync.ParseStats(statsOutput)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := rsync.Stats{
+			FilesTransferred: 1100,
+			TotalFileSize:    52428800,
+			BytesSent:        10485760,
+			BytesReceived:    20972,
+			Speedup:          4.99,
+		}
+		if stats != expected {
+			t.Errorf("got %+v want %+v", stats, expected)
+		}
+	})
+
+	t.Run("parses the stats of older rsync versions", func(t *testing.T) {
+		stats, err := rsync.ParseStats("Number of files: 5\nNumber of files transferred: 3\nTotal bytes sent: 300\n")
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := rsync.Stats{FilesTransferred: 3, BytesSent: 300}
+		if stats != expected {
+			t.Errorf("got %+v want %+v", stats, expected)
+		}
+	})
+
+	t.Run("errors when there are no stats", func(t *testing.T) {
+		_, err := rsync.ParseStats("sending incremental file list\n")
+		if !errors.Is(err, rsync.ErrNoStats) {
+			t.Errorf("got error %#v want %#v", err, rsync.ErrNoStats)
+		}
+	})
+}
+
+func TestStatsAdd(t *testing.T) {
+	stats := rsync.Stats{FilesTransferred: 1, TotalFileSize: 400, BytesSent: 90, BytesReceived: 10, Elapsed: time.Second}
+	stats.Add(rsync.Stats{FilesTransferred: 2, TotalFileSize: 400, BytesSent: 95, BytesReceived: 5, Elapsed: 2 * time.Second})
+
+	expected := rsync.Stats{FilesTransferred: 3, TotalFileSize: 800, BytesSent: 185, BytesReceived: 15, Speedup: 4, Elapsed: 3 * time.Second}
+	if stats != expected {
+		t.Errorf("got %+v want %+v", stats, expected)
+	}
+
+	actual := rsync.StatsFromProto(stats.Proto())
+	if actual != stats {
+		t.Errorf("got %+v want %+v", actual, stats)
+	}
+}
+
+func TestRsyncWithStats(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("collects the stats passing --stats", func(t *testing.T) {
+		cmd := exectest.NewCommandWithVerifier(Stats, func(name string, args ...string) {
+			expected := []string{"--archive", "--stats", "/data/seg0/", "/data/seg1"}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		})
+		rsync.SetRsyncCommand(cmd)
+		defer rsync.ResetRsyncCommand()
+
+		collector := &rsync.Collector{}
+		streams := &step.BufferedStreams{}
+		for i := 0; i < 2; i++ {
+			err := rsync.Rsync(
+				rsync.WithSources("/data/seg0/"),
+				rsync.WithDestination("/data/seg1"),
+				rsync.WithOptions("--archive"),
+				rsync.WithStream(streams),
+				rsync.WithStats(collector),
+			)
+			if err != nil {
+				t.Fatalf("unexpected error %#v", err)
+			}
+		}
+
+		stats := collector.Stats()
+		if stats.FilesTransferred != 2200 || stats.BytesSent != 20971520 {
+			t.Errorf("got %+v want the sum of both transfers", stats)
+		}
+
+		if stats.Elapsed <= 0 {
+			t.Errorf("got elapsed %s want greater than zero", stats.Elapsed)
+		}
+
+		if streams.StdoutBuf.String() != statsOutput+statsOutput {
+			t.Errorf("expected the output to still be written to the stream")
+		}
+	})
+
+	t.Run("does not fail the transfer when the stats cannot be parsed", func(t *testing.T) {
+		rsync.SetRsyncCommand(exectest.NewCommand(Success))
+		defer rsync.ResetRsyncCommand()
+
+		collector := &rsync.Collector{}
+		err := rsync.Rsync(rsync.WithSources("/data/seg0/"), rsync.WithDestination("/data/seg1"), rsync.WithStats(collector))
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		if collector.Stats() != (rsync.Stats{}) {
+			t.Errorf("got %+v want no stats", collector.Stats())
+		}
+	})
+}
+
+func TestStatsFileStore(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	path := filepath.Join(dir, rsync.StatsFileName)
+	testutils.MustWriteToFile(t, path, "{}")
+	store := rsync.NewStatsStoreUsingFile(path)
+
+	_, ok, err := store.Read(idl.Step_finalize, idl.Substep_upgrade_mirrors)
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	if ok {
+		t.Errorf("expected no stats")
+	}
+
+	err = store.Write(idl.Step_finalize, idl.Substep_upgrade_mirrors, rsync.Stats{FilesTransferred: 1})
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	expected := rsync.Stats{FilesTransferred: 2, Elapsed: time.Hour}
+	err = store.Write(idl.Step_finalize, idl.Substep_upgrade_mirrors, expected)
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	stats, ok, err := store.Read(idl.Step_finalize, idl.Substep_upgrade_mirrors)
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	if !ok || stats != expected {
+		t.Errorf("got %+v want %+v", stats, expected)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// traceparentMetadataKey passes the span making a request such that the span
+// of the handler is its child. The value has the W3C traceparent format.
+const traceparentMetadataKey = "traceparent"
+
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+}
+
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+}
+
+// UnaryServerInterceptor starts a span for each request whose parent is the
+// span of the caller. Requests without one such as from an older hub start a
+// new trace.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	ctx, span := start(ctx, remoteSpan(ctx), info.FullMethod)
+	defer func() { span.End(err) }()
+
+	return handler(ctx, req)
+}
+
+func StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	ctx, span := start(stream.Context(), remoteSpan(stream.Context()), info.FullMethod)
+	defer func() { span.End(err) }()
+
+	return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	span := FromContext(ctx)
+	if span == nil {
+		span = Current()
+	}
+
+	if span == nil {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, traceparentMetadataKey, fmt.Sprintf("00-%s-%s-01", span.TraceID, span.SpanID))
+}
+
+// remoteSpan returns the span of the caller or nil when the request does not
+// have a valid traceparent.
+func remoteSpan(ctx context.Context) *Span {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(traceparentMetadataKey)) == 0 {
+		return nil
+	}
+
+	fields := strings.Split(md.Get(traceparentMetadataKey)[0], "-")
+	if len(fields) != 4 || len(fields[1]) != 32 || len(fields[2]) != 16 {
+		return nil
+	}
+
+	return &Span{TraceID: fields[1], SpanID: fields[2], remote: true}
+}
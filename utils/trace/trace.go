@@ -0,0 +1,281 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package trace records spans of the steps, substeps, agent requests, and
+// commands run by the hub and agents such that the time of a slow step can be
+// attributed across hosts. Spans are identified using W3C trace context IDs
+// which are propagated from the hub to the agents in gRPC metadata.
+//
+// Spans are exported as one Chrome trace event per line. The files of the hub
+// and agents can be combined and loaded into a viewer such as Perfetto or
+// chrome://tracing with:
+//
+//	jq -s . hub_trace_*.json agent_trace_*.json > trace.json
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+type Span struct {
+	TraceID    string
+	SpanID     string
+	ParentID   string
+	Name       string
+	Start      time.Time
+	Attributes map[string]string
+
+	mutex    sync.Mutex
+	remote   bool  // started by another process
+	parent   *Span // nil when the parent is remote
+	lane     int   // the row the span is shown on by viewers
+	children int   // running children of the span
+	ended    bool
+}
+
+type spanKey struct{}
+
+// current is the span of the substep being run. It is the parent of spans
+// started without one in their context since many requests to the agents are
+// made using context.Background().
+var current struct {
+	mutex sync.Mutex
+	span  *Span
+}
+
+// Start starts a span that is a child of the span in the context, or else of
+// the current span. Attributes are given as name and value pairs. The returned
+// context holds the new span.
+func Start(ctx context.Context, name string, attributes ...string) (context.Context, *Span) {
+	parent := FromContext(ctx)
+	if parent == nil {
+		parent = Current()
+	}
+
+	return start(ctx, parent, name, attributes...)
+}
+
+// start starts a span that is a child of the parent or else starts a new
+// trace when there is no parent.
+func start(ctx context.Context, parent *Span, name string, attributes ...string) (context.Context, *Span) {
+	span := &Span{
+		SpanID:     newID(8),
+		Name:       name,
+		Start:      time.Now(),
+		Attributes: make(map[string]string),
+	}
+
+	for i := 0; i+1 < len(attributes); i += 2 {
+		span.Attributes[attributes[i]] = attributes[i+1]
+	}
+
+	if parent == nil {
+		span.TraceID = newID(16)
+		span.lane = nextLane()
+	} else {
+		span.TraceID = parent.TraceID
+		span.ParentID = parent.SpanID
+		span.lane = parent.addChild(span)
+	}
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// End ends the span recording the error if any and exports it. Ending a span
+// more than once has no effect.
+func (s *Span) End(err error) {
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return
+	}
+	s.ended = true
+	s.mutex.Unlock()
+
+	if s.parent != nil {
+		s.parent.removeChild()
+	}
+
+	export(s, time.Now(), err)
+}
+
+// addChild returns the lane of the child. The first running child shares the
+// lane of its parent while concurrent children such as the requests to each
+// host are each given their own such that viewers do not overlap them.
+func (s *Span) addChild(child *Span) int {
+	if s.remote {
+		return nextLane()
+	}
+
+	child.parent = s
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.children++
+	if s.children == 1 {
+		return s.lane
+	}
+
+	return nextLane()
+}
+
+func (s *Span) removeChild() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.children--
+}
+
+func FromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+func Current() *Span {
+	current.mutex.Lock()
+	defer current.mutex.Unlock()
+
+	return current.span
+}
+
+// SetCurrent sets the current span returning a function that restores the
+// previous one. The previous span is only restored when the span is still
+// current such that concurrent callers do not restore a stale span.
+func SetCurrent(span *Span) func() {
+	current.mutex.Lock()
+	defer current.mutex.Unlock()
+
+	previous := current.span
+	current.span = span
+
+	return func() {
+		current.mutex.Lock()
+		defer current.mutex.Unlock()
+
+		if current.span == span {
+			current.span = previous
+		}
+	}
+}
+
+var lanes struct {
+	mutex sync.Mutex
+	last  int
+}
+
+func nextLane() int {
+	lanes.mutex.Lock()
+	defer lanes.mutex.Unlock()
+
+	lanes.last++
+	return lanes.last
+}
+
+func newID(size int) string {
+	id := make([]byte, size)
+	if _, err := rand.Read(id); err != nil {
+		// Fall back to the time which is unique enough to correlate spans.
+		return fmt.Sprintf("%0*x", size*2, time.Now().UnixNano())[:size*2]
+	}
+
+	return hex.EncodeToString(id)
+}
+
+// exporter writes the spans of the process. Spans are not exported until
+// Initialize is called.
+var exporter struct {
+	mutex sync.Mutex
+	w     io.Writer
+	pid   uint32
+}
+
+// event is a complete event of the Chrome trace event format.
+type event struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat,omitempty"`
+	Phase     string            `json:"ph"`
+	Timestamp int64             `json:"ts"`
+	Duration  int64             `json:"dur"`
+	PID       uint32            `json:"pid"`
+	TID       int               `json:"tid"`
+	Args      map[string]string `json:"args,omitempty"`
+}
+
+// Initialize exports the spans of the process to the writer. Since the files
+// of many hosts are combined, the process is identified by a hash of its host
+// and pid which are given along with the process name to viewers.
+func Initialize(w io.Writer, process string) {
+	host, _ := os.Hostname()
+
+	exporter.mutex.Lock()
+	exporter.w = w
+	exporter.pid = crc32.ChecksumIEEE([]byte(fmt.Sprintf("%s:%d", host, os.Getpid())))
+	exporter.mutex.Unlock()
+
+	write(event{
+		Name:  "process_name",
+		Phase: "M",
+		Args:  map[string]string{"name": fmt.Sprintf("%s %s (pid %d)", process, host, os.Getpid())},
+	})
+}
+
+func export(span *Span, end time.Time, err error) {
+	args := map[string]string{
+		"trace_id": span.TraceID,
+		"span_id":  span.SpanID,
+	}
+
+	if span.ParentID != "" {
+		args["parent_id"] = span.ParentID
+	}
+
+	for name, value := range span.Attributes {
+		args[name] = value
+	}
+
+	if err != nil {
+		args["error"] = err.Error()
+	}
+
+	write(event{
+		Name:      span.Name,
+		Category:  "gpupgrade",
+		Phase:     "X",
+		Timestamp: span.Start.UnixMicro(),
+		Duration:  end.Sub(span.Start).Microseconds(),
+		TID:       span.lane,
+		Args:      args,
+	})
+}
+
+func write(e event) {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	if exporter.w == nil {
+		return
+	}
+
+	e.PID = exporter.pid
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		log.Printf("marshal span %q: %v", e.Name, err)
+		return
+	}
+
+	if _, err := exporter.w.Write(append(line, '\n')); err != nil {
+		log.Printf("export span %q: %v", e.Name, err)
+	}
+}
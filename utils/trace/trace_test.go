@@ -0,0 +1,200 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package trace_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/greenplum-db/gpupgrade/utils/trace"
+)
+
+type event struct {
+	Name  string
+	Phase string `json:"ph"`
+	TS    int64
+	Dur   int64
+	PID   uint32
+	TID   int
+	Args  map[string]string
+}
+
+func readEvents(t *testing.T, buf *bytes.Buffer) []event {
+	t.Helper()
+
+	var events []event
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var e event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("unmarshal %q: %v", line, err)
+		}
+
+		events = append(events, e)
+	}
+
+	buf.Reset()
+	return events
+}
+
+func TestStart(t *testing.T) {
+	var buf bytes.Buffer
+	trace.Initialize(&buf, "hub")
+	defer trace.Initialize(nil, "")
+
+	events := readEvents(t, &buf)
+	if len(events) != 1 || events[0].Phase != "M" || !strings.HasPrefix(events[0].Args["name"], "hub ") {
+		t.Fatalf("got events %+v want the process name", events)
+	}
+
+	t.Run("exports spans that are children of the span in the context", func(t *testing.T) {
+		ctx, step := trace.Start(context.Background(), "execute")
+		_, substep := trace.Start(ctx, "upgrade_master", "step", "execute")
+
+		if substep.TraceID != step.TraceID {
+			t.Errorf("got trace ID %q want %q", substep.TraceID, step.TraceID)
+		}
+
+		if substep.ParentID != step.SpanID {
+			t.Errorf("got parent ID %q want %q", substep.ParentID, step.SpanID)
+		}
+
+		substep.End(errors.New("permission denied"))
+		substep.End(nil)
+		step.End(nil)
+
+		events := readEvents(t, &buf)
+		if len(events) != 2 {
+			t.Fatalf("got %d events want 2", len(events))
+		}
+
+		e := events[0]
+		if e.Name != "upgrade_master" || e.Phase != "X" || e.PID != events[1].PID {
+			t.Errorf("unexpected event %+v", e)
+		}
+
+		expected := map[string]string{
+			"trace_id":  step.TraceID,
+			"span_id":   substep.SpanID,
+			"parent_id": step.SpanID,
+			"step":      "execute",
+			"error":     "permission denied",
+		}
+		for name, value := range expected {
+			if e.Args[name] != value {
+				t.Errorf("got %s %q want %q", name, e.Args[name], value)
+			}
+		}
+
+		if e.TID != events[1].TID {
+			t.Errorf("got lane %d want the lane of the parent %d", e.TID, events[1].TID)
+		}
+
+		if _, ok := events[1].Args["parent_id"]; ok {
+			t.Errorf("expected the step to not have a parent")
+		}
+	})
+
+	t.Run("shows concurrent children in their own lanes", func(t *testing.T) {
+		ctx, fanout := trace.Start(context.Background(), "ExecuteRPC")
+		_, sdw1 := trace.Start(ctx, "ExecuteRPC sdw1")
+		_, sdw2 := trace.Start(ctx, "ExecuteRPC sdw2")
+		sdw1.End(nil)
+		sdw2.End(nil)
+		fanout.End(nil)
+
+		events := readEvents(t, &buf)
+		if events[0].TID == events[1].TID {
+			t.Errorf("expected concurrent spans to have different lanes got %d", events[0].TID)
+		}
+	})
+
+	t.Run("spans without one in the context are children of the current span", func(t *testing.T) {
+		_, substep := trace.Start(context.Background(), "upgrade_primaries")
+		restore := trace.SetCurrent(substep)
+
+		_, request := trace.Start(context.Background(), "ExecuteRPC")
+		if request.ParentID != substep.SpanID {
+			t.Errorf("got parent ID %q want %q", request.ParentID, substep.SpanID)
+		}
+
+		restore()
+		if trace.Current() != nil {
+			t.Errorf("expected the current span to be restored")
+		}
+	})
+}
+
+func TestInterceptors(t *testing.T) {
+	var buf bytes.Buffer
+	trace.Initialize(&buf, "agent")
+	defer trace.Initialize(nil, "")
+	buf.Reset()
+
+	t.Run("the span of the handler is a child of the span of the caller", func(t *testing.T) {
+		ctx, caller := trace.Start(context.Background(), "upgrade_primaries")
+		defer caller.End(nil)
+
+		var md metadata.MD
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		}
+
+		err := trace.UnaryClientInterceptor(ctx, "/idl.Agent/UpgradePrimaries", nil, nil, nil, invoker)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		var handled *trace.Span
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			handled = trace.FromContext(ctx)
+			return nil, nil
+		}
+
+		info := &grpc.UnaryServerInfo{FullMethod: "/idl.Agent/UpgradePrimaries"}
+		_, err = trace.UnaryServerInterceptor(metadata.NewIncomingContext(context.Background(), md), nil, info, handler)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if handled.TraceID != caller.TraceID || handled.ParentID != caller.SpanID {
+			t.Errorf("got trace %q parent %q want trace %q parent %q", handled.TraceID, handled.ParentID, caller.TraceID, caller.SpanID)
+		}
+
+		events := readEvents(t, &buf)
+		if len(events) != 1 || events[0].Name != info.FullMethod {
+			t.Errorf("got events %+v want the span of the handler", events)
+		}
+	})
+
+	t.Run("requests without a caller span start a new trace", func(t *testing.T) {
+		_, substep := trace.Start(context.Background(), "upgrade_primaries")
+		restore := trace.SetCurrent(substep)
+		defer restore()
+
+		var handled *trace.Span
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			handled = trace.FromContext(ctx)
+			return nil, nil
+		}
+
+		md := metadata.Pairs("traceparent", "invalid")
+		info := &grpc.UnaryServerInfo{FullMethod: "/idl.CliToHub/GetConfig"}
+		_, err := trace.UnaryServerInterceptor(metadata.NewIncomingContext(context.Background(), md), nil, info, handler)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if handled.TraceID == substep.TraceID || handled.ParentID != "" {
+			t.Errorf("expected a new trace got trace %q parent %q", handled.TraceID, handled.ParentID)
+		}
+	})
+}
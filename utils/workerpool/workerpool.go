@@ -0,0 +1,46 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package workerpool bounds the number of goroutines running at once when
+// fanning out work such as pg_upgrade, rsync, or RPCs to each host.
+package workerpool
+
+import "sync"
+
+// Pool runs functions in goroutines with at most limit running at once.
+type Pool struct {
+	wg  sync.WaitGroup
+	sem chan struct{}
+}
+
+// New returns a pool that runs at most limit functions at once. A limit of
+// zero or less is unlimited.
+func New(limit int) *Pool {
+	p := &Pool{}
+	if limit > 0 {
+		p.sem = make(chan struct{}, limit)
+	}
+
+	return p
+}
+
+// Go runs f in a goroutine once the pool has capacity. It does not block the
+// caller.
+func (p *Pool) Go(f func()) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		if p.sem != nil {
+			p.sem <- struct{}{}
+			defer func() { <-p.sem }()
+		}
+
+		f()
+	}()
+}
+
+// Wait blocks until all functions passed to Go have returned.
+func (p *Pool) Wait() {
+	p.wg.Wait()
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package workerpool_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpupgrade/utils/workerpool"
+)
+
+func TestPool(t *testing.T) {
+	cases := []struct {
+		name     string
+		limit    int
+		expected int32
+	}{
+		{name: "bounds the number of running functions", limit: 2, expected: 2},
+		{name: "runs one function at a time", limit: 1, expected: 1},
+		{name: "is unlimited when the limit is zero", limit: 0, expected: 10},
+		{name: "is unlimited when the limit is negative", limit: -1, expected: 10},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var running, maxRunning, calls int32
+			var mutex sync.Mutex
+
+			// Hold each function until all those allowed to run have started
+			// such that the maximum is deterministic.
+			started := make(chan struct{}, 10)
+			release := make(chan struct{})
+
+			pool := workerpool.New(c.limit)
+			for i := 0; i < 10; i++ {
+				pool.Go(func() {
+					n := atomic.AddInt32(&running, 1)
+					mutex.Lock()
+					if n > maxRunning {
+						maxRunning = n
+					}
+					mutex.Unlock()
+
+					started <- struct{}{}
+					<-release
+
+					atomic.AddInt32(&running, -1)
+					atomic.AddInt32(&calls, 1)
+				})
+			}
+
+			for i := int32(0); i < c.expected; i++ {
+				<-started
+			}
+
+			select {
+			case <-started:
+				t.Fatalf("more than %d functions started", c.expected)
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			close(release)
+			pool.Wait()
+
+			if maxRunning != c.expected {
+				t.Errorf("got %d running at once want %d", maxRunning, c.expected)
+			}
+
+			if calls != 10 {
+				t.Errorf("got %d calls want %d", calls, 10)
+			}
+		})
+	}
+}